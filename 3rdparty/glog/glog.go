@@ -575,18 +575,7 @@ where the fields are defined as follows:
 */
 
 func (l *loggingT) header(s severity, depth int) (*buffer, string, int) {
-	var redact bool
-	_, file, line, ok := runtime.Caller(3 + depth)
-	if !ok {
-		file = "???"
-		line = 1
-	} else {
-		slash := strings.LastIndex(file, "/")
-		if slash >= 0 {
-			file = file[slash+1:]
-		}
-		_, redact = redactFnames[file]
-	}
+	file, line, redact := callerFileLine(depth)
 	return l.formatHeader(s, file, line, redact), file, line
 }
 
@@ -670,6 +659,10 @@ func (buf *buffer) someDigits(i, d int) int {
 }
 
 func (l *loggingT) println(s severity, args ...any) {
+	if jsonMode.Load() {
+		l.jprint(s, 0, nil, fmt.Sprintln(args...))
+		return
+	}
 	buf, file, line := l.header(s, 0)
 	fmt.Fprintln(buf, args...)
 	l.output(s, buf, file, line, false)
@@ -680,6 +673,10 @@ func (l *loggingT) print(s severity, args ...any) {
 }
 
 func (l *loggingT) printDepth(s severity, depth int, args ...any) {
+	if jsonMode.Load() {
+		l.jprint(s, depth, nil, fmt.Sprint(args...))
+		return
+	}
 	buf, file, line := l.header(s, depth)
 	fmt.Fprint(buf, args...)
 	if buf.Bytes()[buf.Len()-1] != '\n' {
@@ -689,6 +686,10 @@ func (l *loggingT) printDepth(s severity, depth int, args ...any) {
 }
 
 func (l *loggingT) printf(s severity, format string, args ...any) {
+	if jsonMode.Load() {
+		l.jprint(s, 0, nil, fmt.Sprintf(format, args...))
+		return
+	}
 	buf, file, line := l.header(s, 0)
 	fmt.Fprintf(buf, format, args...)
 	if buf.Bytes()[buf.Len()-1] != '\n' {
@@ -701,10 +702,15 @@ func (l *loggingT) printf(s severity, format string, args ...any) {
 // alsoLogToStderr is true, the log message always appears on standard error; it
 // will also appear in the log file unless --logtostderr is set.
 func (l *loggingT) printWithFileLine(s severity, file string, line int, alsoToStderr bool, args ...any) {
-	buf := l.formatHeader(s, file, line, false)
-	fmt.Fprint(buf, args...)
-	if buf.Bytes()[buf.Len()-1] != '\n' {
-		buf.WriteByte('\n')
+	var buf *buffer
+	if jsonMode.Load() {
+		buf = l.jsonLine(s, nil, file, line, fmt.Sprint(args...))
+	} else {
+		buf = l.formatHeader(s, file, line, false)
+		fmt.Fprint(buf, args...)
+		if buf.Bytes()[buf.Len()-1] != '\n' {
+			buf.WriteByte('\n')
+		}
 	}
 	l.output(s, buf, file, line, alsoToStderr)
 }
@@ -844,6 +850,14 @@ func (sb *syncBuffer) rotateFile(now time.Time) error {
 		fmt.Fprintf(&buf, "Created at %s, %s", snow, x)
 		fmt.Fprint(&buf, FileHeaderCB())
 	}
+	if jsonMode.Load() {
+		// (header as a single JSON line)
+		jbuf := sb.logger.jsonLine(sb.sev, nil, "", 0, buf.String())
+		n, err := sb.file.Write(jbuf.Bytes())
+		sb.logger.putBuffer(jbuf)
+		sb.nbytes += uint64(n)
+		return err
+	}
 	n, err := sb.file.Write(buf.Bytes())
 	sb.nbytes += uint64(n)
 	return err
@@ -0,0 +1,198 @@
+// Structured (JSON) logging.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+
+package glog
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+/*
+When enabled (see SetJSON), each log line is a single JSON object, e.g.:
+	{"ts":"2023-06-01T10:58:38.122973Z","level":"info","node":"t1","comp":"reb","xid":"g12",
+	 "bck":"ais://abc","obj":"a/b/c","file":"globrun.go:212","msg":"..."}
+where empty fields are omitted. Component, xaction ID, bucket, and object are attached
+by the caller - see Fields. Otherwise (text mode), log lines remain unchanged and the
+fields are not logged.
+*/
+
+const jsonTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+// Fields to attach to a structured log line, e.g.:
+//
+//	glog.Fields{Comp: "reb", Xid: xid}.Infof("%s: stage %s", name, stage)
+type Fields struct {
+	Comp string // component, e.g. "reb", "ec", "xact"
+	Xid  string // xaction ID
+	Bck  string // bucket, e.g. "ais://abc"
+	Obj  string // object name
+}
+
+var (
+	// node ID (JSON mode only)
+	NodeID string
+
+	jsonMode atomic.Bool
+
+	jsonLevel = []string{
+		infoLog:    "info",
+		warningLog: "warning",
+		errorLog:   "error",
+	}
+)
+
+func SetJSON(on bool) { jsonMode.Store(on) }
+func IsJSON() bool    { return jsonMode.Load() }
+
+// NOTE: in JSON mode, Fields methods call jprint directly - one frame less than Info(), et al.
+
+func (f Fields) Info(args ...any) {
+	if jsonMode.Load() {
+		logging.jprint(infoLog, -1, &f, fmt.Sprint(args...))
+	} else {
+		logging.print(infoLog, args...)
+	}
+}
+
+func (f Fields) Infof(format string, args ...any) {
+	if jsonMode.Load() {
+		logging.jprint(infoLog, -1, &f, fmt.Sprintf(format, args...))
+	} else {
+		logging.printf(infoLog, format, args...)
+	}
+}
+
+func (f Fields) Warning(args ...any) {
+	if jsonMode.Load() {
+		logging.jprint(warningLog, -1, &f, fmt.Sprint(args...))
+	} else {
+		logging.print(warningLog, args...)
+	}
+}
+
+func (f Fields) Warningf(format string, args ...any) {
+	if jsonMode.Load() {
+		logging.jprint(warningLog, -1, &f, fmt.Sprintf(format, args...))
+	} else {
+		logging.printf(warningLog, format, args...)
+	}
+}
+
+func (f Fields) Error(args ...any) {
+	if jsonMode.Load() {
+		logging.jprint(errorLog, -1, &f, fmt.Sprint(args...))
+	} else {
+		logging.print(errorLog, args...)
+	}
+}
+
+func (f Fields) Errorf(format string, args ...any) {
+	if jsonMode.Load() {
+		logging.jprint(errorLog, -1, &f, fmt.Sprintf(format, args...))
+	} else {
+		logging.printf(errorLog, format, args...)
+	}
+}
+
+// caller's file and line number (compare with header)
+func callerFileLine(depth int) (file string, line int, redact bool) {
+	_, file, line, ok := runtime.Caller(4 + depth)
+	if !ok {
+		return "???", 1, false
+	}
+	if slash := strings.LastIndex(file, "/"); slash >= 0 {
+		file = file[slash+1:]
+	}
+	_, redact = redactFnames[file]
+	return
+}
+
+func (l *loggingT) jprint(s severity, depth int, f *Fields, msg string) {
+	file, line, redact := callerFileLine(depth)
+	fl := file
+	if redact {
+		fl = ""
+	}
+	buf := l.jsonLine(s, f, fl, line, msg)
+	l.output(s, buf, file, line, false)
+}
+
+// formats a single JSON line (see above)
+func (l *loggingT) jsonLine(s severity, f *Fields, file string, line int, msg string) *buffer {
+	now := timeNow()
+	if s > errorLog {
+		s = infoLog // for safety.
+	}
+	buf := l.getBuffer()
+	buf.WriteString(`{"ts":"`)
+	buf.Write(now.AppendFormat(buf.tmp[:0], jsonTimeFormat))
+	buf.WriteString(`","level":"`)
+	buf.WriteString(jsonLevel[s])
+	buf.WriteByte('"')
+	buf.jfield("node", NodeID)
+	if f != nil {
+		buf.jfield("comp", f.Comp)
+		buf.jfield("xid", f.Xid)
+		buf.jfield("bck", f.Bck)
+		buf.jfield("obj", f.Obj)
+	}
+	if file != "" {
+		buf.WriteString(`,"file":"`)
+		buf.WriteString(file)
+		buf.WriteByte(':')
+		buf.Write(strconv.AppendInt(buf.tmp[:0], int64(line), 10))
+		buf.WriteByte('"')
+	}
+	buf.WriteString(`,"msg":`)
+	buf.jstring(strings.TrimRight(msg, "\n"))
+	buf.WriteString("}\n")
+	return buf
+}
+
+func (buf *buffer) jfield(name, value string) {
+	if value == "" {
+		return
+	}
+	buf.WriteString(`,"`)
+	buf.WriteString(name)
+	buf.WriteString(`":`)
+	buf.jstring(value)
+}
+
+const hexDigits = "0123456789abcdef"
+
+func (buf *buffer) jstring(s string) {
+	buf.WriteByte('"')
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 0x20 && c != '"' && c != '\\' {
+			continue
+		}
+		buf.WriteString(s[start:i])
+		switch c {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			buf.WriteString(`\u00`)
+			buf.WriteByte(hexDigits[c>>4])
+			buf.WriteByte(hexDigits[c&0xf])
+		}
+		start = i + 1
+	}
+	buf.WriteString(s[start:])
+	buf.WriteByte('"')
+}
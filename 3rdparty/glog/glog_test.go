@@ -18,6 +18,7 @@ package glog
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	stdLog "log"
 	"path/filepath"
@@ -228,6 +229,49 @@ func TestWarning(t *testing.T) {
 	}
 }
 
+// Test structured (JSON) log lines, with and without fields.
+func TestJSON(t *testing.T) {
+	setFlags()
+	defer logging.swap(logging.newBuffers())
+	SetJSON(true)
+	defer SetJSON(false)
+	NodeID = "t1"
+	defer func() { NodeID = "" }()
+
+	Infof("plain \"%s\"\n", "quoted")
+	_, _, line, _ := runtime.Caller(0)
+	Fields{Comp: "reb", Xid: "g12", Bck: "ais://abc", Obj: "a/b"}.Warningf("obj %d", 1)
+
+	msgs := strings.Split(strings.TrimSuffix(contents(infoLog), "\n"), "\n")
+	if len(msgs) != 2 {
+		t.Fatalf("Got %d lines, expected 2: %q", len(msgs), msgs)
+	}
+	vs := make([]map[string]string, len(msgs))
+	for i, m := range msgs {
+		if err := json.Unmarshal([]byte(m), &vs[i]); err != nil {
+			t.Fatalf("%q: %v", m, err)
+		}
+		if vs[i]["node"] != "t1" || vs[i]["ts"] == "" {
+			t.Errorf("missing node or timestamp: %q", m)
+		}
+	}
+	v := vs[0]
+	if v["level"] != "info" || v["msg"] != `plain "quoted"` || v["comp"] != "" {
+		t.Errorf("unexpected %q", msgs[0])
+	}
+	v = vs[1]
+	if v["level"] != "warning" || v["comp"] != "reb" || v["xid"] != "g12" || v["bck"] != "ais://abc" ||
+		v["obj"] != "a/b" || v["msg"] != "obj 1" {
+		t.Errorf("unexpected %q", msgs[1])
+	}
+	if want := "glog_test.go:" + strconv.Itoa(line+1); v["file"] != want {
+		t.Errorf("expected file %q, got %q", want, v["file"])
+	}
+	if !contains(warningLog, msgs[1]) {
+		t.Error("Warning failed")
+	}
+}
+
 // Test that a V log goes to Info.
 func TestV(t *testing.T) {
 	setFlags()
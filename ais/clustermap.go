@@ -483,6 +483,10 @@ func (r *smapOwner) synchronize(si *cluster.Snode, newSmap *smapX, payload msPay
 		glog.Warningf("%s changing flags from %#b to %#b", si, si.Flags, nsi.Flags)
 		si.Flags = nsi.Flags
 	}
+	if nsi := newSmap.GetNode(si.ID()); nsi != nil && si.Domain != nsi.Domain {
+		glog.Warningf("%s changing failure domain from %q to %q", si, si.Domain, nsi.Domain)
+		si.Domain = nsi.Domain
+	}
 	if smap != nil {
 		curVer, newVer := smap.Version, newSmap.version()
 		if newVer <= curVer {
@@ -20,6 +20,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/ext/dload"
 	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/scrub"
 	"github.com/NVIDIA/aistore/space"
 	"github.com/NVIDIA/aistore/sys"
 	"github.com/NVIDIA/aistore/xact/xreg"
@@ -197,18 +198,19 @@ func initDaemon(version, buildTime string) cos.Runner {
 		p := newProxy(co)
 		p.init(config)
 		cmn.AppGloghdr("Node: " + p.si.Name() + ", " + loghdr)
-		cmn.SetNodeName(p.si.Name())
+		cmn.SetNodeName(p.si.ID(), p.si.Name())
 		return p
 	}
 
 	// reg more xaction factories
 	space.Xreg()
+	scrub.Xreg()
 	dload.Xreg()
 
 	t := newTarget(co)
 	t.init(config)
 	cmn.AppGloghdr("Node: " + t.si.Name() + ", " + loghdr)
-	cmn.SetNodeName(t.si.Name())
+	cmn.SetNodeName(t.si.ID(), t.si.Name())
 
 	return t
 }
@@ -250,6 +250,7 @@ func (p *proxy) primaryStartup(loadedSmap *smapX, config *cmn.Config, ntargets i
 		}
 		// NOTE: use regpool to try to upgrade all the four revs: Smap, BMD, RMD, and global Config
 		before.Smap, before.BMD, before.RMD, before.EtlMD = clone, p.owner.bmd.get(), p.owner.rmd.get(), p.owner.etl.get()
+		before.SchedMD = p.owner.sched.get()
 		before.Config, _ = p.owner.config.get()
 
 		smap = p.regpoolMaxVer(&before, &after)
@@ -259,7 +260,14 @@ func (p *proxy) primaryStartup(loadedSmap *smapX, config *cmn.Config, ntargets i
 		p.owner.smap.mu.Unlock()
 
 		msg := p.newAmsgStr(metaction1, after.BMD)
-		wg := p.metasyncer.sync(revsPair{smap, msg}, revsPair{after.BMD, msg})
+		pairs := []revsPair{{smap, msg}, {after.BMD, msg}}
+		if after.EtlMD != nil && after.EtlMD != before.EtlMD {
+			pairs = append(pairs, revsPair{after.EtlMD, msg})
+		}
+		if after.SchedMD != nil && after.SchedMD != before.SchedMD {
+			pairs = append(pairs, revsPair{after.SchedMD, msg})
+		}
+		wg := p.metasyncer.sync(pairs...)
 
 		// before and after
 		glog.Infof("%s: Smap(loaded %s, merged %s, added %d)", p.si.StringEx(), loadedSmap, before.Smap.StringEx(), added)
@@ -364,6 +372,9 @@ func (p *proxy) primaryStartup(loadedSmap *smapX, config *cmn.Config, ntargets i
 	if etlMD.Version > 0 {
 		_ = p.metasyncer.sync(revsPair{etlMD, aisMsg})
 	}
+	if schedMD := p.owner.sched.get(); schedMD.Version > 0 {
+		_ = p.metasyncer.sync(revsPair{schedMD, aisMsg})
+	}
 
 	// 11. Clear regpool
 	p.reg.mu.Lock()
@@ -532,6 +543,22 @@ func (p *proxy) discoverMeta(smap *smapX) {
 		}
 		p.owner.rmd.Unlock()
 	}
+	if svm.EtlMD != nil {
+		p.owner.etl.Lock()
+		etlMD := p.owner.etl.get()
+		if etlMD == nil || etlMD.version() < svm.EtlMD.version() {
+			glog.Infof("%s: override local %s with %s", p.si.StringEx(), etlMD, svm.EtlMD)
+			if err := p.owner.etl.putPersist(svm.EtlMD, nil); err != nil {
+				glog.Error(err)
+			}
+		}
+		p.owner.etl.Unlock()
+	}
+	if svm.SchedMD != nil {
+		if err := p.receiveSchedMD(svm.SchedMD, &aisMsg{}, ""); err != nil && !isErrDowngrade(err) {
+			glog.Error(err)
+		}
+	}
 
 	if svm.Config != nil && svm.Config.UUID != "" {
 		p.owner.config.Lock()
@@ -723,7 +750,16 @@ func (p *proxy) bcastMaxVer(bcastSmap *smapX, bmds bmds, smaps smaps) (out cluMe
 			}
 		}
 
-		// TODO: maxver of EtlMD
+		if svm.EtlMD != nil && svm.EtlMD.version() > 0 {
+			if out.EtlMD == nil || (!slowp && out.EtlMD.Version < svm.EtlMD.Version) { // init or max(version)
+				out.EtlMD = svm.EtlMD
+			}
+		}
+		if svm.SchedMD != nil && svm.SchedMD.version() > 0 {
+			if out.SchedMD == nil || (!slowp && out.SchedMD.Version < svm.SchedMD.Version) { // ditto
+				out.SchedMD = svm.SchedMD
+			}
+		}
 
 		if svm.Smap != nil && svm.VoteInProgress {
 			var s string
@@ -731,7 +767,7 @@ func (p *proxy) bcastMaxVer(bcastSmap *smapX, bmds bmds, smaps smaps) (out cluMe
 				s = " of the current one " + svm.Smap.Primary.ID()
 			}
 			glog.Warningf("%s: starting up as primary(?) during reelection%s", p.si.StringEx(), s)
-			out.Smap, out.BMD, out.RMD = nil, nil, nil // zero-out as unusable
+			out.Smap, out.BMD, out.RMD, out.EtlMD, out.SchedMD = nil, nil, nil, nil, nil // zero-out as unusable
 			done = false
 			break
 		}
@@ -806,6 +842,16 @@ func (p *proxy) regpoolMaxVer(before, after *cluMeta) (smap *smapX) {
 				after.RMD = regReq.RMD
 			}
 		}
+		if regReq.EtlMD != nil && regReq.EtlMD.version() > 0 {
+			if after.EtlMD == nil || after.EtlMD.version() < regReq.EtlMD.version() {
+				after.EtlMD = regReq.EtlMD
+			}
+		}
+		if regReq.SchedMD != nil && regReq.SchedMD.version() > 0 {
+			if after.SchedMD == nil || after.SchedMD.version() < regReq.SchedMD.version() {
+				after.SchedMD = regReq.SchedMD
+			}
+		}
 		if regReq.Config != nil && regReq.Config.version() > 0 && cos.IsValidUUID(regReq.Config.UUID) {
 			if after.Config != nil && after.Config.version() > 0 {
 				if cos.IsValidUUID(after.Config.UUID) && after.Config.UUID != regReq.Config.UUID {
@@ -840,7 +886,16 @@ func (p *proxy) regpoolMaxVer(before, after *cluMeta) (smap *smapX) {
 		}
 	}
 
-	// TODO: implement EtlMD
+	if after.EtlMD != before.EtlMD {
+		if err := p.owner.etl.putPersist(after.EtlMD, nil); err != nil {
+			glog.Error(err) // (not fatal - ETLs can be re-initialized)
+		}
+	}
+	if after.SchedMD != before.SchedMD {
+		if err := p.owner.sched.putPersist(after.SchedMD); err != nil {
+			glog.Error(err) // (ditto)
+		}
+	}
 ret:
 	if after.Smap.version() == 0 || !cos.IsValidUUID(after.Smap.UUID) {
 		after.Smap.UUID, after.Smap.CreationTime = newClusterUUID()
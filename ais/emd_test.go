@@ -30,6 +30,7 @@ func TestEtlMDDeepCopy(t *testing.T) {
 		},
 		Code: []byte("print('hello')"),
 	})
+	etlMD.SetStopped("init-code", true)
 	clone := etlMD.clone()
 	s1 := string(cos.MustMarshal(etlMD))
 	s2 := string(cos.MustMarshal(clone))
@@ -38,6 +39,10 @@ func TestEtlMDDeepCopy(t *testing.T) {
 		t.Log(s2)
 		t.Fatal("marshal(etlmd) != marshal(clone(etlmd))")
 	}
+	clone.SetStopped("init-code", false)
+	if !etlMD.IsStopped("init-code") {
+		t.Fatal("clone(etlmd) shares stopped state with etlmd")
+	}
 }
 
 var _ = Describe("EtlMD marshal and unmarshal", func() {
@@ -89,6 +94,7 @@ var _ = Describe("EtlMD marshal and unmarshal", func() {
 				etlMD.Add(msg)
 			}
 		}
+		etlMD.SetStopped("init-spec-3", true)
 	})
 
 	for _, node := range []string{apc.Target, apc.Proxy} {
@@ -148,6 +154,8 @@ var _ = Describe("EtlMD marshal and unmarshal", func() {
 							Expect(loaded.Version).To(BeEquivalentTo(clone.Version))
 							_, present := loaded.Get(msg.ID())
 							Expect(present).To(BeTrue())
+							Expect(loaded.IsStopped("init-spec-3")).To(BeTrue())
+							Expect(loaded.IsStopped(msg.ID())).To(BeFalse())
 						}
 					}
 				}
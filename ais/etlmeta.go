@@ -49,9 +49,11 @@ type (
 		pre   func(ctx *etlMDModifier, clone *etlMD) (err error)
 		final func(ctx *etlMDModifier, clone *etlMD)
 
-		msg   etl.InitMsg
-		etlID string
-		wait  bool
+		msg       etl.InitMsg
+		etlID     string
+		wait      bool
+		stopped   bool
+		terminate bool
 	}
 
 	etlMDOwnerBase struct {
@@ -103,6 +105,9 @@ func (e *etlMD) clone() *etlMD {
 	for id, etl := range e.ETLs {
 		dst.ETLs[id] = etl
 	}
+	for id := range e.Stopped {
+		dst.Stopped.Add(id)
+	}
 	return dst
 }
 
@@ -113,6 +118,14 @@ func (e *etlMD) add(spec etl.InitMsg) {
 
 func (e *etlMD) get(id string) etl.InitMsg { return e.ETLs[id] }
 
+func (e *etlMD) setStopped(id string, stopped bool) (changed bool) {
+	if changed = e.IsStopped(id) != stopped; changed {
+		e.SetStopped(id, stopped)
+		e.Version++
+	}
+	return
+}
+
 func (e *etlMD) delete(id string) (exists bool) {
 	_, exists = e.ETLs[id]
 	delete(e.ETLs, id)
@@ -183,7 +196,7 @@ func (eo *etlMDOwnerPrx) _pre(ctx *etlMDModifier) (clone *etlMD, err error) {
 	defer eo.Unlock()
 	etlMD := eo.get()
 	clone = etlMD.clone()
-	if err = ctx.pre(ctx, clone); err != nil {
+	if err = ctx.pre(ctx, clone); err != nil || ctx.terminate {
 		return
 	}
 	err = eo.putPersist(clone, nil)
@@ -191,7 +204,7 @@ func (eo *etlMDOwnerPrx) _pre(ctx *etlMDModifier) (clone *etlMD, err error) {
 }
 
 func (eo *etlMDOwnerPrx) modify(ctx *etlMDModifier) (clone *etlMD, err error) {
-	if clone, err = eo._pre(ctx); err != nil {
+	if clone, err = eo._pre(ctx); err != nil || ctx.terminate {
 		return
 	}
 	if ctx.final != nil {
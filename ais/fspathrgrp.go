@@ -96,6 +96,37 @@ func (g *fsprungroup) detachMpath(mpath string, dontResilver bool) (*fs.Mountpat
 	return g.doDD(apc.ActMountpathDetach, fs.FlagBeingDetached, mpath, dontResilver)
 }
 
+// drainMpath keeps mountpath available for reading while resilvering moves its
+// content to the remaining mountpaths, and detaches it when done (see postDD)
+func (g *fsprungroup) drainMpath(mpath string) (rmi *fs.MountpathInfo, err error) {
+	if !cmn.GCO.Get().Resilver.Enabled {
+		return nil, fmt.Errorf("%s: cannot drain %q - resilvering is disabled", g.t, mpath)
+	}
+	if rmi, err = fs.BeginDrain(mpath); err != nil || rmi == nil {
+		return
+	}
+
+	// TODO: ditto (see doDD)
+	dsort.Managers.AbortAll(fmt.Errorf("%q %s", apc.ActMountpathDrain, rmi))
+
+	// HRW has changed
+	rmi.EvictLomCache()
+
+	prevActive := g.t.res.IsActive(1 /*interval-of-inactivity multiplier*/)
+	glog.Infof("%s: %q %s: starting to resilver (previous active: %t)", g.t, apc.ActMountpathDrain, rmi, prevActive)
+	args := res.Args{
+		Rmi:             rmi,
+		Action:          apc.ActMountpathDrain,
+		PostDD:          g.postDD, // detach when done
+		SingleRmiJogger: !prevActive,
+	}
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go g.t.runResilver(args, wg)
+	wg.Wait()
+	return
+}
+
 func (g *fsprungroup) doDD(action string, flags uint64, mpath string, dontResilver bool) (rmi *fs.MountpathInfo, err error) {
 	var numAvail int
 	if rmi, numAvail, err = fs.BeginDD(action, flags, mpath); err != nil {
@@ -154,6 +185,10 @@ func (g *fsprungroup) postDD(rmi *fs.MountpathInfo, action string, xres *xs.Resi
 		if errCause := cmn.AsErrAborted(err); errCause != nil {
 			err = errCause
 		}
+		if action == apc.ActMountpathDrain {
+			g.revertDrain(rmi, xres, err)
+			return
+		}
 		if err == cmn.ErrXactUserAbort {
 			glog.Errorf("[post-dd interrupted - clearing the state] %s: %q %s %s: %v",
 				g.t.si, action, rmi, xres, err)
@@ -165,8 +200,8 @@ func (g *fsprungroup) postDD(rmi *fs.MountpathInfo, action string, xres *xs.Resi
 		return
 	}
 
-	// 2. this action
-	if action == apc.ActMountpathDetach {
+	// 2. this action (drained mountpath gets detached)
+	if action == apc.ActMountpathDetach || action == apc.ActMountpathDrain {
 		_, err = fs.Remove(rmi.Path, g.redistributeMD)
 	} else {
 		debug.Assert(action == apc.ActMountpathDisable)
@@ -186,11 +221,9 @@ func (g *fsprungroup) postDD(rmi *fs.MountpathInfo, action string, xres *xs.Resi
 		if !mi.IsAnySet(fs.FlagWaitingDD) {
 			continue
 		}
-		// TODO: assumption that `action` is the same for all
-		if action == apc.ActMountpathDetach {
+		if mi.IsAnySet(fs.FlagBeingDetached) {
 			_, err = fs.Remove(mi.Path, g.redistributeMD)
 		} else {
-			debug.Assert(action == apc.ActMountpathDisable)
 			_, err = fs.Disable(mi.Path, g.redistributeMD)
 		}
 		if err != nil {
@@ -198,7 +231,46 @@ func (g *fsprungroup) postDD(rmi *fs.MountpathInfo, action string, xres *xs.Resi
 			return
 		}
 		fspathsConfigAddDel(mi.Path, false /*add*/)
-		glog.Infof("%s: %s %s %s was previously aborted and now done", g.t, action, mi, xres)
+		glog.Infof("%s: %s %s was previously aborted and now done", g.t, mi, xres)
+	}
+}
+
+// an interrupted drain reverts the mountpath to normal, and so does HRW - which is why
+// the objects moved off of it (so far) must be resilvered back
+func (g *fsprungroup) revertDrain(rmi *fs.MountpathInfo, xres *xs.Resilver, err error) {
+	glog.Errorf("[post-drain interrupted - reverting] %s: %s %s: %v", g.t.si, rmi, xres, err)
+	rmi.ClearDD()
+
+	// HRW has changed
+	rmi.EvictLomCache()
+	if !cmn.GCO.Get().Resilver.Enabled {
+		glog.Warningf("%s: %s: resilvering is disabled - objects moved off the mountpath remain misplaced", g.t, rmi)
+		return
+	}
+	go g.t.runResilver(res.Args{}, nil /*wg*/)
+}
+
+//
+// degraded (see health.FSHC)
+//
+
+func (g *fsprungroup) degradeMpath(mpath, reason string, degraded bool) {
+	availablePaths := fs.GetAvail()
+	mi, ok := availablePaths[mpath]
+	if !ok || !mi.SetDegraded(degraded) {
+		return
+	}
+	var cnt int64
+	for _, mi := range availablePaths {
+		if mi.IsAnySet(fs.FlagDegraded) {
+			cnt++
+		}
+	}
+	g.t.statsT.Set(stats.DegradedMpathCount, cnt)
+	if degraded {
+		glog.Errorf("%s: %s is degraded (%s)", g.t, mi, reason)
+	} else {
+		glog.Warningf("%s: %s is no longer degraded (%s)", g.t, mi, reason)
 	}
 }
 
@@ -226,7 +226,12 @@ func setConfigInMem(toUpdate *cmn.ConfigToUpdate, config *cmn.Config, asType str
 			return fmt.Errorf("failed to set log level = %s, err: %v", *toUpdate.Log.Level, err)
 		}
 	}
-	err = config.UpdateClusterConfig(toUpdate, asType)
+	if err = config.UpdateClusterConfig(toUpdate, asType); err != nil {
+		return
+	}
+	if toUpdate.Log != nil && toUpdate.Log.Format != nil {
+		glog.SetJSON(config.Log.Format == apc.LogFormatJSON)
+	}
 	return
 }
 
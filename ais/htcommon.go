@@ -117,6 +117,7 @@ type (
 		BMD            *bucketMD      `json:"bmd"`
 		RMD            *rebMD         `json:"rmd"`
 		EtlMD          *etlMD         `json:"etlMD"`
+		SchedMD        *schedMD       `json:"schedMD"` // proxies only
 		Config         *globalConfig  `json:"config"`
 		SI             *cluster.Snode `json:"si"`
 		RebInterrupted bool           `json:"reb_interrupted"`
@@ -131,6 +132,7 @@ type (
 		skipRMD       bool
 		skipConfig    bool
 		skipEtlMD     bool
+		skipSchedMD   bool
 		fillRebMarker bool
 	}
 
@@ -158,6 +160,9 @@ type (
 		EtlMD struct {
 			Version int64 `json:"version,string"`
 		} `json:"etlmd"`
+		SchedMD struct {
+			Version int64 `json:"version,string"`
+		} `json:"schedmd"`
 		Flags struct {
 			VoteInProgress bool `json:"vote_in_progress"`
 			ClusterStarted bool `json:"cluster_started"`
@@ -423,6 +428,7 @@ type (
 	cresEI struct{} // -> etl.InfoList
 	cresEL struct{} // -> etl.PodLogsMsg
 	cresEH struct{} // -> etl.PodHealthMsg
+	cresES struct{} // -> etl.StartStatus
 	cresIC struct{} // -> icBundle
 	cresBM struct{} // -> bucketMD
 
@@ -439,6 +445,7 @@ var (
 	_ cresv = cresEI{}
 	_ cresv = cresEL{}
 	_ cresv = cresEH{}
+	_ cresv = cresES{}
 	_ cresv = cresIC{}
 	_ cresv = cresBM{}
 	_ cresv = cresBsumm{}
@@ -479,6 +486,9 @@ func (c cresEL) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jr
 func (cresEH) newV() any                              { return &etl.PodHealthMsg{} }
 func (c cresEH) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
 
+func (cresES) newV() any                              { return &etl.StartStatus{} }
+func (c cresES) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
+
 func (cresIC) newV() any                              { return &icBundle{} }
 func (c cresIC) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
 
@@ -663,6 +673,9 @@ func (cii *clusterInfo) fill(h *htrun) {
 	cii.RMD.Version = rmd.Version
 	cii.Config.Version = h.owner.config.version()
 	cii.EtlMD.Version = etl.version()
+	if h.owner.sched != nil {
+		cii.SchedMD.Version = h.owner.sched.get().version()
+	}
 	cii.Flags.ClusterStarted = h.ClusterStarted()
 	cii.Flags.NodeStarted = h.NodeStarted()
 }
@@ -826,7 +839,8 @@ func _reMirror(bprops, nprops *cmn.BucketProps) bool {
 	if bprops.Mirror.Enabled && nprops.Mirror.Enabled {
 		return bprops.Mirror.Copies != nprops.Mirror.Copies
 	}
-	return false
+	// disabling cross-node mirroring: remove replicas
+	return bprops.Mirror.Nodes() && !nprops.Mirror.Enabled
 }
 
 func _reEC(bprops, nprops *cmn.BucketProps, bck *cluster.Bck, smap *smapX) (targetCnt int, yes bool) {
@@ -835,6 +849,8 @@ func _reEC(bprops, nprops *cmn.BucketProps, bck *cluster.Bck, smap *smapX) (targ
 			// abort running ec-encode xaction, if exists
 			flt := xreg.XactFilter{Kind: apc.ActECEncode, Bck: bck}
 			xreg.DoAbort(flt, errors.New("ec-disabled"))
+			flt.Kind = apc.ActECReencode
+			xreg.DoAbort(flt, errors.New("ec-disabled"))
 		}
 		return
 	}
@@ -61,7 +61,8 @@ type htrun struct {
 		bmd    bmdOwner // interface with proxy and target impl-s
 		rmd    *rmdOwner
 		config *configOwner
-		etl    etlOwner // ditto
+		etl    etlOwner    // ditto
+		sched  *schedOwner // proxies only
 	}
 	startup struct {
 		cluster atomic.Int64 // mono.NanoTime() since cluster startup, zero prior to that
@@ -134,6 +135,9 @@ func (h *htrun) cluMeta(opts cmetaFillOpt) (*cluMeta, error) {
 	if !opts.skipEtlMD {
 		cm.EtlMD = h.owner.etl.get()
 	}
+	if !opts.skipSchedMD && h.owner.sched != nil {
+		cm.SchedMD = h.owner.sched.get()
+	}
 	if h.si.IsTarget() && opts.fillRebMarker {
 		rebMarked := xreg.GetRebMarked()
 		cm.RebInterrupted = rebMarked.Interrupted
@@ -384,6 +388,10 @@ func (h *htrun) initNetworks() {
 		PubNet:     pubAddr,
 		ControlNet: intraControlAddr,
 		DataNet:    intraDataAddr,
+		Domain:     config.Domain,
+	}
+	if config.Domain != "" {
+		glog.Infof("failure domain: %q", config.Domain)
 	}
 }
 
@@ -1019,6 +1027,8 @@ func (h *htrun) httpdaeget(w http.ResponseWriter, r *http.Request, query url.Val
 		return
 	case apc.GetWhatStats:
 		body = h.statsT.GetWhatStats()
+	case apc.GetWhatMetricNames:
+		body = h.statsT.GetMetricNames()
 	default:
 		h.writeErrf(w, r, "invalid GET /daemon request: unrecognized what=%s", what)
 		return
@@ -1569,6 +1579,7 @@ func (h *htrun) _recvCfg(newConfig *globalConfig, msg *aisMsg, payload msPayload
 	// update assorted read-mostly knobs
 	cmn.Features = newConfig.Features
 	cmn.Timeout.Set(&newConfig.ClusterConfig)
+	glog.SetJSON(cmn.GCO.Get().Log.Format == apc.LogFormatJSON) // (with local override, if any)
 	return
 }
 
@@ -1715,6 +1726,7 @@ func (h *htrun) registerToURL(url string, psi *cluster.Snode, tout time.Duration
 			skipRMD:       keepalive,
 			skipConfig:    keepalive,
 			skipEtlMD:     keepalive,
+			skipSchedMD:   keepalive,
 			fillRebMarker: !keepalive,
 		}
 	)
@@ -372,6 +372,7 @@ func (pkr *palive) retry(si *cluster.Snode) (ok, stopped bool) {
 				sname := si.StringEx()
 				glog.Warningf("Failed to keepalive %s after %d attempts - removing %s from the %s",
 					sname, i, sname, smap)
+				pkr.p.alerts.event(apc.AlertMetricKaliveFail, si.ID())
 				return false, false
 			}
 			if cos.IsUnreachable(err, status) {
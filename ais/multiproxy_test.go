@@ -16,6 +16,7 @@ import (
 	"github.com/NVIDIA/aistore/cluster/mock"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/etl"
 	"github.com/NVIDIA/aistore/tools"
 	jsoniter "github.com/json-iterator/go"
 )
@@ -63,7 +64,7 @@ func newDiscoverServerPrimary() *proxy {
 	return p
 }
 
-// discoverServerDefaultHandler returns the Smap and BMD with the given version
+// discoverServerDefaultHandler returns the Smap and BMD (and EtlMD) with the given version
 func discoverServerDefaultHandler(sv, lv int64) *httptest.Server {
 	smapVersion := sv
 	bmdVersion := lv
@@ -73,6 +74,7 @@ func discoverServerDefaultHandler(sv, lv int64) *httptest.Server {
 				VoteInProgress: false,
 				Smap:           &smapX{Smap: cluster.Smap{Version: smapVersion}},
 				BMD:            &bucketMD{BMD: cluster.BMD{Version: bmdVersion}},
+				EtlMD:          newTestEtlMD(bmdVersion),
 			}
 			b, _ := jsoniter.Marshal(msg)
 			w.Write(b)
@@ -80,6 +82,16 @@ func discoverServerDefaultHandler(sv, lv int64) *httptest.Server {
 	))
 }
 
+// EtlMD with a single ETL (use '0' for an empty one)
+func newTestEtlMD(version int64) *etlMD {
+	etlMD := newEtlMD()
+	if version > 0 {
+		etlMD.Add(&etl.InitCodeMsg{InitMsgBase: etl.InitMsgBase{IDX: "etl-test"}, Runtime: "python3.8v2"})
+		etlMD.Version = version
+	}
+	return etlMD
+}
+
 // discoverServerVoteOnceHandler returns vote in progress on the first time it is call, returns
 // Smap and BMD on subsequent calls
 func discoverServerVoteOnceHandler(sv, lv int64) *httptest.Server {
@@ -92,6 +104,7 @@ func discoverServerVoteOnceHandler(sv, lv int64) *httptest.Server {
 			VoteInProgress: cnt == 1,
 			Smap:           &smapX{Smap: cluster.Smap{Version: smapVersion}},
 			BMD:            &bucketMD{BMD: cluster.BMD{Version: bmdVersion}},
+			EtlMD:          newTestEtlMD(bmdVersion),
 		}
 		b, _ := jsoniter.Marshal(msg)
 		w.Write(b)
@@ -113,6 +126,7 @@ func discoverServerFailTwiceHandler(sv, lv int64) *httptest.Server {
 				VoteInProgress: false,
 				Smap:           &smapX{Smap: cluster.Smap{Version: smapVersion}},
 				BMD:            &bucketMD{BMD: cluster.BMD{Version: bmdVersion}},
+				EtlMD:          newTestEtlMD(bmdVersion),
 			}
 			b, _ := jsoniter.Marshal(msg)
 			w.Write(b)
@@ -141,6 +155,7 @@ func discoverServerVoteInProgressHandler(_, _ int64) *httptest.Server {
 				VoteInProgress: true,
 				Smap:           &smapX{Smap: cluster.Smap{Version: 12345}},
 				BMD:            &bucketMD{BMD: cluster.BMD{Version: 67890}},
+				EtlMD:          newTestEtlMD(67890),
 			}
 			b, _ := jsoniter.Marshal(msg)
 			w.Write(b)
@@ -319,6 +334,71 @@ func TestDiscoverServers(t *testing.T) {
 					t.Errorf("test case %q: expecting %d, got %d", tc.name, tc.bmdVersion, svm.BMD.Version)
 				}
 			}
+
+			// (EtlMD is versioned the same as BMD)
+			if tc.bmdVersion == 0 {
+				if svm.EtlMD != nil && svm.EtlMD.version() > 0 {
+					t.Errorf("test case %q: expecting nil EtlMD", tc.name)
+				}
+			} else {
+				if svm.EtlMD == nil || svm.EtlMD.version() == 0 {
+					t.Errorf("test case %q: expecting non-empty EtlMD", tc.name)
+				} else if tc.bmdVersion != svm.EtlMD.Version {
+					t.Errorf("test case %q: expecting %d, got %d", tc.name, tc.bmdVersion, svm.EtlMD.Version)
+				}
+			}
 		})
 	}
 }
+
+func TestRegpoolMaxVerEtlMD(t *testing.T) {
+	primary := newDiscoverServerPrimary()
+
+	config := cmn.GCO.BeginUpdate()
+	config.ConfigDir = t.TempDir()
+	cmn.GCO.CommitUpdate(config)
+	eowner := newEtlMDOwnerPrx(config)
+	eowner.put(newTestEtlMD(2))
+	primary.owner.etl = eowner
+
+	smap := newSmap()
+	smap.Version = 1
+	smap.UUID, smap.CreationTime = newClusterUUID()
+
+	stopped := newTestEtlMD(5)
+	stopped.SetStopped("etl-test", true)
+	primary.reg.pool = nodeRegPool{
+		{EtlMD: newTestEtlMD(3)},
+		{EtlMD: stopped},
+		{EtlMD: newTestEtlMD(4)},
+		{EtlMD: newTestEtlMD(0)},
+	}
+	var (
+		before = cluMeta{Smap: smap, BMD: primary.owner.bmd.get(), EtlMD: eowner.get()}
+		after  cluMeta
+	)
+	if primary.regpoolMaxVer(&before, &after) != smap {
+		t.Fatalf("expecting unchanged %s", smap)
+	}
+	if after.EtlMD != stopped {
+		t.Fatalf("expecting max-version %s, got %s", stopped, after.EtlMD)
+	}
+	if eowner.get() != stopped {
+		t.Fatalf("expecting %s to be put, got %s", stopped, eowner.get())
+	}
+
+	// persisted, including the stopped state
+	eowner.put(newEtlMD())
+	eowner.init()
+	if loaded := eowner.get(); loaded.Version != 5 || !loaded.IsStopped("etl-test") {
+		t.Fatalf("expecting persisted %s (stopped), got %s", stopped, loaded)
+	}
+
+	// regpool w/ older versions only
+	primary.reg.pool = nodeRegPool{{EtlMD: newTestEtlMD(1)}}
+	before.EtlMD = eowner.get()
+	primary.regpoolMaxVer(&before, &after)
+	if after.EtlMD != before.EtlMD {
+		t.Fatalf("expecting local %s, got %s", before.EtlMD, after.EtlMD)
+	}
+}
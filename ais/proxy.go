@@ -74,6 +74,9 @@ type (
 		qm         lsobjMem
 		rproxy     reverseProxy
 		notifs     notifs
+		sched      jsched
+		dlbw       dlBudget
+		alerts     alertMgr
 		reg        struct {
 			pool nodeRegPool
 			mu   sync.RWMutex
@@ -168,9 +171,11 @@ func (p *proxy) Run() error {
 	p.htrun.electable = p
 	p.owner.bmd = newBMDOwnerPrx(config)
 	p.owner.etl = newEtlMDOwnerPrx(config)
+	p.owner.sched = newSchedOwner(config)
 
-	p.owner.bmd.init() // initialize owner and load BMD
-	p.owner.etl.init() // initialize owner and load EtlMD
+	p.owner.bmd.init()   // initialize owner and load BMD
+	p.owner.etl.init()   // initialize owner and load EtlMD
+	p.owner.sched.init() // ditto job schedules
 
 	cluster.Init(nil /*cluster.Target*/)
 
@@ -185,6 +190,9 @@ func (p *proxy) Run() error {
 
 	p.notifs.init(p)
 	p.ic.init(p)
+	p.sched.init(p)
+	p.dlbw.init(p)
+	p.alerts.init(p, config)
 	p.qm.init()
 
 	//
@@ -313,6 +321,14 @@ func (p *proxy) recvCluMeta(cluMeta *cluMeta, action, caller string) (err error)
 	} else {
 		glog.Infof("%s: synch %s", p, cluMeta.EtlMD)
 	}
+	// SchedMD
+	if err = p.receiveSchedMD(cluMeta.SchedMD, msg, caller); err != nil {
+		if !isErrDowngrade(err) {
+			glog.Error(cmn.NewErrFailedTo(p, "sync", cluMeta.SchedMD, err))
+		}
+	} else if cluMeta.SchedMD != nil {
+		glog.Infof("%s: synch %s", p, cluMeta.SchedMD)
+	}
 	return
 }
 
@@ -858,6 +874,7 @@ func (p *proxy) metasyncHandler(w http.ResponseWriter, r *http.Request) {
 		newBMD, msgBMD, errBMD       = p.extractBMD(payload, caller)
 		newRMD, msgRMD, errRMD       = p.extractRMD(payload, caller)
 		newEtlMD, msgEtlMD, errEtlMD = p.extractEtlMD(payload, caller)
+		newSchd, msgSchd, errSchd    = p.extractSchedMD(payload, caller)
 		revokedTokens, errTokens     = p.extractRevokedTokenList(payload, caller)
 	)
 	// 2. apply
@@ -876,15 +893,19 @@ func (p *proxy) metasyncHandler(w http.ResponseWriter, r *http.Request) {
 	if errEtlMD == nil && newEtlMD != nil {
 		errEtlMD = p.receiveEtlMD(newEtlMD, msgEtlMD, payload, caller, nil)
 	}
+	if errSchd == nil && newSchd != nil {
+		errSchd = p.receiveSchedMD(newSchd, msgSchd, caller)
+	}
 	if errTokens == nil && revokedTokens != nil {
 		_ = p.authn.updateRevokedList(revokedTokens)
 	}
 	// 3. respond
-	if errConf == nil && errSmap == nil && errBMD == nil && errRMD == nil && errTokens == nil && errEtlMD == nil {
+	if errConf == nil && errSmap == nil && errBMD == nil && errRMD == nil && errTokens == nil && errEtlMD == nil &&
+		errSchd == nil {
 		return
 	}
 	cii.fill(&p.htrun)
-	err.message(errConf, errSmap, errBMD, errRMD, errEtlMD, errTokens)
+	err.message(errConf, errSmap, errBMD, errRMD, errEtlMD, errTokens, errSchd)
 	p.writeErr(w, r, errors.New(cos.MustMarshalToString(err)), http.StatusConflict)
 }
 
@@ -1138,10 +1159,14 @@ func (p *proxy) hpostBucket(w http.ResponseWriter, r *http.Request, msg *apc.Act
 				return
 			}
 		case apc.ActCopyBck:
-			if err = cos.MorphMarshal(msg.Value, &tcbMsg.CopyBckMsg); err != nil {
+			if err = cos.MorphMarshal(msg.Value, tcbMsg); err != nil {
 				p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
 				return
 			}
+			if err = tcbMsg.ValidateCopy(); err != nil {
+				p.writeErr(w, r, err)
+				return
+			}
 		}
 		bckTo, err = newBckFromQuname(query, true /*required*/)
 		if err != nil {
@@ -1185,6 +1210,12 @@ func (p *proxy) hpostBucket(w http.ResponseWriter, r *http.Request, msg *apc.Act
 			p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
 			return
 		}
+		if msg.Action == apc.ActCopyObjects {
+			if err = tcoMsg.ValidateCopy(); err != nil {
+				p.writeErr(w, r, err)
+				return
+			}
+		}
 		bckTo = cluster.CloneBck(&tcoMsg.ToBck)
 		if err = bckTo.Init(p.owner.bmd); err != nil {
 			p.writeErr(w, r, err)
@@ -1203,6 +1234,13 @@ func (p *proxy) hpostBucket(w http.ResponseWriter, r *http.Request, msg *apc.Act
 			return
 		}
 		w.Write([]byte(xactID))
+	case apc.ActReplSync:
+		var xactID string
+		if xactID, err = p.replSync(bck); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		w.Write([]byte(xactID))
 	case apc.ActAddRemoteBck:
 		if err := p.checkAccess(w, r, nil, apc.AceCreateBucket); err != nil {
 			return
@@ -1232,7 +1270,7 @@ func (p *proxy) hpostBucket(w http.ResponseWriter, r *http.Request, msg *apc.Act
 			return
 		}
 		w.Write([]byte(xactID))
-	case apc.ActECEncode:
+	case apc.ActECEncode, apc.ActECReencode:
 		var xactID string
 		if xactID, err = p.ecEncode(bck, msg); err != nil {
 			p.writeErr(w, r, err)
@@ -1684,6 +1722,15 @@ func (p *proxy) httpbckpatch(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	if nprops.Repl.Enabled {
+		// replication destination must exist as well
+		dstBck, _ := nprops.Repl.DstBck() // (validated above)
+		args := bckInitArgs{p: p, w: w, r: r, bck: cluster.CloneBck(dstBck), msg: msg, dpq: apireq.dpq, query: apireq.query}
+		args.createAIS = false
+		if _, err = args.initAndTry(); err != nil {
+			return
+		}
+	}
 	if xactID, err = p.setBucketProps(msg, bck, nprops); err != nil {
 		p.writeErr(w, r, err)
 		return
@@ -2330,7 +2377,8 @@ func (p *proxy) httpdaeget(w http.ResponseWriter, r *http.Request) {
 			p.handlePendingRenamedLB(renamedBucket)
 		}
 		fallthrough // fallthrough
-	case apc.GetWhatConfig, apc.GetWhatSmapVote, apc.GetWhatSnode, apc.GetWhatLog, apc.GetWhatStats:
+	case apc.GetWhatConfig, apc.GetWhatSmapVote, apc.GetWhatSnode, apc.GetWhatLog, apc.GetWhatStats,
+		apc.GetWhatMetricNames:
 		p.htrun.httpdaeget(w, r, query)
 	case apc.GetWhatSysInfo:
 		p.writeJSON(w, r, sys.GetMemCPU(), what)
@@ -2709,6 +2757,10 @@ func (p *proxy) _becomeFinal(ctx *smapModifier, clone *smapX) {
 		pairs = append(pairs, revsPair{etl, msg})
 		glog.Infof("%s: plus %s", p, etl)
 	}
+	if schedMD := p.owner.sched.get(); schedMD.version() > 0 {
+		pairs = append(pairs, revsPair{schedMD, msg})
+		glog.Infof("%s: plus %s", p, schedMD)
+	}
 	debug.Assert(clone._sgl != nil)
 	_ = p.metasyncer.sync(pairs...)
 	p.syncNewICOwners(ctx.smap, clone)
@@ -0,0 +1,557 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xact"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Alert manager: the primary periodically collects node stats (and, when required by the rules,
+// running xactions), evaluates `alert.rules` (see cmn.AlertConf), and keeps the resulting
+// alerts deduplicated by (rule, node). Newly fired and resolved alerts are POST-ed to `alert.webhooks`.
+// Non-primary proxies don't evaluate - they forward api.GetAlerts to the primary and periodically
+// pull (and persist) its alerts, to carry on when elected primary.
+// Alerts are persisted locally - see fname.Alerts.
+
+const (
+	alertName = "alerts"
+
+	dfltAlertIval     = 30 * time.Second
+	dfltKeepResolved  = 24 * time.Hour
+	dfltXactStuckTime = 30 * time.Minute
+
+	maxResolvedAlerts = 1000
+
+	alertMetaver = 1 // persisted alerts
+)
+
+// metrics that are only counted when they occur (see alertMgr.event)
+var alertEvents = []string{apc.AlertMetricKaliveFail}
+
+type (
+	alertMgr struct {
+		p        *proxy
+		client   *http.Client          // webhooks
+		active   map[string]*apc.Alert // (rule, node) => alert
+		resolved []*apc.Alert
+		events   map[string]map[string]int64 // metric => node => count since the last evaluation
+		counters map[string]map[string]int64 // node => counter => cumulative value as of the last evaluation
+		kinds    map[string]cos.StrKVs       // node => metric => kind (see stats.Tracker.GetMetricNames)
+		xacts    map[string]*alertXact       // (node, xaction ID) => progress
+		fpath    string
+		mu       sync.Mutex
+		busy     atomic.Bool
+	}
+	alertXact struct {
+		since time.Time // when last progressed
+		seen  time.Time
+		objs  int64
+		bytes int64
+	}
+	// node => metric => value
+	alertObs map[string]map[string]float64
+)
+
+func (m *alertMgr) init(p *proxy, config *cmn.Config) {
+	m.p = p
+	m.client = cmn.NewClient(cmn.TransportArgs{Timeout: config.Client.Timeout.D()})
+	m.active = make(map[string]*apc.Alert, 8)
+	m.events = make(map[string]map[string]int64, 2)
+	m.counters = make(map[string]map[string]int64, 8)
+	m.kinds = make(map[string]cos.StrKVs, 8)
+	m.xacts = make(map[string]*alertXact, 8)
+	m.fpath = filepath.Join(config.ConfigDir, fname.Alerts)
+	m.load()
+	hk.Reg(alertName+hk.NameSuffix, m.housekeep, dfltAlertIval)
+}
+
+func alertIval(config *cmn.Config) time.Duration {
+	if config.Alert.Interval == 0 {
+		return dfltAlertIval
+	}
+	return config.Alert.Interval.D()
+}
+
+func (m *alertMgr) housekeep() time.Duration {
+	config := cmn.GCO.Get()
+	if !config.Alert.Enabled || len(config.Alert.Rules) == 0 {
+		return alertIval(config)
+	}
+	smap := m.p.owner.smap.get()
+	if !smap.isValid() {
+		return alertIval(config)
+	}
+	// (network round-trips - must not block housekeeper)
+	if m.busy.CAS(false, true) {
+		go func() {
+			if smap.isPrimary(m.p.si) {
+				m.run(config, smap)
+			} else {
+				m.pull(smap)
+			}
+			m.busy.Store(false)
+		}()
+	}
+	return alertIval(config)
+}
+
+// event is counted against the node and evaluated (as the `metric`) at the next run
+func (m *alertMgr) event(metric, sid string) {
+	m.mu.Lock()
+	nodes, ok := m.events[metric]
+	if !ok {
+		nodes = make(map[string]int64, 2)
+		m.events[metric] = nodes
+	}
+	nodes[sid]++
+	m.mu.Unlock()
+}
+
+func (m *alertMgr) run(config *cmn.Config, smap *smapX) {
+	var (
+		now   = time.Now()
+		rules = make([]*apc.AlertRule, 0, len(config.Alert.Rules))
+		stuck bool
+	)
+	for _, s := range config.Alert.Rules {
+		r, err := apc.ParseAlertRule(s)
+		if err != nil {
+			glog.Errorf("%s: %v", m.p, err) // (validated)
+			continue
+		}
+		rules = append(rules, r)
+		stuck = stuck || r.Metric == apc.AlertMetricXactStuck
+	}
+	obs := m.collect(smap)
+	if stuck {
+		m.stuckXacts(obs, config, now)
+	}
+
+	m.mu.Lock()
+	for metric, nodes := range m.events {
+		for sid, n := range nodes {
+			if _, ok := obs[sid]; !ok {
+				obs[sid] = make(map[string]float64, 4) // e.g., removed from the cluster map
+			}
+			obs[sid][metric] = float64(n)
+		}
+		delete(m.events, metric)
+	}
+	for _, vals := range obs {
+		for _, metric := range alertEvents {
+			if _, ok := vals[metric]; !ok {
+				vals[metric] = 0 // (no events)
+			}
+		}
+	}
+	fired, resolved := m.eval(rules, obs, now, config)
+	m.mu.Unlock()
+
+	if len(fired) > 0 || len(resolved) > 0 {
+		m.save(m.list())
+	}
+
+	if len(config.Alert.Webhooks) > 0 {
+		if len(fired) > 0 {
+			m.notify(config, smap, apc.AlertFiring, fired)
+		}
+		if len(resolved) > 0 {
+			m.notify(config, smap, apc.AlertResolved, resolved)
+		}
+	}
+}
+
+// collect stats from all nodes (and self); counters become increments since the previous run
+func (m *alertMgr) collect(smap *smapX) alertObs {
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{
+		Method: http.MethodGet,
+		Path:   apc.URLPathDae.S,
+		Query:  url.Values{apc.QparamWhat: []string{apc.GetWhatStats}},
+	}
+	args.smap = smap
+	args.to = cluster.AllNodes
+	args.timeout = cmn.Timeout.MaxKeepalive()
+	results := m.p.bcastGroup(args)
+	freeBcArgs(args)
+
+	obs := make(alertObs, len(results)+1)
+	for _, res := range results {
+		sid := res.si.ID()
+		if res.err != nil {
+			obs[sid] = map[string]float64{apc.AlertMetricUnreachable: 1}
+			continue
+		}
+		ds := &stats.DaemonStats{}
+		if err := jsoniter.Unmarshal(res.bytes, ds); err != nil {
+			glog.Errorf("%s: failed to unmarshal %s stats: %v", m.p, res.si, err)
+			continue
+		}
+		obs[sid] = m.nodeObs(sid, ds, m.metricKinds(res.si, ds))
+	}
+	freeBcastRes(results)
+	ds := m.p.statsT.GetWhatStats()
+	obs[m.p.si.ID()] = m.nodeObs(m.p.si.ID(), ds, m.metricKinds(m.p.si, ds))
+	for sid := range m.counters {
+		if _, ok := obs[sid]; !ok {
+			delete(m.counters, sid) // no longer in the cluster map
+			delete(m.kinds, sid)
+		}
+	}
+	return obs
+}
+
+// metric kinds as registered by the node itself - fetched once, and again when the node
+// reports metrics that are not known yet (e.g., upon restart)
+func (m *alertMgr) metricKinds(si *cluster.Snode, ds *stats.DaemonStats) cos.StrKVs {
+	sid := si.ID()
+	kinds, ok := m.kinds[sid]
+	if ok {
+		for name := range ds.Tracker {
+			if _, ok = kinds[name]; !ok {
+				break
+			}
+		}
+	}
+	if ok {
+		return kinds
+	}
+	if sid == m.p.si.ID() {
+		kinds = m.p.statsT.GetMetricNames()
+	} else {
+		cargs := allocCargs()
+		{
+			cargs.si = si
+			cargs.req = cmn.HreqArgs{
+				Method: http.MethodGet,
+				Path:   apc.URLPathDae.S,
+				Query:  url.Values{apc.QparamWhat: []string{apc.GetWhatMetricNames}},
+			}
+			cargs.timeout = cmn.Timeout.MaxKeepalive()
+		}
+		res := m.p.call(cargs)
+		freeCargs(cargs)
+		err := res.err
+		if err == nil {
+			kinds = make(cos.StrKVs, len(ds.Tracker))
+			err = jsoniter.Unmarshal(res.bytes, &kinds)
+		}
+		freeCR(res)
+		if err != nil {
+			glog.Errorf("%s: failed to get %s metric names: %v", m.p, si, err)
+			return m.kinds[sid] // (metrics of unknown kind are not evaluated)
+		}
+	}
+	m.kinds[sid] = kinds
+	return kinds
+}
+
+// cumulative metrics (counters, total latencies and throughputs) become increments since the
+// previous run, while gauges (and other metrics) are taken as is; metrics of unknown kind are skipped
+func (m *alertMgr) nodeObs(sid string, ds *stats.DaemonStats, kinds cos.StrKVs) map[string]float64 {
+	vals := make(map[string]float64, len(ds.Tracker)+2)
+	vals[apc.AlertMetricUnreachable] = 0
+	prev, ok := m.counters[sid]
+	if !ok {
+		prev = make(map[string]int64, len(ds.Tracker))
+		m.counters[sid] = prev
+	}
+	for name, v := range ds.Tracker {
+		switch kinds[name] {
+		case "":
+			continue
+		case stats.KindCounter, stats.KindLatency, stats.KindThroughput:
+			// (no increment upon the first run or when the node restarts - the metric remains
+			// unobserved and, therefore, does not change the state of the alerts)
+			if last, ok := prev[name]; ok && v.Value >= last {
+				vals[name] = float64(v.Value - last)
+			}
+			prev[name] = v.Value
+		default:
+			vals[name] = float64(v.Value)
+		}
+	}
+	if len(ds.MPCap) > 0 {
+		var pct int32
+		for _, c := range ds.MPCap {
+			pct = cos.MaxI32(pct, c.PctUsed)
+		}
+		vals[apc.AlertMetricCapacity] = float64(pct)
+	}
+	return vals
+}
+
+// count running xactions that haven't made any progress in the last `alert.xact_stuck_time`
+// (idle on-demand xactions, and paused rebalance, don't count)
+func (m *alertMgr) stuckXacts(obs alertObs, config *cmn.Config, now time.Time) {
+	stuckTime := dfltXactStuckTime
+	if config.Alert.XactStuckTime != 0 {
+		stuckTime = config.Alert.XactStuckTime.D()
+	}
+	var (
+		running = true
+		args    = allocBcArgs()
+	)
+	args.req = cmn.HreqArgs{
+		Method: http.MethodGet,
+		Path:   apc.URLPathXactions.S,
+		Body:   cos.MustMarshal(xact.QueryMsg{OnlyRunning: &running}),
+		Query:  url.Values{apc.QparamWhat: []string{apc.GetWhatQueryXactStats}},
+	}
+	args.to = cluster.Targets
+	args.timeout = cmn.Timeout.MaxKeepalive()
+	results := m.p.bcastGroup(args)
+	freeBcArgs(args)
+
+	for _, res := range results {
+		if res.err != nil {
+			continue
+		}
+		var (
+			snaps []*xact.SnapExt
+			sid   = res.si.ID()
+			cnt   int
+		)
+		if err := jsoniter.Unmarshal(res.bytes, &snaps); err != nil {
+			glog.Errorf("%s: failed to unmarshal %s xactions: %v", m.p, res.si, err)
+			continue
+		}
+		for _, snap := range snaps {
+			if !snap.Running() || alertXactIdle(snap) {
+				continue
+			}
+			key := sid + "/" + snap.ID
+			x, ok := m.xacts[key]
+			if !ok || x.objs != snap.Stats.Objs || x.bytes != snap.Stats.Bytes {
+				m.xacts[key] = &alertXact{since: now, seen: now, objs: snap.Stats.Objs, bytes: snap.Stats.Bytes}
+				continue
+			}
+			x.seen = now
+			if now.Sub(x.since) >= stuckTime {
+				cnt++
+			}
+		}
+		if vals, ok := obs[sid]; ok {
+			vals[apc.AlertMetricXactStuck] = float64(cnt)
+		}
+	}
+	freeBcastRes(results)
+
+	for key, x := range m.xacts {
+		if x.seen != now {
+			delete(m.xacts, key) // finished (or gone)
+		}
+	}
+}
+
+func alertXactIdle(snap *xact.SnapExt) bool {
+	ext, ok := snap.Ext.(map[string]any)
+	if !ok {
+		return false
+	}
+	if idle, ok := ext["is_idle"].(bool); ok && idle {
+		return true
+	}
+	paused, ok := ext["paused"].(string)
+	return ok && paused != ""
+}
+
+// evaluate rules over the observed values (under lock); returns newly fired and resolved alerts
+// - an alert gets resolved only when its condition is evaluated as false, or when its rule or node
+// no longer exists; a metric that is (temporarily) not observed - e.g., unreachable node or the first
+// increment of a counter - keeps the alert as is
+func (m *alertMgr) eval(rules []*apc.AlertRule, obs alertObs, now time.Time, config *cmn.Config) (fired, resolved []*apc.Alert) {
+	firing := make(cos.StrSet, len(m.active))
+	for _, r := range rules {
+		for sid, vals := range obs {
+			key := r.Name + "/" + sid
+			v, ok := vals[r.Metric]
+			if !ok {
+				if _, ok := m.active[key]; ok {
+					firing.Set(key)
+				}
+				continue
+			}
+			if !r.Eval(v) {
+				continue
+			}
+			firing.Set(key)
+			if a, ok := m.active[key]; ok {
+				a.LastSeen, a.Value = now, v
+				a.Count++
+				continue
+			}
+			a := &apc.Alert{
+				Started:  now,
+				LastSeen: now,
+				Rule:     r.Name,
+				Severity: r.Severity,
+				Node:     sid,
+				Cond:     r.Cond(),
+				Value:    v,
+				Count:    1,
+			}
+			m.active[key] = a
+			fired = append(fired, a)
+			glog.Warningf("%s: alert %q (%s) fired on %s: %s = %v", m.p, r.Name, r.Severity, sid, r.Metric, v)
+		}
+	}
+	for key, a := range m.active {
+		if firing.Contains(key) {
+			continue
+		}
+		delete(m.active, key)
+		a.Resolved = now
+		m.resolved = append(m.resolved, a)
+		resolved = append(resolved, a)
+		glog.Infof("%s: alert %q resolved on %s", m.p, a.Rule, a.Node)
+	}
+
+	// cleanup
+	keep := dfltKeepResolved
+	if config.Alert.KeepResolved != 0 {
+		keep = config.Alert.KeepResolved.D()
+	}
+	i := 0
+	for i < len(m.resolved) && (now.Sub(m.resolved[i].Resolved) > keep || len(m.resolved)-i > maxResolvedAlerts) {
+		i++
+	}
+	if i > 0 {
+		m.resolved = append(m.resolved[:0], m.resolved[i:]...)
+	}
+	return
+}
+
+func (m *alertMgr) list() *apc.AlertList {
+	m.mu.Lock()
+	al := &apc.AlertList{
+		Active:   make([]*apc.Alert, 0, len(m.active)),
+		Resolved: make([]*apc.Alert, 0, len(m.resolved)),
+	}
+	for _, a := range m.active {
+		c := *a
+		al.Active = append(al.Active, &c)
+	}
+	for _, a := range m.resolved {
+		c := *a
+		al.Resolved = append(al.Resolved, &c)
+	}
+	m.mu.Unlock()
+	al.Sort()
+	return al
+}
+
+// (non-primary) copy the primary's alerts, to take over if and when elected
+func (m *alertMgr) pull(smap *smapX) {
+	cargs := allocCargs()
+	{
+		cargs.si = smap.Primary
+		cargs.req = cmn.HreqArgs{
+			Method: http.MethodGet,
+			Path:   apc.URLPathClu.S,
+			Query:  url.Values{apc.QparamWhat: []string{apc.GetWhatAlerts}},
+		}
+		cargs.timeout = cmn.Timeout.MaxKeepalive()
+	}
+	res := m.p.call(cargs)
+	freeCargs(cargs)
+	err := res.err
+	al := &apc.AlertList{}
+	if err == nil {
+		err = jsoniter.Unmarshal(res.bytes, al)
+	}
+	freeCR(res)
+	if err != nil {
+		if glog.FastV(4, glog.SmoduleAIS) {
+			glog.Infof("%s: failed to get alerts from %s: %v", m.p, smap.Primary, err)
+		}
+		return
+	}
+	m.mu.Lock()
+	changed := len(al.Active) != len(m.active) || len(al.Resolved) != len(m.resolved)
+	m.restore(al)
+	m.mu.Unlock()
+	if changed {
+		m.save(al)
+	}
+}
+
+// (under lock)
+func (m *alertMgr) restore(al *apc.AlertList) {
+	m.active = make(map[string]*apc.Alert, len(al.Active))
+	for _, a := range al.Active {
+		m.active[a.Rule+"/"+a.Node] = a
+	}
+	m.resolved = al.Resolved
+}
+
+func (m *alertMgr) load() {
+	al := &apc.AlertList{}
+	if _, err := jsp.Load(m.fpath, al, jsp.CksumSign(alertMetaver)); err != nil {
+		if !os.IsNotExist(err) {
+			glog.Errorf("%s: failed to load alerts: %v", m.p, err)
+		}
+		return
+	}
+	m.restore(al)
+	if len(al.Active) > 0 {
+		glog.Infof("%s: loaded %d active alert(s)", m.p, len(al.Active))
+	}
+}
+
+func (m *alertMgr) save(al *apc.AlertList) {
+	if err := jsp.Save(m.fpath, al, jsp.CksumSign(alertMetaver), nil); err != nil {
+		glog.Errorf("%s: failed to save alerts: %v", m.p, err)
+	}
+}
+
+func (m *alertMgr) notify(config *cmn.Config, smap *smapX, status string, alerts []*apc.Alert) {
+	body := cos.MustMarshal(&apc.AlertNotif{Cluster: smap.UUID, Status: status, Alerts: alerts})
+	for _, u := range config.Alert.Webhooks {
+		req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(body))
+		if err != nil {
+			glog.Errorf("%s: alert webhook %q: %v", m.p, u, err)
+			continue
+		}
+		req.Header.Set(cos.HdrContentType, cos.ContentJSON)
+		resp, err := m.client.Do(req) //nolint:bodyclose // closed below
+		if err != nil {
+			glog.Errorf("%s: failed to send %d %s alert(s) to %q: %v", m.p, len(alerts), status, u, err)
+			continue
+		}
+		cos.DrainReader(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusBadRequest {
+			glog.Errorf("%s: alert webhook %q responded with status %d", m.p, u, resp.StatusCode)
+		}
+	}
+}
+
+// GET /v1/cluster?what=alerts
+func (p *proxy) getAlerts(w http.ResponseWriter, r *http.Request, what string) {
+	if p.forwardCP(w, r, nil, what) {
+		return
+	}
+	p.writeJSON(w, r, p.alerts.list(), what)
+}
@@ -0,0 +1,200 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/stats"
+	jsoniter "github.com/json-iterator/go"
+)
+
+func newTestAlertMgr() *alertMgr {
+	p := &proxy{}
+	p.si = cluster.NewSnode("primary", apc.Proxy, cluster.NetInfo{}, cluster.NetInfo{}, cluster.NetInfo{})
+	return &alertMgr{
+		p:        p,
+		active:   make(map[string]*apc.Alert),
+		events:   make(map[string]map[string]int64),
+		counters: make(map[string]map[string]int64),
+		kinds:    make(map[string]cos.StrKVs),
+		xacts:    make(map[string]*alertXact),
+	}
+}
+
+// (as received from a target)
+func testAlertStats(t *testing.T, ioErrs, degraded int64, pct int32) *stats.DaemonStats {
+	s := fmt.Sprintf(`{"tracker": {%q: %d, %q: %d}, "capacity": {"/a": {"pct_used": %d}}}`,
+		stats.ErrIOCount, ioErrs, stats.DegradedMpathCount, degraded, pct)
+	ds := &stats.DaemonStats{}
+	if err := jsoniter.Unmarshal([]byte(s), ds); err != nil {
+		t.Fatal(err)
+	}
+	return ds
+}
+
+var testAlertKinds = cos.StrKVs{stats.ErrIOCount: stats.KindCounter, stats.DegradedMpathCount: stats.KindGauge}
+
+func TestAlertRules(t *testing.T) {
+	for _, s := range []string{"", "oos", "oos:capacity.pct_max", "oos:>95", "oos:capacity.pct_max>x", "oos:x>1:fatal", ":x>1"} {
+		if _, err := apc.ParseAlertRule(s); err == nil {
+			t.Errorf("expected %q to fail", s)
+		}
+	}
+	r, err := apc.ParseAlertRule("oos:capacity.pct_max>=95:critical")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Metric != apc.AlertMetricCapacity || r.Op != ">=" || r.Threshold != 95 || r.Severity != apc.AlertCritical {
+		t.Fatalf("unexpected %+v", r)
+	}
+	if !r.Eval(95) || r.Eval(94.9) {
+		t.Fatalf("%s: wrong evaluation", r.Cond())
+	}
+	if r, _ = apc.ParseAlertRule("low-memory:mem.pressure>2"); r.Severity != apc.AlertWarning || r.Eval(2) {
+		t.Fatalf("unexpected %+v", r)
+	}
+}
+
+func TestAlertEval(t *testing.T) {
+	var (
+		m      = newTestAlertMgr()
+		config = &cmn.Config{}
+		now    = time.Now()
+		rules  []*apc.AlertRule
+	)
+	for _, s := range []string{"oos:capacity.pct_max>=95:critical", "disk-errors:err.io.n>0:critical"} {
+		r, err := apc.ParseAlertRule(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules = append(rules, r)
+	}
+	ds := func(ioErrs int64, pct int32) *stats.DaemonStats { return testAlertStats(t, ioErrs, 0, pct) }
+
+	// first run: counters establish their baseline
+	obs := alertObs{"t1": m.nodeObs("t1", ds(5, 96), testAlertKinds), "t2": m.nodeObs("t2", ds(0, 50), testAlertKinds)}
+	fired, resolved := m.eval(rules, obs, now, config)
+	if len(fired) != 1 || fired[0].Rule != "oos" || fired[0].Node != "t1" || len(resolved) != 0 {
+		t.Fatalf("unexpected fired %v, resolved %v", fired, resolved)
+	}
+
+	// I/O errors on t2; t1 still out of space (deduplicated)
+	now = now.Add(time.Minute)
+	obs = alertObs{"t1": m.nodeObs("t1", ds(5, 97), testAlertKinds), "t2": m.nodeObs("t2", ds(3, 50), testAlertKinds)}
+	fired, resolved = m.eval(rules, obs, now, config)
+	if len(fired) != 1 || fired[0].Rule != "disk-errors" || fired[0].Value != 3 || len(resolved) != 0 {
+		t.Fatalf("unexpected fired %v, resolved %v", fired, resolved)
+	}
+	if a := m.active["oos/t1"]; a == nil || a.Count != 2 || a.Value != 97 || !a.LastSeen.Equal(now) {
+		t.Fatalf("unexpected %+v", a)
+	}
+
+	// no new errors; capacity back to normal
+	now = now.Add(time.Minute)
+	obs = alertObs{"t1": m.nodeObs("t1", ds(5, 80), testAlertKinds), "t2": m.nodeObs("t2", ds(3, 50), testAlertKinds)}
+	fired, resolved = m.eval(rules, obs, now, config)
+	if len(fired) != 0 || len(resolved) != 2 {
+		t.Fatalf("unexpected fired %v, resolved %v", fired, resolved)
+	}
+	al := m.list()
+	if len(al.Active) != 0 || len(al.Resolved) != 2 || !al.Resolved[0].Resolved.Equal(now) {
+		t.Fatalf("unexpected %+v", al)
+	}
+
+	// resolved alerts expire
+	config.Alert.KeepResolved = cos.Duration(time.Hour)
+	m.eval(rules, alertObs{}, now.Add(2*time.Hour), config)
+	if al = m.list(); len(al.Resolved) != 0 {
+		t.Fatalf("expected no resolved alerts, got %d", len(al.Resolved))
+	}
+}
+
+func TestAlertGaugesAndMissing(t *testing.T) {
+	var (
+		m      = newTestAlertMgr()
+		config = &cmn.Config{}
+		now    = time.Now()
+	)
+	r, err := apc.ParseAlertRule("degraded:mpath.degraded.n>0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules := []*apc.AlertRule{r}
+
+	// gauge (even if named "*.n") is taken as is - no baseline, no increments
+	obs := alertObs{"t1": m.nodeObs("t1", testAlertStats(t, 0, 1, 50), testAlertKinds)}
+	if fired, _ := m.eval(rules, obs, now, config); len(fired) != 1 {
+		t.Fatalf("expected degraded alert, got %v", fired)
+	}
+	now = now.Add(time.Minute)
+	obs = alertObs{"t1": m.nodeObs("t1", testAlertStats(t, 0, 1, 50), testAlertKinds)}
+	if fired, resolved := m.eval(rules, obs, now, config); len(fired) != 0 || len(resolved) != 0 {
+		t.Fatalf("unexpected fired %v, resolved %v", fired, resolved)
+	}
+
+	// unreachable (metric missing) - remains active
+	now = now.Add(time.Minute)
+	obs = alertObs{"t1": {apc.AlertMetricUnreachable: 1}}
+	if _, resolved := m.eval(rules, obs, now, config); len(resolved) != 0 || m.active["degraded/t1"] == nil {
+		t.Fatalf("unexpectedly resolved %v", resolved)
+	}
+	// metric of unknown kind is not observed - ditto
+	obs = alertObs{"t1": m.nodeObs("t1", testAlertStats(t, 0, 0, 50), cos.StrKVs{})}
+	if _, resolved := m.eval(rules, obs, now, config); len(resolved) != 0 {
+		t.Fatalf("unexpectedly resolved %v", resolved)
+	}
+
+	// back to normal
+	obs = alertObs{"t1": m.nodeObs("t1", testAlertStats(t, 0, 0, 50), testAlertKinds)}
+	if _, resolved := m.eval(rules, obs, now, config); len(resolved) != 1 {
+		t.Fatalf("expected resolved alert, got %v", resolved)
+	}
+
+	// node gone
+	obs = alertObs{"t2": m.nodeObs("t2", testAlertStats(t, 0, 1, 50), testAlertKinds)}
+	m.eval(rules, obs, now, config)
+	if _, resolved := m.eval(rules, alertObs{}, now, config); len(resolved) != 1 || resolved[0].Node != "t2" {
+		t.Fatalf("expected resolved alert, got %v", resolved)
+	}
+}
+
+func TestAlertPersist(t *testing.T) {
+	var (
+		m      = newTestAlertMgr()
+		config = &cmn.Config{}
+		now    = time.Now()
+	)
+	m.fpath = filepath.Join(t.TempDir(), fname.Alerts)
+	r, err := apc.ParseAlertRule("oos:capacity.pct_max>=95:critical")
+	if err != nil {
+		t.Fatal(err)
+	}
+	obs := alertObs{"t1": m.nodeObs("t1", testAlertStats(t, 0, 0, 96), testAlertKinds)}
+	m.eval([]*apc.AlertRule{r}, obs, now, config)
+	m.save(m.list())
+
+	// e.g., restarted (or newly elected) primary
+	m2 := newTestAlertMgr()
+	m2.fpath = m.fpath
+	m2.load()
+	a := m2.active["oos/t1"]
+	if a == nil || a.Severity != apc.AlertCritical || !a.Started.Equal(now) {
+		t.Fatalf("unexpected %+v", a)
+	}
+	// (deduplicated with the loaded one)
+	obs = alertObs{"t1": m2.nodeObs("t1", testAlertStats(t, 0, 0, 97), testAlertKinds)}
+	if fired, _ := m2.eval([]*apc.AlertRule{r}, obs, now.Add(time.Minute), config); len(fired) != 0 || a.Count != 2 {
+		t.Fatalf("unexpected fired %v (count %d)", fired, a.Count)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -30,6 +31,10 @@ import (
 //
 
 func (p *proxy) clusterHandler(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, apc.URLPathCluSched.S) {
+		p.httpsched(w, r, apc.URLPathCluSched)
+		return
+	}
 	switch r.Method {
 	case http.MethodGet:
 		p.httpcluget(w, r)
@@ -77,6 +82,10 @@ func (p *proxy) httpcluget(w http.ResponseWriter, r *http.Request) {
 		p.queryClusterSysinfo(w, r, what, query)
 	case apc.GetWhatMountpaths:
 		p.queryClusterMountpaths(w, r, what, query)
+	case apc.GetWhatRebPlan:
+		p.planRebalance(w, r, what, query)
+	case apc.GetWhatAlerts:
+		p.getAlerts(w, r, what)
 	case apc.GetWhatRemoteAIS:
 		all, err := p.getRemAises(true /*refresh*/)
 		if err != nil {
@@ -275,6 +284,112 @@ func (p *proxy) queryClusterMountpaths(w http.ResponseWriter, r *http.Request, w
 	_ = p.writeJSON(w, r, out, what)
 }
 
+// apc.GetWhatRebPlan: dry-run rebalance - each target tallies its objects that would
+// migrate given hypothetical cluster map change, proxy aggregates the results
+func (p *proxy) planRebalance(w http.ResponseWriter, r *http.Request, what string, query url.Values) {
+	var (
+		msg    apc.ActValPlanReb
+		smap   = p.owner.smap.get()
+		config = cmn.GCO.Get()
+	)
+	if err := cmn.ReadJSON(w, r, &msg); err != nil {
+		return
+	}
+	if err := msg.Validate(); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	for _, tid := range msg.Remove {
+		if smap.GetTarget(tid) == nil {
+			p.writeErr(w, r, &errNodeNotFound{"rebalance plan", tid, p.si, smap}, http.StatusNotFound)
+			return
+		}
+	}
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodGet, Path: apc.URLPathDae.S, Query: query, Body: cos.MustMarshal(&msg)}
+	args.timeout = config.Client.TimeoutLong.D() // (traversing)
+	args.to = cluster.Targets
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+
+	tplans := make(map[string]*apc.RebPlanTarget, len(results))
+	for _, res := range results {
+		if res.err != nil {
+			p.writeErr(w, r, res.toErr())
+			freeBcastRes(results)
+			return
+		}
+		tplan := &apc.RebPlanTarget{}
+		if err := jsoniter.Unmarshal(res.bytes, tplan); err != nil {
+			p.writeErr(w, r, err)
+			freeBcastRes(results)
+			return
+		}
+		tplans[res.si.ID()] = tplan
+	}
+	freeBcastRes(results)
+
+	numTargets := smap.CountActiveTargets() + len(msg.Add) - len(msg.Remove)
+	plan := aggrRebPlan(tplans, &config.Rebalance, numTargets)
+	p.writeJSON(w, r, plan, what)
+}
+
+func aggrRebPlan(tplans map[string]*apc.RebPlanTarget, conf *cmn.RebalanceConf, numTargets int) *apc.RebPlan {
+	var (
+		plan = &apc.RebPlan{
+			Buckets: make(map[string]*apc.RebPlanStats, 8),
+			Pairs:   make(map[string]*apc.RebPlanStats, len(tplans)),
+			Targets: make(map[string]*apc.RebPlanNode, len(tplans)),
+		}
+		node = func(tid string) *apc.RebPlanNode {
+			if plan.Targets[tid] == nil {
+				plan.Targets[tid] = &apc.RebPlanNode{}
+			}
+			return plan.Targets[tid]
+		}
+		known, sum int64
+	)
+	for tid, tplan := range tplans {
+		for name, s := range tplan.Buckets {
+			if plan.Buckets[name] == nil {
+				plan.Buckets[name] = &apc.RebPlanStats{}
+			}
+			plan.Buckets[name].Add(s.Objs, s.Bytes)
+			plan.Total.Add(s.Objs, s.Bytes)
+		}
+		for dst, s := range tplan.Dsts {
+			plan.Pairs[tid+apc.PlanPairSepa+dst] = &apc.RebPlanStats{Objs: s.Objs, Bytes: s.Bytes}
+			node(tid).Sent.Add(s.Objs, s.Bytes)
+			node(dst).Recv.Add(s.Objs, s.Bytes)
+		}
+		if tplan.Throughput > 0 {
+			known++
+			sum += tplan.Throughput
+		}
+	}
+	if known == 0 || plan.Total.Bytes == 0 {
+		return plan
+	}
+	// throughput per target: observed (or the average of observed), subject to the configured limit
+	var eta time.Duration
+	for tid, n := range plan.Targets {
+		throughput := sum / known
+		if tplan, ok := tplans[tid]; ok && tplan.Throughput > 0 {
+			throughput = tplan.Throughput
+		}
+		if conf.BytesPerSec > 0 && numTargets > 0 {
+			throughput = cos.MinI64(throughput, conf.BytesPerSec/int64(numTargets))
+		}
+		if throughput <= 0 {
+			continue
+		}
+		bytes := cos.MaxI64(n.Sent.Bytes, n.Recv.Bytes)
+		eta = cos.MaxDuration(eta, time.Duration(float64(bytes)/float64(throughput)*float64(time.Second)))
+	}
+	plan.ETA = cos.Duration(eta)
+	return plan
+}
+
 // helper methods for querying targets
 
 func (p *proxy) _queryTargets(w http.ResponseWriter, r *http.Request, query url.Values) (cos.JSONRawMsgs, bool) {
@@ -355,6 +470,9 @@ func (p *proxy) httpclupost(w http.ResponseWriter, r *http.Request) {
 		}
 		nsi = regReq.SI
 		nsi.DaeID = si.ID()
+		if nsi.Domain == "" {
+			nsi.Domain = si.Domain // as per node's (local) config
+		}
 	case apc.SelfJoin: // auto-join at node startup
 		if cmn.ReadJSON(w, r, &regReq) != nil {
 			return
@@ -636,6 +754,9 @@ func (p *proxy) _updFinal(ctx *smapModifier, clone *smapX) {
 	if etlMD != nil && etlMD.version() > 0 {
 		pairs = append(pairs, revsPair{etlMD, aisMsg})
 	}
+	if schedMD := p.owner.sched.get(); schedMD.version() > 0 && ctx.nsi.IsProxy() {
+		pairs = append(pairs, revsPair{schedMD, aisMsg})
+	}
 	if ctx.rmd != nil && ctx.nsi.IsTarget() && mustRunRebalance(ctx, clone) {
 		pairs = append(pairs, revsPair{ctx.rmd, aisMsg})
 		nl := xact.NewXactNL(xact.RebID2S(ctx.rmd.version()), apc.ActRebalance, &clone.Smap, nil)
@@ -901,25 +1022,35 @@ func (p *proxy) xactStart(w http.ResponseWriter, r *http.Request, msg *apc.Actio
 	}
 
 	// all the rest `startable` (see xaction/api.go)
-	body := cos.MustMarshal(apc.ActionMsg{Action: msg.Action, Value: xactMsg})
+	if err := p.xstart(&xactMsg); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	w.Write([]byte(xactMsg.ID))
+}
+
+// start xaction on all targets (see also jsched.start)
+func (p *proxy) xstart(xactMsg *xact.QueryMsg) (err error) {
+	body := cos.MustMarshal(apc.ActionMsg{Action: apc.ActXactStart, Value: xactMsg})
 	args := allocBcArgs()
 	args.req = cmn.HreqArgs{Method: http.MethodPut, Path: apc.URLPathXactions.S, Body: body}
 	args.to = cluster.Targets
 	results := p.bcastGroup(args)
 	freeBcArgs(args)
 	for _, res := range results {
-		if res.err == nil {
-			continue
+		if res.err != nil {
+			err = res.toErr()
+			break
 		}
-		p.writeErr(w, r, res.toErr())
-		freeBcastRes(results)
-		return
 	}
 	freeBcastRes(results)
+	if err != nil {
+		return
+	}
 	smap := p.owner.smap.get()
 	nl := xact.NewXactNL(xactMsg.ID, xactMsg.Kind, &smap.Smap, nil)
 	p.ic.registerEqual(regIC{smap: smap, nl: nl})
-	w.Write([]byte(xactMsg.ID))
+	return
 }
 
 func (p *proxy) xactStop(w http.ResponseWriter, r *http.Request, msg *apc.ActionMsg) {
@@ -947,15 +1078,26 @@ func (p *proxy) xactStop(w http.ResponseWriter, r *http.Request, msg *apc.Action
 }
 
 func (p *proxy) rebalanceCluster(w http.ResponseWriter, r *http.Request) {
-	// note operational priority over config-disabled `errRebalanceDisabled`
-	if err := p.canRunRebalance(); err != nil && err != errRebalanceDisabled {
+	rebID, err := p.startRebalance()
+	if err != nil {
 		p.writeErr(w, r, err)
 		return
 	}
+	if rebID != "" {
+		w.Write([]byte(rebID))
+	}
+}
+
+// returns empty ID when there's nothing to do
+func (p *proxy) startRebalance() (string, error) {
+	// note operational priority over config-disabled `errRebalanceDisabled`
+	if err := p.canRunRebalance(); err != nil && err != errRebalanceDisabled {
+		return "", err
+	}
 	if smap := p.owner.smap.get(); smap.CountActiveTargets() < 2 {
 		err := &errNotEnoughTargets{p.si, smap, 2}
 		glog.Warningf("%s: %v - nothing to do", p, err)
-		return
+		return "", nil
 	}
 	rmdCtx := &rmdModifier{
 		pre:   func(_ *rmdModifier, clone *rebMD) { clone.inc() },
@@ -965,10 +1107,9 @@ func (p *proxy) rebalanceCluster(w http.ResponseWriter, r *http.Request) {
 	}
 	rmdClone, err := p.owner.rmd.modify(rmdCtx)
 	if err != nil {
-		p.writeErr(w, r, err)
-		return
+		return "", err
 	}
-	w.Write([]byte(xact.RebID2S(rmdClone.version())))
+	return xact.RebID2S(rmdClone.version()), nil
 }
 
 func (p *proxy) resilverOne(w http.ResponseWriter, r *http.Request, msg *apc.ActionMsg, xactMsg xact.QueryMsg) {
@@ -1668,7 +1809,12 @@ func mustRunRebalance(ctx *smapModifier, cur *smapX) bool {
 		if si.IsProxy() || si.IsAnySet(cluster.NodeFlagsMaintDecomm) {
 			continue
 		}
-		if prev.GetNodeNotMaint(si.ID()) == nil { // added or activated
+		psi := prev.GetNodeNotMaint(si.ID())
+		if psi == nil { // added or activated
+			ctx._mustReb = true
+			goto ret
+		}
+		if psi.Domain != si.Domain { // moved to another failure domain (see cluster.HrwTargetList)
 			ctx._mustReb = true
 			goto ret
 		}
@@ -0,0 +1,52 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RebalancePlan", func() {
+	const mib = 1024 * 1024
+
+	tplans := map[string]*apc.RebPlanTarget{
+		"t1": {
+			Buckets:    map[string]*apc.RebPlanStats{"ais://a": {Objs: 3, Bytes: 30 * mib}},
+			Dsts:       map[string]*apc.RebPlanStats{"t3": {Objs: 3, Bytes: 30 * mib}},
+			Throughput: 10 * mib,
+		},
+		"t2": {
+			Buckets: map[string]*apc.RebPlanStats{"ais://a": {Objs: 1, Bytes: 10 * mib}, "ais://b": {Objs: 2, Bytes: 20 * mib}},
+			Dsts:    map[string]*apc.RebPlanStats{"t3": {Objs: 1, Bytes: 10 * mib}, "t1": {Objs: 2, Bytes: 20 * mib}},
+		},
+	}
+
+	It("should aggregate target plans", func() {
+		plan := aggrRebPlan(tplans, &cmn.RebalanceConf{}, 3)
+		Expect(plan.Total).To(Equal(apc.RebPlanStats{Objs: 6, Bytes: 60 * mib}))
+		Expect(plan.Buckets).To(HaveKey("ais://a"))
+		Expect(plan.Buckets["ais://a"].Objs).To(BeEquivalentTo(4))
+		Expect(plan.Pairs).To(HaveKey("t2" + apc.PlanPairSepa + "t1"))
+		Expect(plan.Pairs["t2"+apc.PlanPairSepa+"t1"].Bytes).To(BeEquivalentTo(20 * mib))
+		Expect(plan.Targets).To(HaveKey("t3"))
+		Expect(plan.Targets["t3"].Recv.Objs).To(BeEquivalentTo(4))
+		Expect(plan.Targets["t3"].Sent.Objs).To(BeZero())
+	})
+
+	It("should estimate time", func() {
+		// the slowest: t3 receiving 40MiB at (the average of observed) 10MiB/s
+		plan := aggrRebPlan(tplans, &cmn.RebalanceConf{}, 3)
+		Expect(plan.ETA.D()).To(Equal(4 * time.Second))
+
+		// ditto, limited to 15MiB/s cluster-wide
+		plan = aggrRebPlan(tplans, &cmn.RebalanceConf{BytesPerSec: 15 * mib}, 3)
+		Expect(plan.ETA.D()).To(Equal(8 * time.Second))
+	})
+})
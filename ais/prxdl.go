@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -22,11 +23,16 @@ import (
 )
 
 // [METHOD] /v1/download
+// (and /v1/download/schedule - see prxsched.go)
 func (p *proxy) downloadHandler(w http.ResponseWriter, r *http.Request) {
 	if !p.ClusterStarted() {
 		w.WriteHeader(http.StatusServiceUnavailable)
 		return
 	}
+	if strings.HasPrefix(r.URL.Path, apc.URLPathDownloadSched.S) {
+		p.httpsched(w, r, apc.URLPathDownloadSched)
+		return
+	}
 	switch r.Method {
 	case http.MethodGet, http.MethodDelete:
 		p.httpdladm(w, r)
@@ -83,8 +89,6 @@ func (p *proxy) httpdlpost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jobID := dload.PrefixJobID + cos.GenUUID() // prefix to visually differentiate vs. xaction IDs
-
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		p.writeErrStatusf(w, r, http.StatusInternalServerError, "Error starting download: %v", err)
@@ -94,27 +98,15 @@ func (p *proxy) httpdlpost(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
-
-	var progressInterval = dload.DownloadProgressInterval
-	if dlBase.ProgressInterval != "" {
-		ival, err := time.ParseDuration(dlBase.ProgressInterval)
-		if err != nil {
-			p.writeErrf(w, r, "%s: invalid progress interval %q: %v", p, dlBase.ProgressInterval, err)
-			return
-		}
-		progressInterval = ival
+	// limited jobs are started by the primary (see dlBudget)
+	if !dlBase.Limits.IsZero() && p.forwardCP(w, r, nil, "download", body) {
+		return
 	}
-
-	xactID := cos.GenUUID()
-	if errCode, err := p.dlstart(r, xactID, jobID, body); err != nil {
+	jobID, errCode, err := p.dlstart(body, dlb.Type, &dlBase)
+	if err != nil {
 		p.writeErrStatusf(w, r, errCode, "Error starting download: %v", err)
 		return
 	}
-	smap := p.owner.smap.get()
-	nl := dload.NewDownloadNL(jobID, string(dlb.Type), &smap.Smap, progressInterval)
-	nl.SetOwner(equalIC)
-	p.ic.registerEqual(regIC{nl: nl, smap: smap})
-
 	w.Header().Set(cos.HdrContentType, cos.ContentJSON)
 	b := cos.MustMarshal(dload.DlPostResp{ID: jobID})
 	w.Write(b)
@@ -239,12 +231,40 @@ func (p *proxy) dlstatus(nl nl.NotifListener) ([]byte, int, error) {
 	return respJSON, http.StatusOK, nil
 }
 
-func (p *proxy) dlstart(r *http.Request, xactID, jobID string, body []byte) (errCode int, err error) {
+// start new download job and register the corresponding notification listener with IC
+// (used by the POST handler and by the scheduler - see prxsched.go)
+func (p *proxy) dlstart(body []byte, dlt dload.Type, dlBase *dload.Base) (jobID string, errCode int, err error) {
+	var progressInterval = dload.DownloadProgressInterval
+	if dlBase.ProgressInterval != "" {
+		ival, errV := time.ParseDuration(dlBase.ProgressInterval)
+		if errV != nil {
+			err = fmt.Errorf("%s: invalid progress interval %q: %v", p, dlBase.ProgressInterval, errV)
+			return "", http.StatusBadRequest, err
+		}
+		progressInterval = ival
+	}
+
+	jobID = dload.PrefixJobID + cos.GenUUID() // prefix to visually differentiate vs. xaction IDs
+	xactID := cos.GenUUID()
+	if errCode, err = p.dlbcast(xactID, jobID, body); err != nil {
+		return
+	}
+	smap := p.owner.smap.get()
+	nl := dload.NewDownloadNL(jobID, string(dlt), &smap.Smap, progressInterval)
+	nl.SetOwner(equalIC)
+	p.ic.registerEqual(regIC{nl: nl, smap: smap})
+	if !dlBase.Limits.IsZero() {
+		p.dlbw.add(jobID, xactID, dlBase.Limits)
+	}
+	return
+}
+
+func (p *proxy) dlbcast(xactID, jobID string, body []byte) (errCode int, err error) {
 	query := make(url.Values, 2)
 	query.Set(apc.QparamUUID, xactID)
 	query.Set(apc.QparamJobID, jobID)
 	args := allocBcArgs()
-	args.req = cmn.HreqArgs{Method: http.MethodPost, Path: r.URL.Path, Body: body, Query: query}
+	args.req = cmn.HreqArgs{Method: http.MethodPost, Path: apc.URLPathDownload.S, Body: body, Query: query}
 	config := cmn.GCO.Get()
 	args.timeout = config.Timeout.MaxHostBusy.D()
 	results := p.bcastGroup(args)
@@ -0,0 +1,300 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dload"
+	"github.com/NVIDIA/aistore/hk"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Download job limits (connections, bytes per hour, and the same per origin host) are cluster-wide.
+// The primary proxy periodically divides the limits among the targets in proportion to the number
+// of tasks each target has pending, so that targets that are done don't keep holding their (unused)
+// share - see dload.Limits.Apportion and dload.LimitsMsg. Per-host limits of the hosts that are being
+// fetched from are divided only among the targets that fetch from them.
+// Limited jobs are started (and registered) by the primary itself. Once after restart or primary change,
+// the primary also discovers limited jobs from the targets (where the limits are persisted along with
+// the job itself) to recover the state; with no limited jobs, there's nothing to do.
+
+const (
+	dlBudgetName = "dl-limits"
+	dlBudgetIval = 10 * time.Second
+)
+
+type (
+	dlBudget struct {
+		p    *proxy
+		mu   sync.Mutex
+		jobs map[string]*dlBudgetJob // jobID => limited job
+		busy atomic.Bool
+		// discovered since this proxy has become primary
+		discovered bool
+	}
+	dlBudgetJob struct {
+		xactID string
+		limits dload.Limits
+		shares map[string]dload.Share // most recently sent
+	}
+)
+
+func (b *dlBudget) init(p *proxy) {
+	b.p = p
+	b.jobs = make(map[string]*dlBudgetJob, 4)
+	hk.Reg(dlBudgetName+hk.NameSuffix, b.housekeep, dlBudgetIval)
+}
+
+// when the (primary) proxy starts a limited job, and upon discovery
+func (b *dlBudget) add(jobID, xactID string, limits dload.Limits) {
+	b.mu.Lock()
+	if _, ok := b.jobs[jobID]; !ok {
+		b.jobs[jobID] = &dlBudgetJob{xactID: xactID, limits: limits}
+	}
+	b.mu.Unlock()
+}
+
+func (b *dlBudget) housekeep() time.Duration {
+	if smap := b.p.owner.smap.get(); !smap.isPrimary(b.p.si) {
+		b.mu.Lock()
+		if len(b.jobs) > 0 {
+			b.jobs = make(map[string]*dlBudgetJob, 4) // (no longer primary)
+		}
+		b.discovered = false
+		b.mu.Unlock()
+		return dlBudgetIval
+	}
+	// (network round-trips - must not block housekeeper)
+	if b.busy.CAS(false, true) {
+		go b.reshare()
+	}
+	return dlBudgetIval
+}
+
+func (b *dlBudget) reshare() {
+	b.mu.Lock()
+	discovered := b.discovered
+	b.mu.Unlock()
+	if !discovered {
+		b.discover()
+	}
+
+	b.mu.Lock()
+	if len(b.jobs) == 0 {
+		b.mu.Unlock()
+		b.busy.Store(false)
+		return
+	}
+	jobs := make(map[string]*dlBudgetJob, len(b.jobs))
+	for jobID, job := range b.jobs {
+		jobs[jobID] = job
+	}
+	b.mu.Unlock()
+
+	for jobID, job := range jobs {
+		pending, hosts, done := b.pending(jobID, job.xactID)
+		if done {
+			b.mu.Lock()
+			delete(b.jobs, jobID)
+			b.mu.Unlock()
+			continue
+		}
+		if len(pending) == 0 {
+			continue
+		}
+		shares := job.apportion(pending, hosts)
+		// targets that are done with the job (have no pending tasks) keep their last share - it's unused
+		if sharesEqual(shares, job.shares) {
+			continue
+		}
+		if err := b.send(&dload.LimitsMsg{ID: jobID, Shares: shares}, job.xactID); err != nil {
+			glog.Errorf("%s: failed to re-share %s limits: %v", b.p, jobID, err)
+			continue
+		}
+		job.shares = shares
+	}
+	b.busy.Store(false)
+}
+
+// list the jobs that are currently running and add those that are limited
+func (b *dlBudget) discover() {
+	var (
+		p    = b.p
+		msg  = &dload.AdminBody{OnlyActive: true}
+		args = allocBcArgs()
+	)
+	args.req = cmn.HreqArgs{Method: http.MethodGet, Path: apc.URLPathDownload.S, Body: cos.MustMarshal(msg)}
+	args.timeout = cmn.GCO.Get().Timeout.MaxHostBusy.D()
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	var failed bool
+	for _, res := range results {
+		if res.err != nil {
+			failed = true
+			continue
+		}
+		if len(res.bytes) == 0 {
+			continue
+		}
+		var jobs map[string]*dload.Job
+		if err := jsoniter.Unmarshal(res.bytes, &jobs); err != nil {
+			glog.Errorf("%s: failed to unmarshal download jobs from %s: %v", p, res.si, err)
+			continue
+		}
+		for jobID, job := range jobs {
+			if !job.Limits.IsZero() {
+				b.add(jobID, job.XactID, job.Limits)
+			}
+		}
+	}
+	freeBcastRes(results)
+	if !failed { // otherwise, retry next time
+		b.mu.Lock()
+		b.discovered = true
+		b.mu.Unlock()
+	}
+}
+
+// returns the number of tasks pending on each target (at least 1 for targets that haven't finished yet)
+// and, for each origin host, the number of tasks fetching from it on each target;
+// `done` when the job is finished (or aborted, or removed) cluster-wide
+func (b *dlBudget) pending(jobID, xactID string) (pending map[string]int, hosts map[string]map[string]int, done bool) {
+	var (
+		p    = b.p
+		msg  = &dload.AdminBody{ID: jobID, OnlyActive: true}
+		args = allocBcArgs()
+	)
+	args.req = cmn.HreqArgs{
+		Method: http.MethodGet,
+		Path:   apc.URLPathDownload.S,
+		Body:   cos.MustMarshal(msg),
+		Query:  url.Values{apc.QparamUUID: []string{xactID}},
+	}
+	args.timeout = cmn.GCO.Get().Timeout.MaxHostBusy.D()
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+
+	var notFound, finished int
+	pending = make(map[string]int, len(results))
+	for _, res := range results {
+		switch {
+		case res.status == http.StatusNotFound:
+			notFound++
+		case res.err != nil:
+			glog.Warningf("%s: failed to get %s status from %s: %v", p, jobID, res.si, res.err)
+		default:
+			status := dload.StatusResp{}
+			if err := jsoniter.Unmarshal(res.bytes, &status); err != nil {
+				glog.Errorf("%s: failed to unmarshal %s status from %s: %v", p, jobID, res.si, err)
+				continue
+			}
+			if status.Aborted || status.JobFinished() {
+				finished++
+				continue
+			}
+			tid := res.si.ID()
+			pending[tid] = cos.Max(status.PendingCnt(), 1)
+			for host, n := range status.Hosts {
+				if hosts == nil {
+					hosts = make(map[string]map[string]int, len(status.Hosts))
+				}
+				if hosts[host] == nil {
+					hosts[host] = make(map[string]int, len(results))
+				}
+				hosts[host][tid] = n
+			}
+		}
+	}
+	done = len(results) > 0 && notFound+finished == len(results)
+	freeBcastRes(results)
+	return
+}
+
+func (b *dlBudget) send(msg *dload.LimitsMsg, xactID string) error {
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{
+		Method: http.MethodPut,
+		Path:   apc.URLPathDownload.S,
+		Body:   cos.MustMarshal(msg),
+		Query:  url.Values{apc.QparamUUID: []string{xactID}},
+	}
+	args.timeout = cmn.GCO.Get().Timeout.MaxHostBusy.D()
+	results := b.p.bcastGroup(args)
+	freeBcArgs(args)
+	defer freeBcastRes(results)
+	for _, res := range results {
+		if res.err != nil && res.status != http.StatusNotFound {
+			return res.toErr()
+		}
+	}
+	return nil
+}
+
+/////////////////
+// dlBudgetJob //
+/////////////////
+
+func (job *dlBudgetJob) apportion(pending map[string]int, hosts map[string]map[string]int) map[string]dload.Share {
+	var (
+		perHost = job.limits.PerHost
+		shares  = make(map[string]dload.Share, len(pending))
+	)
+	for tid, limits := range job.limits.Apportion(pending) {
+		shares[tid] = dload.Share{Limits: limits}
+	}
+	if perHost.Connections == 0 && perHost.BytesPerHour == 0 {
+		return shares
+	}
+	// targets that don't fetch from a given host get NoShare (until they do)
+	noShare := dload.HostLimits{}
+	if perHost.Connections > 0 {
+		noShare.Connections = dload.NoShare
+	}
+	if perHost.BytesPerHour > 0 {
+		noShare.BytesPerHour = dload.NoShare
+	}
+	for host, weights := range hosts {
+		hshares := perHost.Apportion(weights)
+		for tid, share := range shares {
+			hl, ok := hshares[tid]
+			if !ok {
+				hl = noShare
+			}
+			if share.Hosts == nil {
+				share.Hosts = make(map[string]dload.HostLimits, len(hosts))
+			}
+			share.Hosts[host] = hl
+			shares[tid] = share
+		}
+	}
+	return shares
+}
+
+func sharesEqual(a, b map[string]dload.Share) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for tid, sa := range a {
+		sb, ok := b[tid]
+		if !ok || sa.Limits != sb.Limits || len(sa.Hosts) != len(sb.Hosts) {
+			return false
+		}
+		for host, hl := range sa.Hosts {
+			if hlb, ok := sb.Hosts[host]; !ok || hlb != hl {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -99,6 +99,12 @@ func (p *proxy) handleETLPut(w http.ResponseWriter, r *http.Request) {
 		p.writeErr(w, r, err)
 		return
 	}
+	if pmsg, ok := initMsg.(*etl.InitProcMsg); ok {
+		if err = pmsg.Allowed(&cmn.GCO.Get().ETL); err != nil {
+			p.writeErr(w, r, err, http.StatusForbidden)
+			return
+		}
+	}
 
 	etlMD := p.owner.etl.get()
 	if etlMD.get(initMsg.ID()) != nil {
@@ -136,11 +142,11 @@ func (p *proxy) handleETLPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if apiItems[1] == apc.ETLStop {
-		p.stopETL(w, r)
+		p.stopETL(w, r, etlID)
 		return
 	}
 	if apiItems[1] == apc.ETLStart {
-		p.startETL(w, etlMsg, false /*add to etlMD*/)
+		p.restartETL(w, r, etlID)
 		return
 	}
 	p.writeErrURL(w, r)
@@ -181,9 +187,8 @@ func (p *proxy) _deleteETLPre(ctx *etlMDModifier, clone *etlMD) (err error) {
 	return
 }
 
-// startETL broadcasts a init or start ETL request
+// startETL broadcasts a init ETL request
 // `addToMD` is set `true` for init requests to add a new ETL to etlMD
-// `addToMD` is `false` for start requests, where ETL already exists in `etlMD`
 func (p *proxy) startETL(w http.ResponseWriter, msg etl.InitMsg, addToMD bool) (err error) {
 	args := allocBcArgs()
 	args.req = cmn.HreqArgs{Method: http.MethodPut, Path: apc.URLPathETL.S, Body: cos.MustMarshal(msg)}
@@ -227,6 +232,7 @@ func (p *proxy) startETL(w http.ResponseWriter, msg etl.InitMsg, addToMD bool) (
 func _addETLPre(ctx *etlMDModifier, clone *etlMD) (_ error) {
 	debug.Assert(ctx.msg != nil)
 	clone.add(ctx.msg)
+	clone.SetStopped(ctx.msg.ID(), false) // (re-init)
 	return
 }
 
@@ -368,19 +374,81 @@ func (p *proxy) healthETL(w http.ResponseWriter, r *http.Request) {
 	p.writeJSON(w, r, healths, "health-etl")
 }
 
+// POST /v1/etl/<uuid>/start
+// targets rebuild the (stopped) ETL from its init message stored in EtlMD;
+// the response contains per-target status
+func (p *proxy) restartETL(w http.ResponseWriter, r *http.Request, etlID string) {
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodPost, Path: r.URL.Path}
+	args.timeout = apc.LongTimeout
+	args.cresv = cresES{} // -> etl.StartStatus
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+
+	var (
+		statuses = make(etl.StartStatusList, 0, len(results))
+		failed   int
+	)
+	for _, res := range results {
+		if res.err != nil {
+			err := res.toErr()
+			glog.Error(err)
+			statuses = append(statuses, &etl.StartStatus{TargetID: res.si.ID(), Status: etl.StatusFailed, Err: err.Error()})
+			failed++
+			continue
+		}
+		status := res.v.(*etl.StartStatus)
+		if status.Status == etl.StatusFailed {
+			failed++
+		}
+		statuses = append(statuses, status)
+	}
+	freeBcastRes(results)
+	// the ETL remains marked as stopped unless it is now running on all targets
+	if failed == 0 {
+		p.markStoppedETL(etlID, false)
+	}
+	sort.SliceStable(statuses, func(i, j int) bool { return statuses[i].TargetID < statuses[j].TargetID })
+	p.writeJSON(w, r, statuses, "start-etl")
+}
+
 // POST /v1/etl/<uuid>/stop
-func (p *proxy) stopETL(w http.ResponseWriter, r *http.Request) {
+func (p *proxy) stopETL(w http.ResponseWriter, r *http.Request, etlID string) {
 	args := allocBcArgs()
 	args.req = cmn.HreqArgs{Method: http.MethodPost, Path: r.URL.Path}
 	args.timeout = apc.LongTimeout
 	results := p.bcastGroup(args)
 	freeBcArgs(args)
 	for _, res := range results {
-		if res.err == nil {
+		if res.err == nil || res.status == http.StatusNotFound { // (not running - nothing to do)
 			continue
 		}
 		p.writeErr(w, r, res.toErr())
-		break
+		freeBcastRes(results)
+		return
 	}
 	freeBcastRes(results)
+	p.markStoppedETL(etlID, true)
+}
+
+// persist the desired state: targets restart (upon node restart) only those ETLs
+// that are not marked as stopped
+func (p *proxy) markStoppedETL(etlID string, stopped bool) {
+	ctx := &etlMDModifier{
+		pre:     _stoppedETLPre,
+		final:   p._syncEtlMDFinal,
+		etlID:   etlID,
+		stopped: stopped,
+	}
+	if _, err := p.owner.etl.modify(ctx); err != nil {
+		glog.Errorf("%s: failed to mark ETL %q (stopped=%t): %v", p, etlID, stopped, err)
+	}
+}
+
+func _stoppedETLPre(ctx *etlMDModifier, clone *etlMD) error {
+	if clone.get(ctx.etlID) == nil {
+		return cmn.NewErrNotFound("etl UUID %s", ctx.etlID)
+	}
+	ctx.terminate = !clone.setStopped(ctx.etlID, ctx.stopped)
+	return nil
 }
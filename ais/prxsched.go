@@ -0,0 +1,588 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/ext/dload"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/xact"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Recurring jobs: every minute the primary checks SchedMD, records the outcomes of the runs
+// that have completed since the previous check (arranging for retries, if need be), and starts
+// the jobs that are due - on their cron schedules and/or upon success of their dependencies.
+// Each run (started, skipped, failed) is recorded in the SchedMD.
+// Concurrency policy: skip the run while the previous (scheduled) run of the same job is still in progress.
+
+const (
+	jschedName = "job-schedules"
+	jschedIval = time.Minute // (cron granularity)
+)
+
+type jsched struct {
+	p    *proxy
+	busy atomic.Bool
+}
+
+func (js *jsched) init(p *proxy) {
+	js.p = p
+	hk.Reg(jschedName+hk.NameSuffix, js.housekeep, jschedIval)
+}
+
+func (js *jsched) housekeep() time.Duration {
+	p := js.p
+	if !p.ClusterStarted() || !p.owner.smap.get().isPrimary(p.si) {
+		return jschedIval
+	}
+	if len(p.owner.sched.get().Schedules) == 0 {
+		return jschedIval
+	}
+	// (starting jobs entails network round-trips - must not block housekeeper)
+	if js.busy.CAS(false, true) {
+		go js.run(time.Now())
+	}
+	return jschedIval
+}
+
+func (js *jsched) run(now time.Time) {
+	js.recordDone(now)
+	if due := js.due(now); len(due) > 0 {
+		js.runDue(due, now)
+	}
+	js.busy.Store(false)
+}
+
+// record the outcomes of the runs that have completed since the previous check
+func (js *jsched) recordDone(now time.Time) {
+	var (
+		p    = js.p
+		done = make(map[string]cmn.SchedRun, 2)
+	)
+	for _, s := range p.owner.sched.get().Schedules {
+		last := s.LastStarted()
+		if last == nil || last.Status != cmn.SchedStarted {
+			continue
+		}
+		run := *last
+		if p.schedRunStatus(&run, now) {
+			done[s.Name] = run
+		}
+	}
+	if len(done) == 0 {
+		return
+	}
+	ctx := &schedModifier{
+		pre: func(_ *schedModifier, clone *schedMD) error {
+			for name, run := range done {
+				s, ok := clone.Schedules[name]
+				if !ok {
+					continue
+				}
+				last := s.LastStarted()
+				if last == nil || last.JobID != run.JobID || last.Status != cmn.SchedStarted {
+					continue
+				}
+				*last = run
+				glog.Infof("%s: schedule %q: job %s %s %s", p, name, run.JobID, run.Status, run.Err)
+				schedRetry(s, &run, now)
+			}
+			return nil
+		},
+		final: p._syncSchedFinal,
+	}
+	if _, err := p.owner.sched.modify(ctx); err != nil {
+		glog.Errorf("%s: failed to record completed scheduled runs: %v", p, err)
+	}
+}
+
+// jobs that are due: on their cron schedules (or retry), or upon success of their dependencies
+func (js *jsched) due(now time.Time) (due []*cmn.Schedule) {
+	md := js.p.owner.sched.get()
+	for _, s := range md.Schedules {
+		switch {
+		case !s.NextRun.IsZero() && !now.Before(s.NextRun):
+		case s.Cron == "" && s.Retry == 0 && md.unmetDep(s, s.Triggered()) == "":
+		default:
+			continue
+		}
+		due = append(due, s)
+	}
+	return
+}
+
+func (js *jsched) runDue(due []*cmn.Schedule, now time.Time) {
+	var (
+		p    = js.p
+		md   = p.owner.sched.get()
+		runs = make(map[string]cmn.SchedRun, len(due))
+	)
+	for _, s := range due {
+		run := cmn.SchedRun{Started: now, Attempt: s.Retry}
+		if jobID := js.inProgress(s); jobID != "" {
+			run.Status, run.Err = cmn.SchedSkipped, "previous run "+jobID+" is still in progress"
+		} else if dep := md.unmetDep(s, time.Time{}); s.Retry == 0 && s.Cron != "" && dep != "" {
+			run.Status, run.Err = cmn.SchedSkipped, "dependency "+dep+" has not succeeded"
+		} else if run.JobID, run.Err = js.start(&s.SchedMsg); run.Err != "" {
+			run.Status, run.Finished = cmn.SchedFailed, now
+		} else if run.JobID == "" {
+			run.Status, run.Finished = cmn.SchedFinished, now // nothing to do
+		} else {
+			run.Status = cmn.SchedStarted
+		}
+		glog.Infof("%s: schedule %q (%s): %s %s %s", p, s.Name, s.Cron, run.Status, run.JobID, run.Err)
+		runs[s.Name] = run
+	}
+	ctx := &schedModifier{
+		pre: func(_ *schedModifier, clone *schedMD) error {
+			for name, run := range runs {
+				s, ok := clone.Schedules[name]
+				if !ok { // removed in the meantime
+					continue
+				}
+				s.AddRun(run)
+				s.NextRun, s.Retry = nextRun(s.Cron, now), 0
+				schedRetry(s, &run, now)
+			}
+			return nil
+		},
+		final: p._syncSchedFinal,
+	}
+	if _, err := p.owner.sched.modify(ctx); err != nil {
+		glog.Errorf("%s: failed to record scheduled runs: %v", p, err)
+	}
+}
+
+// returns the ID of the still-running job started by the previous (non-skipped) run, if any
+func (js *jsched) inProgress(s *cmn.Schedule) string {
+	run := s.LastStarted()
+	if run == nil || run.Status != cmn.SchedStarted {
+		return ""
+	}
+	if nl := js.p.notifs.entry(run.JobID); nl != nil && !nl.Finished() {
+		return run.JobID
+	}
+	return ""
+}
+
+func (js *jsched) start(msg *cmn.SchedMsg) (jobID, errs string) {
+	var err error
+	switch msg.Kind {
+	case apc.ActDownload:
+		jobID, err = js.p.schedDownload(msg)
+	case apc.ActPrefetchObjects, apc.ActEvictObjects, apc.ActDeleteObjects:
+		jobID, err = js.p.schedListRange(msg)
+	case apc.ActCopyBck, apc.ActETLBck:
+		jobID, err = js.p.schedTCB(msg)
+	case apc.ActArchive:
+		jobID, err = js.p.schedArchive(msg)
+	default:
+		debug.Assert(schedGeneric(msg.Kind), msg.Kind)
+		jobID, err = js.p.schedXact(msg)
+	}
+	if err != nil {
+		errs = err.Error()
+	}
+	return
+}
+
+// updates the status of a started run; returns false while the job is still running
+func (p *proxy) schedRunStatus(run *cmn.SchedRun, now time.Time) (done bool) {
+	nl := p.notifs.entry(run.JobID)
+	switch {
+	case nl == nil:
+		run.Status, run.Finished = cmn.SchedUnknown, now
+		return true
+	case !nl.Finished():
+		run.Status = cmn.SchedRunning
+		return false
+	case nl.Aborted():
+		run.Status = cmn.SchedAborted
+	default:
+		run.Status = cmn.SchedFinished
+		if err := nl.Err(); err != nil {
+			run.Status, run.Err = cmn.SchedFailed, err.Error()
+		}
+	}
+	run.Finished = time.Unix(0, nl.EndTime())
+	return true
+}
+
+// failed run: retry as per the schedule's retry policy
+// (aborted runs are not retried - aborting is deliberate)
+func schedRetry(s *cmn.Schedule, run *cmn.SchedRun, now time.Time) {
+	if run.Status != cmn.SchedFailed || run.Attempt >= s.Retries {
+		return
+	}
+	s.Retry = run.Attempt + 1
+	s.NextRun = now.Add(s.RetryDelay.D())
+}
+
+func nextRun(expr string, now time.Time) time.Time {
+	if expr == "" {
+		return time.Time{} // runs upon success of its dependencies
+	}
+	cron, err := cos.ParseCron(expr)
+	debug.AssertNoErr(err) // validated upon creation
+	if err != nil {
+		return time.Time{}
+	}
+	return cron.Next(now)
+}
+
+//
+// start scheduled jobs
+//
+
+func (p *proxy) schedDownload(msg *cmn.SchedMsg) (string, error) {
+	var (
+		dlb    dload.Body
+		dlBase dload.Base
+	)
+	if err := jsoniter.Unmarshal(msg.Body, &dlb); err != nil {
+		return "", err
+	}
+	if err := jsoniter.Unmarshal(dlb.RawMessage, &dlBase); err != nil {
+		return "", err
+	}
+	bck := cluster.CloneBck(&dlBase.Bck)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		return "", err
+	}
+	jobID, _, err := p.dlstart(msg.Body, dlb.Type, &dlBase)
+	return jobID, err
+}
+
+func (p *proxy) schedListRange(msg *cmn.SchedMsg) (string, error) {
+	lrMsg := &cmn.SelectObjsMsg{}
+	if err := jsoniter.Unmarshal(msg.Body, lrMsg); err != nil {
+		return "", err
+	}
+	bck := cluster.CloneBck(&msg.Bck)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		return "", err
+	}
+	method := http.MethodDelete
+	switch msg.Kind {
+	case apc.ActPrefetchObjects:
+		method = http.MethodPost
+		fallthrough
+	case apc.ActEvictObjects:
+		if bck.IsAIS() {
+			return "", fmt.Errorf(fmtNotRemote, bck.Name)
+		}
+	}
+	amsg := &apc.ActionMsg{Action: msg.Kind, Value: lrMsg}
+	return p.doListRange(method, bck.Name, amsg, bck.AddToQuery(nil))
+}
+
+func (p *proxy) schedTCB(msg *cmn.SchedMsg) (string, error) {
+	tcbMsg := &apc.TCBMsg{}
+	if len(msg.Body) > 0 {
+		if err := jsoniter.Unmarshal(msg.Body, tcbMsg); err != nil {
+			return "", err
+		}
+	}
+	bckFrom, bckTo := cluster.CloneBck(&msg.Bck), cluster.CloneBck(&msg.BckTo)
+	if err := bckFrom.Init(p.owner.bmd); err != nil {
+		return "", err
+	}
+	if err := bckTo.Init(p.owner.bmd); err != nil {
+		// same as copy-bucket API: AIS destination gets created on the fly
+		if !cmn.IsErrBucketNought(err) || !bckTo.IsAIS() {
+			return "", err
+		}
+	}
+	amsg := &apc.ActionMsg{Action: msg.Kind, Value: tcbMsg}
+	return p.tcb(bckFrom, bckTo, amsg, false /*dry-run*/)
+}
+
+func (p *proxy) schedArchive(msg *cmn.SchedMsg) (string, error) {
+	archMsg := &cmn.ArchiveMsg{}
+	if err := jsoniter.Unmarshal(msg.Body, archMsg); err != nil {
+		return "", err
+	}
+	bckFrom := cluster.CloneBck(&msg.Bck)
+	if err := bckFrom.Init(p.owner.bmd); err != nil {
+		return "", err
+	}
+	bckTo := bckFrom
+	if !archMsg.ToBck.IsEmpty() {
+		bckTo = cluster.CloneBck(&archMsg.ToBck)
+		if err := bckTo.Init(p.owner.bmd); err != nil {
+			return "", err
+		}
+	}
+	amsg := &apc.ActionMsg{Action: apc.ActArchive, Value: archMsg}
+	return p.createArchMultiObj(bckFrom, bckTo, amsg)
+}
+
+// any other xaction that can be started via api.StartXaction
+func (p *proxy) schedXact(msg *cmn.SchedMsg) (string, error) {
+	if msg.Kind == apc.ActRebalance {
+		return p.startRebalance()
+	}
+	xactMsg := &xact.QueryMsg{ID: cos.GenUUID(), Kind: msg.Kind}
+	if !msg.Bck.IsEmpty() {
+		bck := cluster.CloneBck(&msg.Bck)
+		if err := bck.Init(p.owner.bmd); err != nil {
+			return "", err
+		}
+		xactMsg.Bck = *bck.Bucket()
+	}
+	if err := p.xstart(xactMsg); err != nil {
+		return "", err
+	}
+	return xactMsg.ID, nil
+}
+
+//
+// API: [METHOD] /v1/cluster/schedule[/<name>] (primary only)
+// (and /v1/download/schedule - the original location)
+//
+
+func (p *proxy) httpsched(w http.ResponseWriter, r *http.Request, path apc.URLPath) {
+	apiItems, err := p.apiItems(w, r, 0, true, path.L)
+	if err != nil {
+		return
+	}
+	if p.forwardCP(w, r, nil, "job schedules") {
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		p.listSchedules(w, r)
+	case http.MethodPost:
+		p.addSchedule(w, r)
+	case http.MethodDelete:
+		if len(apiItems) == 0 || apiItems[0] == "" {
+			p.writeErrMsg(w, r, "missing schedule name")
+			return
+		}
+		p.delSchedule(w, r, apiItems[0])
+	default:
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodPost)
+	}
+}
+
+// current schedules with the status of their respective most recent runs
+func (p *proxy) listSchedules(w http.ResponseWriter, r *http.Request) {
+	var (
+		now       = time.Now()
+		schedules = p.owner.sched.get().sorted()
+		out       = make(cmn.Schedules, 0, len(schedules))
+	)
+	for _, s := range schedules {
+		c := *s
+		c.History = append([]cmn.SchedRun(nil), s.History...)
+		for i := range c.History {
+			if run := &c.History[i]; run.Status == cmn.SchedStarted {
+				p.schedRunStatus(run, now)
+			}
+		}
+		out = append(out, &c)
+	}
+	p.writeJSON(w, r, out, "list-schedules")
+}
+
+func (p *proxy) addSchedule(w http.ResponseWriter, r *http.Request) {
+	msg := &cmn.SchedMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	if kind, _ := xact.GetKindName(msg.Kind); kind != "" {
+		msg.Kind = kind // display name => kind
+	}
+	if err := validateSched(msg); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	// buckets: same checks (and permissions) as the respective APIs
+	switch msg.Kind {
+	case apc.ActDownload:
+		if _, _, ok := p.validateStartDownload(w, r, msg.Body); !ok {
+			return
+		}
+	case apc.ActPrefetchObjects, apc.ActEvictObjects, apc.ActDeleteObjects:
+		perms := apc.AccessRW
+		if msg.Kind != apc.ActPrefetchObjects {
+			perms = apc.AceObjDELETE
+		}
+		args := bckInitArgs{p: p, w: w, r: r, bck: cluster.CloneBck(&msg.Bck), perms: perms}
+		bck, err := args.initAndTry()
+		if err != nil {
+			return
+		}
+		if bck.IsAIS() && msg.Kind != apc.ActDeleteObjects {
+			p.writeErrf(w, r, fmtNotRemote, bck.Name)
+			return
+		}
+	case apc.ActCopyBck, apc.ActETLBck:
+		args := bckInitArgs{p: p, w: w, r: r, bck: cluster.CloneBck(&msg.Bck), perms: apc.AccessRO}
+		if _, err := args.initAndTry(); err != nil {
+			return
+		}
+		bckTo := cluster.CloneBck(&msg.BckTo)
+		if err := bckTo.Init(p.owner.bmd); err != nil && (!cmn.IsErrBucketNought(err) || !bckTo.IsAIS()) {
+			p.writeErr(w, r, err)
+			return
+		}
+		if bckTo.IsHTTP() {
+			p.writeErrf(w, r, "cannot %s to HTTP bucket %q", msg.Kind, bckTo)
+			return
+		}
+	case apc.ActArchive:
+		args := bckInitArgs{p: p, w: w, r: r, bck: cluster.CloneBck(&msg.Bck), perms: apc.AccessRO}
+		if _, err := args.initAndTry(); err != nil {
+			return
+		}
+		archMsg := &cmn.ArchiveMsg{}
+		if err := jsoniter.Unmarshal(msg.Body, archMsg); err == nil && !archMsg.ToBck.IsEmpty() {
+			argsTo := bckInitArgs{p: p, w: w, r: r, bck: cluster.CloneBck(&archMsg.ToBck), perms: apc.AcePUT}
+			if _, err := argsTo.initAndTry(); err != nil {
+				return
+			}
+		}
+	default:
+		if !msg.Bck.IsEmpty() {
+			args := bckInitArgs{p: p, w: w, r: r, bck: cluster.CloneBck(&msg.Bck), perms: apc.AccessRW}
+			if _, err := args.initAndTry(); err != nil {
+				return
+			}
+		}
+	}
+
+	now := time.Now()
+	ctx := &schedModifier{
+		pre: func(ctx *schedModifier, clone *schedMD) error {
+			if _, ok := clone.Schedules[ctx.msg.Name]; ok {
+				return fmt.Errorf("schedule %q already exists", ctx.msg.Name)
+			}
+			if err := clone.checkDeps(ctx.msg); err != nil {
+				return err
+			}
+			clone.Schedules[ctx.msg.Name] = &cmn.Schedule{
+				SchedMsg: *ctx.msg,
+				Created:  now,
+				NextRun:  nextRun(ctx.msg.Cron, now),
+			}
+			return nil
+		},
+		final: p._syncSchedFinal,
+		msg:   msg,
+	}
+	if _, err := p.owner.sched.modify(ctx); err != nil {
+		if cmn.IsErrNotFound(err) {
+			p.writeErr(w, r, err, http.StatusNotFound)
+		} else {
+			p.writeErr(w, r, err, http.StatusConflict)
+		}
+	}
+}
+
+func (p *proxy) delSchedule(w http.ResponseWriter, r *http.Request, name string) {
+	ctx := &schedModifier{
+		pre: func(ctx *schedModifier, clone *schedMD) error {
+			if _, ok := clone.Schedules[ctx.name]; !ok {
+				return cmn.NewErrNotFound("%s: schedule %q", p.si, ctx.name)
+			}
+			if deps := clone.dependents(ctx.name); len(deps) > 0 {
+				return fmt.Errorf("cannot remove schedule %q: required by %v", ctx.name, deps)
+			}
+			delete(clone.Schedules, ctx.name)
+			return nil
+		},
+		final: p._syncSchedFinal,
+		name:  name,
+	}
+	if _, err := p.owner.sched.modify(ctx); err != nil {
+		if cmn.IsErrNotFound(err) {
+			p.writeErr(w, r, err, http.StatusNotFound)
+		} else {
+			p.writeErr(w, r, err, http.StatusConflict)
+		}
+	}
+}
+
+func (p *proxy) _syncSchedFinal(_ *schedModifier, clone *schedMD) {
+	msg := p.newAmsgStr(apc.Schedule, nil)
+	_ = p.metasyncer.sync(revsPair{clone, msg})
+}
+
+// in addition to cmn.SchedMsg.Validate: download requests and generic xactions
+func validateSched(msg *cmn.SchedMsg) (err error) {
+	if err = msg.Validate(); err != nil {
+		return
+	}
+	if len(msg.Body) == 0 && schedNeedsBody(msg.Kind) {
+		return fmt.Errorf("schedule %q: missing %s request body", msg.Name, msg.Kind)
+	}
+	switch msg.Kind {
+	case apc.ActDownload:
+		var (
+			dlb    dload.Body
+			dlBase dload.Base
+		)
+		if err = json.Unmarshal(msg.Body, &dlb); err != nil {
+			return fmt.Errorf("schedule %q: invalid download request: %v", msg.Name, err)
+		}
+		if !dload.IsType(string(dlb.Type)) {
+			return fmt.Errorf("schedule %q: invalid download type %q", msg.Name, dlb.Type)
+		}
+		if err = json.Unmarshal(dlb.RawMessage, &dlBase); err != nil {
+			return fmt.Errorf("schedule %q: invalid download request: %v", msg.Name, err)
+		}
+		if err = dlBase.Validate(); err != nil {
+			return fmt.Errorf("schedule %q: %v", msg.Name, err)
+		}
+	case apc.ActPrefetchObjects, apc.ActEvictObjects, apc.ActDeleteObjects, apc.ActArchive, apc.ActCopyBck, apc.ActETLBck:
+		// (validated by cmn.SchedMsg)
+	default:
+		if !schedGeneric(msg.Kind) {
+			return fmt.Errorf("schedule %q: invalid job kind %q (expecting one of: %q, %q, %q, %q, %q, %q, %q, "+
+				"or any other xaction that can be started via api.StartXaction)", msg.Name, msg.Kind, apc.ActDownload,
+				apc.ActPrefetchObjects, apc.ActCopyBck, apc.ActETLBck, apc.ActArchive, apc.ActEvictObjects,
+				apc.ActDeleteObjects)
+		}
+		switch {
+		case xact.IsSameScope(msg.Kind, xact.ScopeB):
+			err = msg.Bck.Validate()
+		case xact.IsSameScope(msg.Kind, xact.ScopeGB):
+			if !msg.Bck.IsEmpty() {
+				err = msg.Bck.Validate()
+			}
+		case !msg.Bck.IsEmpty():
+			err = fmt.Errorf("schedule %q: %q is a cluster-wide job (not expecting bucket %s)", msg.Name, msg.Kind, msg.Bck)
+		}
+	}
+	return
+}
+
+func schedNeedsBody(kind string) bool {
+	return kind != apc.ActCopyBck && !schedGeneric(kind)
+}
+
+// xactions that are started via the generic api.StartXaction
+// (others, e.g. make-n-copies and ec-bucket, require their respective bucket-modifying APIs)
+func schedGeneric(kind string) bool {
+	switch kind {
+	case apc.ActDownload, apc.ActPrefetchObjects, apc.ActMakeNCopies, apc.ActECEncode, apc.ActECReencode:
+		return false
+	}
+	_, dtor, err := xact.GetDescriptor(kind)
+	return err == nil && dtor.Startable
+}
@@ -618,6 +618,32 @@ func (p *proxy) tcobjs(bckFrom, bckTo *cluster.Bck, msg *apc.ActionMsg) (xactID
 	return
 }
 
+// initial (full) sync of a replicated bucket (see cmn.ReplConf): copy all existing objects
+// (or only those that match the replication prefix) to the replication destination
+func (p *proxy) replSync(bck *cluster.Bck) (xactID string, err error) {
+	conf := &bck.Props.Repl
+	if !conf.Enabled {
+		err = fmt.Errorf("%s: replication of %s is not enabled", p.si, bck)
+		return
+	}
+	dst, err := conf.DstBck()
+	if err != nil {
+		return
+	}
+	bckTo := cluster.CloneBck(dst)
+	if err = bckTo.Init(p.owner.bmd); err != nil {
+		return
+	}
+	glog.Infof("%s %s => %s (prefix %q)", apc.ActReplSync, bck, bckTo, conf.Prefix)
+	if conf.Prefix == "" {
+		msg := &apc.ActionMsg{Action: apc.ActCopyBck, Value: &apc.TCBMsg{}}
+		return p.tcb(bck, bckTo, msg, false /*dry-run*/)
+	}
+	tcoMsg := &cmn.TCObjsMsg{ToBck: *dst}
+	tcoMsg.Template = conf.Prefix
+	return p.tcobjs(bck, bckTo, &apc.ActionMsg{Action: apc.ActCopyObjects, Value: tcoMsg})
+}
+
 func bmodTCB(ctx *bmdModifier, clone *bucketMD) error {
 	var (
 		bckFrom, bckTo  = ctx.bcks[0], ctx.bcks[1]
@@ -660,6 +686,7 @@ func parseECConf(value any) (*cmn.ECConfToUpdate, error) {
 }
 
 // ec-encode: { confirm existence -- begin -- update locally -- metasync -- commit }
+// (ec-reencode: same, except that the bucket must be already erasure coded)
 func (p *proxy) ecEncode(bck *cluster.Bck, msg *apc.ActionMsg) (xactID string, err error) {
 	nlp := bck.GetNameLockPair()
 	ecConf, err := parseECConf(msg.Value)
@@ -683,9 +710,13 @@ func (p *proxy) ecEncode(bck *cluster.Bck, msg *apc.ActionMsg) (xactID string, e
 		err = cmn.NewErrBckNotFound(bck.Bucket())
 		return
 	}
-	if props.EC.Enabled {
-		// Changing data or parity slice count on the fly is unsupported yet
-		err = fmt.Errorf("%s: EC is already enabled for bucket %s", p, bck)
+	if msg.Action == apc.ActECReencode {
+		if err = p.validateECReencode(bck, props, ecConf); err != nil {
+			return
+		}
+	} else if props.EC.Enabled {
+		err = fmt.Errorf("%s: EC is already enabled for bucket %s (use %q to change the number of slices)",
+			p, bck, apc.ActECReencode)
 		return
 	}
 
@@ -727,6 +758,23 @@ func (p *proxy) ecEncode(bck *cluster.Bck, msg *apc.ActionMsg) (xactID string, e
 	return
 }
 
+func (p *proxy) validateECReencode(bck *cluster.Bck, props *cmn.BucketProps, ecConf *cmn.ECConfToUpdate) error {
+	if !props.EC.Enabled {
+		return fmt.Errorf("%s: EC is not enabled for bucket %s (use %q instead)", p, bck, apc.ActECEncode)
+	}
+	nprops := props.Clone()
+	nprops.Apply(&cmn.BucketPropsToUpdate{EC: ecConf})
+	if nprops.EC.DataSlices == props.EC.DataSlices && nprops.EC.ParitySlices == props.EC.ParitySlices {
+		return fmt.Errorf("%s: bucket %s is already erasure coded with %d data and %d parity slices",
+			p, bck, props.EC.DataSlices, props.EC.ParitySlices)
+	}
+	smap := p.owner.smap.get()
+	if err := nprops.EC.ValidateAsProps(smap.CountActiveTargets()); err != nil {
+		return fmt.Errorf("%s: cannot re-encode %s: %v", p, bck, err)
+	}
+	return nil
+}
+
 // compare w/ bmodSetProps
 func bmodUpdateProps(ctx *bmdModifier, clone *bucketMD) error {
 	var (
@@ -1070,7 +1118,8 @@ func (p *proxy) makeNewBckProps(bck *cluster.Bck, propsToUpdate *cmn.BucketProps
 		sameSlices := bprops.EC.DataSlices == nprops.EC.DataSlices && bprops.EC.ParitySlices == nprops.EC.ParitySlices
 		sameLimit := bprops.EC.ObjSizeLimit == nprops.EC.ObjSizeLimit
 		if !sameSlices || (!sameLimit && !propsToUpdate.Force) {
-			err = fmt.Errorf("%s: once enabled, EC configuration can be only disabled but cannot change", p.si)
+			err = fmt.Errorf("%s: once enabled, EC configuration can be only disabled but cannot change (use %q to change the number of slices)",
+				p.si, apc.ActECReencode)
 			return
 		}
 	} else if nprops.EC.Enabled {
@@ -1088,6 +1137,10 @@ func (p *proxy) makeNewBckProps(bck *cluster.Bck, propsToUpdate *cmn.BucketProps
 	} else if nprops.Mirror.Copies == 1 {
 		nprops.Mirror.Enabled = false
 	}
+	if bprops.Mirror.Enabled && nprops.Mirror.Enabled && bprops.Mirror.Nodes() != nprops.Mirror.Nodes() {
+		err = fmt.Errorf("%s: cannot change mirroring mode of %s while mirroring is enabled (disable it first)", p.si, bck)
+		return
+	}
 	if provider := nprops.BackendBck.Provider; nprops.BackendBck.Name != "" {
 		nprops.BackendBck.Provider, err = cmn.NormalizeProvider(provider)
 		if err != nil {
@@ -1095,12 +1148,18 @@ func (p *proxy) makeNewBckProps(bck *cluster.Bck, propsToUpdate *cmn.BucketProps
 		}
 	}
 	// cannot have re-mirroring and erasure coding on the same bucket at the same time
-	remirror := _reMirror(bprops, nprops)
-	targetCnt, reec := _reEC(bprops, nprops, bck, p.owner.smap.get())
+	var (
+		smap            = p.owner.smap.get()
+		remirror        = _reMirror(bprops, nprops)
+		targetCnt, reec = _reEC(bprops, nprops, bck, smap)
+	)
 	if len(creating) == 0 && remirror && reec {
 		err = cmn.NewErrBckIsBusy(bck.Bucket())
 		return
 	}
+	if nprops.Mirror.Nodes() {
+		targetCnt = smap.CountActiveTargets()
+	}
 	err = nprops.Validate(targetCnt)
 	if cmn.IsErrSoft(err) && propsToUpdate.Force {
 		glog.Warningf("Ignoring soft error: %v", err)
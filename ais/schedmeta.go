@@ -0,0 +1,230 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/memsys"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Job schedules: cron-like recurring jobs and their dependencies (see cmn.Schedule).
+// SchedMD is versioned and replicated across proxies (targets ignore it); the primary
+// is the one that triggers scheduled runs and records their history - see prxsched.go
+
+type (
+	schedMD struct {
+		Version   int64                    `json:"version,string"`
+		Schedules map[string]*cmn.Schedule `json:"schedules"`
+	}
+	schedOwner struct {
+		sync.Mutex
+		md    atomic.Pointer
+		fpath string
+	}
+	schedModifier struct {
+		pre   func(ctx *schedModifier, clone *schedMD) error
+		final func(ctx *schedModifier, clone *schedMD)
+
+		msg       *cmn.SchedMsg
+		name      string
+		run       cmn.SchedRun
+		terminate bool
+	}
+)
+
+// interface guard
+var _ revs = (*schedMD)(nil)
+
+var schedMDJspOpts = jsp.CCSign(cmn.MetaverSchedMD)
+
+func newSchedMD() *schedMD { return &schedMD{Schedules: make(map[string]*cmn.Schedule, 4)} }
+
+// as revs
+func (*schedMD) tag() string        { return revsSchedTag }
+func (md *schedMD) version() int64  { return md.Version }
+func (md *schedMD) marshal() []byte { return cos.MustMarshal(md) }
+func (*schedMD) jit(p *proxy) revs  { return p.owner.sched.get() }
+func (*schedMD) sgl() *memsys.SGL   { return nil }
+
+func (*schedMD) JspOpts() jsp.Options { return schedMDJspOpts }
+
+func (md *schedMD) String() string {
+	if md == nil {
+		return "SchedMD <nil>"
+	}
+	return fmt.Sprintf("SchedMD v%d(%d)", md.Version, len(md.Schedules))
+}
+
+// (history is copied on write - see cmn.Schedule.AddRun)
+func (md *schedMD) clone() *schedMD {
+	dst := &schedMD{Version: md.Version, Schedules: make(map[string]*cmn.Schedule, len(md.Schedules))}
+	for name, s := range md.Schedules {
+		c := *s
+		c.History = append([]cmn.SchedRun(nil), s.History...)
+		dst.Schedules[name] = &c
+	}
+	return dst
+}
+
+func (md *schedMD) sorted() cmn.Schedules {
+	out := make(cmn.Schedules, 0, len(md.Schedules))
+	for _, s := range md.Schedules {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// dependencies must exist, which is also why the graph remains acyclic:
+// a new schedule can only depend on the ones that were added prior to it
+func (md *schedMD) checkDeps(msg *cmn.SchedMsg) error {
+	for _, name := range msg.DependsOn {
+		if _, ok := md.Schedules[name]; !ok {
+			return cmn.NewErrNotFound("schedule %q: dependency %q", msg.Name, name)
+		}
+	}
+	return nil
+}
+
+// returns the first dependency whose most recent run has not succeeded (or has succeeded prior to `since`)
+func (md *schedMD) unmetDep(s *cmn.Schedule, since time.Time) string {
+	for _, name := range s.DependsOn {
+		dep, ok := md.Schedules[name]
+		if !ok {
+			return name
+		}
+		if run := dep.LastStarted(); run == nil || !run.Succeeded() || run.Finished.Before(since) {
+			return name
+		}
+	}
+	return ""
+}
+
+// names of the schedules that depend on a given one
+func (md *schedMD) dependents(name string) (out []string) {
+	for _, s := range md.Schedules {
+		if cos.StringInSlice(name, s.DependsOn) {
+			out = append(out, s.Name)
+		}
+	}
+	sort.Strings(out)
+	return
+}
+
+////////////////
+// schedOwner //
+////////////////
+
+func newSchedOwner(config *cmn.Config) *schedOwner {
+	return &schedOwner{fpath: filepath.Join(config.ConfigDir, fname.Schd)}
+}
+
+func (so *schedOwner) init() {
+	md := newSchedMD()
+	if _, err := jsp.LoadMeta(so.fpath, md); err != nil {
+		if !os.IsNotExist(err) {
+			glog.Errorf("failed to load %s from %s, err: %v", md, so.fpath, err)
+		}
+		md = newSchedMD()
+	}
+	so.put(md)
+}
+
+func (so *schedOwner) get() *schedMD   { return (*schedMD)(so.md.Load()) }
+func (so *schedOwner) put(md *schedMD) { so.md.Store(unsafe.Pointer(md)) }
+
+func (so *schedOwner) putPersist(md *schedMD) (err error) {
+	if err = jsp.SaveMeta(so.fpath, md, nil /*wto*/); err == nil {
+		so.put(md)
+	}
+	return
+}
+
+func (so *schedOwner) _pre(ctx *schedModifier) (clone *schedMD, err error) {
+	so.Lock()
+	defer so.Unlock()
+	clone = so.get().clone()
+	if err = ctx.pre(ctx, clone); err != nil || ctx.terminate {
+		return
+	}
+	clone.Version++
+	err = so.putPersist(clone)
+	return
+}
+
+func (so *schedOwner) modify(ctx *schedModifier) (clone *schedMD, err error) {
+	if clone, err = so._pre(ctx); err != nil || ctx.terminate {
+		return
+	}
+	if ctx.final != nil {
+		ctx.final(ctx, clone)
+	}
+	return
+}
+
+//////////////////////////////
+// proxy: extract & receive //
+//////////////////////////////
+
+func (p *proxy) extractSchedMD(payload msPayload, caller string) (newMD *schedMD, msg *aisMsg, err error) {
+	value, ok := payload[revsSchedTag]
+	if !ok {
+		return
+	}
+	newMD, msg = newSchedMD(), &aisMsg{}
+	if err1 := jsoniter.Unmarshal(value, newMD); err1 != nil {
+		err = fmt.Errorf(cmn.FmtErrUnmarshal, p.si, "new SchedMD", cos.BHead(value), err1)
+		return
+	}
+	if msgValue, ok := payload[revsSchedTag+revsActionTag]; ok {
+		if err1 := jsoniter.Unmarshal(msgValue, msg); err1 != nil {
+			err = fmt.Errorf(cmn.FmtErrUnmarshal, p.si, "action message", cos.BHead(msgValue), err1)
+			return
+		}
+	}
+	md := p.owner.sched.get()
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("extract %s%s", newMD, _msdetail(md.Version, msg, caller))
+	}
+	if newMD.version() <= md.version() {
+		if newMD.version() < md.version() {
+			err = newErrDowngrade(p.si, md.String(), newMD.String())
+		}
+		newMD = nil
+	}
+	return
+}
+
+func (p *proxy) receiveSchedMD(newMD *schedMD, msg *aisMsg, caller string) (err error) {
+	if newMD == nil {
+		return
+	}
+	so := p.owner.sched
+	so.Lock()
+	md := so.get()
+	glog.Infof("receive %s%s", newMD, _msdetail(md.Version, msg, caller))
+	switch {
+	case newMD.version() > md.version():
+		err = so.putPersist(newMD)
+	case newMD.version() < md.version():
+		err = newErrDowngrade(p.si, md.String(), newMD.String())
+	}
+	so.Unlock()
+	return
+}
@@ -0,0 +1,143 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dload"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestSchedDeps(t *testing.T) {
+	var (
+		now = time.Now()
+		md  = newSchedMD()
+		add = func(name, cron string, deps ...string) *cmn.Schedule {
+			msg := &cmn.SchedMsg{Name: name, Cron: cron, Kind: apc.ActLRU, DependsOn: deps}
+			if err := md.checkDeps(msg); err != nil {
+				t.Fatal(err)
+			}
+			s := &cmn.Schedule{SchedMsg: *msg, Created: now.Add(-time.Hour), NextRun: nextRun(cron, now)}
+			md.Schedules[name] = s
+			return s
+		}
+	)
+	prefetch := add("prefetch", "@daily")
+	etl := add("etl", "", "prefetch")
+	add("archive", "", "etl")
+
+	if err := md.checkDeps(&cmn.SchedMsg{Name: "x", DependsOn: []string{"nonexistent"}}); err == nil {
+		t.Fatal("expected missing dependency error")
+	}
+	if deps := md.dependents("prefetch"); len(deps) != 1 || deps[0] != "etl" {
+		t.Fatalf("unexpected dependents %v", deps)
+	}
+	if !etl.NextRun.IsZero() {
+		t.Fatalf("dependency-only schedule with next run %v", etl.NextRun)
+	}
+
+	// not yet run
+	if dep := md.unmetDep(etl, etl.Triggered()); dep != "prefetch" {
+		t.Fatalf("expected unmet %q, got %q", "prefetch", dep)
+	}
+	// still running
+	prefetch.AddRun(cmn.SchedRun{Started: now.Add(-time.Minute), JobID: "j1", Status: cmn.SchedStarted})
+	if dep := md.unmetDep(etl, etl.Triggered()); dep != "prefetch" {
+		t.Fatalf("expected unmet %q, got %q", "prefetch", dep)
+	}
+	// succeeded
+	prefetch.History[0].Status, prefetch.History[0].Finished = cmn.SchedFinished, now
+	if dep := md.unmetDep(etl, etl.Triggered()); dep != "" {
+		t.Fatalf("expected all dependencies met, got %q", dep)
+	}
+	// ran since - must wait for the next success
+	etl.AddRun(cmn.SchedRun{Started: now.Add(time.Second), JobID: "j2", Status: cmn.SchedStarted})
+	if dep := md.unmetDep(etl, etl.Triggered()); dep != "prefetch" {
+		t.Fatalf("expected unmet %q, got %q", "prefetch", dep)
+	}
+	// skipped runs do not count
+	prefetch.AddRun(cmn.SchedRun{Started: now.Add(time.Minute), Status: cmn.SchedSkipped})
+	if dep := md.unmetDep(etl, time.Time{}); dep != "" {
+		t.Fatalf("expected all dependencies met, got %q", dep)
+	}
+}
+
+func TestSchedRetry(t *testing.T) {
+	var (
+		now = time.Now()
+		s   = &cmn.Schedule{SchedMsg: cmn.SchedMsg{Name: "s", Retries: 2, RetryDelay: cos.Duration(time.Minute)}}
+	)
+	for attempt := 0; attempt <= 2; attempt++ {
+		run := cmn.SchedRun{Started: now, Status: cmn.SchedFailed, Attempt: attempt}
+		s.Retry, s.NextRun = 0, time.Time{}
+		schedRetry(s, &run, now)
+		if attempt < 2 {
+			if s.Retry != attempt+1 || !s.NextRun.Equal(now.Add(time.Minute)) {
+				t.Fatalf("attempt %d: expected retry %d at %v, got %d at %v", attempt, attempt+1, now.Add(time.Minute),
+					s.Retry, s.NextRun)
+			}
+		} else if s.Retry != 0 || !s.NextRun.IsZero() {
+			t.Fatalf("attempt %d: retries exhausted, got retry %d at %v", attempt, s.Retry, s.NextRun)
+		}
+	}
+	// aborted is not retried
+	run := cmn.SchedRun{Started: now, Status: cmn.SchedAborted}
+	s.Retry = 0
+	schedRetry(s, &run, now)
+	if s.Retry != 0 {
+		t.Fatalf("aborted run must not be retried (retry %d)", s.Retry)
+	}
+}
+
+func TestValidateSched(t *testing.T) {
+	var (
+		src   = cmn.Bck{Name: "src", Provider: apc.AWS}
+		dst   = cmn.Bck{Name: "dst", Provider: apc.AIS}
+		dlReq = cos.MustMarshal(dload.Body{Type: dload.TypeBackend, RawMessage: cos.MustMarshal(dload.BackendBody{Base: dload.Base{Bck: src}})})
+	)
+	valid := []cmn.SchedMsg{
+		{Name: "sync-src", Cron: "@daily", Kind: apc.ActDownload, Body: dlReq},
+		{Name: "prefetch.1", Cron: "0 */6 * * *", Kind: apc.ActPrefetchObjects, Bck: src,
+			Body: cos.MustMarshal(cmn.SelectObjsMsg{Template: "shard-{0..9}.tar"})},
+		{Name: "backup", Cron: "@every 12h", Kind: apc.ActCopyBck, Bck: src, BckTo: dst},
+		{Name: "transform", Kind: apc.ActETLBck, Bck: src, BckTo: dst, DependsOn: []string{"prefetch.1"},
+			Body: cos.MustMarshal(apc.TCBMsg{ID: "md5"}), Retries: 3},
+		{Name: "pack", Kind: apc.ActArchive, Bck: dst, DependsOn: []string{"transform"},
+			Body: cos.MustMarshal(cmn.ArchiveMsg{ArchName: "all.tar", SelectObjsMsg: cmn.SelectObjsMsg{Template: "{0..9}"}})},
+		{Name: "evict", Kind: apc.ActEvictObjects, Bck: src, DependsOn: []string{"pack"},
+			Body: cos.MustMarshal(cmn.SelectObjsMsg{Template: "shard-{0..9}.tar"})},
+		{Name: "lru", Cron: "@daily", Kind: apc.ActLRU},
+		{Name: "cleanup", Cron: "@weekly", Kind: apc.ActStoreCleanup, Bck: dst},
+		{Name: "reb", Cron: "@weekly", Kind: apc.ActRebalance},
+	}
+	for i := range valid {
+		tassert.CheckError(t, validateSched(&valid[i]))
+	}
+	invalid := []cmn.SchedMsg{
+		{Name: "", Cron: "@daily", Kind: apc.ActCopyBck, Bck: src, BckTo: dst},
+		{Name: "a/b", Cron: "@daily", Kind: apc.ActCopyBck, Bck: src, BckTo: dst},
+		{Name: "bad-cron", Cron: "* * *", Kind: apc.ActCopyBck, Bck: src, BckTo: dst},
+		{Name: "bad-kind", Cron: "@daily", Kind: apc.ActECEncode, Bck: src},
+		{Name: "no-body", Cron: "@daily", Kind: apc.ActDownload},
+		{Name: "bad-type", Cron: "@daily", Kind: apc.ActDownload, Body: []byte(`{"type":"torrent"}`)},
+		{Name: "onto-itself", Cron: "@daily", Kind: apc.ActCopyBck, Bck: src, BckTo: src},
+		{Name: "no-trigger", Kind: apc.ActCopyBck, Bck: src, BckTo: dst},
+		{Name: "self-dep", Kind: apc.ActCopyBck, Bck: src, BckTo: dst, DependsOn: []string{"self-dep"}},
+		{Name: "bad-retries", Cron: "@daily", Kind: apc.ActLRU, Retries: -1},
+		{Name: "no-etl", Cron: "@daily", Kind: apc.ActETLBck, Bck: src, BckTo: dst, Body: []byte(`{}`)},
+		{Name: "bad-arch", Cron: "@daily", Kind: apc.ActArchive, Bck: src, Body: []byte(`{"archname":"a.xyz"}`)},
+		{Name: "no-bck", Cron: "@daily", Kind: apc.ActECScrub},
+		{Name: "clu-bck", Cron: "@daily", Kind: apc.ActRebalance, Bck: src},
+		{Name: "not-startable", Cron: "@daily", Kind: apc.ActPutCopies, Bck: src},
+	}
+	for i := range invalid {
+		tassert.Errorf(t, validateSched(&invalid[i]) != nil, "%q: expected error", invalid[i].Name)
+	}
+}
@@ -41,6 +41,7 @@ import (
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/reb"
+	"github.com/NVIDIA/aistore/repl"
 	"github.com/NVIDIA/aistore/res"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/transport"
@@ -290,17 +291,31 @@ func (t *target) Run() error {
 		glog.Errorln("")
 	}
 
-	// register object type and workfile type
+	// register object, workfile, and quarantine types
 	if err := fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}); err != nil {
 		cos.ExitLogf("%v", err)
 	}
 	if err := fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}); err != nil {
 		cos.ExitLogf("%v", err)
 	}
+	if err := fs.CSM.Reg(fs.QuarantineType, &fs.QuarantineResolver{}); err != nil {
+		cos.ExitLogf("%v", err)
+	}
+
+	db, err := kvdb.NewBuntDB(filepath.Join(config.ConfigDir, dbName))
+	if err != nil {
+		glog.Errorf("Failed to initialize DB: %v", err)
+		return err
+	}
+	defer cos.Close(db)
+
+	// (prior to joining - see resumeDownloads)
+	dload.Init(db, &daemon.stopping)
 
 	// Init meta-owners and load local instances
 	t.owner.bmd.init()
 	t.owner.etl.init()
+	etl.OnGiveUp = t.etlGaveUp
 
 	smap, reliable := t.tryLoadSmap()
 	if !reliable {
@@ -343,6 +358,8 @@ func (t *target) Run() error {
 				}
 			}
 			t.markClusterStarted()
+			go t.restartETLs()
+			t.resumeDownloads()
 
 			if t.fsprg.newVol && !config.TestingEnv() {
 				config := cmn.GCO.BeginUpdate()
@@ -353,15 +370,6 @@ func (t *target) Run() error {
 
 	t.initBackends()
 
-	db, err := kvdb.NewBuntDB(filepath.Join(config.ConfigDir, dbName))
-	if err != nil {
-		glog.Errorf("Failed to initialize DB: %v", err)
-		return err
-	}
-	defer cos.Close(db)
-
-	dload.SetDB(db)
-
 	// transactions
 	t.transactions.init(t)
 
@@ -372,9 +380,11 @@ func (t *target) Run() error {
 	t.initRecvHandlers()
 
 	ec.Init(t)
-	mirror.Init()
+	mirror.Init(t, t.statsT)
+	repl.Init(t, db, t.statsT)
 
 	xreg.RegWithHK()
+	(&scrubSched{}).init(t)
 
 	marked := xreg.GetResilverMarked()
 	if marked.Interrupted || daemon.resilver.required {
@@ -1018,7 +1028,8 @@ func (t *target) httpecget(w http.ResponseWriter, r *http.Request) {
 	}
 	switch apireq.items[0] {
 	case ec.URLMeta:
-		t.sendECMetafile(w, r, apireq.bck, apireq.items[2])
+		verify := cos.IsParseBool(apireq.query.Get(apc.QparamECVerify))
+		t.sendECMetafile(w, r, apireq.bck, apireq.items[2], verify)
 	case ec.URLCT:
 		t.sendECCT(w, r, apireq.bck, apireq.items[2])
 	default:
@@ -1028,7 +1039,8 @@ func (t *target) httpecget(w http.ResponseWriter, r *http.Request) {
 }
 
 // Returns a CT's metadata.
-func (t *target) sendECMetafile(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string) {
+// (when requested, verifies the slice or replica itself - see ec-scrub)
+func (t *target) sendECMetafile(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string, verify bool) {
 	if err := bck.Init(t.owner.bmd); err != nil {
 		if !cmn.IsErrRemoteBckNotFound(err) { // is ais
 			t.writeErrSilent(w, r, err)
@@ -1044,6 +1056,19 @@ func (t *target) sendECMetafile(w http.ResponseWriter, r *http.Request, bck *clu
 		}
 		return
 	}
+	if verify {
+		if err := ec.VerifyCT(t, bck, objName, md); err != nil {
+			switch {
+			case cos.IsErrBadCksum(err):
+				t.writeErr(w, r, err, http.StatusUnprocessableEntity)
+			case os.IsNotExist(err) || cmn.IsObjNotExist(err):
+				t.writeErrSilent(w, r, err, http.StatusNotFound)
+			default:
+				t.writeErr(w, r, err, http.StatusInternalServerError)
+			}
+			return
+		}
+	}
 	w.Write(md.NewPack())
 }
 
@@ -1176,6 +1201,10 @@ func (t *target) doAppendArch(r *http.Request, lom *cluster.LOM, started time.Ti
 
 func (t *target) putMirror(lom *cluster.LOM) {
 	mconfig := lom.MirrorConf()
+	if mconfig.Nodes() {
+		mirror.NM.Repl(lom, nil /*all replicas*/)
+		return
+	}
 	if !mconfig.Enabled {
 		return
 	}
@@ -1229,6 +1258,9 @@ func (t *target) DeleteObject(lom *cluster.LOM, evict bool) (int, error) {
 			t.statsT.Add(stats.DeleteCount, 1)
 		}
 	}
+	if lom.MirrorConf().Nodes() {
+		mirror.NM.Del(lom) // (cross-node replicas)
+	}
 	if delFromAIS {
 		size := lom.SizeBytes()
 		aisErr = lom.Remove()
@@ -1245,6 +1277,8 @@ func (t *target) DeleteObject(lom *cluster.LOM, evict bool) (int, error) {
 				cos.NamedVal64{Name: stats.LruEvictCount, Value: 1},
 				cos.NamedVal64{Name: stats.LruEvictSize, Value: size},
 			)
+		} else {
+			repl.Del(lom)
 		}
 	}
 	if backendErr != nil {
@@ -1320,3 +1354,16 @@ func (t *target) fsErr(err error, filepath string) {
 	t.statsT.AddMany(cos.NamedVal64{Name: stats.ErrIOCount, NameSuffix: keyName, Value: 1})
 	t.fshc.OnErr(filepath)
 }
+
+// unlike fsErr (above), test the mountpath regardless of the error type - the caller
+// (e.g., scrubber) has already established repeated failures on this mountpath
+func (t *target) checkFS(filepath string) {
+	if !cmn.GCO.Get().FSHC.Enabled {
+		return
+	}
+	if mi, _ := fs.Path2Mpath(filepath); mi == nil {
+		return
+	}
+	glog.Errorf("%s: waking up FSHC to check %q", t, filepath)
+	t.fshc.OnErr(filepath)
+}
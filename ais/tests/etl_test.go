@@ -635,8 +635,12 @@ func TestETLStopAndRestartETL(t *testing.T) {
 
 	// 3. Start ETL and verify it is in running state
 	tlog.Logf("restarting ETL %q", uuid)
-	err = api.ETLStart(baseParams, uuid)
+	statuses, err := api.ETLStartStatus(baseParams, uuid)
 	tassert.CheckFatal(t, err)
+	for _, status := range statuses {
+		tassert.Errorf(t, status.Status == etl.StatusStarted, "%s: expected ETL %q to be %q, got %q (%s)",
+			status.TargetID, uuid, etl.StatusStarted, status.Status, status.Err)
+	}
 	tetl.ETLShouldBeRunning(t, baseParams, uuid)
 }
 
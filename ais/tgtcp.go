@@ -241,7 +241,7 @@ func (t *target) httpdaeget(w http.ResponseWriter, r *http.Request) {
 	)
 	switch getWhat {
 	case apc.GetWhatConfig, apc.GetWhatSmap, apc.GetWhatBMD, apc.GetWhatSmapVote,
-		apc.GetWhatSnode, apc.GetWhatLog, apc.GetWhatStats:
+		apc.GetWhatSnode, apc.GetWhatLog, apc.GetWhatStats, apc.GetWhatMetricNames:
 		t.htrun.httpdaeget(w, r, query)
 	case apc.GetWhatSysInfo:
 		tsysinfo := apc.TSysInfo{MemCPUInfo: sys.GetMemCPU(), CapacityInfo: fs.CapStatusGetWhat()}
@@ -295,6 +295,17 @@ func (t *target) httpdaeget(w http.ResponseWriter, r *http.Request) {
 		debug.Assert(ok)
 
 		t.writeJSON(w, r, aisBackend.GetInfo(aisConf), httpdaeWhat)
+	case apc.GetWhatRebPlan:
+		var msg apc.ActValPlanReb
+		if err := cmn.ReadJSON(w, r, &msg); err != nil {
+			return
+		}
+		plan, err := reb.Plan(t, &msg)
+		if err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		t.writeJSON(w, r, plan, httpdaeWhat)
 	default:
 		t.htrun.httpdaeget(w, r, query)
 	}
@@ -440,6 +451,8 @@ func (t *target) handleMountpathReq(w http.ResponseWriter, r *http.Request) {
 		t.disableMpath(w, r, mpath)
 	case apc.ActMountpathDetach:
 		t.detachMpath(w, r, mpath)
+	case apc.ActMountpathDrain:
+		t.drainMpath(w, r, mpath)
 	default:
 		t.writeErrAct(w, r, msg.Action)
 	}
@@ -525,6 +538,22 @@ func (t *target) detachMpath(w http.ResponseWriter, r *http.Request, mpath strin
 	}
 }
 
+func (t *target) drainMpath(w http.ResponseWriter, r *http.Request, mpath string) {
+	drainingMi, err := t.fsprg.drainMpath(mpath)
+	if err != nil {
+		if cmn.IsErrMountpathNotFound(err) {
+			t.writeErr(w, r, err, http.StatusNotFound)
+		} else {
+			t.writeErr(w, r, err)
+		}
+		return
+	}
+	if drainingMi == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+}
+
 func (t *target) receiveBMD(newBMD *bucketMD, msg *aisMsg, payload msPayload, tag, caller string, silent bool) (err error) {
 	var (
 		rmbcks []*cluster.Bck
@@ -614,6 +643,8 @@ func (t *target) _applyBMD(newBMD *bucketMD, msg *aisMsg, payload msPayload) (rm
 			if obck.Props.EC.Enabled && !nbck.Props.EC.Enabled {
 				flt := xreg.XactFilter{Kind: apc.ActECEncode, Bck: nbck}
 				xreg.DoAbort(flt, errors.New("apply-bmd"))
+				flt.Kind = apc.ActECReencode
+				xreg.DoAbort(flt, errors.New("apply-bmd"))
 			}
 			return true
 		})
@@ -686,12 +717,18 @@ func (t *target) receiveRMD(newRMD *rebMD, msg *aisMsg, caller string) (err erro
 			NotifBase: nl.NotifBase{When: cluster.UponTerm, Dsts: []string{equalIC}, F: t.callerNotifyFin},
 		}
 		if msg.Action == apc.ActRebalance {
-			glog.Infof("%s: starting user-requested rebalance", t.si)
-			go t.reb.RunRebalance(&smap.Smap, newRMD.Version, notif)
+			// (unless resuming interrupted one - see `resumeReb`)
+			user := msg.Value != metaction3
+			if user {
+				glog.Infof("%s: starting user-requested rebalance", t.si)
+			} else {
+				glog.Infof("%s: resuming rebalance", t.si)
+			}
+			go t.reb.RunRebalance(&smap.Smap, newRMD.Version, notif, user)
 			return
 		}
 		glog.Infof("%s: starting auto-rebalance", t.si)
-		go t.reb.RunRebalance(&smap.Smap, newRMD.Version, notif)
+		go t.reb.RunRebalance(&smap.Smap, newRMD.Version, notif, false /*user*/)
 		if newRMD.Resilver != "" {
 			glog.Infof("%s: ... and resilver", t.si)
 			go t.runResilver(res.Args{UUID: newRMD.Resilver, SkipGlobMisplaced: true}, nil /*wg*/)
@@ -86,15 +86,7 @@ func (t *target) downloadHandler(w http.ResponseWriter, r *http.Request) {
 			glog.Infof("Downloading: %s", dljob.ID())
 		}
 
-		dljob.AddNotif(&dload.NotifDownload{
-			NotifBase: nl.NotifBase{
-				When:     cluster.UponProgress,
-				Interval: progressInterval,
-				Dsts:     []string{equalIC},
-				F:        t.callerNotifyFin,
-				P:        t.callerNotifyProgress,
-			},
-		}, dljob)
+		dljob.AddNotif(t.dlNotif(progressInterval), dljob)
 		response, statusCode, respErr = xdl.Download(dljob)
 
 	case http.MethodGet:
@@ -133,6 +125,23 @@ func (t *target) downloadHandler(w http.ResponseWriter, r *http.Request) {
 			response, statusCode, respErr = dload.ListJobs(regex, msg.OnlyActive)
 		}
 
+	case http.MethodPut: // (proxy) job limits re-shared
+		if _, err := t.apiItems(w, r, 0, false, apc.URLPathDownload.L); err != nil {
+			return
+		}
+		msg := &dload.LimitsMsg{}
+		if err := cmn.ReadJSON(w, r, msg); err != nil {
+			return
+		}
+		xactID := r.URL.Query().Get(apc.QparamUUID)
+		debug.Assertf(cos.IsValidUUID(xactID), "%q", xactID)
+		xdl, err := t.renewdl(xactID)
+		if err != nil {
+			t.writeErr(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		response, statusCode, respErr = xdl.SetLimits(msg)
+
 	case http.MethodDelete:
 		items, err := t.apiItems(w, r, 1, false, apc.URLPathDownload.L)
 		if err != nil {
@@ -167,7 +176,7 @@ func (t *target) downloadHandler(w http.ResponseWriter, r *http.Request) {
 			response, statusCode, respErr = xdl.RemoveJob(payload.ID)
 		}
 	default:
-		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodPost)
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodPost, http.MethodPut)
 		return
 	}
 
@@ -180,6 +189,69 @@ func (t *target) downloadHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (t *target) dlNotif(progressInterval time.Duration) *dload.NotifDownload {
+	return &dload.NotifDownload{
+		NotifBase: nl.NotifBase{
+			When:     cluster.UponProgress,
+			Interval: progressInterval,
+			Dsts:     []string{equalIC},
+			F:        t.callerNotifyFin,
+			P:        t.callerNotifyProgress,
+		},
+	}
+}
+
+// upon startup: restore persisted download jobs and resume those that were interrupted
+func (t *target) resumeDownloads() {
+	pjs, err := dload.LoadJobs()
+	if err != nil {
+		glog.Errorf("%s: failed to load download jobs: %v", t, err)
+		return
+	}
+	if len(pjs) == 0 {
+		return
+	}
+	xdl, err := t.renewdl(cos.GenUUID())
+	if err != nil {
+		glog.Errorf("%s: failed to resume %d download job%s: %v", t, len(pjs), cos.Plural(len(pjs)), err)
+		for _, pj := range pjs {
+			dload.FailResume(pj, err)
+		}
+		return
+	}
+	for _, pj := range pjs {
+		if err := t.resumeDownload(xdl, pj); err != nil {
+			glog.Errorf("%s: failed to resume download job %q: %v", t, pj.ID, err)
+			continue
+		}
+		glog.Infof("%s: resumed download job %q", t, pj.ID)
+	}
+}
+
+func (t *target) resumeDownload(xdl *dload.Xact, pj *dload.PersistedJob) error {
+	var (
+		dlBodyBase       = dload.Base{}
+		progressInterval = dload.DownloadProgressInterval
+		bck              = cluster.CloneBck(&pj.Bck)
+	)
+	if err := bck.Init(t.Bowner()); err != nil {
+		dload.FailResume(pj, err)
+		return err
+	}
+	if err := jsoniter.Unmarshal(pj.Body.RawMessage, &dlBodyBase); err == nil && dlBodyBase.ProgressInterval != "" {
+		if dur, err := time.ParseDuration(dlBodyBase.ProgressInterval); err == nil {
+			progressInterval = dur
+		}
+	}
+	dljob, err := dload.ParseStartRequest(t, bck, pj.ID, pj.Body, xdl)
+	if err != nil {
+		dload.FailResume(pj, err)
+		return err
+	}
+	dljob.AddNotif(t.dlNotif(progressInterval), dljob)
+	return xdl.Resume(dljob, pj)
+}
+
 func (t *target) renewdl(xactID string) (*dload.Xact, error) {
 	rns := xreg.RenewDownloader(t, t.statsT, xactID)
 	if rns.Err != nil {
@@ -9,21 +9,19 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 
+	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
-	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/ext/etl"
 )
 
 // [METHOD] /v1/etl
 func (t *target) etlHandler(w http.ResponseWriter, r *http.Request) {
-	if err := k8s.Detect(); err != nil {
-		t.writeErrSilent(w, r, err)
-		return
-	}
 	switch {
 	case r.Method == http.MethodPut:
 		t.handleETLPut(w, r)
@@ -60,19 +58,25 @@ func (t *target) handleETLPut(w http.ResponseWriter, r *http.Request) {
 		t.writeErr(w, r, err)
 		return
 	}
+	if err := t.initETL(initMsg); err != nil {
+		t.writeErr(w, r, err)
+	}
+}
 
+func (t *target) initETL(initMsg etl.InitMsg) (err error) {
 	switch msg := initMsg.(type) {
 	case *etl.InitSpecMsg:
 		err = etl.InitSpec(t, msg, etl.StartOpts{})
 	case *etl.InitCodeMsg:
 		err = etl.InitCode(t, msg)
+	case *etl.InitProcMsg:
+		err = etl.InitProc(t, msg)
+	case *etl.InitWasmMsg:
+		err = etl.InitWasm(t, msg)
 	default:
 		debug.Assert(false)
 	}
-	if err != nil {
-		t.writeErr(w, r, err)
-		return
-	}
+	return
 }
 
 func (t *target) handleETLGet(w http.ResponseWriter, r *http.Request) {
@@ -110,7 +114,7 @@ func (t *target) handleETLGet(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// POST /v1/etl/<uuid>/stop (or) TODO: /v1/etl/<uuid>/start
+// POST /v1/etl/<uuid>/stop (or) /v1/etl/<uuid>/start
 //
 // handleETLPost handles start/stop ETL pods
 func (t *target) handleETLPost(w http.ResponseWriter, r *http.Request) {
@@ -118,15 +122,92 @@ func (t *target) handleETLPost(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if apiItems[1] == apc.ETLStop {
+	switch apiItems[1] {
+	case apc.ETLStop:
 		t.stopETL(w, r, apiItems[0])
+	case apc.ETLStart:
+		t.startETL(w, r, apiItems[0])
+	default:
+		t.writeErrURL(w, r)
+	}
+}
+
+// (re)start stopped ETL from its init message stored in EtlMD
+func (t *target) startETL(w http.ResponseWriter, r *http.Request, etlID string) {
+	initMsg := t.owner.etl.get().get(etlID)
+	if initMsg == nil {
+		t.writeErr(w, r, cmn.NewErrNotFound("%s: etl UUID %s", t.si, etlID), http.StatusNotFound)
 		return
 	}
-	// TODO: Implement ETLStart to start inactive ETLs
-	t.writeErrURL(w, r)
+	status := &etl.StartStatus{TargetID: t.SID(), Status: etl.StatusRunning}
+	if _, err := etl.GetCommunicator(etlID, t.si); err != nil {
+		if err := t.initETL(initMsg); err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		status.Status = etl.StatusStarted
+	}
+	t.writeJSON(w, r, status, "start-etl")
+}
+
+// upon startup: (re)start in parallel all ETLs recorded in EtlMD - except those
+// that were explicitly stopped (or given up on by the supervisor)
+func (t *target) restartETLs() {
+	var (
+		wg    sync.WaitGroup
+		etlMD = t.owner.etl.get()
+	)
+	for etlID, initMsg := range etlMD.ETLs {
+		if etlMD.IsStopped(etlID) {
+			continue
+		}
+		if _, err := etl.GetCommunicator(etlID, t.si); err == nil {
+			continue // already running
+		}
+		wg.Add(1)
+		go func(etlID string, initMsg etl.InitMsg) {
+			defer wg.Done()
+			if err := t.initETL(initMsg); err != nil {
+				glog.Errorf("%s: failed to restart ETL %q: %v", t, etlID, err)
+				return
+			}
+			glog.Infof("%s: restarted ETL %q", t, etlID)
+		}(etlID, initMsg)
+	}
+	wg.Wait()
+}
+
+// (via `etl.OnGiveUp`) ask primary to stop the ETL cluster-wide and
+// persist its stopped state, so that it won't be restarted upon node restart
+func (t *target) etlGaveUp(etlID string) {
+	smap := t.owner.smap.get()
+	if err := smap.validate(); err != nil {
+		glog.Errorf("%s: failed to report ETL %q given up: %v", t, etlID, err)
+		return
+	}
+	cargs := allocCargs()
+	{
+		cargs.si = smap.Primary
+		cargs.req = cmn.HreqArgs{
+			Method: http.MethodPost,
+			Base:   smap.Primary.URL(cmn.NetIntraControl),
+			Path:   apc.URLPathETL.Join(etlID, apc.ETLStop),
+		}
+		cargs.timeout = apc.LongTimeout
+	}
+	res := t.call(cargs)
+	if res.err != nil {
+		glog.Errorf("%s: failed to report ETL %q given up: %v", t, etlID, res.toErr())
+	}
+	freeCargs(cargs)
+	freeCR(res)
 }
 
 func (t *target) stopETL(w http.ResponseWriter, r *http.Request, etlID string) {
+	if _, err := etl.GetCommunicator(etlID, t.si); err != nil {
+		t.writeErrSilent(w, r, err, http.StatusNotFound) // (e.g., given up by the supervisor)
+		return
+	}
 	if err := etl.Stop(t, etlID, cmn.ErrXactUserAbort); err != nil {
 		statusCode := http.StatusBadRequest
 		if cmn.IsErrNotFound(err) {
@@ -137,12 +218,18 @@ func (t *target) stopETL(w http.ResponseWriter, r *http.Request, etlID string) {
 	}
 }
 
+// `uuid` is either a single ETL ID or a comma-separated list of ETL IDs (chain)
 func (t *target) doETL(w http.ResponseWriter, r *http.Request, uuid string, bck *cluster.Bck, objName string) {
 	var (
-		comm etl.Communicator
-		err  error
+		comm  etl.Communicator
+		chain *etl.Chain
+		err   error
 	)
-	comm, err = etl.GetCommunicator(uuid, t.si)
+	if strings.IndexByte(uuid, ',') >= 0 {
+		chain, err = etl.NewChain(strings.Split(uuid, ","), t.si)
+	} else {
+		comm, err = etl.GetCommunicator(uuid, t.si)
+	}
 	if err != nil {
 		if cmn.IsErrNotFound(err) {
 			smap := t.owner.smap.Get()
@@ -155,7 +242,13 @@ func (t *target) doETL(w http.ResponseWriter, r *http.Request, uuid string, bck
 		t.writeErr(w, r, err)
 		return
 	}
-	if err := comm.OnlineTransform(w, r, bck, objName); err != nil {
+	if chain != nil {
+		comm = chain.Head()
+		err = chain.OnlineTransform(w, bck, objName)
+	} else {
+		err = comm.OnlineTransform(w, r, bck, objName)
+	}
+	if err != nil {
 		t.writeErr(w, r, cmn.NewErrETL(&cmn.ETLErrorContext{
 			UUID:    uuid,
 			PodName: comm.PodName(),
@@ -28,6 +28,7 @@ import (
 var _ cluster.Target = (*target)(nil)
 
 func (t *target) FSHC(err error, path string) { t.fsErr(err, path) }
+func (t *target) CheckFS(path string)         { t.checkFS(path) }
 func (t *target) PageMM() *memsys.MMSA        { return t.gmm }
 func (t *target) ByteMM() *memsys.MMSA        { return t.smm }
 
@@ -335,6 +336,11 @@ func (t *target) DisableMpath(mpath, reason string) (err error) {
 	return
 }
 
+// degraded mountpath stays available (and in HRW) but takes no new copies
+func (t *target) DegradeMpath(mpath, reason string, degraded bool) {
+	t.fsprg.degradeMpath(mpath, reason, degraded)
+}
+
 func (t *target) RebalanceNamespace(si *cluster.Snode) (b []byte, status int, err error) {
 	// pull the data
 	query := url.Values{}
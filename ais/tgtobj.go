@@ -29,7 +29,9 @@ import (
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/reb"
+	"github.com/NVIDIA/aistore/repl"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/xact/xreg"
@@ -184,6 +186,7 @@ func (poi *putObjInfo) putObject() (int, error) {
 			cos.NamedVal64{Name: stats.PutCount, Value: 1},
 			cos.NamedVal64{Name: stats.PutLatency, Value: int64(delta)},
 		)
+		poi.lom.MpathInfo().Consume(poi.lom.SizeBytes()) // user I/O takes from the mountpath budget (never waits)
 	}
 	// xaction in-objs counters, promote first
 	if poi.t2t && poi.xctn != nil && poi.owt == cmn.OwtPromote {
@@ -233,6 +236,9 @@ func (poi *putObjInfo) finalize() (errCode int, err error) {
 		}
 	}
 	poi.t.putMirror(poi.lom)
+	if poi.owt == cmn.OwtPut || poi.owt == cmn.OwtFinalize || poi.owt == cmn.OwtPromote {
+		repl.Put(poi.lom)
+	}
 	return
 }
 
@@ -247,7 +253,7 @@ func (poi *putObjInfo) fini() (errCode int, err error) {
 		errCode, err = poi.putRemote()
 		if err != nil {
 			loghdr := poi.loghdr()
-			glog.Errorf("PUT (%s): %v(%d)", loghdr, err, errCode)
+			lomFields(poi.lom).Errorf("PUT (%s): %v(%d)", loghdr, err, errCode)
 			if errCode != http.StatusServiceUnavailable {
 				return
 			}
@@ -300,7 +306,7 @@ func (poi *putObjInfo) fini() (errCode int, err error) {
 	}
 	if lom.HasCopies() {
 		if errdc := lom.DelAllCopies(); errdc != nil {
-			glog.Errorf("PUT (%s): failed to delete old copies [%v], proceeding to PUT anyway...", poi.loghdr(), errdc)
+			lomFields(poi.lom).Errorf("PUT (%s): failed to delete old copies [%v], proceeding to PUT anyway...", poi.loghdr(), errdc)
 		}
 	}
 	if lom.AtimeUnix() == 0 { // (is set when migrating within cluster; prefetch special case)
@@ -565,7 +571,7 @@ fin:
 	retry, errCode, err = goi.finalize(cold)
 	if retry && !retried {
 		debug.Assert(err != errSendingResp)
-		glog.Warningf("GET %s: retrying...", goi.lom)
+		lomFields(goi.lom).Warningf("GET %s: retrying...", goi.lom)
 		retried = true // only once
 		goi.lom.Uncache(true /*delDirty*/)
 		goto do
@@ -598,7 +604,7 @@ validate:
 		return
 	}
 
-	glog.Warning(err)
+	lomFields(lom).Warning(err)
 	redundant := lom.HasCopies() || lom.Bprops().EC.Enabled
 	//
 	// return err if there's no redundancy OR already recovered once (and failed)
@@ -608,7 +614,7 @@ validate:
 		// TODO: mark `deleted` and postpone actual deletion
 		//
 		if erl := lom.Remove(true /*force through rlock*/); erl != nil {
-			glog.Warningf("%s: failed to remove corrupted %s, err: %v", goi.t, lom, erl)
+			lomFields(lom).Warningf("%s: failed to remove corrupted %s, err: %v", goi.t, lom, erl)
 		}
 		return
 	}
@@ -624,7 +630,7 @@ validate:
 		restored := lom.RestoreToLocation()
 		goi.lom.Lock(false)
 		if restored {
-			glog.Warningf("%s: recovered corrupted %s from local replica", goi.t, lom)
+			lomFields(lom).Warningf("%s: recovered corrupted %s from local replica", goi.t, lom)
 			code = 0
 			goto validate
 		}
@@ -636,7 +642,7 @@ validate:
 		_, code, err = goi.restoreFromAny(true /*skipLomRestore*/)
 		goi.lom.Lock(false)
 		if err == nil {
-			glog.Warningf("%s: recovered corrupted %s from EC slices", goi.t, lom)
+			lomFields(lom).Warningf("%s: recovered corrupted %s from EC slices", goi.t, lom)
 			code = 0
 			goto validate
 		}
@@ -644,7 +650,7 @@ validate:
 
 	// TODO: ditto
 	if erl := lom.Remove(true /*force through rlock*/); erl != nil {
-		glog.Warningf("%s: failed to remove corrupted %s, err: %v", goi.t, lom, erl)
+		lomFields(lom).Warningf("%s: failed to remove corrupted %s, err: %v", goi.t, lom, erl)
 	}
 	return
 }
@@ -672,7 +678,8 @@ func (goi *getObjInfo) restoreFromAny(skipLomRestore bool) (doubleCheck bool, er
 			interrupted, running = marked.Interrupted, marked.Xact != nil
 			gfnActive            = goi.t.res.IsActive(3 /*interval-of-inactivity multiplier*/)
 		)
-		if interrupted || running || gfnActive {
+		// (ditto when any of the mountpaths is being drained and may still hold the object)
+		if interrupted || running || gfnActive || fs.AnyDraining() {
 			if goi.lom.RestoreToLocation() { // from copies
 				if glog.FastV(4, glog.SmoduleAIS) {
 					glog.Infof("%s restored", goi.lom)
@@ -716,6 +723,16 @@ gfn:
 		}
 	}
 
+	// cross-node mirroring: get from any of the replicas (and restore the main one)
+	if goi.lom.MirrorConf().Nodes() {
+		if tsi := goi.replicaNode(smap); tsi != nil && goi.getFromNeighbor(goi.lom, tsi) {
+			if glog.FastV(4, glog.SmoduleAIS) {
+				glog.Infof("%s: %s restored from replica %s", tname, goi.lom, tsi)
+			}
+			return
+		}
+	}
+
 	// restore from existing EC slices, if possible
 	ecErr := ec.ECM.RestoreObject(goi.lom)
 	if ecErr == nil {
@@ -745,6 +762,20 @@ gfn:
 	return
 }
 
+func (goi *getObjInfo) replicaNode(smap *smapX) *cluster.Snode {
+	nodes, err := mirror.Holders(goi.lom, &smap.Smap)
+	if err != nil {
+		glog.Error(err)
+		return nil
+	}
+	for _, tsi := range nodes {
+		if tsi.ID() != goi.t.SID() && goi.t.HeadObjT2T(goi.lom, tsi) {
+			return tsi
+		}
+	}
+	return nil
+}
+
 func (goi *getObjInfo) getFromNeighbor(lom *cluster.LOM, tsi *cluster.Snode) bool {
 	query := lom.Bck().AddToQuery(nil)
 	query.Set(apc.QparamIsGFNRequest, "true")
@@ -770,7 +801,7 @@ func (goi *getObjInfo) getFromNeighbor(lom *cluster.LOM, tsi *cluster.Snode) boo
 	resp, err := goi.t.client.data.Do(req) //nolint:bodyclose // closed by `poi.putObject`
 	cmn.FreeHra(reqArgs)
 	if err != nil {
-		glog.Errorf("%s: gfn failure, %s %q, err: %v", goi.t, tsi, lom, err)
+		lomFields(lom).Errorf("%s: gfn failure, %s %q, err: %v", goi.t, tsi, lom, err)
 		return false
 	}
 
@@ -790,7 +821,7 @@ func (goi *getObjInfo) getFromNeighbor(lom *cluster.LOM, tsi *cluster.Snode) boo
 	if erp == nil {
 		return true
 	}
-	glog.Errorf("%s: gfn-GET failed to PUT locally: %v(%d)", goi.t, erp, errCode)
+	lomFields(goi.lom).Errorf("%s: gfn-GET failed to PUT locally: %v(%d)", goi.t, erp, errCode)
 	return false
 }
 
@@ -924,7 +955,7 @@ func (goi *getObjInfo) transmit(r io.Reader, buf []byte, fqn string, coldGet boo
 			goi.t.fsErr(err, fqn)
 			goi.t.statsT.Add(stats.ErrGetCount, 1)
 		}
-		glog.Error(cmn.NewErrFailedTo(goi.t, "GET", fqn, err))
+		lomFields(goi.lom).Error(cmn.NewErrFailedTo(goi.t, "GET", fqn, err))
 		// at this point, error is already written into the response -
 		// return special code to indicate just that
 		return errSendingResp
@@ -944,6 +975,7 @@ func (goi *getObjInfo) transmit(r io.Reader, buf []byte, fqn string, coldGet boo
 	if goi.isGFN {
 		goi.t.reb.FilterAdd([]byte(goi.lom.Uname()))
 	}
+	goi.lom.MpathInfo().Consume(written) // user GET takes from the mountpath budget
 	// stats
 	goi.t.statsT.AddMany(
 		cos.NamedVal64{Name: stats.GetThroughput, Value: written},
@@ -1177,6 +1209,7 @@ func (coi *copyObjInfo) copyObject(lom *cluster.LOM, objNameTo string) (size int
 		size = lom.SizeBytes()
 		if coi.finalize {
 			coi.t.putMirror(dst2)
+			repl.Put(dst2)
 		}
 	}
 	err = err2
@@ -1474,6 +1507,7 @@ func (aaoi *appendArchObjInfo) finalize(fqn string) error {
 		}
 	}
 	aaoi.t.putMirror(aaoi.lom)
+	repl.Put(aaoi.lom)
 	return nil
 }
 
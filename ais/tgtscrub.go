@@ -0,0 +1,80 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// periodic scrubbing - see `disk.scrub_interval` and scrub.XactScrub
+
+const (
+	scrubName     = "scrub"
+	scrubIdleIval = time.Hour // when not configured
+)
+
+type scrubSched struct {
+	t    *target
+	last int64 // mono time of the last periodic scrub (or startup)
+}
+
+func (ss *scrubSched) init(t *target) {
+	ss.t, ss.last = t, mono.NanoTime()
+	hk.Reg(scrubName+hk.NameSuffix, ss.housekeep, scrubIdleIval)
+}
+
+func (ss *scrubSched) housekeep() time.Duration {
+	ival := cmn.GCO.Get().Disk.ScrubInterval.D()
+	if ival == 0 {
+		return scrubIdleIval
+	}
+	if elapsed := mono.Since(ss.last); elapsed < ival {
+		return cos.MinDuration(ival-elapsed, scrubIdleIval)
+	}
+	ss.last = mono.NanoTime()
+	go ss.t.runScrub("" /*uuid*/, nil /*wg*/)
+	return cos.MinDuration(ival, scrubIdleIval)
+}
+
+func (t *target) runScrub(id string, wg *sync.WaitGroup, bcks ...cmn.Bck) {
+	regToIC := id == ""
+	if regToIC {
+		id = cos.GenUUID()
+	}
+	rns := xreg.RenewScrub(t, id, bcks)
+	if rns.Err != nil || rns.IsRunning() {
+		debug.Assert(rns.Err == nil || cmn.IsErrUsePrevXaction(rns.Err))
+		if wg != nil {
+			wg.Done()
+		}
+		return
+	}
+	xctn := rns.Entry.Get()
+	if regToIC && xctn.ID() == id {
+		regMsg := xactRegMsg{UUID: id, Kind: apc.ActScrub, Srcs: []string{t.si.ID()}}
+		msg := t.newAmsgActVal(apc.ActRegGlobalXaction, regMsg)
+		t.bcastAsyncIC(msg)
+	}
+	xctn.AddNotif(&xact.NotifXact{
+		NotifBase: nl.NotifBase{When: cluster.UponTerm, Dsts: []string{equalIC}, F: t.callerNotifyFin},
+		Xact:      xctn,
+	})
+	xact.GoRunW(xctn)
+	if wg != nil {
+		wg.Done()
+	}
+}
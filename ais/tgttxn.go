@@ -20,7 +20,6 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/feat"
-	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/ext/etl"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/mirror"
@@ -112,6 +111,9 @@ func (t *target) txnHandler(w http.ResponseWriter, r *http.Request) {
 				t.writeErr(w, r, err)
 				return
 			}
+		} else if err := tcmsg.ValidateCopy(); err != nil {
+			t.writeErr(w, r, err)
+			return
 		}
 		xactID, err = t.tcb(c, tcmsg, dp)
 	case apc.ActCopyObjects, apc.ActETLObjects:
@@ -129,9 +131,12 @@ func (t *target) txnHandler(w http.ResponseWriter, r *http.Request) {
 				t.writeErr(w, r, err)
 				return
 			}
+		} else if err := tcoMsg.ValidateCopy(); err != nil {
+			t.writeErr(w, r, err)
+			return
 		}
 		xactID, err = t.tcobjs(c, tcoMsg, dp)
-	case apc.ActECEncode:
+	case apc.ActECEncode, apc.ActECReencode:
 		xactID, err = t.ecEncode(c)
 	case apc.ActArchive:
 		xactID, err = t.createArchMultiObj(c)
@@ -265,7 +270,13 @@ func (t *target) validateMakeNCopies(bck *cluster.Bck, msg *aisMsg) (curCopies,
 	curCopies = bck.Props.Mirror.Copies
 	newCopies, err = _parseNCopies(msg.Value)
 	if err == nil {
-		err = fs.ValidateNCopies(t.si.Name(), int(newCopies))
+		if bck.Props.Mirror.Mode == apc.MirrorNode {
+			mconfig := bck.Props.Mirror
+			mconfig.Copies, mconfig.Enabled = newCopies, newCopies > 1
+			err = mconfig.ValidateAsProps(t.owner.smap.get().CountActiveTargets())
+		} else {
+			err = fs.ValidateNCopies(t.si.Name(), int(newCopies))
+		}
 	}
 	// NOTE: #791 "limited coexistence" here and elsewhere
 	// TODO: support "force" option to ignore "limited coexistence" conflicts (see t.tcb)
@@ -371,12 +382,14 @@ func (t *target) validateNprops(bck *cluster.Bck, msg *aisMsg) (nprops *cmn.Buck
 		err = fmt.Errorf(cmn.FmtErrUnmarshal, t, "new bucket props", cos.BHead(body), err)
 		return
 	}
-	if nprops.Mirror.Enabled {
+	if nprops.Mirror.Local() {
 		mpathCount := fs.NumAvail()
 		if int(nprops.Mirror.Copies) > mpathCount {
 			err = fmt.Errorf(fmtErrInsuffMpaths1, t, mpathCount, bck, nprops.Mirror.Copies)
 			return
 		}
+	}
+	if nprops.Mirror.Enabled {
 		if nprops.Mirror.Copies > bck.Props.Mirror.Copies && cs.Err != nil {
 			return nprops, cs.Err
 		}
@@ -484,11 +497,7 @@ func (t *target) validateBckRenTxn(bckFrom, bckTo *cluster.Bck, msg *aisMsg) err
 }
 
 func (t *target) etlDP(msg *apc.TCBMsg) (dp cluster.DP, err error) {
-	if err = k8s.Detect(); err != nil {
-		return
-	}
-	if msg.ID == "" {
-		err = apc.ErrETLMissingUUID
+	if err = msg.Validate(); err != nil {
 		return
 	}
 	return etl.NewOfflineDataProvider(msg, t.si)
@@ -636,7 +645,7 @@ func (t *target) tcobjs(c *txnServerCtx, msg *cmn.TCObjsMsg, dp cluster.DP) (str
 			return xactID, cmn.NewErrBckNotFound(bckFrom.Bucket())
 		}
 		// begin
-		custom := &xreg.TCObjsArgs{BckFrom: bckFrom, BckTo: bckTo, DP: dp}
+		custom := &xreg.TCObjsArgs{BckFrom: bckFrom, BckTo: bckTo}
 		rns := xreg.RenewTCObjs(t, c.uuid, c.msg.Action /*kind*/, custom)
 		if rns.Err != nil {
 			glog.Errorf("%s: %q %+v %v", t, c.uuid, c.msg, rns.Err)
@@ -652,7 +661,7 @@ func (t *target) tcobjs(c *txnServerCtx, msg *cmn.TCObjsMsg, dp cluster.DP) (str
 		if err := t.transactions.begin(txn); err != nil {
 			return xactID, err
 		}
-		xtco.Begin(msg)
+		xtco.Begin(msg, dp)
 	case apc.ActAbort:
 		txn, err := t.transactions.find(c.uuid, apc.ActAbort)
 		if err == nil {
@@ -713,7 +722,12 @@ func (t *target) ecEncode(c *txnServerCtx) (string, error) {
 		if err = t.transactions.wait(txn, c.timeout.netw, c.timeout.host); err != nil {
 			return "", cmn.NewErrFailedTo(t, "commit", txn, err)
 		}
-		rns := xreg.RenewECEncode(t, c.bck, c.uuid, apc.ActCommit)
+		var rns xreg.RenewRes
+		if c.msg.Action == apc.ActECReencode {
+			rns = xreg.RenewECReencode(t, c.bck, c.uuid, apc.ActCommit)
+		} else {
+			rns = xreg.RenewECEncode(t, c.bck, c.uuid, apc.ActCommit)
+		}
 		if rns.Err != nil {
 			glog.Errorf("%s: %s %v", t, txn, rns.Err)
 			return "", rns.Err
@@ -170,6 +170,15 @@ func (t *target) cmdXactStart(xactMsg *xact.QueryMsg, bck *cluster.Bck) error {
 		wg.Add(1)
 		go t.runStoreCleanup(xactMsg.ID, wg, xactMsg.Buckets...)
 		wg.Wait()
+	case apc.ActScrub:
+		bcks := xactMsg.Buckets
+		if bck != nil && len(bcks) == 0 {
+			bcks = []cmn.Bck{*bck.Bucket()}
+		}
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		go t.runScrub(xactMsg.ID, wg, bcks...)
+		wg.Wait()
 	case apc.ActResilver:
 		if bck != nil {
 			glog.Errorf(erfmb, xactMsg.Kind, bck)
@@ -204,13 +213,30 @@ func (t *target) cmdXactStart(xactMsg *xact.QueryMsg, bck *cluster.Bck) error {
 			Xact: xctn,
 		})
 		go xctn.Run(nil)
+	case apc.ActECScrub:
+		rns := xreg.RenewECScrub(t, bck, xactMsg.ID)
+		if rns.Err != nil {
+			glog.Errorf("%s: %s %v", t, bck, rns.Err)
+			return rns.Err
+		}
+		xctn := rns.Entry.Get()
+		xctn.AddNotif(&xact.NotifXact{
+			NotifBase: nl.NotifBase{
+				When: cluster.UponTerm,
+				Dsts: []string{equalIC},
+				F:    t.callerNotifyFin,
+			},
+			Xact: xctn,
+		})
+		xact.GoRunW(xctn)
 	case apc.ActLoadLomCache:
 		rns := xreg.RenewBckLoadLomCache(t, xactMsg.ID, bck)
 		return rns.Err
 	// 3. cannot start
 	case apc.ActPutCopies:
 		return fmt.Errorf("cannot start %q (is driven by PUTs into a mirrored bucket)", xactMsg)
-	case apc.ActDownload, apc.ActEvictObjects, apc.ActDeleteObjects, apc.ActMakeNCopies, apc.ActECEncode:
+	case apc.ActDownload, apc.ActEvictObjects, apc.ActDeleteObjects, apc.ActMakeNCopies, apc.ActECEncode,
+		apc.ActECReencode:
 		return fmt.Errorf("initiating %q must be done via a separate documented API", xactMsg)
 	// 4. unknown
 	case "":
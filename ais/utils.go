@@ -240,3 +240,8 @@ func cleanupConfigDir(name string, keepInitialConfig bool) {
 		return nil
 	})
 }
+
+// structured-logging fields (see glog.Fields)
+func lomFields(lom *cluster.LOM) glog.Fields {
+	return glog.Fields{Comp: cmn.LogCompAIS, Bck: lom.Bucket().String(), Obj: lom.ObjName}
+}
@@ -20,10 +20,12 @@ const (
 	ActSummaryBck     = "summary-bck"
 	ActCopyBck        = "copy-bck"
 	ActDownload       = "download"
-	ActECEncode       = "ec-encode" // erasure code a bucket
-	ActECGet          = "ec-get"    // erasure decode objects
-	ActECPut          = "ec-put"    // erasure encode objects
-	ActECRespond      = "ec-resp"   // respond to other targets' EC requests
+	ActECEncode       = "ec-encode"   // erasure code a bucket
+	ActECGet          = "ec-get"      // erasure decode objects
+	ActECPut          = "ec-put"      // erasure encode objects
+	ActECReencode     = "ec-reencode" // change the number of data and parity slices of an erasure coded bucket
+	ActECRespond      = "ec-resp"     // respond to other targets' EC requests
+	ActECScrub        = "ec-scrub"    // verify and repair erasure coded bucket
 	ActETLInline      = "etl-inline"
 	ActETLBck         = "etl-bck"
 	ActElection       = "election"
@@ -39,10 +41,13 @@ const (
 	ActPutCopies      = "put-copies"
 	ActRebalance      = "rebalance"
 	ActRenameObject   = "rename-obj"
+	ActReplSync       = "repl-sync" // initial (full) sync of a bucket with its replication destination
+	ActReplicate      = "replicate" // asynchronous replication of a bucket (see cmn.ReplConf)
 	ActResetBprops    = "reset-bprops"
 	ActResetConfig    = "reset-config"
 	ActResilver       = "resilver"
 	ActResyncBprops   = "resync-bprops"
+	ActScrub          = "scrub" // verify objects and copies against their checksums; repair or quarantine corrupted
 	ActSetBprops      = "set-bprops"
 	ActSetConfig      = "set-config"
 	ActShutdown       = "shutdown"
@@ -93,6 +98,7 @@ const (
 	ActMountpathEnable  = "enable-mp"
 	ActMountpathDetach  = "detach-mp"
 	ActMountpathDisable = "disable-mp"
+	ActMountpathDrain   = "drain-mp"
 
 	// Actions on xactions
 	ActXactStop  = Stop
@@ -132,16 +138,28 @@ type (
 	}
 )
 
-// MountpathList contains two lists:
+// MountpathList contains the following lists:
 //   - Available - list of local mountpaths available to the storage target
 //   - WaitingDD - waiting for resilvering completion to be detached or disabled (moved to `Disabled`)
 //   - Disabled  - list of disabled mountpaths, the mountpaths that generated
 //     IO errors followed by (FSHC) health check, etc.
+//   - Degraded  - available mountpaths with slow or failing disks (as per FSHC) that take no new copies
+//   - Draining  - readable mountpaths that take no new objects and get detached once evacuated
 type (
 	MountpathList struct {
-		Available []string `json:"available"`
-		WaitingDD []string `json:"waiting_dd"`
-		Disabled  []string `json:"disabled"`
+		Available []string         `json:"available"`
+		WaitingDD []string         `json:"waiting_dd"`
+		Disabled  []string         `json:"disabled"`
+		Degraded  []string         `json:"degraded,omitempty"`
+		Draining  []MountpathDrain `json:"draining,omitempty"`
+	}
+	// drain progress: objects (and their sizes) moved off the mountpath so far
+	// vs. filesystem capacity it still uses
+	MountpathDrain struct {
+		Path    string `json:"mpath"`
+		Objs    int64  `json:"objs,string"`
+		Bytes   int64  `json:"bytes,string"`
+		PctUsed int32  `json:"pct_used"`
 	}
 )
 
@@ -0,0 +1,141 @@
+// Package apc: API messages and constants
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Alerts: the primary proxy periodically evaluates configured rules (see cmn.AlertConf)
+// over node stats and events; alerts are deduplicated by (rule, node) and remain active
+// for as long as the rule's condition holds - after that they are kept as resolved (for a while).
+
+// severity
+const (
+	AlertWarning  = "warning"
+	AlertCritical = "critical"
+)
+
+// webhook notification status
+const (
+	AlertFiring   = "firing"
+	AlertResolved = "resolved"
+)
+
+// metrics that the primary computes (or counts) itself, in addition to the node stats
+// (counters - as per their registered kind - are evaluated as increments between evaluations)
+const (
+	AlertMetricCapacity    = "capacity.pct_max" // max used capacity (%) across target's mountpaths
+	AlertMetricUnreachable = "node.unreachable" // 1 when the node fails to respond with its stats
+	AlertMetricKaliveFail  = "kalive.fail.n"    // failed keepalives (the node is then removed from the cluster map)
+	AlertMetricXactStuck   = "xact.stuck.n"     // running xactions that made no progress for `alert.xact_stuck_time`
+)
+
+type (
+	// parsed form of "<name>:<metric><op><threshold>[:<severity>]", e.g.: "disk-errors:err.io.n>0:critical"
+	AlertRule struct {
+		Name      string
+		Metric    string
+		Op        string
+		Severity  string
+		Threshold float64
+	}
+
+	Alert struct {
+		Started  time.Time `json:"started"`
+		LastSeen time.Time `json:"last_seen"`
+		Resolved time.Time `json:"resolved"` // zero when active
+		Rule     string    `json:"rule"`
+		Severity string    `json:"severity"`
+		Node     string    `json:"node"`
+		Cond     string    `json:"cond"` // e.g. "err.io.n>0"
+		Value    float64   `json:"value"`
+		Count    int64     `json:"count,string"` // number of evaluations the condition held
+	}
+	AlertList struct {
+		Active   []*Alert `json:"active"`
+		Resolved []*Alert `json:"resolved"`
+	}
+
+	// POST-ed to `alert.webhooks`
+	AlertNotif struct {
+		Cluster string   `json:"cluster"` // cluster UUID
+		Status  string   `json:"status"`  // enum { AlertFiring, AlertResolved }
+		Alerts  []*Alert `json:"alerts"`
+	}
+)
+
+// NOTE: two-character operators first
+var alertOps = []string{">=", "<=", "==", "!=", ">", "<"}
+
+func ParseAlertRule(s string) (*AlertRule, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, fmt.Errorf("invalid alert rule %q (expecting \"<name>:<metric><op><threshold>[:<severity>]\")", s)
+	}
+	r := &AlertRule{Name: parts[0], Severity: AlertWarning}
+	if r.Name == "" {
+		return nil, fmt.Errorf("invalid alert rule %q: missing name", s)
+	}
+	if len(parts) == 3 {
+		r.Severity = parts[2]
+		if r.Severity != AlertWarning && r.Severity != AlertCritical {
+			return nil, fmt.Errorf("invalid alert rule %q: severity must be one of (%s, %s)", s, AlertWarning, AlertCritical)
+		}
+	}
+	cond := parts[1]
+	for _, op := range alertOps {
+		i := strings.Index(cond, op)
+		if i <= 0 {
+			continue
+		}
+		threshold, err := strconv.ParseFloat(cond[i+len(op):], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alert rule %q: %v", s, err)
+		}
+		r.Metric, r.Op, r.Threshold = cond[:i], op, threshold
+		return r, nil
+	}
+	return nil, fmt.Errorf("invalid alert rule %q: expecting one of %v comparison operators", s, alertOps)
+}
+
+func (r *AlertRule) Cond() string {
+	return r.Metric + r.Op + strconv.FormatFloat(r.Threshold, 'f', -1, 64)
+}
+
+func (r *AlertRule) Eval(v float64) bool {
+	switch r.Op {
+	case ">=":
+		return v >= r.Threshold
+	case "<=":
+		return v <= r.Threshold
+	case "==":
+		return v == r.Threshold
+	case "!=":
+		return v != r.Threshold
+	case ">":
+		return v > r.Threshold
+	default:
+		return v < r.Threshold
+	}
+}
+
+func (a *Alert) IsCritical() bool { return a.Severity == AlertCritical }
+
+// active: critical first, then most recent first; resolved: most recently resolved first
+func (al *AlertList) Sort() {
+	sort.Slice(al.Active, func(i, j int) bool {
+		ai, aj := al.Active[i], al.Active[j]
+		if ai.IsCritical() != aj.IsCritical() {
+			return ai.IsCritical()
+		}
+		return ai.Started.After(aj.Started)
+	})
+	sort.Slice(al.Resolved, func(i, j int) bool { return al.Resolved[i].Resolved.After(al.Resolved[j].Resolved) })
+}
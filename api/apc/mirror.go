@@ -0,0 +1,21 @@
+// Package apc: API messages and constants
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+import (
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// n-way mirroring modes (`mirror.mode`)
+const (
+	MirrorMpath = "mountpath" // copies on different mountpaths of the same target (default)
+	MirrorNode  = "node"      // copies on different targets: object's HRW target and the next (copies - 1) in HRW order
+)
+
+var SupportedMirrorModes = []string{MirrorMpath, MirrorNode}
+
+func IsValidMirrorMode(mode string) bool {
+	return mode == "" || cos.StringInSlice(mode, SupportedMirrorModes)
+}
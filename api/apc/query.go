@@ -126,6 +126,7 @@ const (
 	QparamSilent           = "sln" // true: destination should not log errors (HEAD request)
 	QparamRebStatus        = "rbs" // true: get detailed rebalancing status
 	QparamRebData          = "rbd" // true: get EC rebalance data (pulling data if push way fails)
+	QparamECVerify         = "ecv" // true: verify local EC slice (replica) prior to returning its metadata (ec-scrub)
 	QparamTaskAction       = "tac" // "start", "status", "result"
 	QparamClusterInfo      = "cii" // true: /Health to return cluster info and status
 	QparamOWT              = "owt" // object write transaction enum { OwtPut, ..., OwtGet* }
@@ -166,6 +167,9 @@ const (
 	GetWhatSysInfo       = "sysinfo"
 	GetWhatTargetIPs     = "target_ips" // comma-separated list of all target IPs (compare w/ GetWhatSnode)
 	GetWhatLog           = "log"
+	GetWhatRebPlan       = "rebplan" // dry-run rebalance (see ActValPlanReb)
+	GetWhatAlerts        = "alerts"  // active and resolved alerts (see AlertList)
+	GetWhatMetricNames   = "metrics" // node's metric names and their kinds (counter, gauge, etc.)
 	// xactions
 	GetWhatOneXactStatus   = "status"      // IC status by uuid (returns a single matching xaction or none)
 	GetWhatAllXactStatus   = "status_all"  // ditto - all matching xactions
@@ -185,3 +189,9 @@ const (
 	LogWarn = "warning"
 	LogErr  = "error"
 )
+
+// log.format enum (see cmn.LogConf)
+const (
+	LogFormatText = "text" // glog (default)
+	LogFormatJSON = "json" // JSON lines
+)
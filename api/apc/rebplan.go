@@ -0,0 +1,63 @@
+// Package apc: API messages and constants
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+import (
+	"errors"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// dry-run (what-if) rebalance: hypothetical cluster map change and the resulting migration plan
+type (
+	// hypothetical change: targets to join and/or leave the cluster
+	ActValPlanReb struct {
+		Add    []string `json:"add,omitempty"`    // IDs of the targets to (hypothetically) join or take out of maintenance
+		Remove []string `json:"remove,omitempty"` // IDs of the existing targets to decommission (or put in maintenance)
+		// failure domains of the new targets to join (target ID => domain; see Snode.Domain)
+		Domains map[string]string `json:"domains,omitempty"`
+	}
+
+	RebPlanStats struct {
+		Objs  int64 `json:"objs,string"`
+		Bytes int64 `json:"bytes,string"`
+	}
+	RebPlanNode struct {
+		Sent RebPlanStats `json:"sent"`
+		Recv RebPlanStats `json:"recv"`
+	}
+
+	// produced by each target that (hypothetically) sends
+	RebPlanTarget struct {
+		Buckets    map[string]*RebPlanStats `json:"buckets"`           // bucket => outgoing
+		Dsts       map[string]*RebPlanStats `json:"dsts"`              // destination target ID => outgoing
+		Throughput int64                    `json:"throughput,string"` // bytes/s of the latest rebalance (0 - unknown)
+	}
+
+	// aggregated across the cluster
+	RebPlan struct {
+		Buckets map[string]*RebPlanStats `json:"buckets"` // bucket => to migrate
+		Pairs   map[string]*RebPlanStats `json:"pairs"`   // "source => destination" (target IDs) => to migrate
+		Targets map[string]*RebPlanNode  `json:"targets"` // target ID => sent and received
+		Total   RebPlanStats             `json:"total"`
+		// estimated total time, at the current (that is, observed during the latest rebalance) throughput
+		// and subject to the configured rebalance limits; zero when unknown
+		ETA cos.Duration `json:"eta"`
+	}
+)
+
+const PlanPairSepa = " => "
+
+func (msg *ActValPlanReb) Validate() error {
+	if len(msg.Add) == 0 && len(msg.Remove) == 0 {
+		return errors.New("rebalance plan: no targets to add or remove")
+	}
+	return nil
+}
+
+func (s *RebPlanStats) Add(objs, bytes int64) {
+	s.Objs += objs
+	s.Bytes += bytes
+}
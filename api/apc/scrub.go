@@ -0,0 +1,36 @@
+// Package apc: API messages and constants
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+// scrub (checksum-verifying) xaction: findings and repairs, per bucket
+type (
+	ScrubReport struct {
+		Checked         int64    `json:"checked,string"`          // objects
+		CopiesChecked   int64    `json:"copies_checked,string"`   // local copies (replicas)
+		Corrupted       int64    `json:"corrupted,string"`        // objects that failed checksum validation
+		CopiesCorrupted int64    `json:"copies_corrupted,string"` // ditto, copies
+		CopiesMissing   int64    `json:"copies_missing,string"`   // listed in the object's metadata but not found
+		Repaired        int64    `json:"repaired,string"`         // from copies, EC slices, or remote backend
+		Quarantined     int64    `json:"quarantined,string"`      // could not be repaired
+		Errs            int64    `json:"errs,string"`             // failed to check (e.g., I/O errors)
+		QuarantinedObjs []string `json:"quarantined_objs,omitempty"`
+	}
+	ScrubReports map[string]*ScrubReport // bucket (e.g., "ais://abc") => report
+)
+
+// max number of quarantined object names reported by a given target
+const ScrubMaxQuarantined = 100
+
+func (r *ScrubReport) Merge(other *ScrubReport) {
+	r.Checked += other.Checked
+	r.CopiesChecked += other.CopiesChecked
+	r.Corrupted += other.Corrupted
+	r.CopiesCorrupted += other.CopiesCorrupted
+	r.CopiesMissing += other.CopiesMissing
+	r.Repaired += other.Repaired
+	r.Quarantined += other.Quarantined
+	r.Errs += other.Errs
+	r.QuarantinedObjs = append(r.QuarantinedObjs, other.QuarantinedObjs...)
+}
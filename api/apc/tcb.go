@@ -6,6 +6,7 @@ package apc
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -27,7 +28,13 @@ type (
 		// TODO: this field might not be required when transformation on subset (template) of bucket is supported.
 		Ext cos.StrKVs `json:"ext"`
 
-		ID             string       `json:"id,omitempty"`              // optional, ETL only
+		ID    string   `json:"id,omitempty"`    // optional, ETL only
+		Chain []string `json:"chain,omitempty"` // optional, ETL only: ordered list of ETLs (pipeline)
+
+		// optional, ETL only: transformer may produce any number of named objects per input (one-to-many)
+		// and/or receive up to `BatchSize` input objects per request (many-to-one)
+		MultiOut       bool         `json:"multi_out,omitempty"`
+		BatchSize      int          `json:"batch_size,omitempty"`
 		RequestTimeout cos.Duration `json:"request_timeout,omitempty"` // optional, ETL only
 
 		CopyBckMsg
@@ -39,12 +46,45 @@ type (
 ////////////
 
 func (msg *TCBMsg) Validate() error {
-	if msg.ID == "" {
+	if msg.ID == "" && len(msg.Chain) == 0 {
 		return ErrETLMissingUUID
 	}
+	if msg.ID != "" && len(msg.Chain) != 0 {
+		return fmt.Errorf("ETL %q and ETL chain %v are mutually exclusive", msg.ID, msg.Chain)
+	}
+	for _, id := range msg.Chain {
+		if id == "" {
+			return ErrETLMissingUUID
+		}
+	}
+	if msg.BatchSize < 0 {
+		return fmt.Errorf("invalid batch size %d", msg.BatchSize)
+	}
+	if msg.Multi() && len(msg.Chain) > 1 {
+		return fmt.Errorf("ETL chain %v does not support multiple inputs or outputs", msg.Chain)
+	}
+	return nil
+}
+
+// ValidateCopy validates copy (i.e., no transformation) request.
+func (msg *TCBMsg) ValidateCopy() error {
+	if msg.Multi() {
+		return errors.New("multiple inputs or outputs ('multi_out', 'batch_size') require ETL")
+	}
 	return nil
 }
 
+// Multi returns true if the transformation is not one-to-one.
+func (msg *TCBMsg) Multi() bool { return msg.MultiOut || msg.BatchSize > 1 }
+
+// ETLs returns the ordered list of ETLs to apply (a single ETL is a one-stage chain).
+func (msg *TCBMsg) ETLs() []string {
+	if len(msg.Chain) != 0 {
+		return msg.Chain
+	}
+	return []string{msg.ID}
+}
+
 // Replace extension and add suffix if provided.
 func (msg *TCBMsg) ToName(name string) string {
 	if msg.Ext != nil {
@@ -68,10 +68,15 @@ const (
 	Discard     = "discard"
 	WorkerOwner = "worker" // TODO: it should be removed once get-next-bytes endpoint is ready
 
+	// recurring (download, prefetch, copy-bucket) jobs
+	Schedule = "schedule"
+
 	// ETL
 	ETL         = "etl"
 	ETLInitSpec = "init_spec"
 	ETLInitCode = "init_code"
+	ETLInitProc = "init_proc"
+	ETLInitWasm = "init_wasm"
 	ETLInfo     = "info"
 	ETLList     = List
 	ETLLogs     = "logs"
@@ -113,6 +118,7 @@ var (
 	URLPathCluSetConf = urlpath(Version, Cluster, ActSetConfig)
 	URLPathCluAttach  = urlpath(Version, Cluster, ActAttachRemAis)
 	URLPathCluDetach  = urlpath(Version, Cluster, ActDetachRemAis)
+	URLPathCluSched   = urlpath(Version, Cluster, Schedule)
 
 	URLPathDae          = urlpath(Version, Daemon)
 	URLPathDaeProxy     = urlpath(Version, Daemon, Proxy)
@@ -142,6 +148,7 @@ var (
 	URLPathDownload       = urlpath(Version, Download)
 	URLPathDownloadAbort  = urlpath(Version, Download, Abort)
 	URLPathDownloadRemove = urlpath(Version, Download, Remove)
+	URLPathDownloadSched  = urlpath(Version, Download, Schedule)
 
 	URLPathETL       = urlpath(Version, ETL)
 	URLPathETLObject = urlpath(Version, ETL, ETLObject)
@@ -545,3 +545,43 @@ func ECEncodeBucket(bp BaseParams, bck cmn.Bck, data, parity int) (xactID string
 	FreeRp(reqParams)
 	return
 }
+
+// ECReencodeBucket changes the number of data and parity slices of an erasure coded bucket
+// and starts an extended action (xaction) to re-encode all objects in the bucket.
+func ECReencodeBucket(bp BaseParams, bck cmn.Bck, data, parity int) (xactID string, err error) {
+	bp.Method = http.MethodPost
+	// Without `string` conversion it makes base64 from []byte in `Body`.
+	ecConf := string(cos.MustMarshal(&cmn.ECConfToUpdate{
+		DataSlices:   &data,
+		ParitySlices: &parity,
+	}))
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBuckets.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(apc.ActionMsg{Action: apc.ActECReencode, Value: ecConf})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.AddToQuery(nil)
+	}
+	err = reqParams.DoReqResp(&xactID)
+	FreeRp(reqParams)
+	return
+}
+
+// ReplSyncBucket starts copying existing objects of a replicated bucket to its
+// replication destination (see cmn.ReplConf); new changes get replicated asynchronously
+// and do not require this call.
+func ReplSyncBucket(bp BaseParams, bck cmn.Bck) (xactID string, err error) {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBuckets.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(apc.ActionMsg{Action: apc.ActReplSync})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.AddToQuery(nil)
+	}
+	err = reqParams.DoReqResp(&xactID)
+	FreeRp(reqParams)
+	return
+}
@@ -214,6 +214,53 @@ func SetClusterConfig(bp BaseParams, nvs cos.StrKVs, transient bool) error {
 	return err
 }
 
+// PauseRebalance pauses global rebalance (the one that is currently running, if any,
+// and the ones that will run later) until ResumeRebalance. Paused rebalance keeps
+// its position and continues from where it stopped.
+// Implemented via (and persisted as) cluster config `rebalance.paused`.
+func PauseRebalance(bp BaseParams) error {
+	return SetClusterConfig(bp, cos.StrKVs{"rebalance.paused": "true"}, false /*transient*/)
+}
+
+// ResumeRebalance resumes global rebalance paused via PauseRebalance.
+func ResumeRebalance(bp BaseParams) error {
+	return SetClusterConfig(bp, cos.StrKVs{"rebalance.paused": "false"}, false /*transient*/)
+}
+
+// PlanRebalance returns the migration plan (objects and bytes per bucket, per pair
+// of targets, and the estimated time) for the given hypothetical cluster map change,
+// without moving any data (dry-run).
+func PlanRebalance(bp BaseParams, msg *apc.ActValPlanReb) (plan *apc.RebPlan, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.GetWhatRebPlan}}
+	}
+	plan = &apc.RebPlan{}
+	err = reqParams.DoReqResp(plan)
+	FreeRp(reqParams)
+	return
+}
+
+// GetAlerts returns active and resolved alerts (as evaluated by the primary proxy - see cmn.AlertConf)
+func GetAlerts(bp BaseParams) (al *apc.AlertList, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.GetWhatAlerts}}
+	}
+	al = &apc.AlertList{}
+	err = reqParams.DoReqResp(al)
+	FreeRp(reqParams)
+	return
+}
+
 // SetClusterConfigUsingMsg sets the cluster-wide configuration
 // using the `cmn.ConfigToUpdate` parameter provided.
 func SetClusterConfigUsingMsg(bp BaseParams, configToUpdate *cmn.ConfigToUpdate, transient bool) error {
@@ -132,6 +132,25 @@ func DisableMountpath(bp BaseParams, node *cluster.Snode, mountpath string, dont
 	return err
 }
 
+// DrainMountpath moves all content off the mountpath (that remains readable in the meantime)
+// and detaches it when done; use `GetMountpaths` to monitor the progress.
+func DrainMountpath(bp BaseParams, node *cluster.Snode, mountpath string) error {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathReverseDae.Join(apc.Mountpaths)
+		reqParams.Body = cos.MustMarshal(apc.ActionMsg{Action: apc.ActMountpathDrain, Value: mountpath})
+		reqParams.Header = http.Header{
+			apc.HdrNodeID:      []string{node.ID()},
+			cos.HdrContentType: []string{cos.ContentJSON},
+		}
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // GetDaemonConfig returns the configuration of a specific daemon in a cluster.
 // (compare with `api.GetClusterConfig`)
 func GetDaemonConfig(bp BaseParams, node *cluster.Snode) (config *cmn.Config, err error) {
@@ -144,3 +144,43 @@ func (reqParams *ReqParams) doDlDownloadRequest() (string, error) {
 	err := reqParams.DoReqResp(&resp)
 	return resp.ID, err
 }
+
+// AddDownloadSchedule adds a recurring job - see cmn.SchedMsg and AddJobSchedule
+func AddDownloadSchedule(bp BaseParams, msg *cmn.SchedMsg) error {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathDownloadSched.S
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
+func RemoveDownloadSchedule(bp BaseParams, name string) error {
+	bp.Method = http.MethodDelete
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathDownloadSched.Join(name)
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
+// GetDownloadSchedules returns all job schedules, each with its (most recent) run history
+func GetDownloadSchedules(bp BaseParams) (schedules cmn.Schedules, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathDownloadSched.S
+	}
+	err = reqParams.DoReqResp(&schedules)
+	FreeRp(reqParams)
+	return
+}
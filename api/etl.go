@@ -10,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -120,7 +121,7 @@ func ETLGetInitMsg(params BaseParams, id string) (initMsg etl.InitMsg, err error
 	}
 
 	if _, ok := msgInf["spec"]; !ok {
-		err = fmt.Errorf("invalid response body: %+v", msgInf)
+		initMsg, err = etl.UnmarshalInitMsg(b)
 		return
 	}
 	initMsg = &etl.InitSpecMsg{}
@@ -132,8 +133,32 @@ func ETLStop(bp BaseParams, id string) (err error) {
 	return etlPostAction(bp, id, apc.ETLStop)
 }
 
-func ETLStart(bp BaseParams, id string) (err error) {
-	return etlPostAction(bp, id, apc.ETLStart)
+// ETLStart (re)starts stopped ETL on all targets; fails if any of the targets fails to start it
+// (see also: ETLStartStatus).
+func ETLStart(bp BaseParams, id string) error {
+	statuses, err := ETLStartStatus(bp, id)
+	if err != nil {
+		return err
+	}
+	for _, st := range statuses {
+		if st.Status == etl.StatusFailed {
+			return fmt.Errorf("failed to start ETL %q on target %s: %s", id, st.TargetID, st.Err)
+		}
+	}
+	return nil
+}
+
+// ETLStartStatus (re)starts stopped ETL on all targets and returns per-target status.
+func ETLStartStatus(bp BaseParams, id string) (statuses etl.StartStatusList, err error) {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathETL.Join(id, apc.ETLStart)
+	}
+	err = reqParams.DoReqResp(&statuses)
+	FreeRp(reqParams)
+	return
 }
 
 func etlPostAction(bp BaseParams, id, action string) (err error) {
@@ -157,6 +182,11 @@ func ETLObject(bp BaseParams, id string, bck cmn.Bck, objName string, w io.Write
 	return
 }
 
+// ETLChainObject applies a pipeline of ETLs to the object, in the specified order.
+func ETLChainObject(bp BaseParams, ids []string, bck cmn.Bck, objName string, w io.Writer) error {
+	return ETLObject(bp, strings.Join(ids, ","), bck, objName, w)
+}
+
 func ETLBucket(bp BaseParams, fromBck, toBck cmn.Bck, bckMsg *apc.TCBMsg) (xactID string, err error) {
 	if err = toBck.Validate(); err != nil {
 		return
@@ -0,0 +1,55 @@
+// Package api provides AIStore API over HTTP(S)
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// AddJobSchedule adds a recurring job that runs on a cron schedule and/or upon success
+// of the jobs it depends on - see cmn.SchedMsg
+func AddJobSchedule(bp BaseParams, msg *cmn.SchedMsg) error {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathCluSched.S
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
+// RemoveJobSchedule fails if the job is a dependency of another scheduled job
+func RemoveJobSchedule(bp BaseParams, name string) error {
+	bp.Method = http.MethodDelete
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathCluSched.Join(name)
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
+// GetJobSchedules returns all job schedules, each with its (most recent) run history
+func GetJobSchedules(bp BaseParams) (schedules cmn.Schedules, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathCluSched.S
+	}
+	err = reqParams.DoReqResp(&schedules)
+	FreeRp(reqParams)
+	return
+}
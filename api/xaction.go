@@ -65,7 +65,7 @@ func StartXaction(bp BaseParams, args XactReqArgs) (id string, err error) {
 			ext.Force = args.Force
 		}
 		xactMsg.Ext = ext
-	} else if strings.Contains(args.Kind, "cleanup") && args.Buckets != nil {
+	} else if (strings.Contains(args.Kind, "cleanup") || args.Kind == apc.ActScrub) && args.Buckets != nil {
 		xactMsg.Buckets = args.Buckets
 	}
 
@@ -60,6 +60,9 @@ func _hrwTarget(uname string, smap *Smap, skipMaint bool) (si *Snode, err error)
 // returns resulting subset (aka slice) that has the requested length = count.
 // Returns error if the cluster does not have enough targets.
 // If count == length of Smap.Tmap, the function returns as many targets as possible.
+//
+// When targets are labeled with failure domains (Snode.Domain), the sorted targets are
+// interleaved across domains - see hrwDomainList.
 func HrwTargetList(uname string, smap *Smap, count int) (sis Nodes, err error) {
 	const fmterr = "%v: required %d, available %d, %s"
 	cnt := smap.CountTargets()
@@ -68,6 +71,14 @@ func HrwTargetList(uname string, smap *Smap, count int) (sis Nodes, err error) {
 		return
 	}
 	digest := xxhash.ChecksumString64S(uname, cos.MLCG32)
+	if smap.CountDomains() > 0 {
+		sis = hrwDomainList(digest, smap, count)
+		if count != cnt && len(sis) < count {
+			err = fmt.Errorf(fmterr, cmn.ErrNotEnoughTargets, count, len(sis), smap)
+			return nil, err
+		}
+		return sis, nil
+	}
 	hlist := newHrwList(count)
 
 	for _, tsi := range smap.Tmap {
@@ -85,6 +96,133 @@ func HrwTargetList(uname string, smap *Smap, count int) (sis Nodes, err error) {
 	return sis, nil
 }
 
+// Given targets sorted by HRW weight, returns the highest-weight target from each failure domain,
+// followed by the second highest from each domain, etc. - the domains themselves are ordered
+// by their respective highest weights (unlabeled targets are separate domains of their own).
+// This way, consecutive slices of an erasure coded object (and replicas) end up in different
+// domains whenever possible, while the list remains stable for a given Smap: its first target
+// is still HrwTarget, and any shorter list is a prefix of a longer one.
+func hrwDomainList(digest uint64, smap *Smap, count int) Nodes {
+	d := smap.getDomains()
+	hlist := newHrwList(len(d.index))
+	for tsi := range d.index {
+		hlist.add(xoshiro256.Hash(tsi.idDigest^digest), tsi)
+	}
+	var (
+		sorted  = hlist.get()
+		domains = make([]Nodes, d.ngroups)
+		order   = make([]int, 0, d.ngroups) // by the highest weight
+	)
+	for _, tsi := range sorted {
+		i := d.index[tsi]
+		if len(domains[i]) == 0 {
+			order = append(order, i)
+		}
+		domains[i] = append(domains[i], tsi)
+	}
+	count = cos.Min(count, len(sorted))
+	sis := make(Nodes, 0, count)
+	for round := 0; len(sis) < count; round++ {
+		for _, i := range order {
+			if nodes := domains[i]; round < len(nodes) {
+				sis = append(sis, nodes[round])
+				if len(sis) == count {
+					break
+				}
+			}
+		}
+	}
+	return sis
+}
+
+// failure domains of the active targets - computed once per Smap version (see InitDigests)
+type smapDomains struct {
+	smap    *Smap
+	index   map[*Snode]int // active target => its domain (group) index
+	version int64
+	ngroups int // number of groups, including unlabeled targets
+	count   int // see CountDomains
+}
+
+func newSmapDomains(smap *Smap) *smapDomains {
+	var (
+		d      = &smapDomains{smap: smap, version: smap.Version, index: make(map[*Snode]int, len(smap.Tmap))}
+		byName = make(map[string]int, 4)
+	)
+	for _, tsi := range smap.Tmap {
+		if tsi.IsAnySet(NodeFlagsMaintDecomm) {
+			continue
+		}
+		if tsi.Domain == "" {
+			d.index[tsi] = d.ngroups
+			d.ngroups++
+			continue
+		}
+		i, ok := byName[tsi.Domain]
+		if !ok {
+			i = d.ngroups
+			byName[tsi.Domain] = i
+			d.ngroups++
+		}
+		d.index[tsi] = i
+	}
+	if len(byName) > 0 {
+		d.count = d.ngroups
+	}
+	return d
+}
+
+// precomputed unless the Smap is not (yet) published - e.g., a clone being modified
+func (m *Smap) getDomains() *smapDomains {
+	if d := m.domains; d != nil && d.smap == m && d.version == m.Version {
+		return d
+	}
+	return newSmapDomains(m)
+}
+
+// ValidateDomains checks whether erasure coded objects (D data and P parity slices plus
+// the main replica - all placed by HrwTargetList) survive the loss of an entire failure domain.
+// No-op if the targets are not labeled or if there are not enough targets in the first place.
+func (m *Smap) ValidateDomains(data, parity int) error {
+	if m.CountDomains() == 0 {
+		return nil
+	}
+	var (
+		sizes     = make(map[string]int, 8) // domain => number of targets
+		unlabeled int                       // (each a domain of its own)
+		n         = data + parity + 1
+	)
+	for _, tsi := range m.Tmap {
+		if tsi.IsAnySet(NodeFlagsMaintDecomm) {
+			continue
+		}
+		if tsi.Domain == "" {
+			unlabeled++
+		} else {
+			sizes[tsi.Domain]++
+		}
+	}
+	if m.CountActiveTargets() < n {
+		return nil
+	}
+	// given interleaving (see hrwDomainList), the max number of nodes that share a domain
+	// equals the number of rounds it takes to place all `n` nodes
+	var rounds int
+	for placed := 0; placed < n; {
+		rounds++
+		placed = unlabeled
+		for _, size := range sizes {
+			placed += cos.Min(size, rounds)
+		}
+	}
+	// losing the main replica along with (rounds - 1) slices - the remaining slices must suffice
+	if rounds-1 > parity {
+		return fmt.Errorf("EC (%d data, %d parity slices) places up to %d out of %d nodes in the same failure domain "+
+			"(have %d domains): data loss when losing the domain", data, parity, rounds, n, len(sizes)+unlabeled)
+	}
+	return nil
+}
+
 func HrwProxy(smap *Smap, idToSkip string) (pi *Snode, err error) {
 	var max uint64
 	for pid, psi := range smap.Pmap {
@@ -160,7 +298,7 @@ func HrwMpath(uname string) (mi *fs.MountpathInfo, digest uint64, err error) {
 	)
 	digest = xxhash.ChecksumString64S(uname, cos.MLCG32)
 	for _, mpathInfo := range availablePaths {
-		if mpathInfo.IsAnySet(fs.FlagWaitingDD) {
+		if mpathInfo.IsAnySet(fs.FlagWaitingDD | fs.FlagBeingDrained) {
 			continue
 		}
 		cs := xoshiro256.Hash(mpathInfo.PathDigest ^ digest)
@@ -0,0 +1,119 @@
+// Package cluster_test provides tests for cluster package
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster_test
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HRW", func() {
+	newSmap := func(domains, perDomain int) *cluster.Smap {
+		smap := &cluster.Smap{Tmap: make(cluster.NodeMap, domains*perDomain)}
+		for d := 0; d < domains; d++ {
+			for i := 0; i < perDomain; i++ {
+				id := fmt.Sprintf("t%d-%d", d, i)
+				tsi := cluster.NewSnode(id, apc.Target, cluster.NetInfo{}, cluster.NetInfo{}, cluster.NetInfo{})
+				if domains > 1 {
+					tsi.Domain = fmt.Sprintf("rack%d", d)
+				}
+				smap.Tmap[id] = tsi
+			}
+		}
+		return smap
+	}
+
+	Describe("HrwTargetList", func() {
+		It("should spread targets across failure domains", func() {
+			smap := newSmap(3, 4)
+			Expect(smap.CountDomains()).To(Equal(3))
+			for i := 0; i < 100; i++ {
+				uname := fmt.Sprintf("ais/@#/bck/obj-%d", i)
+				sis, err := cluster.HrwTargetList(uname, smap, 6)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sis).To(HaveLen(6))
+
+				main, err := cluster.HrwTarget(uname, smap)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sis[0].ID()).To(Equal(main.ID()))
+
+				perDomain := make(map[string]int, 3)
+				for _, tsi := range sis {
+					perDomain[tsi.Domain]++
+				}
+				Expect(perDomain).To(HaveLen(3))
+				for _, n := range perDomain {
+					Expect(n).To(Equal(2))
+				}
+
+				short, err := cluster.HrwTargetList(uname, smap, 4)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(short).To(Equal(sis[:4]))
+			}
+		})
+
+		It("should recompute failure domains for a new Smap version", func() {
+			smap := newSmap(3, 4)
+			smap.Version = 1
+			smap.InitDigests()
+			uname := "ais/@#/bck/obj"
+			sis, err := cluster.HrwTargetList(uname, smap, 6)
+			Expect(err).NotTo(HaveOccurred())
+
+			// move all targets of the second domain into the first one
+			from, to := sis[1].Domain, sis[0].Domain
+			for _, tsi := range smap.Tmap {
+				if tsi.Domain == from {
+					tsi.Domain = to
+				}
+			}
+			smap.Version++
+			Expect(smap.CountDomains()).To(Equal(2))
+			moved, err := cluster.HrwTargetList(uname, smap, 6)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(moved[0]).To(Equal(sis[0]))
+			Expect(moved[1].Domain).NotTo(Equal(sis[0].Domain))
+
+			smap.InitDigests()
+			cached, err := cluster.HrwTargetList(uname, smap, 6)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cached).To(Equal(moved))
+		})
+
+		It("should not change placement when targets are not labeled", func() {
+			smap := newSmap(1, 8)
+			Expect(smap.CountDomains()).To(Equal(0))
+			sis, err := cluster.HrwTargetList("ais/@#/bck/obj", smap, 3)
+			Expect(err).NotTo(HaveOccurred())
+			main, err := cluster.HrwTarget("ais/@#/bck/obj", smap)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sis[0].ID()).To(Equal(main.ID()))
+		})
+	})
+
+	Describe("Snode.Equals", func() {
+		It("should compare failure domains", func() {
+			a := cluster.NewSnode("t1", apc.Target, cluster.NetInfo{}, cluster.NetInfo{}, cluster.NetInfo{})
+			b := a.Clone()
+			Expect(a.Equals(b)).To(BeTrue())
+			b.Domain = "rack1"
+			Expect(a.Equals(b)).To(BeFalse())
+		})
+	})
+
+	Describe("ValidateDomains", func() {
+		It("should validate EC layout against failure domains", func() {
+			smap := newSmap(3, 4)
+			Expect(smap.ValidateDomains(4, 2)).To(Succeed())
+			Expect(smap.ValidateDomains(8, 3)).To(Succeed())
+			Expect(smap.ValidateDomains(8, 2)).NotTo(Succeed())
+			Expect(newSmap(1, 12).ValidateDomains(8, 2)).To(Succeed()) // not labeled
+		})
+	})
+})
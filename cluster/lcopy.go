@@ -55,7 +55,7 @@ func (lom *LOM) IsCopy() bool {
 // determines whether the two LOM _structures_ represent objects that must be _copies_ of each other
 // (compare with IsCopy above)
 func (lom *LOM) isMirror(dst *LOM) bool {
-	return lom.MirrorConf().Enabled &&
+	return lom.MirrorConf().Local() &&
 		lom.ObjName == dst.ObjName &&
 		lom.Bck().Equal(dst.Bck(), true /* must have same BID*/, true /* same backend */)
 }
@@ -160,7 +160,7 @@ func (lom *LOM) syncMetaWithCopies() (err error) {
 		}
 		lom.delCopyMd(copyFQN)
 		if err1 := cos.Stat(copyFQN); err1 != nil && !os.IsNotExist(err1) {
-			T.FSHC(err, copyFQN) // TODO: notify scrubber
+			T.FSHC(err, copyFQN) // (the copy, if any, becomes an orphan - removed by the scrubber)
 		}
 	}
 	return
@@ -363,9 +363,16 @@ func (lom *LOM) leastUtilCopy() (fqn string) {
 		copies     = lom.GetCopies()
 	)
 	fqn = lom.FQN
+	if lom.mpathInfo.IsAnySet(fs.FlagDegraded | fs.FlagBeingDrained) {
+		minUtil += 100 // prefer copies on healthy mountpaths
+	}
 	for copyFQN, copyMPI := range copies {
 		if copyFQN != lom.FQN {
-			if util := mpathUtils.Get(copyMPI.Path); util < minUtil {
+			util := mpathUtils.Get(copyMPI.Path)
+			if copyMPI.IsAnySet(fs.FlagDegraded | fs.FlagBeingDrained) {
+				util += 100
+			}
+			if util < minUtil {
 				fqn, minUtil = copyFQN, util
 			}
 		}
@@ -382,7 +389,7 @@ func (lom *LOM) LeastUtilNoCopy() (mi *fs.MountpathInfo) {
 		minUtil        = int64(101) // to motivate the first assignment
 	)
 	for mpath, mpathInfo := range availablePaths {
-		if lom.haveMpath(mpath) || mpathInfo.IsAnySet(fs.FlagWaitingDD) {
+		if lom.haveMpath(mpath) || mpathInfo.IsAnySet(fs.FlagWaitingDD|fs.FlagBeingDrained|fs.FlagDegraded) {
 			continue
 		}
 		if util := mpathUtils.Get(mpath); util < minUtil {
@@ -408,7 +415,7 @@ func (lom *LOM) haveMpath(mpath string) bool {
 // returns mountpath destination to copy this object, or nil if no copying is required
 // - checks hrw location first, and
 // - checks copies (if any) against the current configuation and available mountpaths;
-// - does not check `fstat` in either case (TODO: configurable or scrub);
+// - does not check `fstat` in either case (see scrub package for the content check);
 func (lom *LOM) ToMpath() (mi *fs.MountpathInfo, isHrw bool) {
 	var (
 		availablePaths = fs.GetAvail()
@@ -418,12 +425,12 @@ func (lom *LOM) ToMpath() (mi *fs.MountpathInfo, isHrw bool) {
 		glog.Error(err)
 		return
 	}
-	debug.Assert(!hrwMi.IsAnySet(fs.FlagWaitingDD))
+	debug.Assert(!hrwMi.IsAnySet(fs.FlagWaitingDD | fs.FlagBeingDrained))
 	if lom.mpathInfo.Path != hrwMi.Path {
 		return hrwMi, true
 	}
 	mirror := lom.MirrorConf()
-	if !mirror.Enabled || mirror.Copies < 2 {
+	if !mirror.Local() || mirror.Copies < 2 {
 		return
 	}
 	// count copies vs. configuration
@@ -431,7 +438,7 @@ func (lom *LOM) ToMpath() (mi *fs.MountpathInfo, isHrw bool) {
 	expCopies, gotCopies := int(mirror.Copies), 0
 	for fqn, mpi := range lom.md.copies {
 		mpathInfo, ok := availablePaths[mpi.Path]
-		if !ok || mpathInfo.IsAnySet(fs.FlagWaitingDD) {
+		if !ok || mpathInfo.IsAnySet(fs.FlagWaitingDD|fs.FlagBeingDrained) {
 			lom.delCopyMd(fqn)
 		} else {
 			gotCopies++
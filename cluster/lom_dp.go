@@ -21,6 +21,27 @@ type (
 		Reader(lom *LOM) (reader cos.ReadOpenCloser, objMeta cmn.ObjAttrsHolder, err error)
 	}
 
+	// optional: data provider that maintains its own stats (e.g., multi-stage ETL)
+	DPStats interface {
+		DPStats() []DPStageStats
+	}
+	DPStageStats struct {
+		Chain    string `json:"chain"` // comma-separated names of all the stages
+		Name     string `json:"etl"`   // this stage
+		Objs     int64  `json:"objs"`
+		InBytes  int64  `json:"in_bytes"`
+		OutBytes int64  `json:"out_bytes"`
+		Errs     int64  `json:"errs"`
+	}
+
+	// optional: data provider that emits any number of (named) objects for any number of inputs
+	DPM interface {
+		Emit(lom *LOM, cb EmitCb) error // transform `lom` (or add it to the current batch)
+		Flush(cb EmitCb) error          // transform the remaining batch, if any
+	}
+	// `dp` provides the content of the emitted object `objName`; `lom` is the (last) source object
+	EmitCb func(lom *LOM, objName string, dp DP) error
+
 	LDP struct{}
 
 	// compare with `deferROC` from cmn/cos/io.go
@@ -39,6 +60,13 @@ func (r *deferROC) Close() (err error) {
 	return
 }
 
+func (r *deferROC) FQN() (fqn string) {
+	if fh, ok := r.ReadOpenCloser.(*cos.FileHandle); ok {
+		fqn = fh.FQN()
+	}
+	return
+}
+
 // is called under rlock
 func (lom *LOM) NewDeferROC() (cos.ReadOpenCloser, error) {
 	fh, err := cos.NewFileHandle(lom.FQN)
@@ -65,6 +65,7 @@ type (
 		ControlNet NetInfo    `json:"intra_control_net"` // cmn.NetIntraControl
 		DaeType    string     `json:"daemon_type"`       // "target" or "proxy"
 		DaeID      string     `json:"daemon_id"`
+		Domain     string     `json:"failure_domain,omitempty"` // e.g., rack or zone (see HrwTargetList)
 		name       string
 		Flags      cos.BitFlags `json:"flags"` // enum { SnodeNonElectable, SnodeIC, ... }
 		idDigest   uint64
@@ -73,13 +74,14 @@ type (
 	NodeMap map[string]*Snode // map of Snodes: DaeID => Snodes
 
 	Smap struct {
-		Ext          any     `json:"ext,omitempty"`
-		Pmap         NodeMap `json:"pmap"` // [pid => Snode]
-		Primary      *Snode  `json:"proxy_si"`
-		Tmap         NodeMap `json:"tmap"`          // [tid => Snode]
-		UUID         string  `json:"uuid"`          // assigned once at creation time and never change
-		CreationTime string  `json:"creation_time"` // creation timestamp
-		Version      int64   `json:"version,string"`
+		Ext          any          `json:"ext,omitempty"`
+		Pmap         NodeMap      `json:"pmap"` // [pid => Snode]
+		Primary      *Snode       `json:"proxy_si"`
+		Tmap         NodeMap      `json:"tmap"`          // [tid => Snode]
+		UUID         string       `json:"uuid"`          // assigned once at creation time and never change
+		CreationTime string       `json:"creation_time"` // creation timestamp
+		Version      int64        `json:"version,string"`
+		domains      *smapDomains // precomputed failure domains (see InitDigests)
 	}
 
 	// Smap on-change listeners
@@ -185,6 +187,9 @@ func (d *Snode) Equals(o *Snode) (eq bool) {
 		return
 	}
 	eq = d.ID() == o.ID()
+	if eq && d.Domain != o.Domain { // (failure domain changed - placement changes as well)
+		return false
+	}
 	debug.Func(func() {
 		if !eq {
 			return
@@ -290,6 +295,8 @@ func (ni *NetInfo) Equals(other NetInfo) bool {
 //
 //===============================================================
 
+// InitDigests is called once per Smap version, prior to publishing it - precomputes
+// node digests and failure domains
 func (m *Smap) InitDigests() {
 	for _, node := range m.Tmap {
 		node.Digest()
@@ -297,6 +304,7 @@ func (m *Smap) InitDigests() {
 	for _, node := range m.Pmap {
 		node.Digest()
 	}
+	m.domains = newSmapDomains(m)
 }
 
 func (m *Smap) String() string {
@@ -330,6 +338,10 @@ func (m *Smap) CountActiveTargets() (count int) {
 	return
 }
 
+// number of distinct failure domains of the active targets, where unlabeled targets
+// count as separate domains; zero if none of the targets is labeled
+func (m *Smap) CountDomains() int { return m.getDomains().count }
+
 func (m *Smap) CountNonElectable() (count int) {
 	for _, p := range m.Pmap {
 		if p.nonElectable() {
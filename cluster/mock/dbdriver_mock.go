@@ -124,3 +124,25 @@ func (bd *DBDriver) GetAll(collection, pattern string) (map[string]string, error
 	}
 	return values, nil
 }
+
+func (bd *DBDriver) GetPage(collection, prefix, after string, limit int) (keys, values []string, err error) {
+	bd.mtx.RLock()
+	defer bd.mtx.RUnlock()
+	filter := bd.makePath(collection, prefix)
+	for k := range bd.values {
+		if strings.HasPrefix(k, filter) {
+			if _, key := kvdb.ParsePath(k); key != "" && key > after {
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+	values = make([]string, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, bd.values[bd.makePath(collection, key)])
+	}
+	return keys, values, nil
+}
@@ -23,10 +23,12 @@ func NewStatsTracker() stats.Tracker {
 
 func (*StatsTracker) StartedUp() bool                  { return true }
 func (*StatsTracker) Add(string, int64)                {}
+func (*StatsTracker) Set(string, int64)                {}
 func (*StatsTracker) Get(string) int64                 { return 0 }
 func (*StatsTracker) AddErrorHTTP(string, int64)       {}
 func (*StatsTracker) AddMany(...cos.NamedVal64)        {}
 func (*StatsTracker) RegMetrics(*cluster.Snode)        {}
 func (*StatsTracker) CoreStats() *stats.CoreStats      { return nil }
 func (*StatsTracker) GetWhatStats() *stats.DaemonStats { return nil }
+func (*StatsTracker) GetMetricNames() cos.StrKVs       { return nil }
 func (*StatsTracker) IsPrometheus() bool               { return false }
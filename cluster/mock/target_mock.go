@@ -55,6 +55,7 @@ func (*TargetMock) HeadObjT2T(*cluster.LOM, *cluster.Snode) bool
 func (*TargetMock) RebalanceNamespace(*cluster.Snode) ([]byte, int, error)      { return nil, 0, nil }
 func (*TargetMock) BMDVersionFixup(*http.Request, ...cmn.Bck)                   {}
 func (*TargetMock) FSHC(error, string)                                          {}
+func (*TargetMock) CheckFS(string)                                              {}
 func (*TargetMock) OOS(*fs.CapStatus) fs.CapStatus                              { return fs.CapStatus{} }
 
 func (*TargetMock) CopyObject(*cluster.LOM, *cluster.CopyObjectParams, bool) (int64, error) {
@@ -66,6 +66,7 @@ type (
 
 		// FS health and Health
 		FSHC(err error, path string)
+		CheckFS(path string) // run FSHC on the path's mountpath (e.g., upon repeated scrubbing failures)
 		Health(si *Snode, timeout time.Duration, query url.Values) (body []byte, errCode int, err error)
 	}
 
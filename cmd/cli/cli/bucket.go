@@ -674,6 +674,27 @@ func ecEncode(c *cli.Context, bck cmn.Bck, data, parity int) (err error) {
 	return
 }
 
+// change the number of slices of an erasure coded bucket
+func ecReencode(c *cli.Context, bck cmn.Bck, data, parity int) (err error) {
+	var xactID string
+	if xactID, err = api.ECReencodeBucket(apiBP, bck, data, parity); err != nil {
+		return
+	}
+	msg := fmt.Sprintf("Re-encoding bucket %s (%d data and %d parity slices). ", bck.DisplayName(), data, parity)
+	actionDone(c, msg+toMonitorMsg(c, xactID))
+	return
+}
+
+func replSync(c *cli.Context, bck cmn.Bck, dst string) (err error) {
+	var xactID string
+	if xactID, err = api.ReplSyncBucket(apiBP, bck); err != nil {
+		return
+	}
+	msg := fmt.Sprintf("Copying existing objects of bucket %s to %s. ", bck.DisplayName(), dst)
+	actionDone(c, msg+toMonitorMsg(c, xactID))
+	return
+}
+
 // This function returns buckets based on arguments provided to the command.
 // In case something is missing it also generates a meaningful error message.
 func parseBcks(c *cli.Context) (bckFrom, bckTo cmn.Bck, err error) {
@@ -36,20 +36,28 @@ var (
 		subcmdPrimary:  {},
 		subcmdJoin: {
 			roleFlag,
+			failureDomainFlag,
+			dryRunFlag,
 		},
 		subcmdStartMaint: {
 			noRebalanceFlag,
+			dryRunFlag,
+		},
+		subcmdStopMaint: {
+			dryRunFlag,
 		},
 		subcmdShutdown + ".node": {
 			noRebalanceFlag,
 			noShutdownFlag,
 			rmUserDataFlag,
+			dryRunFlag,
 		},
 		subcmdNodeDecommission + ".node": {
 			noRebalanceFlag,
 			noShutdownFlag,
 			rmUserDataFlag,
 			yesFlag,
+			dryRunFlag,
 		},
 		subcmdClusterDecommission: {
 			rmUserDataFlag,
@@ -75,6 +83,16 @@ var (
 		Flags:  clusterCmdsFlags[commandStop],
 		Action: stopClusterRebalanceHandler,
 	}
+	pauseRebalance = cli.Command{
+		Name:   commandPause,
+		Usage:  "pause global rebalance (to resume from where it stopped, run 'ais cluster rebalance resume')",
+		Action: pauseClusterRebalanceHandler,
+	}
+	resumeRebalance = cli.Command{
+		Name:   commandResume,
+		Usage:  "resume paused global rebalance",
+		Action: resumeClusterRebalanceHandler,
+	}
 
 	clusterCmd = cli.Command{
 		Name:  commandCluster,
@@ -101,6 +119,8 @@ var (
 				Subcommands: []cli.Command{
 					startRebalance,
 					stopRebalance,
+					pauseRebalance,
+					resumeRebalance,
 					{
 						Name:         commandShow,
 						Usage:        "show global rebalance",
@@ -153,6 +173,7 @@ var (
 						Name:         subcmdStopMaint,
 						Usage:        "activate node by taking it back from \"maintenance\"",
 						ArgsUsage:    daemonIDArgument,
+						Flags:        clusterCmdsFlags[subcmdStopMaint],
 						Action:       nodeMaintShutDecommHandler,
 						BashComplete: suggestAllNodes,
 					},
@@ -235,6 +256,9 @@ func joinNodeHandler(c *cli.Context) (err error) {
 	if c.NArg() < 1 {
 		return missingArgumentsError(c, "public socket address to communicate with the node")
 	}
+	if flagIsSet(c, dryRunFlag) {
+		return joinDryRun(c)
+	}
 	socketAddr = c.Args().Get(0)
 	socketAddrParts = strings.Split(socketAddr, ":")
 	if len(socketAddrParts) != 2 {
@@ -263,6 +287,7 @@ func joinNodeHandler(c *cli.Context) (err error) {
 		PubNet:     netInfo,
 		ControlNet: netInfo,
 		DataNet:    netInfo,
+		Domain:     parseStrFlag(c, failureDomainFlag), // (if empty, the node's config applies)
 	}
 	if rebID, nodeInfo.DaeID, err = api.JoinCluster(apiBP, nodeInfo); err != nil {
 		return
@@ -285,6 +310,10 @@ func nodeMaintShutDecommHandler(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	action := c.Command.Name
+	if flagIsSet(c, dryRunFlag) {
+		return nodeDryRun(c, smap, action)
+	}
 	sid, sname, err := getNodeIDName(c, c.Args().First())
 	if err != nil {
 		return err
@@ -292,7 +321,6 @@ func nodeMaintShutDecommHandler(c *cli.Context) error {
 	node := smap.GetNode(sid)
 	debug.Assert(node != nil)
 
-	action := c.Command.Name
 	if smap.IsPrimary(node) {
 		return fmt.Errorf("%s is primary (cannot %s the primary node)", sname, action)
 	}
@@ -366,6 +394,52 @@ func nodeMaintShutDecommHandler(c *cli.Context) error {
 	return nil
 }
 
+// dry-run: show what would migrate upon adding target(s); the node IDs get generated by the nodes
+// themselves (see ais/target.go initTID), so here we use placeholders - the totals are
+// statistically the same
+func joinDryRun(c *cli.Context) error {
+	switch parseStrFlag(c, roleFlag) {
+	case apc.Target, roleTargetShort:
+	default:
+		return fmt.Errorf("option '--%s' is valid only for targets (proxies do not store data)", dryRunFlag.Name)
+	}
+	var (
+		msg    = &apc.ActValPlanReb{}
+		domain = parseStrFlag(c, failureDomainFlag)
+	)
+	if domain != "" {
+		msg.Domains = make(map[string]string, c.NArg())
+	}
+	for _, addr := range c.Args() {
+		tid := "t[" + addr + "]"
+		msg.Add = append(msg.Add, tid)
+		if domain != "" {
+			msg.Domains[tid] = domain
+		}
+	}
+	return planRebalance(c, msg)
+}
+
+// dry-run: show what would migrate upon removing (or activating) target(s)
+func nodeDryRun(c *cli.Context, smap *cluster.Smap, action string) error {
+	msg := &apc.ActValPlanReb{}
+	for _, arg := range c.Args() {
+		sid, sname, err := getNodeIDName(c, arg)
+		if err != nil {
+			return err
+		}
+		if node := smap.GetNode(sid); node == nil || !node.IsTarget() {
+			return fmt.Errorf("option '--%s' is valid only for targets (%s is not)", dryRunFlag.Name, sname)
+		}
+		if action == subcmdStopMaint {
+			msg.Add = append(msg.Add, sid)
+		} else {
+			msg.Remove = append(msg.Remove, sid)
+		}
+	}
+	return planRebalance(c, msg)
+}
+
 func setPrimaryHandler(c *cli.Context) error {
 	if c.NArg() == 0 {
 		return missingArgumentsError(c, c.Command.ArgsUsage)
@@ -422,6 +496,22 @@ func stopClusterRebalanceHandler(c *cli.Context) error {
 	return nil
 }
 
+func pauseClusterRebalanceHandler(c *cli.Context) error {
+	if err := api.PauseRebalance(apiBP); err != nil {
+		return err
+	}
+	actionDone(c, "Rebalance paused")
+	return nil
+}
+
+func resumeClusterRebalanceHandler(c *cli.Context) error {
+	if err := api.ResumeRebalance(apiBP); err != nil {
+		return err
+	}
+	actionDone(c, "Rebalance resumed")
+	return nil
+}
+
 func showClusterRebalanceHandler(c *cli.Context) error {
 	var (
 		xid      = c.Args().Get(0)
@@ -37,6 +37,7 @@ var (
 		"write_policy.data":                   apc.SupportedWritePolicy,
 		"write_policy.md":                     apc.SupportedWritePolicy,
 		"ec.compression":                      apc.SupportedCompression,
+		"mirror.mode":                         apc.SupportedMirrorModes,
 		"compression.checksum":                apc.SupportedCompression,
 		"rebalance.compression":               apc.SupportedCompression,
 		"distributed_sort.compression":        apc.SupportedCompression,
@@ -54,11 +55,11 @@ var (
 		"lru.enabled":                         supportedBool,
 		"mirror.enabled":                      supportedBool,
 		"rebalance.enabled":                   supportedBool,
+		"rebalance.paused":                    supportedBool,
 		"resilver.enabled":                    supportedBool,
 		"versioning.enabled":                  supportedBool,
-		"replication.on_cold_get":             supportedBool,
-		"replication.on_lru_eviction":         supportedBool,
-		"replication.on_put":                  supportedBool,
+		"replication.enabled":                 supportedBool,
+		"replication.deletes":                 supportedBool,
 	}
 )
 
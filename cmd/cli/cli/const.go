@@ -50,24 +50,28 @@ const (
 // - show subcommands (`show <what>`)
 // - 3rd level subcommands
 const (
-	commandCat       = "cat"
-	commandConcat    = "concat"
-	commandCopy      = "cp"
-	commandCreate    = "create"
-	commandECEncode  = "ec-encode"
-	commandMirror    = "mirror"
-	commandEvict     = "evict"
-	commandPrefetch  = "prefetch"
-	commandGet       = "get"
-	commandList      = "ls"
-	commandPromote   = "promote"
-	commandSetCustom = "set-custom"
-	commandPut       = "put"
-	commandRemove    = "rm"
-	commandRename    = "mv"
-	commandSet       = "set"
-	commandStart     = apc.ActXactStart
-	commandStop      = apc.ActXactStop
+	commandCat        = "cat"
+	commandConcat     = "concat"
+	commandCopy       = "cp"
+	commandCreate     = "create"
+	commandECEncode   = "ec-encode"
+	commandECReencode = "ec-reencode"
+	commandMirror     = "mirror"
+	commandReplSync   = "repl-sync"
+	commandEvict      = "evict"
+	commandPrefetch   = "prefetch"
+	commandGet        = "get"
+	commandList       = "ls"
+	commandPromote    = "promote"
+	commandSetCustom  = "set-custom"
+	commandPut        = "put"
+	commandRemove     = "rm"
+	commandRename     = "mv"
+	commandSet        = "set"
+	commandStart      = apc.ActXactStart
+	commandStop       = apc.ActXactStop
+	commandPause      = "pause"
+	commandResume     = "resume"
 
 	commandLog = "log"
 
@@ -111,6 +115,7 @@ const (
 	subcmdMpathEnable  = "enable"
 	subcmdMpathDetach  = subcmdDetach
 	subcmdMpathDisable = "disable"
+	subcmdMpathDrain   = "drain"
 
 	// Node subcommands
 	subcmdJoin                = "join"
@@ -121,9 +126,11 @@ const (
 
 	subcmdShowRemoteAIS    = "remote-cluster"
 	subcmdShowClusterStats = "stats"
+	subcmdShowAlerts       = "alerts"
 	subcmdShowDisk         = subcmdMpath
 	subcmdStgValidate      = "validate"
 	subcmdStgCleanup       = "cleanup"
+	subcmdStgScrub         = "scrub"
 
 	// Bucket and Storage subcommands
 	subcmdSummary = "summary"
@@ -135,6 +142,12 @@ const (
 	// Archive subcommands
 	subcmdAppend = "append"
 
+	// Job schedule subcommands
+	subcmdSchedule    = "schedule"
+	subcmdSchedAdd    = "add"
+	subcmdSchedShow   = commandShow
+	subcmdSchedRemove = commandRemove
+
 	// AuthN subcommands
 	subcmdAuthAdd     = "add"
 	subcmdAuthShow    = "show"
@@ -213,6 +226,9 @@ const (
 	jsonKeyValueArgument  = "JSON-formatted-KEY-VALUE"
 	jsonSpecArgument      = "JSON_SPECIFICATION"
 
+	addSchedArgument = "NAME JOB_KIND [BUCKET [DST_BUCKET]]"
+	schedArgument    = "NAME"
+
 	// Buckets
 	bucketArgument         = "BUCKET"
 	optionalBucketArgument = "[BUCKET]"
@@ -387,7 +403,12 @@ var (
 	}
 
 	// Log severity (cmn.LogInfo, ....) enum
-	logSevFlag   = cli.StringFlag{Name: "severity", Usage: "show the specified log, one of: 'i[nfo]','w[arning]','e[rror]'"}
+	logSevFlag    = cli.StringFlag{Name: "severity", Usage: "show the specified log, one of: 'i[nfo]','w[arning]','e[rror]'"}
+	logFilterFlag = cli.StringFlag{
+		Name: "filter",
+		Usage: "show only structured log lines (see 'log.format') with the specified fields, e.g.: 'comp=reb,xid=g12' " +
+			"(fields: level, node, comp, xid, bck, obj)",
+	}
 	logFlushFlag = DurationFlag{
 		Name: "log-flush",
 		Usage: "can be used in combination with '--" + refreshFlag.Name +
@@ -410,17 +431,53 @@ var (
 
 	limitConnectionsFlag = cli.IntFlag{
 		Name:  "max-conns",
-		Usage: "max number of connections each target can make concurrently (up to num mountpaths)",
+		Usage: "max number of concurrent connections (cluster-wide)",
 	}
 	limitBytesPerHourFlag = cli.StringFlag{
 		Name:  "limit-bph",
-		Usage: "max downloaded size per target per hour " + sizeUnits,
+		Usage: "max downloaded size per hour (cluster-wide) " + sizeUnits,
+	}
+	limitHostConnectionsFlag = cli.IntFlag{
+		Name:  "max-conns-per-host",
+		Usage: "max number of concurrent connections to a single origin server (web links only)",
+	}
+	limitHostBytesPerHourFlag = cli.StringFlag{
+		Name:  "limit-bph-per-host",
+		Usage: "max size downloaded from a single origin server per hour (web links only) " + sizeUnits,
 	}
 	objectsListFlag = cli.StringFlag{
 		Name:  "object-list,from",
 		Usage: "path to file containing JSON array of object names to download",
 	}
-	syncFlag = cli.BoolFlag{Name: "sync", Usage: "sync bucket with cloud"}
+	syncFlag        = cli.BoolFlag{Name: "sync", Usage: "sync bucket with cloud"}
+	dlChunkSizeFlag = cli.StringFlag{
+		Name:  "chunk-size",
+		Usage: "fetch objects larger than this size in parallel chunks of this size (web links only) " + sizeUnits,
+	}
+	dlChunkConnsFlag = cli.IntFlag{
+		Name:  "chunk-conns",
+		Usage: "max number of parallel connections per object (chunked fetching is enabled when greater than 1)",
+	}
+	dlVerifyCksumFlag = cli.StringFlag{
+		Name:  "verify-cksum",
+		Usage: "verify downloaded content using the specified checksum type (one of: md5, sha256, sha512)",
+	}
+	dlCksumValueFlag = cli.StringFlag{
+		Name:  "cksum-value",
+		Usage: "expected (hex-encoded) checksum of the downloaded object (single-object download only)",
+	}
+	dlSidecarFlag = cli.BoolFlag{
+		Name:  "cksum-sidecar",
+		Usage: "fetch expected checksum from the sidecar file \"<link>.<checksum-type>\" (e.g., \"<link>.md5\")",
+	}
+	dlManifestFlag = cli.BoolFlag{
+		Name:  "manifest",
+		Usage: "download objects listed in the manifest (CSV or JSONL) that is specified as the source, e.g. \"ais://meta/train.csv\"",
+	}
+	dlManifestFormatFlag = cli.StringFlag{
+		Name:  "manifest-format",
+		Usage: "manifest format (one of: csv, jsonl); default: manifest name extension",
+	}
 
 	// dSort
 	fileSizeFlag = cli.StringFlag{Name: "fsize", Value: "1024", Usage: "size of the files inside a shard"}
@@ -436,6 +493,25 @@ var (
 	fileCountFlag = cli.IntFlag{Name: "fcount", Value: 5, Usage: "number of files inside single shard"}
 	specFileFlag  = cli.StringFlag{Name: "file,f", Value: "", Usage: "path to file with dSort specification"}
 
+	// job schedules
+	schedCronFlag = cli.StringFlag{
+		Name:  "cron",
+		Usage: "cron expression, e.g.: '0 */6 * * *', '@daily', '@every 2h' (optional when '--depends-on' is specified)",
+	}
+	schedDependsOnFlag = cli.StringFlag{
+		Name:  "depends-on",
+		Usage: "comma-separated list of scheduled jobs that must succeed prior to running this one, e.g.: 'prefetch,etl'",
+	}
+	schedRetriesFlag    = cli.IntFlag{Name: "retries", Usage: "max number of times to retry a failed run"}
+	schedRetryDelayFlag = DurationFlag{
+		Name:  "retry-delay",
+		Usage: "time between retries, valid time units: " + timeUnits,
+	}
+	schedBodyFlag = cli.StringFlag{
+		Name:  "body",
+		Usage: "JSON-formatted job request (download, prefetch, archive, etc.) or path to file that contains it",
+	}
+
 	// multi-object
 	listFlag     = cli.StringFlag{Name: "list", Usage: "comma-separated list of object names, e.g.: 'o1,o2,o3'"}
 	templateFlag = cli.StringFlag{Name: "template", Usage: "template for matching object names, e.g.: 'shard-{900..999}.tar'"}
@@ -599,6 +675,10 @@ var (
 		Name: "role", Required: true,
 		Usage: "role of this AIS daemon: proxy or target",
 	}
+	failureDomainFlag = cli.StringFlag{
+		Name:  "failure-domain",
+		Usage: "failure domain (e.g., rack or zone) of the joining node; overrides the node's configuration",
+	}
 	noRebalanceFlag = cli.BoolFlag{
 		Name:  "no-rebalance",
 		Usage: "do _not_ run global rebalance after putting node in maintenance (advanced usage only!)",
@@ -104,11 +104,34 @@ func cluDaeStatus(c *cli.Context, smap *cluster.Smap, cfg *cmn.ClusterConfig, si
 		template := tmpls.NewProxiesTable(&body.Status, smap).Template(false) + "\n" +
 			tmpls.NewTargetsTable(&body.Status).Template(false) + "\n" +
 			tmpls.ClusterSummary
-		return tmpls.Print(body, c.App.Writer, template, nil, useJSON)
+		if err := tmpls.Print(body, c.App.Writer, template, nil, useJSON); err != nil || useJSON {
+			return err
+		}
+		return checkFailureDomains(c, smap)
 	}
 	return fmt.Errorf("%s is not a valid NODE_ID nor NODE_TYPE", sid)
 }
 
+// warn about erasure coded buckets that won't survive the loss of an entire failure domain
+func checkFailureDomains(c *cli.Context, smap *cluster.Smap) error {
+	if smap.CountDomains() == 0 {
+		return nil
+	}
+	bmd, err := api.GetBMD(apiBP)
+	if err != nil {
+		return err
+	}
+	bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+		if bck.Props.EC.Enabled {
+			if err := smap.ValidateDomains(bck.Props.EC.DataSlices, bck.Props.EC.ParitySlices); err != nil {
+				actionWarn(c, fmt.Sprintf("bucket %s violates failure domain policy: %v", bck.Bucket().DisplayName(), err))
+			}
+		}
+		return false
+	})
+	return nil
+}
+
 func daemonDiskStats(c *cli.Context, sid string) error {
 	var (
 		useJSON    = flagIsSet(c, jsonFlag)
@@ -240,13 +263,16 @@ func showRebalance(c *cli.Context, keepMonitoring bool, refreshRate time.Duratio
 		// NOTE: when changing header do not forget to change `colCount` couple
 		//  lines below and `displayRebStats` logic.
 		if !hideHeader {
-			fmt.Fprintln(tw, "REB ID\t NODE\t OBJECTS RECV\t SIZE RECV\t OBJECTS SENT\t SIZE SENT\t START TIME\t END TIME\t ABORTED")
+			if caption := rebLimitsCaption(); caption != "" {
+				fmt.Fprintln(c.App.Writer, caption)
+			}
+			fmt.Fprintln(tw, "REB ID\t NODE\t OBJECTS RECV\t SIZE RECV\t OBJECTS SENT\t SIZE SENT\t START TIME\t END TIME\t STATE\t ABORTED")
 		}
 		prevID := ""
 		for _, sts := range allSnaps {
 			if flagIsSet(c, allXactionsFlag) {
 				if prevID != "" && sts.snap.ID != prevID {
-					fmt.Fprintln(tw, strings.Repeat("\t ", 9 /*colCount*/))
+					fmt.Fprintln(tw, strings.Repeat("\t ", 10 /*colCount*/))
 				}
 				displayRebStats(tw, sts)
 			} else {
@@ -289,10 +315,102 @@ func displayRebStats(tw *tabwriter.Writer, st *targetRebSnap) {
 	startTime := st.snap.StartTime.Format("01-02 15:04:05")
 
 	fmt.Fprintf(tw,
-		"%s\t %s\t %d\t %s\t %d\t %s\t %s\t %s\t %t\n",
+		"%s\t %s\t %d\t %s\t %d\t %s\t %s\t %s\t %s\t %t\n",
 		st.snap.ID, st.tid,
 		st.snap.Snap.Stats.InObjs, cos.B2S(st.snap.Snap.Stats.InBytes, 2),
 		st.snap.Snap.Stats.OutObjs, cos.B2S(st.snap.Snap.Stats.OutBytes, 2),
-		startTime, endTime, st.snap.IsAborted(),
+		startTime, endTime, rebState(st.snap), st.snap.IsAborted(),
 	)
 }
+
+func rebState(snap *xact.SnapExt) string {
+	switch {
+	case snap.IsAborted():
+		return "aborted"
+	case !snap.EndTime.IsZero():
+		return "finished"
+	}
+	if ext, ok := snap.Ext.(map[string]any); ok {
+		if reason, ok := ext["paused"].(string); ok && reason != "" {
+			return "paused (" + reason + ")"
+		}
+	}
+	return "running"
+}
+
+// rebalance limits, schedule window, and paused state (if any)
+func rebLimitsCaption() string {
+	config, err := api.GetClusterConfig(apiBP)
+	if err != nil {
+		return ""
+	}
+	var (
+		conf  = &config.Rebalance
+		parts = make([]string, 0, 4)
+	)
+	if conf.Paused {
+		parts = append(parts, "paused")
+	}
+	if conf.Window != "" {
+		parts = append(parts, "window: "+conf.Window)
+	}
+	if conf.BytesPerSec > 0 {
+		parts = append(parts, "max "+cos.B2S(conf.BytesPerSec, 0)+"/s")
+	}
+	if conf.ObjsPerSec > 0 {
+		parts = append(parts, fmt.Sprintf("max %d objects/s", conf.ObjsPerSec))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "Rebalance: " + strings.Join(parts, ", ")
+}
+
+func planRebalance(c *cli.Context, msg *apc.ActValPlanReb) error {
+	plan, err := api.PlanRebalance(apiBP, msg)
+	if err != nil {
+		return err
+	}
+	if plan.Total.Objs == 0 {
+		fmt.Fprintln(c.App.Writer, "Dry-run: no objects to migrate.")
+		return nil
+	}
+	eta := "unknown (no rebalance statistics yet)"
+	if plan.ETA > 0 {
+		eta = plan.ETA.D().Round(time.Second).String()
+	}
+	fmt.Fprintf(c.App.Writer, "Dry-run: %d objects (%s) to migrate, estimated time: %s\n\n",
+		plan.Total.Objs, cos.B2S(plan.Total.Bytes, 2), eta)
+
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	printPlanStats(tw, "BUCKET", plan.Buckets)
+	fmt.Fprintln(tw)
+	printPlanStats(tw, "SOURCE"+apc.PlanPairSepa+"DESTINATION", plan.Pairs)
+	fmt.Fprintln(tw)
+
+	tids := make([]string, 0, len(plan.Targets))
+	for tid := range plan.Targets {
+		tids = append(tids, tid)
+	}
+	sort.Strings(tids)
+	fmt.Fprintln(tw, "TARGET\t OBJECTS SENT\t SIZE SENT\t OBJECTS RECV\t SIZE RECV")
+	for _, tid := range tids {
+		n := plan.Targets[tid]
+		fmt.Fprintf(tw, "%s\t %d\t %s\t %d\t %s\n", tid,
+			n.Sent.Objs, cos.B2S(n.Sent.Bytes, 2), n.Recv.Objs, cos.B2S(n.Recv.Bytes, 2))
+	}
+	return tw.Flush()
+}
+
+func printPlanStats(tw *tabwriter.Writer, header string, all map[string]*apc.RebPlanStats) {
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(tw, header+"\t OBJECTS\t SIZE")
+	for _, name := range names {
+		fmt.Fprintf(tw, "%s\t %d\t %s\n", name, all[name].Objs, cos.B2S(all[name].Bytes, 2))
+	}
+}
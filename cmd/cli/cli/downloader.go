@@ -336,7 +336,16 @@ func downloadJobsList(c *cli.Context, regex string) error {
 		return true
 	})
 
-	return tmpls.Print(list, c.App.Writer, tmpls.DownloadListTmpl, nil, flagIsSet(c, jsonFlag))
+	if err := tmpls.Print(list, c.App.Writer, tmpls.DownloadListTmpl, nil, flagIsSet(c, jsonFlag)); err != nil {
+		return err
+	}
+	// scheduled (recurring) jobs and their run history
+	schedules, err := api.GetDownloadSchedules(apiBP)
+	if err != nil || len(schedules) == 0 {
+		return err
+	}
+	fmt.Fprintln(c.App.Writer)
+	return tmpls.Print(schedules, c.App.Writer, tmpls.JobSchedTmpl, nil, flagIsSet(c, jsonFlag))
 }
 
 func downloadJobStatus(c *cli.Context, id string) error {
@@ -80,7 +80,7 @@ var (
 	}
 	startCmdETL = cli.Command{
 		Name:         subcmdStart,
-		Usage:        "start ETL",
+		Usage:        "start (stopped) ETL from its stored init spec/code",
 		ArgsUsage:    "ETL_ID",
 		Action:       etlStartHandler,
 		BashComplete: etlIDCompletions,
@@ -375,12 +375,21 @@ func etlStartHandler(c *cli.Context) (err error) {
 		return missingArgumentsError(c, c.Command.ArgsUsage)
 	}
 	etlID := c.Args()[0]
-	if err := api.ETLStart(apiBP, etlID); err != nil {
+	statuses, err := api.ETLStartStatus(apiBP, etlID)
+	if err != nil {
 		if herr, ok := err.(*cmn.ErrHTTP); ok && herr.Status == http.StatusNotFound {
 			color.New(color.FgYellow).Fprintf(c.App.Writer, "ETL %q not found", etlID)
 		}
 		return err
 	}
+	if err := tmpls.Print(statuses, c.App.Writer, tmpls.TransformStartTmpl, nil, false); err != nil {
+		return err
+	}
+	for _, status := range statuses {
+		if status.Status == etl.StatusFailed {
+			return fmt.Errorf("failed to start ETL %q on target %s", etlID, status.TargetID)
+		}
+	}
 	fmt.Fprintf(c.App.Writer, "ETL %q started successfully\n", etlID)
 	return nil
 }
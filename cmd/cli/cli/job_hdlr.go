@@ -41,6 +41,7 @@ var (
 		jobStopSub,
 		jobWaitSub,
 		jobRemoveSub,
+		jobSchedSub,
 		makeAlias(showCmdJob, "", true, commandShow), // alias for `ais show`
 	}
 )
@@ -60,7 +61,16 @@ var (
 			progressBarFlag,
 			waitFlag,
 			limitBytesPerHourFlag,
+			limitHostConnectionsFlag,
+			limitHostBytesPerHourFlag,
 			syncFlag,
+			dlChunkSizeFlag,
+			dlChunkConnsFlag,
+			dlVerifyCksumFlag,
+			dlCksumValueFlag,
+			dlSidecarFlag,
+			dlManifestFlag,
+			dlManifestFormatFlag,
 		},
 		subcmdDsort: {
 			specFileFlag,
@@ -344,8 +354,17 @@ func startDownloadHandler(c *cli.Context) error {
 	}
 
 	src, dst := c.Args().Get(0), c.Args().Get(1)
-	source, err := parseSource(src)
-	if err != nil {
+	var (
+		source      dlSource
+		manifestBck cmn.Bck
+		manifest    string
+		err         error
+	)
+	if flagIsSet(c, dlManifestFlag) {
+		if manifestBck, manifest, err = parseBckObjectURI(c, src); err != nil {
+			return err
+		}
+	} else if source, err = parseSource(src); err != nil {
 		return err
 	}
 	bck, pathSuffix, err := parseDest(c, dst)
@@ -357,6 +376,10 @@ func startDownloadHandler(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	limitHostBPH, err := parseByteFlagToInt(c, limitHostBytesPerHourFlag)
+	if err != nil {
+		return err
+	}
 
 	if _, err := time.ParseDuration(progressInterval); err != nil {
 		return err
@@ -370,7 +393,26 @@ func startDownloadHandler(c *cli.Context) error {
 		Limits: dload.Limits{
 			Connections:  parseIntFlag(c, limitConnectionsFlag),
 			BytesPerHour: int(limitBPH),
+			PerHost: dload.HostLimits{
+				Connections:  parseIntFlag(c, limitHostConnectionsFlag),
+				BytesPerHour: int(limitHostBPH),
+			},
+		},
+		Chunks: dload.Chunks{
+			Size:  parseStrFlag(c, dlChunkSizeFlag),
+			Conns: parseIntFlag(c, dlChunkConnsFlag),
 		},
+		Verify: dload.Verify{
+			CksumType:  parseStrFlag(c, dlVerifyCksumFlag),
+			CksumValue: parseStrFlag(c, dlCksumValueFlag),
+			Sidecar:    flagIsSet(c, dlSidecarFlag),
+		},
+	}
+	if err := basePayload.Chunks.Validate(); err != nil {
+		return err
+	}
+	if err := basePayload.Verify.Validate(); err != nil {
+		return err
 	}
 
 	if basePayload.Bck.Props, err = api.HeadBucket(apiBP, basePayload.Bck, true /* don't add */); err != nil {
@@ -384,7 +426,9 @@ func startDownloadHandler(c *cli.Context) error {
 
 	// Heuristics to determine the download type.
 	var dlType dload.Type
-	if objectsListPath != "" {
+	if manifest != "" {
+		dlType = dload.TypeManifest
+	} else if objectsListPath != "" {
 		dlType = dload.TypeMulti
 	} else if strings.Contains(source.link, "{") && strings.Contains(source.link, "}") {
 		dlType = dload.TypeRange
@@ -469,6 +513,17 @@ func startDownloadHandler(c *cli.Context) error {
 			Prefix: source.backend.prefix,
 		}
 		id, err = api.DownloadWithParam(apiBP, dlType, payload)
+	case dload.TypeManifest:
+		payload := dload.ManifestBody{
+			Base:        basePayload,
+			Manifest:    manifest,
+			ManifestBck: manifestBck,
+			Format:      parseStrFlag(c, dlManifestFormatFlag),
+		}
+		if err := payload.Validate(); err != nil {
+			return err
+		}
+		id, err = api.DownloadWithParam(apiBP, dlType, payload)
 	default:
 		debug.Assert(false)
 	}
@@ -5,7 +5,26 @@
  */
 package cli
 
-import "github.com/urfave/cli"
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/urfave/cli"
+)
+
+// structured (JSON) log fields that can be filtered by (see `log.format` config)
+var logFilterFields = []string{"level", "node", "comp", "xid", "bck", "obj"}
+
+// logFilter passes through only those structured log lines that have all the specified fields
+type logFilter struct {
+	w    io.Writer
+	kvs  cos.StrKVs
+	line []byte // partial line (to be completed by the next Write)
+}
 
 var logCmd = cli.Command{
 	Name:  commandLog,
@@ -14,3 +33,53 @@ var logCmd = cli.Command{
 		makeAlias(showCmdLog, "", true, commandShow), // alias for `ais show`
 	},
 }
+
+// e.g.: "comp=reb,xid=g12"
+func newLogFilter(w io.Writer, s string) (*logFilter, error) {
+	f := &logFilter{w: w, kvs: make(cos.StrKVs, 2)}
+	for _, kv := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(kv), "=")
+		if !ok || v == "" {
+			return nil, fmt.Errorf("invalid log filter %q (expecting comma-separated name=value pairs)", kv)
+		}
+		if !cos.StringInSlice(k, logFilterFields) {
+			return nil, fmt.Errorf("invalid log filter %q: unknown field %q (expecting one of %v)", kv, k, logFilterFields)
+		}
+		f.kvs[k] = v
+	}
+	return f, nil
+}
+
+func (f *logFilter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			f.line = append(f.line, p...)
+			break
+		}
+		f.line = append(f.line, p[:i+1]...)
+		if f.match(f.line) {
+			if _, err := f.w.Write(f.line); err != nil {
+				return n, err
+			}
+		}
+		f.line = f.line[:0]
+		p = p[i+1:]
+	}
+	return n, nil
+}
+
+// NOTE: plain-text (glog) lines never match
+func (f *logFilter) match(line []byte) bool {
+	var fields map[string]string
+	if err := jsoniter.Unmarshal(line, &fields); err != nil {
+		return false
+	}
+	for k, v := range f.kvs {
+		if fields[k] != v {
+			return false
+		}
+	}
+	return true
+}
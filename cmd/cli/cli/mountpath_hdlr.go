@@ -28,6 +28,7 @@ var (
 		subcmdMpathDisable: {
 			noResilverFlag,
 		},
+		subcmdMpathDrain: {},
 	}
 
 	mpathCmd = cli.Command{
@@ -68,6 +69,14 @@ var (
 				Action:       mpathDisableHandler,
 				BashComplete: suggestTargetNodes,
 			},
+			{
+				Name:         subcmdMpathDrain,
+				Usage:        "drain mountpath (keep it readable while moving its content elsewhere, then detach)",
+				ArgsUsage:    daemonMountpathPairArgument,
+				Flags:        mpathCmdsFlags[subcmdMpathDrain],
+				Action:       mpathDrainHandler,
+				BashComplete: suggestTargetNodes,
+			},
 		},
 	}
 )
@@ -76,6 +85,7 @@ func mpathAttachHandler(c *cli.Context) (err error)  { return mpathAction(c, apc
 func mpathEnableHandler(c *cli.Context) (err error)  { return mpathAction(c, apc.ActMountpathEnable) }
 func mpathDetachHandler(c *cli.Context) (err error)  { return mpathAction(c, apc.ActMountpathDetach) }
 func mpathDisableHandler(c *cli.Context) (err error) { return mpathAction(c, apc.ActMountpathDisable) }
+func mpathDrainHandler(c *cli.Context) (err error)   { return mpathAction(c, apc.ActMountpathDrain) }
 
 func mpathAction(c *cli.Context, action string) error {
 	if c.NArg() == 0 {
@@ -128,6 +138,9 @@ func mpathAction(c *cli.Context, action string) error {
 		case apc.ActMountpathDisable:
 			acted = "disabled"
 			err = api.DisableMountpath(apiBP, si, mountpath, flagIsSet(c, noResilverFlag))
+		case apc.ActMountpathDrain:
+			acted = "started draining"
+			err = api.DrainMountpath(apiBP, si, mountpath)
 		default:
 			return incorrectUsageMsg(c, "invalid mountpath action %q", action)
 		}
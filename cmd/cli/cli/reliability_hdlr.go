@@ -21,6 +21,10 @@ var (
 			dataSlicesFlag,
 			paritySlicesFlag,
 		},
+		commandECReencode: {
+			dataSlicesFlag,
+			paritySlicesFlag,
+		},
 	}
 
 	storageSvcCmds = []cli.Command{
@@ -40,6 +44,21 @@ var (
 			Action:       ecEncodeHandler,
 			BashComplete: bucketCompletions(bcmplop{}),
 		},
+		{
+			Name:         commandECReencode,
+			Usage:        "change the number of data and parity slices of an erasure coded bucket and re-encode all its objects",
+			ArgsUsage:    bucketArgument,
+			Flags:        storageSvcCmdsFlags[commandECReencode],
+			Action:       ecReencodeHandler,
+			BashComplete: bucketCompletions(bcmplop{}),
+		},
+		{
+			Name:         commandReplSync,
+			Usage:        "copy existing objects of a replicated bucket to its replication destination (see 'replication.*' bucket props)",
+			ArgsUsage:    bucketArgument,
+			Action:       replSyncHandler,
+			BashComplete: bucketCompletions(bcmplop{}),
+		},
 	}
 )
 
@@ -84,10 +103,55 @@ func ecEncodeHandler(c *cli.Context) (err error) {
 	paritySlices := c.Int(firstName(paritySlicesFlag.Name))
 	if p.EC.Enabled {
 		// EC-encode is called automatically when EC is enabled. Changing
-		// data or parity numbers on the fly is unsupported yet.
-		fmt.Fprintf(c.App.Writer, "Bucket %q is already erasure-coded\n", bck.DisplayName())
+		// data or parity numbers is done via ec-reencode.
+		fmt.Fprintf(c.App.Writer, "Bucket %q is already erasure-coded (see 'ais job start %s --help')\n",
+			bck.DisplayName(), commandECReencode)
 		return
 	}
 
 	return ecEncode(c, bck, dataSlices, paritySlices)
 }
+
+func ecReencodeHandler(c *cli.Context) (err error) {
+	var (
+		bck cmn.Bck
+		p   *cmn.BucketProps
+	)
+	if bck, err = parseBckURI(c, c.Args().First(), true /*require provider*/); err != nil {
+		return
+	}
+	if p, err = headBucket(bck, false /* don't add */); err != nil {
+		return
+	}
+
+	dataSlices := c.Int(firstName(dataSlicesFlag.Name))
+	paritySlices := c.Int(firstName(paritySlicesFlag.Name))
+	if !p.EC.Enabled {
+		return fmt.Errorf("bucket %q is not erasure-coded (see 'ais job start %s --help')",
+			bck.DisplayName(), commandECEncode)
+	}
+	if p.EC.DataSlices == dataSlices && p.EC.ParitySlices == paritySlices {
+		fmt.Fprintf(c.App.Writer, "Bucket %q is already erasure-coded with %d data and %d parity slices, nothing to do\n",
+			bck.DisplayName(), dataSlices, paritySlices)
+		return
+	}
+	return ecReencode(c, bck, dataSlices, paritySlices)
+}
+
+func replSyncHandler(c *cli.Context) (err error) {
+	var (
+		bck cmn.Bck
+		p   *cmn.BucketProps
+	)
+	if bck, err = parseBckURI(c, c.Args().First(), true /*require provider*/); err != nil {
+		return
+	}
+	if p, err = headBucket(bck, false /* don't add */); err != nil {
+		return
+	}
+	if !p.Repl.Enabled {
+		return fmt.Errorf("bucket %q is not replicated (see 'ais bucket props set %s replication.enabled=true replication.dst=...')",
+			bck.DisplayName(), bck.DisplayName())
+	}
+	return replSync(c, bck, p.Repl.Dst)
+}
@@ -0,0 +1,146 @@
+// Package cli provides easy-to-use commands to manage, monitor, and utilize AIS clusters.
+// This file handles recurring (scheduled) jobs and their dependencies.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cmd/cli/tmpls"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/urfave/cli"
+)
+
+var (
+	schedCmdsFlags = map[string][]cli.Flag{
+		subcmdSchedAdd: {
+			schedCronFlag,
+			schedDependsOnFlag,
+			schedRetriesFlag,
+			schedRetryDelayFlag,
+			schedBodyFlag,
+		},
+		subcmdSchedShow: {
+			jsonFlag,
+		},
+	}
+
+	jobSchedSub = cli.Command{
+		Name:  subcmdSchedule,
+		Usage: "manage recurring jobs that run on cron schedules and/or upon success of other scheduled jobs",
+		Subcommands: []cli.Command{
+			{
+				Name: subcmdSchedAdd,
+				Usage: "add recurring job of any kind that can be started via 'ais start' " +
+					"(as well as download, copy and ETL bucket, archive, and evict)",
+				ArgsUsage: addSchedArgument,
+				Flags:     schedCmdsFlags[subcmdSchedAdd],
+				Action:    addSchedHandler,
+			},
+			{
+				Name:         subcmdSchedRemove,
+				Usage:        "remove job schedule (that no other scheduled job depends on)",
+				ArgsUsage:    schedArgument,
+				Action:       removeSchedHandler,
+				BashComplete: schedCompletions,
+			},
+			{
+				Name:   subcmdSchedShow,
+				Usage:  "show job schedules, dependencies, and run history",
+				Flags:  schedCmdsFlags[subcmdSchedShow],
+				Action: showSchedHandler,
+			},
+		},
+	}
+)
+
+func addSchedHandler(c *cli.Context) (err error) {
+	if c.NArg() < 2 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	msg := &cmn.SchedMsg{
+		Name:       c.Args().Get(0),
+		Kind:       c.Args().Get(1),
+		Cron:       parseStrFlag(c, schedCronFlag),
+		Retries:    parseIntFlag(c, schedRetriesFlag),
+		RetryDelay: cos.Duration(parseDurationFlag(c, schedRetryDelayFlag)),
+	}
+	if flagIsSet(c, schedDependsOnFlag) {
+		msg.DependsOn = strings.Split(parseStrFlag(c, schedDependsOnFlag), ",")
+	}
+	if c.NArg() > 2 {
+		if msg.Bck, err = parseBckURI(c, c.Args().Get(2), false /*require provider*/); err != nil {
+			return
+		}
+	}
+	if c.NArg() > 3 {
+		if msg.BckTo, err = parseBckURI(c, c.Args().Get(3), false /*require provider*/); err != nil {
+			return
+		}
+	}
+	if flagIsSet(c, schedBodyFlag) {
+		if msg.Body, err = schedBody(parseStrFlag(c, schedBodyFlag)); err != nil {
+			return
+		}
+	}
+	if err = api.AddJobSchedule(apiBP, msg); err != nil {
+		return
+	}
+	actionDone(c, fmt.Sprintf("Added %s job schedule %q", msg.Kind, msg.Name))
+	return
+}
+
+// inline JSON or the name of the file that contains it
+func schedBody(value string) (json.RawMessage, error) {
+	b := []byte(value)
+	if !strings.HasPrefix(strings.TrimSpace(value), "{") {
+		var err error
+		if b, err = os.ReadFile(value); err != nil {
+			return nil, err
+		}
+	}
+	if !json.Valid(b) {
+		return nil, fmt.Errorf("invalid JSON in job request %q", cos.BHead(b))
+	}
+	return json.RawMessage(b), nil
+}
+
+func removeSchedHandler(c *cli.Context) (err error) {
+	if c.NArg() < 1 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	name := c.Args().First()
+	if err = api.RemoveJobSchedule(apiBP, name); err != nil {
+		return
+	}
+	actionDone(c, fmt.Sprintf("Removed job schedule %q", name))
+	return
+}
+
+func showSchedHandler(c *cli.Context) error {
+	schedules, err := api.GetJobSchedules(apiBP)
+	if err != nil {
+		return err
+	}
+	return tmpls.Print(schedules, c.App.Writer, tmpls.JobSchedTmpl, nil, flagIsSet(c, jsonFlag))
+}
+
+func schedCompletions(c *cli.Context) {
+	if c.NArg() > 0 {
+		return
+	}
+	schedules, err := api.GetJobSchedules(apiBP)
+	if err != nil {
+		return
+	}
+	for _, s := range schedules {
+		fmt.Println(s.Name)
+	}
+}
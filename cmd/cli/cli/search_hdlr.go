@@ -24,26 +24,28 @@ var (
 	searchCommands []cli.Command
 
 	similarWords = map[string][]string{
-		commandMountpath: {"mount", "unmount", "umount"},
-		commandList:      {"list", "dir"},
-		commandSet:       {"update", "assign", "modify"},
-		commandShow:      {"view", "display", "list"},
-		commandRemove:    {"remove", "delete", "del", "evict", "destroy"},
-		commandRename:    {"move", "rename"},
-		commandCopy:      {"copy", "replicate"},
-		commandGet:       {"fetch", "read"},
-		commandPrefetch:  {"load", "preload", "warmup", "cache"},
-		commandMirror:    {"protect", "replicate"},
-		commandECEncode:  {"protect", "encode", "replicate", "erasure-code"},
-		commandStart:     {"do", "run", "execute"},
-		commandStop:      {"abort", "termnate"},
-		commandPut:       {"update", "write", "promote", "modify"},
-		commandCreate:    {"add", "new"},
-		commandObject:    {"file"},
-		commandStorage:   {"disk", "mountpath", "capacity", "used", "available"},
-		commandBucket:    {"dir", "directory"},
-		commandJob:       {"batch", "async"},
-		commandArch:      {"serialize", "format", "reformat", "tar", "zip", "gzip"},
+		commandMountpath:  {"mount", "unmount", "umount"},
+		commandList:       {"list", "dir"},
+		commandSet:        {"update", "assign", "modify"},
+		commandShow:       {"view", "display", "list"},
+		commandRemove:     {"remove", "delete", "del", "evict", "destroy"},
+		commandRename:     {"move", "rename"},
+		commandCopy:       {"copy", "replicate"},
+		commandGet:        {"fetch", "read"},
+		commandPrefetch:   {"load", "preload", "warmup", "cache"},
+		commandMirror:     {"protect", "replicate"},
+		commandECEncode:   {"protect", "encode", "replicate", "erasure-code"},
+		commandECReencode: {"encode", "erasure-code", "reencode", "parity"},
+		commandReplSync:   {"replicate", "sync", "disaster-recovery", "dr"},
+		commandStart:      {"do", "run", "execute"},
+		commandStop:       {"abort", "termnate"},
+		commandPut:        {"update", "write", "promote", "modify"},
+		commandCreate:     {"add", "new"},
+		commandObject:     {"file"},
+		commandStorage:    {"disk", "mountpath", "capacity", "used", "available"},
+		commandBucket:     {"dir", "directory"},
+		commandJob:        {"batch", "async"},
+		commandArch:       {"serialize", "format", "reformat", "tar", "zip", "gzip"},
 		//
 		subcmdAuthAdd:  {"register", "create"},
 		subcmdDownload: {"load"},
@@ -124,12 +124,18 @@ var (
 			longRunFlags,
 			logSevFlag,
 			logFlushFlag,
+			logFilterFlag,
 		),
 		subcmdShowClusterStats: {
 			jsonFlag,
 			rawFlag,
 			refreshFlag,
 		},
+		subcmdShowAlerts: append(
+			longRunFlags,
+			jsonFlag,
+			noHeaderFlag,
+		),
 	}
 
 	showCmd = cli.Command{
@@ -146,6 +152,7 @@ var (
 			showCmdStorage,
 			showCmdJob,
 			showCmdLog,
+			showCmdAlerts,
 		},
 	}
 
@@ -159,6 +166,7 @@ var (
 			showCmdDisk,
 			showCmdMpath,
 			showCmdStgSummary,
+			showCmdStgScrub,
 		},
 	}
 	showCmdObject = cli.Command{
@@ -251,6 +259,14 @@ var (
 		BashComplete: suggestAllNodes,
 	}
 
+	showCmdAlerts = cli.Command{
+		Name:      subcmdShowAlerts,
+		Usage:     "show active and recently resolved cluster alerts",
+		ArgsUsage: noArguments,
+		Flags:     showCmdsFlags[subcmdShowAlerts],
+		Action:    showAlertsHandler,
+	}
+
 	showCmdJob = cli.Command{
 		Name:         commandJob,
 		Usage:        "show running and finished jobs (use <TAB-TAB> to select, help to see options)",
@@ -800,14 +816,39 @@ func showDaemonLogHandler(c *cli.Context) error {
 		}
 	}
 
-	args := api.GetLogInput{Writer: os.Stdout, Severity: sev, Offset: getLongRunOffset(c)}
+	var (
+		filter *logFilter
+		args   = api.GetLogInput{Writer: os.Stdout, Severity: sev, Offset: getLongRunOffset(c)}
+	)
+	if flagIsSet(c, logFilterFlag) {
+		if filter, err = newLogFilter(os.Stdout, parseStrFlag(c, logFilterFlag)); err != nil {
+			return err
+		}
+		args.Writer = filter
+	}
 	readsize, err := api.GetDaemonLog(apiBP, node, args)
 	if err == nil {
+		if filter != nil {
+			readsize -= int64(len(filter.line)) // (to reread the partial line next time)
+		}
 		addLongRunOffset(c, readsize)
 	}
 	return err
 }
 
+func showAlertsHandler(c *cli.Context) error {
+	setLongRunParams(c)
+	alerts, err := api.GetAlerts(apiBP)
+	if err != nil {
+		return err
+	}
+	tmpl := tmpls.AlertsBody
+	if !flagIsSet(c, noHeaderFlag) {
+		tmpl = tmpls.AlertsHeader + tmpl
+	}
+	return tmpls.Print(alerts, c.App.Writer, tmpl, nil, flagIsSet(c, jsonFlag))
+}
+
 func showRemoteAISHandler(c *cli.Context) error {
 	all, err := api.GetRemoteAIS(apiBP)
 	if err != nil {
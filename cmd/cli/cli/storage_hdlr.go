@@ -7,10 +7,14 @@ package cli
 
 import (
 	"fmt"
+	"sort"
+	"text/tabwriter"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmd/cli/tmpls"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/urfave/cli"
 )
 
@@ -20,6 +24,19 @@ var (
 			waitFlag,
 			waitTimeoutFlag,
 		},
+		subcmdStgScrub: {
+			waitFlag,
+			waitTimeoutFlag,
+		},
+	}
+
+	showCmdStgScrub = cli.Command{
+		Name:         subcmdStgScrub,
+		Usage:        "show the results of the most recent (or currently running) storage scrub",
+		ArgsUsage:    optionalBucketArgument,
+		Flags:        []cli.Flag{jsonFlag, noHeaderFlag},
+		Action:       showScrubHandler,
+		BashComplete: bucketCompletions(bcmplop{}),
 	}
 
 	storageCmd = cli.Command{
@@ -46,6 +63,14 @@ var (
 				Action:       cleanupStorageHandler,
 				BashComplete: bucketCompletions(bcmplop{}),
 			},
+			{
+				Name:         subcmdStgScrub,
+				Usage:        "verify objects and copies against their checksums; repair (from copies, EC, or remote) or quarantine corrupted",
+				ArgsUsage:    listAnyCommandArgument,
+				Flags:        storageCmdFlags[subcmdStgScrub],
+				Action:       scrubStorageHandler,
+				BashComplete: bucketCompletions(bcmplop{}),
+			},
 		},
 	}
 )
@@ -89,3 +114,131 @@ func cleanupStorageHandler(c *cli.Context) (err error) {
 	fmt.Fprint(c.App.Writer, fmtXactSucceeded)
 	return
 }
+
+func scrubStorageHandler(c *cli.Context) (err error) {
+	var (
+		bck cmn.Bck
+		id  string
+	)
+	if c.NArg() != 0 {
+		bck, err = parseBckURI(c, c.Args().First(), true /*require provider*/)
+		if err != nil {
+			return
+		}
+		if _, err = headBucket(bck, true /* don't add */); err != nil {
+			return
+		}
+	}
+	xactArgs := api.XactReqArgs{Kind: apc.ActScrub, Bck: bck}
+	if id, err = api.StartXaction(apiBP, xactArgs); err != nil {
+		return
+	}
+	if !flagIsSet(c, waitFlag) {
+		fmt.Fprintf(c.App.Writer, "Started storage scrub %q. %s\n", id, toMonitorMsg(c, id))
+		return
+	}
+
+	fmt.Fprintf(c.App.Writer, "Started storage scrub %s...\n", id)
+	wargs := api.XactReqArgs{ID: id, Kind: apc.ActScrub}
+	if flagIsSet(c, waitTimeoutFlag) {
+		wargs.Timeout = parseDurationFlag(c, waitTimeoutFlag)
+	}
+	if err = api.WaitForXactionIdle(apiBP, wargs); err != nil {
+		return
+	}
+	reps, err := scrubReports(api.XactReqArgs{ID: id, Kind: apc.ActScrub})
+	if err != nil {
+		return
+	}
+	return printScrubReports(c, reps, cmn.Bck{})
+}
+
+func showScrubHandler(c *cli.Context) (err error) {
+	var bck cmn.Bck
+	if c.NArg() != 0 {
+		if bck, err = parseBckURI(c, c.Args().First(), true /*require provider*/); err != nil {
+			return
+		}
+	}
+	reps, err := scrubReports(api.XactReqArgs{Kind: apc.ActScrub})
+	if err != nil {
+		return
+	}
+	return printScrubReports(c, reps, bck)
+}
+
+// aggregate per-bucket reports of the most recent scrub across all targets
+func scrubReports(xactArgs api.XactReqArgs) (apc.ScrubReports, error) {
+	xs, err := api.QueryXactionSnaps(apiBP, xactArgs)
+	if err != nil {
+		return nil, err
+	}
+	all := make(apc.ScrubReports, 4)
+	for _, snaps := range xs {
+		var latest int
+		for i, snap := range snaps {
+			if snap.StartTime.After(snaps[latest].StartTime) {
+				latest = i
+			}
+		}
+		if len(snaps) == 0 || snaps[latest].Ext == nil {
+			continue
+		}
+		reps := make(apc.ScrubReports, 4)
+		if err := cos.MorphMarshal(snaps[latest].Ext, &reps); err != nil {
+			return nil, err
+		}
+		for name, rep := range reps {
+			if agg, ok := all[name]; ok {
+				agg.Merge(rep)
+			} else {
+				all[name] = rep
+			}
+		}
+	}
+	return all, nil
+}
+
+func printScrubReports(c *cli.Context, reps apc.ScrubReports, bck cmn.Bck) error {
+	if !bck.IsEmpty() {
+		name := bck.String()
+		for n := range reps {
+			if n != name {
+				delete(reps, n)
+			}
+		}
+	}
+	if flagIsSet(c, jsonFlag) {
+		return tmpls.Print(reps, c.App.Writer, "", nil, true)
+	}
+	if len(reps) == 0 {
+		fmt.Fprintln(c.App.Writer, "No scrub results")
+		return nil
+	}
+	names := make([]string, 0, len(reps))
+	for name := range reps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "BUCKET\tOBJECTS\tCOPIES\tCORRUPTED\tCOPIES CORRUPTED\tCOPIES MISSING\tREPAIRED\tQUARANTINED\tERRORS")
+	}
+	for _, name := range names {
+		rep := reps[name]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\n", name, rep.Checked, rep.CopiesChecked,
+			rep.Corrupted, rep.CopiesCorrupted, rep.CopiesMissing, rep.Repaired, rep.Quarantined, rep.Errs)
+	}
+	tw.Flush()
+	for _, name := range names {
+		if qobjs := reps[name].QuarantinedObjs; len(qobjs) > 0 {
+			fmt.Fprintf(c.App.Writer, "\nQuarantined in %s:\n", name)
+			for _, o := range qobjs {
+				fmt.Fprintln(c.App.Writer, "\t"+o)
+			}
+		}
+	}
+	return nil
+}
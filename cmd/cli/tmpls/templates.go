@@ -69,6 +69,7 @@ const (
 
 	ClusterSummary = "Summary:\n  Proxies:\t{{len .Smap.Pmap}} ({{ .Smap.CountNonElectable }} unelectable)\n  " +
 		"Targets:\t{{len .Smap.Tmap}}\n  " +
+		"{{if .Smap.CountDomains}}Failure domains:\t{{ .Smap.CountDomains }}\n  {{end}}" +
 		"Primary:\t{{.Smap.Primary.StringEx}}\n  " +
 		"Smap:\t{{FormatSmapVersion .Smap.Version}}\n  " +
 		"Deployment:\t{{ ( Deployments .Status) }}\n  " +
@@ -117,6 +118,27 @@ const (
 		"{{end}}\t {{$value.ErrorCnt}}\t {{$value.Description}}\n"
 	DownloadListTmpl = DownloadListHeader + "{{ range $key, $value := . }}" + DownloadListBody + "{{end}}"
 
+	// (recurring jobs along with their respective dependencies, retry policies, and run histories)
+	JobSchedTmpl = "SCHEDULE\t CRON\t JOB\t DEPENDS ON\t RETRIES\t NEXT RUN\n" +
+		"{{range $s := . }}" +
+		"{{$s.Name}}\t {{if $s.Cron}}{{$s.Cron}}{{else}}-{{end}}\t {{$s.Kind}}\t {{JoinList $s.DependsOn}}\t " +
+		"{{$s.Retries}}\t {{if (IsUnsetTime $s.NextRun)}}-{{else}}{{FormatTime $s.NextRun}}{{end}}\n" +
+		"{{range $run := $s.History}}" +
+		"  {{FormatTime $run.Started}}\t {{$run.Status}}{{if $run.Attempt}} (retry {{$run.Attempt}}){{end}}\t " +
+		"{{$run.JobID}}\t {{$run.Err}}\n" +
+		"{{end}}{{end}}"
+
+	// (active alerts first, followed by the resolved ones)
+	AlertsHeader = "SEVERITY\t RULE\t NODE\t CONDITION\t VALUE\t COUNT\t STARTED\t LAST SEEN\t RESOLVED\n"
+	AlertsBody   = "{{range $a := .Active}}" +
+		"{{$a.Severity}}\t {{$a.Rule}}\t {{$a.Node}}\t {{$a.Cond}}\t {{$a.Value}}\t {{$a.Count}}\t " +
+		"{{FormatTime $a.Started}}\t {{FormatTime $a.LastSeen}}\t -\n" +
+		"{{end}}" +
+		"{{range $a := .Resolved}}" +
+		"{{$a.Severity}}\t {{$a.Rule}}\t {{$a.Node}}\t {{$a.Cond}}\t {{$a.Value}}\t {{$a.Count}}\t " +
+		"{{FormatTime $a.Started}}\t {{FormatTime $a.LastSeen}}\t {{FormatTime $a.Resolved}}\n" +
+		"{{end}}"
+
 	DSortListHeader = "JOB ID\t STATUS\t START\t FINISH\t DESCRIPTION\n"
 	DSortListBody   = "{{$value.ID}}\t " +
 		"{{if $value.Aborted}}Aborted" +
@@ -288,6 +310,10 @@ const (
 		"{{range $transform := .}}" +
 		"{{$transform.ID}}\n" +
 		"{{end}}"
+	TransformStartTmpl = "TARGET\tSTATUS\tERROR\n" +
+		"{{range $s := .}}" +
+		"{{$s.TargetID}}\t{{$s.Status}}\t{{$s.Err}}\n" +
+		"{{end}}"
 
 	// Command `show mountpath`
 	TargetMpathListTmpl = "{{range $p := . }}" +
@@ -310,6 +336,16 @@ const (
 		"{{range $mp := $p.Mpl.WaitingDD }}" +
 		"\t\t{{ $mp }}\n" +
 		"{{end}}{{end}}" +
+		"{{if ne (len $p.Mpl.Draining) 0}}" +
+		"\tDraining (to be detached):\n" +
+		"{{range $d := $p.Mpl.Draining }}" +
+		"\t\t{{ $d.Path }}\tmoved {{ $d.Objs }} objects ({{FormatBytesSig $d.Bytes 2}}), {{ $d.PctUsed }}% used\n" +
+		"{{end}}{{end}}" +
+		"{{if ne (len $p.Mpl.Degraded) 0}}" +
+		"\tDegraded (slow or failing disks):\n" +
+		"{{range $mp := $p.Mpl.Degraded }}" +
+		"\t\t{{ $mp }}\n" +
+		"{{end}}{{end}}" +
 		"{{end}}{{end}}"
 )
 
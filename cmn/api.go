@@ -6,6 +6,7 @@
 package cmn
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"path/filepath"
@@ -45,6 +46,7 @@ type (
 		BID         uint64          `json:"bid,string" list:"omit"`         // unique ID
 		Created     int64           `json:"created,string" list:"readonly"` // creation timestamp
 		Versioning  VersionConf     `json:"versioning"`                     // versioning (see "inherit" here and elsewhere)
+		Repl        ReplConf        `json:"replication"`                    // asynchronous replication (not inheritable)
 	}
 
 	ExtraProps struct {
@@ -87,6 +89,22 @@ type (
 		RefDirectory *string `json:"ref_directory"`
 	}
 
+	// Asynchronous replication of an ais:// bucket to a remote AIS cluster or cloud bucket.
+	// Targets durably queue each change (PUT or DELETE) and ship it in the background;
+	// the existing content is replicated via apc.ActReplSync (built on copy-objects).
+	ReplConf struct {
+		Dst     string `json:"dst"`     // destination bucket, e.g. "ais://@remais/abc" or "s3://abc"
+		Prefix  string `json:"prefix"`  // replicate only the objects with names starting with the prefix
+		Deletes bool   `json:"deletes"` // replicate deletions
+		Enabled bool   `json:"enabled"` // enabled/disabled
+	}
+	ReplConfToUpdate struct {
+		Dst     *string `json:"dst"`
+		Prefix  *string `json:"prefix"`
+		Deletes *bool   `json:"deletes"`
+		Enabled *bool   `json:"enabled"`
+	}
+
 	// Once validated, BucketPropsToUpdate are copied to BucketProps.
 	// The struct may have extra fields that do not exist in BucketProps.
 	// Add tag 'copy:"skip"' to ignore those fields when copying values.
@@ -100,6 +118,7 @@ type (
 		Access      *apc.AccessAttrs         `json:"access,string,omitempty"`
 		WritePolicy *WritePolicyConfToUpdate `json:"write_policy,omitempty"`
 		Extra       *ExtraToUpdate           `json:"extra,omitempty"`
+		Repl        *ReplConfToUpdate        `json:"replication,omitempty"`
 		Force       bool                     `json:"force,omitempty" copy:"skip" list:"omit"`
 	}
 
@@ -254,11 +273,17 @@ func (bp *BucketProps) Validate(targetCnt int) error {
 			return fmt.Errorf("backend bucket %q must be remote", bp.BackendBck)
 		}
 	}
+	if bp.Repl.Enabled && (bp.Provider != apc.AIS || !bp.BackendBck.IsEmpty()) {
+		return fmt.Errorf("replication: source bucket must be an ais:// bucket without remote backend (have %q, %q)",
+			bp.Provider, bp.BackendBck)
+	}
 	var softErr error
-	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy} {
+	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy, &bp.Repl} {
 		var err error
 		if pv == &bp.EC {
 			err = bp.EC.ValidateAsProps(targetCnt)
+		} else if pv == &bp.Mirror {
+			err = bp.Mirror.ValidateAsProps(targetCnt)
 		} else if pv == &bp.Extra {
 			err = bp.Extra.ValidateAsProps(bp.Provider)
 		} else {
@@ -321,6 +346,54 @@ func (c *ExtraProps) ValidateAsProps(arg ...any) error {
 	return nil
 }
 
+//////////////
+// ReplConf //
+//////////////
+
+func (c *ReplConf) ValidateAsProps(...any) error {
+	if !c.Enabled {
+		return nil
+	}
+	_, err := c.DstBck()
+	return err
+}
+
+// parse and validate replication destination
+func (c *ReplConf) DstBck() (*Bck, error) {
+	if c.Dst == "" {
+		return nil, errors.New("replication: destination bucket is not specified")
+	}
+	bck, objName, err := ParseBckObjectURI(c.Dst, ParseURIOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("replication: invalid destination %q: %v", c.Dst, err)
+	}
+	if objName != "" {
+		return nil, fmt.Errorf("replication: destination %q must be a bucket", c.Dst)
+	}
+	if err := bck.Validate(); err != nil {
+		return nil, fmt.Errorf("replication: invalid destination %q: %v", c.Dst, err)
+	}
+	if !bck.IsRemote() || bck.IsHTTP() {
+		return nil, fmt.Errorf("replication: destination %q must be a bucket in a remote AIS cluster or in the cloud",
+			c.Dst)
+	}
+	return &bck, nil
+}
+
+func (c *ReplConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	s := "=> " + c.Dst
+	if c.Prefix != "" {
+		s += ", prefix " + c.Prefix
+	}
+	if c.Deletes {
+		s += ", with deletes"
+	}
+	return s
+}
+
 //
 // bucket summary
 //
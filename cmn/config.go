@@ -83,6 +83,12 @@ type (
 		// metadata write policy: (immediate | delayed | never)
 		WritePolicy WritePolicyConf `json:"write_policy"`
 
+		// alerting rules and sinks (evaluated by the primary proxy)
+		Alert AlertConf `json:"alert"`
+
+		// ETL transformers that run outside Kubernetes
+		ETL ETLConf `json:"etl"`
+
 		// standalone enumerated features that can be configured
 		// to flip assorted global defaults (see cmn/feat/feat.go)
 		Features feat.Flags `json:"features,string" allow:"cluster"`
@@ -118,6 +124,8 @@ type (
 		Memsys      *MemsysConfToUpdate      `json:"memsys,omitempty"`
 		TCB         *TCBConfToUpdate         `json:"tcb,omitempty"`
 		WritePolicy *WritePolicyConfToUpdate `json:"write_policy,omitempty"`
+		Alert       *AlertConfToUpdate       `json:"alert,omitempty"`
+		ETL         *ETLConfToUpdate         `json:"etl,omitempty"`
 		Proxy       *ProxyConfToUpdate       `json:"proxy,omitempty"`
 		Features    *feat.Flags              `json:"features,string,omitempty"`
 
@@ -131,6 +139,8 @@ type (
 		HostNet   LocalNetConfig `json:"host_net"`
 		FSP       FSPConf        `json:"fspaths"`
 		TestFSP   TestFSPConf    `json:"test_fspaths"`
+		// failure domain (e.g., rack or zone) this node belongs to; optional
+		Domain string `json:"failure_domain,omitempty"`
 	}
 
 	// Network config specific to node
@@ -160,14 +170,16 @@ type (
 	BackendConfAIS map[string][]string // cluster alias -> [urls...]
 
 	MirrorConf struct {
-		Copies  int64 `json:"copies"`       // num copies
-		Burst   int   `json:"burst_buffer"` // xaction channel (buffer) size
-		Enabled bool  `json:"enabled"`      // enabled (to generate copies)
+		Mode    string `json:"mode,omitempty"` // enum { apc.MirrorMpath (default), apc.MirrorNode }
+		Copies  int64  `json:"copies"`         // num copies
+		Burst   int    `json:"burst_buffer"`   // xaction channel (buffer) size
+		Enabled bool   `json:"enabled"`        // enabled (to generate copies)
 	}
 	MirrorConfToUpdate struct {
-		Copies  *int64 `json:"copies,omitempty"`
-		Burst   *int   `json:"burst_buffer,omitempty"`
-		Enabled *bool  `json:"enabled,omitempty"`
+		Mode    *string `json:"mode,omitempty"`
+		Copies  *int64  `json:"copies,omitempty"`
+		Burst   *int    `json:"burst_buffer,omitempty"`
+		Enabled *bool   `json:"enabled,omitempty"`
 	}
 
 	ECConf struct {
@@ -195,6 +207,7 @@ type (
 		MaxTotal  cos.Size     `json:"max_total"`  // (sum individual log sizes); exceeding this number triggers cleanup
 		FlushTime cos.Duration `json:"flush_time"` // log flush interval
 		StatsTime cos.Duration `json:"stats_time"` // log stats interval (must be a multiple of `PeriodConf.StatsTime`)
+		Format    string       `json:"format"`     // enum { apc.LogFormatText (default), apc.LogFormatJSON }
 	}
 	LogConfToUpdate struct {
 		Level     *string       `json:"level,omitempty"`
@@ -202,6 +215,7 @@ type (
 		MaxTotal  *cos.Size     `json:"max_total,omitempty"`
 		FlushTime *cos.Duration `json:"flush_time,omitempty"`
 		StatsTime *cos.Duration `json:"stats_time,omitempty"`
+		Format    *string       `json:"format,omitempty"`
 	}
 
 	// NOTE: StatsTime is a one important timer
@@ -306,6 +320,8 @@ type (
 		DiskUtilMaxWM   int64        `json:"disk_util_max_wm"`
 		IostatTimeLong  cos.Duration `json:"iostat_time_long"`
 		IostatTimeShort cos.Duration `json:"iostat_time_short"`
+		MpathBudget     int64        `json:"mpath_budget"`   // per-mountpath bytes/s shared by user I/O and xactions (0 - unlimited)
+		ScrubInterval   cos.Duration `json:"scrub_interval"` // periodically verify all objects and copies (0 - never)
 	}
 	DiskConfToUpdate struct {
 		DiskUtilLowWM   *int64        `json:"disk_util_low_wm,omitempty"`
@@ -313,19 +329,29 @@ type (
 		DiskUtilMaxWM   *int64        `json:"disk_util_max_wm,omitempty"`
 		IostatTimeLong  *cos.Duration `json:"iostat_time_long,omitempty"`
 		IostatTimeShort *cos.Duration `json:"iostat_time_short,omitempty"`
+		MpathBudget     *int64        `json:"mpath_budget,omitempty"`
+		ScrubInterval   *cos.Duration `json:"scrub_interval,omitempty"`
 	}
 
 	RebalanceConf struct {
 		Compression   string       `json:"compression"`       // enum { CompressAlways, ... } in api/apc/compression.go
 		DestRetryTime cos.Duration `json:"dest_retry_time"`   // max wait for ACKs & neighbors to complete
+		Window        string       `json:"window"`            // daily schedule, e.g. "22:00-06:00" (targets' local time); "" - any time
+		BytesPerSec   int64        `json:"bytes_per_sec"`     // cluster-wide cap on the rebalanced bytes/s (0 - unlimited)
+		ObjsPerSec    int64        `json:"objs_per_sec"`      // ditto, objects/s
 		SbundleMult   int          `json:"bundle_multiplier"` // stream-bundle multiplier: num streams to destination
 		Enabled       bool         `json:"enabled"`           // true=auto-rebalance | manual rebalancing
+		Paused        bool         `json:"paused"`            // see api.PauseRebalance
 	}
 	RebalanceConfToUpdate struct {
 		DestRetryTime *cos.Duration `json:"dest_retry_time,omitempty"`
 		Compression   *string       `json:"compression,omitempty"`
+		Window        *string       `json:"window,omitempty"`
+		BytesPerSec   *int64        `json:"bytes_per_sec,omitempty"`
+		ObjsPerSec    *int64        `json:"objs_per_sec,omitempty"`
 		SbundleMult   *int          `json:"bundle_multiplier"`
 		Enabled       *bool         `json:"enabled,omitempty"`
+		Paused        *bool         `json:"paused,omitempty"`
 	}
 
 	ResilverConf struct {
@@ -417,14 +443,20 @@ type (
 	}
 
 	FSHCConf struct {
-		TestFileCount int  `json:"test_files"`  // number of files to read/write
-		ErrorLimit    int  `json:"error_limit"` // exceeding err limit causes disabling mountpath
-		Enabled       bool `json:"enabled"`
+		TestFileCount int `json:"test_files"`  // number of files to read/write
+		ErrorLimit    int `json:"error_limit"` // exceeding err limit causes disabling mountpath
+		// mountpath is considered degraded when (over the last few minutes) its disks' p90 latency
+		// reaches `degraded_latency` or the number of I/O errors reaches `degraded_errors` (0 - disabled)
+		DegradedLatency cos.Duration `json:"degraded_latency"`
+		DegradedErrors  int          `json:"degraded_errors"`
+		Enabled         bool         `json:"enabled"`
 	}
 	FSHCConfToUpdate struct {
-		TestFileCount *int  `json:"test_files,omitempty"`
-		ErrorLimit    *int  `json:"error_limit,omitempty"`
-		Enabled       *bool `json:"enabled,omitempty"`
+		TestFileCount   *int          `json:"test_files,omitempty"`
+		ErrorLimit      *int          `json:"error_limit,omitempty"`
+		DegradedLatency *cos.Duration `json:"degraded_latency,omitempty"`
+		DegradedErrors  *int          `json:"degraded_errors,omitempty"`
+		Enabled         *bool         `json:"enabled,omitempty"`
 	}
 
 	AuthConf struct {
@@ -551,6 +583,42 @@ type (
 		Data *apc.WritePolicy `json:"data,omitempty" list:"readonly"` // NOTE: NIY
 		MD   *apc.WritePolicy `json:"md,omitempty"`
 	}
+
+	AlertConf struct {
+		// "<name>:<metric><op><threshold>[:<severity>]", e.g. "disk-errors:err.io.n>0:critical"
+		// where metric is any node stats name or one of the apc.AlertMetric* names
+		Rules []string `json:"rules"`
+		// URLs to POST fired and resolved alerts to (see apc.AlertNotif)
+		Webhooks []string `json:"webhooks"`
+		// how often to evaluate the rules
+		Interval cos.Duration `json:"interval"`
+		// how long to keep resolved alerts
+		KeepResolved cos.Duration `json:"keep_resolved"`
+		// running xaction that makes no progress for this long is considered stuck (apc.AlertMetricXactStuck)
+		XactStuckTime cos.Duration `json:"xact_stuck_time"`
+		Enabled       bool         `json:"enabled"`
+	}
+	AlertConfToUpdate struct {
+		Rules         *[]string     `json:"rules,omitempty"`
+		Webhooks      *[]string     `json:"webhooks,omitempty"`
+		Interval      *cos.Duration `json:"interval,omitempty"`
+		KeepResolved  *cos.Duration `json:"keep_resolved,omitempty"`
+		XactStuckTime *cos.Duration `json:"xact_stuck_time,omitempty"`
+		Enabled       *bool         `json:"enabled,omitempty"`
+	}
+
+	ETLConf struct {
+		// executables (local process) and Docker images that targets are permitted
+		// to run as transformers (apc.ETLInitProc or pod spec outside Kubernetes);
+		// each entry is either an exact match or a prefix followed by '*'
+		LocalAllow []string `json:"local_allow"`
+		// when false (default), running transformers outside Kubernetes is disabled
+		LocalEnabled bool `json:"local_enabled"`
+	}
+	ETLConfToUpdate struct {
+		LocalAllow   *[]string `json:"local_allow,omitempty"`
+		LocalEnabled *bool     `json:"local_enabled,omitempty"`
+	}
 )
 
 // read-mostly and most often used timeouts: assign at startup to reduce the number of GCO.Get() calls
@@ -619,6 +687,9 @@ var (
 	_ Validator = (*MemsysConf)(nil)
 	_ Validator = (*TCBConf)(nil)
 	_ Validator = (*WritePolicyConf)(nil)
+	_ Validator = (*FSHCConf)(nil)
+	_ Validator = (*AlertConf)(nil)
+	_ Validator = (*ETLConf)(nil)
 
 	_ PropsValidator = (*CksumConf)(nil)
 	_ PropsValidator = (*SpaceConf)(nil)
@@ -768,6 +839,11 @@ func (c *LogConf) Validate() error {
 	if c.StatsTime.D() > 10*time.Minute {
 		return fmt.Errorf("invalid log.stats_time=%s (expected range [log.stats_time, 10m])", c.StatsTime)
 	}
+	switch c.Format {
+	case "", apc.LogFormatText, apc.LogFormatJSON:
+	default:
+		return fmt.Errorf("invalid log.format=%q (expecting %q or %q)", c.Format, apc.LogFormatText, apc.LogFormatJSON)
+	}
 	return nil
 }
 
@@ -956,6 +1032,26 @@ func (c *DiskConf) Validate() (err error) {
 		return fmt.Errorf("disk.iostat_time_long %v shorter than disk.iostat_time_short %v",
 			c.IostatTimeLong, c.IostatTimeShort)
 	}
+	if c.MpathBudget < 0 {
+		return fmt.Errorf("invalid disk.mpath_budget %d (expecting non-negative bytes/s)", c.MpathBudget)
+	}
+	if c.ScrubInterval != 0 && c.ScrubInterval.D() < time.Hour {
+		return fmt.Errorf("invalid disk.scrub_interval %v (expecting 0 (disabled) or at least 1h)", c.ScrubInterval)
+	}
+	return nil
+}
+
+//////////////
+// FSHCConf //
+//////////////
+
+func (c *FSHCConf) Validate() error {
+	if c.DegradedLatency < 0 {
+		return fmt.Errorf("invalid fshc.degraded_latency %v (expecting non-negative)", c.DegradedLatency)
+	}
+	if c.DegradedErrors < 0 {
+		return fmt.Errorf("invalid fshc.degraded_errors %d (expecting non-negative)", c.DegradedErrors)
+	}
 	return nil
 }
 
@@ -1068,21 +1164,45 @@ func (c *MirrorConf) Validate() error {
 	if c.Copies < 2 || c.Copies > 32 {
 		return fmt.Errorf("invalid mirror.copies: %d (expected value in range [2, 32])", c.Copies)
 	}
+	if !apc.IsValidMirrorMode(c.Mode) {
+		return fmt.Errorf("invalid mirror.mode: %q (expecting one of %v)", c.Mode, apc.SupportedMirrorModes)
+	}
 	return nil
 }
 
-func (c *MirrorConf) ValidateAsProps(...any) error {
+// optional arg: number of targets in the cluster (to validate cross-node mirroring)
+func (c *MirrorConf) ValidateAsProps(arg ...any) error {
 	if !c.Enabled {
 		return nil
 	}
-	return c.Validate()
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	if c.Mode != apc.MirrorNode || len(arg) == 0 {
+		return nil
+	}
+	targetCnt, ok := arg[0].(int)
+	debug.Assert(ok)
+	if int(c.Copies) > targetCnt {
+		return NewErrSoft(fmt.Sprintf("%v: cross-node mirroring (%d copies) requires at least %d targets (have %d)",
+			ErrNotEnoughTargets, c.Copies, c.Copies, targetCnt))
+	}
+	return nil
 }
 
+// local (mountpath) mirroring
+func (c *MirrorConf) Local() bool { return c.Enabled && c.Mode != apc.MirrorNode }
+
+// cross-node mirroring
+func (c *MirrorConf) Nodes() bool { return c.Enabled && c.Mode == apc.MirrorNode }
+
 func (c *MirrorConf) String() string {
 	if !c.Enabled {
 		return "Disabled"
 	}
-
+	if c.Mode == apc.MirrorNode {
+		return fmt.Sprintf("%d copies (across nodes)", c.Copies)
+	}
 	return fmt.Sprintf("%d copies", c.Copies)
 }
 
@@ -1171,6 +1291,61 @@ func (c *WritePolicyConf) Validate() (err error) {
 
 func (c *WritePolicyConf) ValidateAsProps(...any) error { return c.Validate() }
 
+///////////////
+// AlertConf //
+///////////////
+
+func (c *AlertConf) Validate() error {
+	if c.Interval < 0 || c.KeepResolved < 0 || c.XactStuckTime < 0 {
+		return fmt.Errorf("invalid alert (interval, keep_resolved, xact_stuck_time) = (%v, %v, %v): expecting non-negative",
+			c.Interval, c.KeepResolved, c.XactStuckTime)
+	}
+	names := make(cos.StrSet, len(c.Rules))
+	for _, s := range c.Rules {
+		r, err := apc.ParseAlertRule(s)
+		if err != nil {
+			return err
+		}
+		if names.Contains(r.Name) {
+			return fmt.Errorf("duplicate alert rule name %q", r.Name)
+		}
+		names.Set(r.Name)
+	}
+	for _, u := range c.Webhooks {
+		if _, err := url.ParseRequestURI(u); err != nil {
+			return fmt.Errorf("invalid alert webhook %q: %v", u, err)
+		}
+	}
+	return nil
+}
+
+/////////////
+// ETLConf //
+/////////////
+
+func (c *ETLConf) Validate() error {
+	for _, s := range c.LocalAllow {
+		if i := strings.IndexByte(s, '*'); s == "" || s == "*" || (i >= 0 && i != len(s)-1) {
+			return fmt.Errorf("invalid etl.local_allow entry %q (expecting exact name or prefix followed by '*')", s)
+		}
+	}
+	return nil
+}
+
+// LocalAllowed returns nil if the named executable or Docker image is permitted
+// to run as a transformer outside Kubernetes
+func (c *ETLConf) LocalAllowed(name string) error {
+	if !c.LocalEnabled {
+		return errors.New("running ETL transformers outside Kubernetes is disabled (see etl.local_enabled)")
+	}
+	for _, s := range c.LocalAllow {
+		if s == name || (strings.HasSuffix(s, "*") && strings.HasPrefix(name, s[:len(s)-1])) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is not permitted to run as ETL transformer (see etl.local_allow)", name)
+}
+
 ///////////////////
 // KeepaliveConf //
 ///////////////////
@@ -1589,6 +1764,13 @@ func (c *RebalanceConf) Validate() error {
 		return fmt.Errorf("invalid rebalance.compression: %q (expecting one of: %v)",
 			c.Compression, apc.SupportedCompression)
 	}
+	if c.BytesPerSec < 0 || c.ObjsPerSec < 0 {
+		return fmt.Errorf("invalid rebalance.bytes_per_sec=%d, objs_per_sec=%d (expecting non-negative)",
+			c.BytesPerSec, c.ObjsPerSec)
+	}
+	if _, _, err := c.parseWindow(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1599,6 +1781,52 @@ func (c *RebalanceConf) String() string {
 	return "Disabled"
 }
 
+// whether the given time is within the (daily) schedule window; the window may wrap
+// around midnight, e.g. "22:00-06:00"
+func (c *RebalanceConf) InWindow(now time.Time) bool {
+	if c.Window == "" {
+		return true
+	}
+	from, to, err := c.parseWindow()
+	if err != nil {
+		return true // (validated)
+	}
+	m := now.Hour()*60 + now.Minute()
+	if from <= to {
+		return m >= from && m < to
+	}
+	return m >= from || m < to
+}
+
+// returns minutes since midnight
+func (c *RebalanceConf) parseWindow() (from, to int, err error) {
+	if c.Window == "" {
+		return
+	}
+	parts := strings.Split(c.Window, "-")
+	if len(parts) != 2 {
+		err = fmt.Errorf("invalid rebalance.window %q (expecting HH:MM-HH:MM)", c.Window)
+		return
+	}
+	if from, err = _hhmm(parts[0]); err == nil {
+		to, err = _hhmm(parts[1])
+	}
+	if err == nil && from == to {
+		err = fmt.Errorf("invalid rebalance.window %q (empty)", c.Window)
+	} else if err != nil {
+		err = fmt.Errorf("invalid rebalance.window %q (expecting HH:MM-HH:MM): %v", c.Window, err)
+	}
+	return
+}
+
+func _hhmm(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
 func (*ResilverConf) Validate() error { return nil }
 
 func (c *ResilverConf) String() string {
@@ -1776,6 +2004,8 @@ func LoadConfig(globalConfPath, localConfPath, daeRole string, config *Config) e
 		glog.Warningf("log.max_size %d exceeds 1GB, setting log.max_size=4MB", glog.MaxSize)
 		glog.MaxSize = 4 * cos.MiB
 	}
+	// log format
+	glog.SetJSON(config.Log.Format == apc.LogFormatJSON)
 	// log level
 	if err := SetLogLevel(config.Log.Level); err != nil {
 		return fmt.Errorf("failed to set log level %q: %s", config.Log.Level, err)
@@ -17,11 +17,14 @@ const (
 	HdrContentType           = "Content-Type"
 	HdrContentTypeOptions    = "X-Content-Type-Options"
 	HdrContentLength         = "Content-Length"
+	HdrContentDisposition    = "Content-Disposition"
 	HdrUserAgent             = "User-Agent"
 	HdrAccept                = "Accept"
 	HdrLocation              = "Location"
 	HdrServer                = "Server"
 	HdrETag                  = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Hdrs/ETag
+	HdrLastModified          = "Last-Modified"
+	HdrIfRange               = "If-Range" // Ref: https://www.rfc-editor.org/rfc/rfc9110#field.if-range
 )
 
 // provider-specific headers (=> custom props, and more)
@@ -62,4 +65,5 @@ const (
 	ContentMsgPack        = "application/msgpack"
 	ContentXML            = "application/xml"
 	ContentBinary         = "application/octet-stream"
+	ContentMultipartMixed = "multipart/mixed"
 )
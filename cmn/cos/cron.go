@@ -0,0 +1,179 @@
+// Package cos provides common low-level types and utilities for all aistore projects.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed standard 5-field cron expression ("minute hour day-of-month month day-of-week");
+// all times are UTC. Supported: '*', lists ("1,15"), ranges ("1-5"), steps ("*/10", "0-30/5"),
+// and shortcuts @hourly, @daily (@midnight), @weekly, @monthly, @yearly (@annually), and "@every <duration>".
+// As in classic cron, when both day-of-month and day-of-week are restricted either one matching will do.
+type Cron struct {
+	expr   string
+	every  time.Duration
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	star   struct{ dom, dow bool }
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = [5]cronField{{"minute", 0, 59}, {"hour", 0, 23}, {"day-of-month", 1, 31}, {"month", 1, 12}, {"day-of-week", 0, 7}}
+
+var cronShortcuts = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+const cronEvery = "@every "
+
+// (upper bound on searching for the next activation; e.g., "0 0 30 2 *" never fires)
+const cronMaxYears = 5
+
+func ParseCron(expr string) (*Cron, error) {
+	var (
+		c = &Cron{expr: expr}
+		s = strings.TrimSpace(expr)
+	)
+	if strings.HasPrefix(s, cronEvery) {
+		d, err := time.ParseDuration(strings.TrimSpace(s[len(cronEvery):]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron %q: %v", expr, err)
+		}
+		if d < time.Minute {
+			return nil, fmt.Errorf("invalid cron %q: interval must be at least 1m", expr)
+		}
+		c.every = d
+		return c, nil
+	}
+	if sc, ok := cronShortcuts[s]; ok {
+		s = sc
+	}
+	fields := strings.Fields(s)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron %q: expecting %d fields, got %d", expr, len(cronFields), len(fields))
+	}
+	bits := [5]*uint64{&c.minute, &c.hour, &c.dom, &c.month, &c.dow}
+	for i, f := range fields {
+		v, err := cronFields[i].parse(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron %q: %v", expr, err)
+		}
+		*bits[i] = v
+	}
+	if c.dow&(1<<7) != 0 { // Sunday is either 0 or 7
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	c.star.dom, c.star.dow = fields[2] == "*", fields[4] == "*"
+	return c, nil
+}
+
+func (c *Cron) String() string { return c.expr }
+
+// Next returns the first activation time strictly after `t` (truncated to the minute),
+// or zero time if there's none within the next few years.
+func (c *Cron) Next(t time.Time) time.Time {
+	if c.every > 0 {
+		return t.Add(c.every)
+	}
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	end := t.AddDate(cronMaxYears, 0, 0)
+	for t.Before(end) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (c *Cron) dayMatches(t time.Time) bool {
+	var (
+		dom = c.dom&(1<<uint(t.Day())) != 0
+		dow = c.dow&(1<<uint(t.Weekday())) != 0
+	)
+	if c.star.dom || c.star.dow {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// e.g. "*", "5", "1-5", "*/15", "10-50/10", "1,3,5-7"
+func (cf *cronField) parse(s string) (bits uint64, err error) {
+	for _, part := range strings.Split(s, ",") {
+		var (
+			lo, hi = cf.min, cf.max
+			step   = 1
+			rng    = part
+		)
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("%s: invalid step in %q", cf.name, part)
+			}
+			rng = part[:i]
+		}
+		switch {
+		case rng == "*":
+		case strings.IndexByte(rng, '-') > 0:
+			i := strings.IndexByte(rng, '-')
+			if lo, err = cf.atoi(rng[:i]); err != nil {
+				return 0, err
+			}
+			if hi, err = cf.atoi(rng[i+1:]); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("%s: invalid range %q", cf.name, rng)
+			}
+		default:
+			if lo, err = cf.atoi(rng); err != nil {
+				return 0, err
+			}
+			if rng == part {
+				hi = lo
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	if bits == 0 {
+		err = errors.New(cf.name + ": empty")
+	}
+	return
+}
+
+func (cf *cronField) atoi(s string) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < cf.min || v > cf.max {
+		return 0, fmt.Errorf("%s: %q is out of range [%d, %d]", cf.name, s, cf.min, cf.max)
+	}
+	return v, nil
+}
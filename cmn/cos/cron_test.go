@@ -0,0 +1,58 @@
+// Package cos provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos_test
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron", func() {
+	// Wednesday
+	from := time.Date(2023, time.March, 15, 10, 7, 30, 0, time.UTC)
+
+	DescribeTable("next activation",
+		func(expr string, expected time.Time) {
+			c, err := cos.ParseCron(expr)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(c.Next(from)).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", time.Date(2023, time.March, 15, 10, 8, 0, 0, time.UTC)),
+		Entry("step", "*/15 * * * *", time.Date(2023, time.March, 15, 10, 15, 0, 0, time.UTC)),
+		Entry("range w/ step", "10-50/20 * * * *", time.Date(2023, time.March, 15, 10, 10, 0, 0, time.UTC)),
+		Entry("list", "5,20 3,12 * * *", time.Date(2023, time.March, 15, 12, 5, 0, 0, time.UTC)),
+		Entry("@hourly", "@hourly", time.Date(2023, time.March, 15, 11, 0, 0, 0, time.UTC)),
+		Entry("@daily", "@daily", time.Date(2023, time.March, 16, 0, 0, 0, 0, time.UTC)),
+		Entry("@weekly", "@weekly", time.Date(2023, time.March, 19, 0, 0, 0, 0, time.UTC)),
+		Entry("@monthly", "@monthly", time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("sunday as 7", "30 2 * * 7", time.Date(2023, time.March, 19, 2, 30, 0, 0, time.UTC)),
+		Entry("dom or dow", "0 0 20 * 5", time.Date(2023, time.March, 17, 0, 0, 0, 0, time.UTC)),
+		Entry("month", "0 0 1 6 *", time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("next year", "0 0 1 1 *", time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("leap day", "0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)),
+		Entry("@every", "@every 90m", from.Add(90*time.Minute)),
+		Entry("never", "0 0 30 2 *", time.Time{}),
+	)
+
+	DescribeTable("invalid expressions",
+		func(expr string) {
+			_, err := cos.ParseCron(expr)
+			Expect(err).Should(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("too few fields", "* * * *"),
+		Entry("out of range", "60 * * * *"),
+		Entry("zero day", "* * 0 * *"),
+		Entry("reversed range", "* 10-5 * * *"),
+		Entry("bad step", "*/0 * * * *"),
+		Entry("not a number", "x * * * *"),
+		Entry("@every too short", "@every 30s"),
+		Entry("unknown shortcut", "@often"),
+	)
+})
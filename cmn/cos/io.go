@@ -208,6 +208,8 @@ func (f *FileHandle) Open() (ReadOpenCloser, error) {
 	return NewFileHandle(f.fqn)
 }
 
+func (f *FileHandle) FQN() string { return f.fqn }
+
 ////////////
 // Sized* //
 ////////////
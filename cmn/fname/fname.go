@@ -24,12 +24,15 @@ const (
 	ProxyID = ".ais.proxy_id"
 
 	// metadata
-	Smap        = ".ais.smap"   // Smap persistent file basename
-	Rmd         = ".ais.rmd"    // rmd persistent file basename
-	Bmd         = ".ais.bmd"    // bmd persistent file basename
-	BmdPrevious = Bmd + ".prev" // bmd previous version
-	Vmd         = ".ais.vmd"    // vmd persistent file basename
-	Emd         = ".ais.emd"    // emd persistent file basename
+	Smap        = ".ais.smap"         // Smap persistent file basename
+	Rmd         = ".ais.rmd"          // rmd persistent file basename
+	RebProgress = ".ais.rmd.progress" // rebalance progress (see reb/progress.go)
+	Bmd         = ".ais.bmd"          // bmd persistent file basename
+	BmdPrevious = Bmd + ".prev"       // bmd previous version
+	Vmd         = ".ais.vmd"          // vmd persistent file basename
+	Emd         = ".ais.emd"          // emd persistent file basename
+	Schd        = ".ais.schd"         // job schedules (primary proxy) persistent file basename
+	Alerts      = ".ais.alerts"       // active and resolved alerts (see ais/prxalert.go)
 
 	// CLI config
 	CliConfig = "cli.json" // see jsp/app.go
@@ -9,8 +9,17 @@ import "github.com/NVIDIA/aistore/3rdparty/glog"
 
 var loghdr string
 
-func SetNodeName(sname string) {
+// components (glog.Fields.Comp) in structured log lines
+const (
+	LogCompAIS  = "ais"
+	LogCompReb  = "reb"
+	LogCompEC   = "ec"
+	LogCompXact = "xact"
+)
+
+func SetNodeName(sid, sname string) {
 	thisNodeName = sname
+	glog.NodeID = sid
 	glog.FileHeaderCB = glocb
 }
 
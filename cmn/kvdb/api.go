@@ -49,6 +49,9 @@ type (
 		List(collection, pattern string) ([]string, error)
 		// Return subkeys with their values: map[key]value
 		GetAll(collection, pattern string) (map[string]string, error)
+		// Return up to `limit` subkeys that start with `prefix` and follow `after` (in
+		// ascending order), along with their values - to iterate large collections page by page
+		GetPage(collection, prefix, after string, limit int) (keys, values []string, err error)
 	}
 
 	ErrNotFound struct {
@@ -169,3 +169,28 @@ func (bd *BuntDriver) GetAll(collection, pattern string) (map[string]string, err
 	})
 	return values, buntToCommonErr(err, collection, "")
 }
+
+func (bd *BuntDriver) GetPage(collection, prefix, after string, limit int) (keys, values []string, err error) {
+	var (
+		filter = makePath(collection, prefix)
+		pivot  = filter
+	)
+	if after != "" && makePath(collection, after) > pivot {
+		pivot = makePath(collection, after)
+	}
+	keys, values = make([]string, 0, limit), make([]string, 0, limit)
+	err = bd.driver.View(func(tx *buntdb.Tx) error {
+		return tx.AscendGreaterOrEqual("", pivot, func(path, val string) bool {
+			if !strings.HasPrefix(path, filter) {
+				return false
+			}
+			_, key := ParsePath(path)
+			if key != "" && key != after {
+				keys = append(keys, key)
+				values = append(values, val)
+			}
+			return len(keys) < limit
+		})
+	})
+	return keys, values, buntToCommonErr(err, collection, "")
+}
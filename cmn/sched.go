@@ -0,0 +1,185 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Schedules: cluster-level recurring jobs - download, prefetch, copy-bucket and ETL-bucket,
+// archive, evict (delete) objects, and any other xaction that can be started via api.StartXaction.
+// Schedules are stored in cluster metadata (SchedMD) and triggered by the primary proxy.
+//
+// A job runs on its cron schedule and/or upon successful completion of all the jobs it depends
+// on (`DependsOn`), thus forming a DAG, e.g.: prefetch => etl-bucket => archive => evict.
+// With both cron and dependencies, the latter is a precondition: the scheduled run gets skipped
+// unless the most recent runs of all dependencies have succeeded.
+// A failed run is retried up to `Retries` times, `RetryDelay` apart.
+// Concurrency policy: a run is skipped while the previous one is still in progress.
+
+const SchedHistoryLen = 10 // max run records per schedule
+
+// SchedRun.Status
+const (
+	SchedStarted  = "started"
+	SchedSkipped  = "skipped"
+	SchedFailed   = "failed"
+	SchedRunning  = "running"
+	SchedFinished = "finished"
+	SchedAborted  = "aborted"
+	SchedUnknown  = "unknown" // e.g., job's status lost upon primary change
+)
+
+type (
+	SchedMsg struct {
+		Name string `json:"name"`
+		Cron string `json:"cron"` // e.g. "0 */6 * * *", "@daily", "@every 2h" (optional when `DependsOn`)
+		Kind string `json:"kind"` // apc.ActDownload, apc.ActPrefetchObjects, apc.ActCopyBck, etc. - see Validate
+		// all kinds except download (that specifies its destination in the `Body`);
+		// optional for the xactions that can run on all buckets, n/a for the cluster-wide ones
+		Bck   Bck `json:"bck"`
+		BckTo Bck `json:"bck_to"` // copy-bucket and etl-bucket destination
+		// the job itself:
+		// - download:                  dload.Body (e.g., {"type": "backend", ...,"sync": true})
+		// - prefetch, evict, delete:   SelectObjsMsg
+		// - copy-bucket, etl-bucket:   apc.TCBMsg
+		// - archive:                   ArchiveMsg
+		// - all other (startable) xactions: none
+		Body json.RawMessage `json:"body"`
+		// names of the (existing) schedules that must succeed prior to this job
+		DependsOn []string `json:"depends_on,omitempty"`
+		// retry policy
+		Retries    int          `json:"retries,omitempty"`     // max number of times to retry a failed run
+		RetryDelay cos.Duration `json:"retry_delay,omitempty"` // time between retries (zero - the next scheduler tick)
+	}
+	SchedRun struct {
+		Started  time.Time `json:"started"`
+		Finished time.Time `json:"finished,omitempty"`
+		JobID    string    `json:"job_id,omitempty"`
+		Status   string    `json:"status"`
+		Err      string    `json:"err,omitempty"`
+		Attempt  int       `json:"attempt,omitempty"` // retry number (zero - the original run)
+	}
+	Schedule struct {
+		SchedMsg
+		Created time.Time  `json:"created"`
+		NextRun time.Time  `json:"next_run"`
+		History []SchedRun `json:"history,omitempty"` // most recent last
+		Retry   int        `json:"retry,omitempty"`   // pending retry (zero - none), due at `NextRun`
+	}
+	Schedules []*Schedule
+)
+
+// NOTE: validates all but download requests and the generic (startable) xactions -
+// the latter are validated by the primary (see ais/prxsched.go)
+func (msg *SchedMsg) Validate() (err error) {
+	if msg.Name == "" || !cos.IsAlphaPlus(msg.Name, true /*with period*/) {
+		return fmt.Errorf("invalid schedule name %q (expecting letters, numbers, dashes, and underscores)", msg.Name)
+	}
+	if msg.Cron != "" || len(msg.DependsOn) == 0 {
+		if _, err = cos.ParseCron(msg.Cron); err != nil {
+			return
+		}
+	}
+	for _, name := range msg.DependsOn {
+		if name == msg.Name {
+			return fmt.Errorf("schedule %q cannot depend on itself", msg.Name)
+		}
+	}
+	if msg.Retries < 0 || msg.RetryDelay < 0 {
+		return fmt.Errorf("schedule %q: invalid retry policy (%d, %v)", msg.Name, msg.Retries, msg.RetryDelay)
+	}
+	switch msg.Kind {
+	case apc.ActPrefetchObjects, apc.ActEvictObjects, apc.ActDeleteObjects:
+		if err = msg.Bck.Validate(); err != nil {
+			return
+		}
+		var lrMsg SelectObjsMsg
+		if err = json.Unmarshal(msg.Body, &lrMsg); err != nil {
+			return fmt.Errorf("schedule %q: invalid %s request: %v", msg.Name, msg.Kind, err)
+		}
+	case apc.ActArchive:
+		if err = msg.Bck.Validate(); err != nil {
+			return
+		}
+		var archMsg ArchiveMsg
+		if err = json.Unmarshal(msg.Body, &archMsg); err != nil {
+			return fmt.Errorf("schedule %q: invalid archive request: %v", msg.Name, err)
+		}
+		if _, err = cos.Mime(archMsg.Mime, archMsg.ArchName); err != nil {
+			return fmt.Errorf("schedule %q: %v", msg.Name, err)
+		}
+	case apc.ActCopyBck, apc.ActETLBck:
+		if err = msg.Bck.Validate(); err != nil {
+			return
+		}
+		if err = msg.BckTo.Validate(); err != nil {
+			return
+		}
+		if msg.Bck.Equal(&msg.BckTo) {
+			return fmt.Errorf("schedule %q: cannot copy bucket %s onto itself", msg.Name, msg.Bck)
+		}
+		if len(msg.Body) > 0 {
+			var tcbMsg apc.TCBMsg
+			if err = json.Unmarshal(msg.Body, &tcbMsg); err != nil {
+				return fmt.Errorf("schedule %q: invalid %s request: %v", msg.Name, msg.Kind, err)
+			}
+			if msg.Kind == apc.ActETLBck {
+				err = tcbMsg.Validate()
+			} else {
+				err = tcbMsg.ValidateCopy()
+			}
+		}
+	}
+	return
+}
+
+func (s *Schedule) LastRun() *SchedRun {
+	if len(s.History) == 0 {
+		return nil
+	}
+	return &s.History[len(s.History)-1]
+}
+
+// the most recent run that was not skipped
+func (s *Schedule) LastStarted() *SchedRun {
+	for i := len(s.History) - 1; i >= 0; i-- {
+		if s.History[i].Status != SchedSkipped {
+			return &s.History[i]
+		}
+	}
+	return nil
+}
+
+// time of the most recent run, skipped or not (or creation time, if none)
+func (s *Schedule) Triggered() time.Time {
+	if run := s.LastRun(); run != nil {
+		return run.Started
+	}
+	return s.Created
+}
+
+func (s *Schedule) AddRun(run SchedRun) {
+	if len(s.History) >= SchedHistoryLen {
+		s.History = append(s.History[:0:0], s.History[len(s.History)-SchedHistoryLen+1:]...)
+	}
+	s.History = append(s.History, run)
+}
+
+func (run *SchedRun) Done() bool {
+	switch run.Status {
+	case SchedFinished, SchedFailed, SchedAborted, SchedUnknown:
+		return true
+	}
+	return false
+}
+
+func (run *SchedRun) Succeeded() bool { return run.Status == SchedFinished && run.Err == "" }
@@ -0,0 +1,31 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestScheduleHistory(t *testing.T) {
+	s := &Schedule{}
+	tassert.Errorf(t, s.LastRun() == nil, "expected no runs")
+	for i := 0; i < SchedHistoryLen+5; i++ {
+		s.AddRun(SchedRun{JobID: fmt.Sprintf("job-%d", i), Status: SchedStarted})
+	}
+	tassert.Fatalf(t, len(s.History) == SchedHistoryLen, "expected %d runs, got %d", SchedHistoryLen, len(s.History))
+	tassert.Errorf(t, s.History[0].JobID == "job-5", "oldest: %+v", s.History[0])
+	last := fmt.Sprintf("job-%d", SchedHistoryLen+4)
+	tassert.Errorf(t, s.LastRun().JobID == last, "last: %+v", s.LastRun())
+
+	s.AddRun(SchedRun{Status: SchedSkipped})
+	tassert.Errorf(t, s.LastStarted().JobID == last, "last started: %+v", s.LastStarted())
+	tassert.Errorf(t, !s.LastStarted().Done(), "expected %+v in progress", s.LastStarted())
+	s.LastStarted().Status, s.LastStarted().Err = SchedFinished, "failed on t1"
+	tassert.Errorf(t, s.LastStarted().Done() && !s.LastStarted().Succeeded(), "%+v", s.LastStarted())
+}
@@ -137,6 +137,7 @@ var _ = Describe("API", func() {
 						EnableReadRange: api.Bool(false),
 					},
 					Mirror: &cmn.MirrorConfToUpdate{
+						Mode:    api.String(apc.MirrorNode),
 						Copies:  api.Int64(10),
 						Burst:   api.Int(32),
 						Enabled: api.Bool(false),
@@ -152,6 +153,12 @@ var _ = Describe("API", func() {
 					WritePolicy: &cmn.WritePolicyConfToUpdate{
 						MD: api.WritePolicy(apc.WriteDelayed),
 					},
+					Repl: &cmn.ReplConfToUpdate{
+						Dst:     api.String("ais://@remais/abc"),
+						Prefix:  api.String("dir/"),
+						Deletes: api.Bool(true),
+						Enabled: api.Bool(true),
+					},
 				},
 				cmn.BucketProps{
 					Versioning: cmn.VersionConf{
@@ -166,6 +173,7 @@ var _ = Describe("API", func() {
 						EnableReadRange: false,
 					},
 					Mirror: cmn.MirrorConf{
+						Mode:    apc.MirrorNode,
 						Copies:  10,
 						Burst:   32,
 						Enabled: false,
@@ -182,8 +190,34 @@ var _ = Describe("API", func() {
 						Data: "",
 						MD:   apc.WriteDelayed,
 					},
+					Repl: cmn.ReplConf{
+						Dst:     "ais://@remais/abc",
+						Prefix:  "dir/",
+						Deletes: true,
+						Enabled: true,
+					},
 				},
 			),
 		)
 	})
+
+	Describe("ReplConf", func() {
+		DescribeTable("should validate replication destination",
+			func(conf cmn.ReplConf, valid bool) {
+				err := conf.ValidateAsProps()
+				if valid {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(HaveOccurred())
+				}
+			},
+			Entry("disabled", cmn.ReplConf{}, true),
+			Entry("remote AIS", cmn.ReplConf{Dst: "ais://@remais/abc", Enabled: true}, true),
+			Entry("cloud", cmn.ReplConf{Dst: "s3://abc", Prefix: "dir/", Enabled: true}, true),
+			Entry("no destination", cmn.ReplConf{Enabled: true}, false),
+			Entry("local AIS", cmn.ReplConf{Dst: "ais://abc", Enabled: true}, false),
+			Entry("HTTP", cmn.ReplConf{Dst: "ht://abc", Enabled: true}, false),
+			Entry("object", cmn.ReplConf{Dst: "s3://abc/obj", Enabled: true}, false),
+		)
+	})
 })
@@ -8,9 +8,11 @@ import (
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/jsp"
 	"github.com/NVIDIA/aistore/tools/tassert"
 )
@@ -72,3 +74,30 @@ func TestValidateMpath(t *testing.T) {
 		}
 	}
 }
+
+func TestRebalanceWindow(t *testing.T) {
+	at := func(hh, mm int) time.Time { return time.Date(2023, 1, 1, hh, mm, 0, 0, time.Local) }
+	tests := []struct {
+		window string
+		now    time.Time
+		in     bool
+	}{
+		{"", at(12, 0), true},
+		{"01:00-05:30", at(3, 0), true},
+		{"01:00-05:30", at(5, 30), false},
+		{"01:00-05:30", at(0, 59), false},
+		{"22:00-06:00", at(23, 15), true},
+		{"22:00-06:00", at(2, 0), true},
+		{"22:00-06:00", at(12, 0), false},
+	}
+	for _, test := range tests {
+		conf := cmn.RebalanceConf{Window: test.window}
+		in := conf.InWindow(test.now)
+		tassert.Errorf(t, in == test.in, "window %q at %s: expected %t, got %t",
+			test.window, test.now.Format("15:04"), test.in, in)
+	}
+	for _, window := range []string{"22:00", "25:00-01:00", "01:00-01:00", "1-2"} {
+		conf := cmn.RebalanceConf{Window: window, DestRetryTime: cos.Duration(time.Minute)}
+		tassert.Errorf(t, conf.Validate() != nil, "expected window %q to fail validation", window)
+	}
+}
@@ -69,6 +69,7 @@ var _ = Describe("IterFields", func() {
 					"mirror.enabled":      false,
 					"mirror.copies":       int64(0),
 					"mirror.burst_buffer": 0,
+					"mirror.mode":         "",
 
 					"ec.enabled":           true,
 					"ec.parity_slices":     1024,
@@ -99,6 +100,11 @@ var _ = Describe("IterFields", func() {
 
 					"write_policy.data": apc.WritePolicy(""),
 					"write_policy.md":   apc.WritePolicy(""),
+
+					"replication.dst":     "",
+					"replication.prefix":  "",
+					"replication.deletes": false,
+					"replication.enabled": false,
 				},
 			),
 			Entry("list BucketPropsToUpdate fields",
@@ -123,6 +129,7 @@ var _ = Describe("IterFields", func() {
 					"mirror.enabled":      (*bool)(nil),
 					"mirror.copies":       (*int64)(nil),
 					"mirror.burst_buffer": (*int)(nil),
+					"mirror.mode":         (*string)(nil),
 
 					"ec.enabled":           api.Bool(true),
 					"ec.parity_slices":     api.Int(1024),
@@ -154,6 +161,11 @@ var _ = Describe("IterFields", func() {
 					"extra.aws.cloud_region":   (*string)(nil),
 					"extra.aws.endpoint":       (*string)(nil),
 					"extra.http.original_url":  (*string)(nil),
+
+					"replication.dst":     (*string)(nil),
+					"replication.prefix":  (*string)(nil),
+					"replication.deletes": (*bool)(nil),
+					"replication.enabled": (*bool)(nil),
 				},
 			),
 			Entry("check for omit tag",
@@ -31,11 +31,12 @@ const (
 )
 
 const (
-	MetaverSmap  = 1 // Smap (cluster map) formatting version (jsp)
-	MetaverBMD   = 2 // BMD (bucket metadata) --/-- (jsp)
-	MetaverRMD   = 1 // Rebalance MD (jsp)
-	MetaverVMD   = 1 // Volume MD (jsp)
-	MetaverEtlMD = 1 // ETL MD (jsp)
+	MetaverSmap    = 1 // Smap (cluster map) formatting version (jsp)
+	MetaverBMD     = 2 // BMD (bucket metadata) --/-- (jsp)
+	MetaverRMD     = 1 // Rebalance MD (jsp)
+	MetaverVMD     = 1 // Volume MD (jsp)
+	MetaverEtlMD   = 1 // ETL MD (jsp)
+	MetaverSchedMD = 1 // job schedules MD (jsp)
 
 	MetaverLOM = 1 // LOM
 
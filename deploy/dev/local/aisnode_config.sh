@@ -33,7 +33,8 @@ cat > $AIS_CONF_FILE <<EOL
 		"max_size":  "1mb",
 		"max_total": "64mb",
 		"flush_time": "40s",
-		"stats_time": "60s"
+		"stats_time": "60s",
+		"format":     "${AIS_LOG_FORMAT:-text}"
 	},
 	"periodic": {
 		"stats_time":        "10s",
@@ -75,13 +76,19 @@ cat > $AIS_CONF_FILE <<EOL
 	    "iostat_time_short": "${AIS_IOSTAT_TIME_SHORT:-100ms}",
 	    "disk_util_low_wm":  20,
 	    "disk_util_high_wm": 80,
-	    "disk_util_max_wm":  95
+	    "disk_util_max_wm":  95,
+	    "mpath_budget":      0,
+	    "scrub_interval":    "0s"
 	},
 	"rebalance": {
 		"dest_retry_time":	"2m",
 		"compression":     	"${AIS_REBALANCE_COMPRESSION:-never}",
 		"bundle_multiplier":	${AIS_REBALANCE_BUNDLE_MULTIPLIER:-2},
-		"enabled":         	true
+		"window":          	"",
+		"bytes_per_sec":   	0,
+		"objs_per_sec":    	0,
+		"enabled":         	true,
+		"paused":          	false
 	},
 	"resilver": {
 		"enabled": true
@@ -129,9 +136,11 @@ cat > $AIS_CONF_FILE <<EOL
 		}
 	},
 	"fshc": {
-		"enabled":     true,
-		"test_files":  4,
-		"error_limit": 2
+		"enabled":          true,
+		"test_files":       4,
+		"error_limit":      2,
+		"degraded_latency": "0s",
+		"degraded_errors":  0
 	},
 	"auth": {
 		"secret":      "$AIS_SECRET_KEY",
@@ -172,6 +181,27 @@ cat > $AIS_CONF_FILE <<EOL
 		"data": "${WRITE_POLICY_DATA:-}",
 		"md": "${WRITE_POLICY_MD:-}"
 	},
+	"alert": {
+		"enabled":		true,
+		"interval":		"30s",
+		"keep_resolved":	"24h",
+		"xact_stuck_time":	"30m",
+		"webhooks":		[],
+		"rules": [
+			"oos:capacity.pct_max>=95:critical",
+			"disk-errors:err.io.n>0:critical",
+			"cksum-errors:err.cksum.n>0",
+			"keepalive:kalive.fail.n>0:critical",
+			"unreachable:node.unreachable>0:critical",
+			"stuck-xaction:xact.stuck.n>0",
+			"low-memory:mem.pressure>=3",
+			"degraded-mpath:mpath.degraded.n>0"
+		]
+	},
+	"etl": {
+		"local_enabled":	false,
+		"local_allow":		[]
+	},
 	"features": "0"
 }
 EOL
@@ -195,7 +225,8 @@ cat > $AIS_LOCAL_CONF_FILE <<EOL
 		"root":     "${TEST_FSPATH_ROOT:-/tmp/ais$NEXT_TIER/}",
 		"count":    ${TEST_FSPATH_COUNT:-0},
 		"instance": ${INSTANCE:-0}
-	}
+	},
+	"failure_domain": "${AIS_FAILURE_DOMAIN}"
 }
 EOL
 
@@ -97,6 +97,7 @@ func (r *XactBckEncode) Run(wg *sync.WaitGroup) {
 		CTs:      []string{fs.ObjectType},
 		VisitObj: r.bckEncode,
 		DoLoad:   mpather.Load,
+		Prio:     xact.Prio(apc.ActECEncode),
 	}
 	opts.Bck.Copy(r.bck.Bucket())
 	jg := mpather.NewJoggerGroup(opts)
@@ -158,6 +159,9 @@ func (r *XactBckEncode) bckEncode(lom *cluster.LOM, _ []byte) error {
 	// beforeECObj increases a counter, and callback afterECObj decreases it.
 	// After Walk finishes, the xaction waits until counter drops to zero.
 	// That means all objects have been processed and xaction can finalize.
+	if err = r.Reserve(lom.MpathInfo(), lom.SizeBytes()); err != nil {
+		return err
+	}
 	r.beforeECObj()
 	if err = ECM.EncodeObject(lom, r.afterECObj); err != nil {
 		// something went wrong: abort xaction
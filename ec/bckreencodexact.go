@@ -0,0 +1,246 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/transport"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Re-encoding an erasure coded bucket with a new (data, parity) layout.
+// By the time the xaction starts, the bucket's EC props already contain the new layout.
+// Each HRW-local object that was encoded with the previous layout gets encoded again:
+// new slices and metafiles (with a newer generation) replace the old ones on the targets
+// that are in the new layout - but only after all of them have been sent (see pendingCTs);
+// the main target's own metafile gets switched last. After that, the targets that are not
+// in the new layout get asked to remove their (no longer needed) slices and metafiles.
+// Objects are never removed or overwritten in the process, GETs keep working throughout,
+// and an object that fails to re-encode keeps its current slices (the failure is counted
+// and the xaction moves on).
+
+type (
+	reencFactory struct {
+		xreg.RenewBase
+		xctn  *XactBckReencode
+		phase string
+	}
+	XactBckReencode struct {
+		xact.Base
+		t    cluster.Target
+		bck  *cluster.Bck
+		wg   *sync.WaitGroup // to wait for all objects (and cleanup requests)
+		smap *cluster.Smap
+		errs atomic.Int64 // objects that failed to re-encode
+	}
+
+	ExtECReencodeStats struct {
+		Errs int64 `json:"ec.reencode.err.n,string"`
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact   = (*XactBckReencode)(nil)
+	_ xreg.Renewable = (*reencFactory)(nil)
+)
+
+//////////////////
+// reencFactory //
+//////////////////
+
+func (*reencFactory) New(args xreg.Args, bck *cluster.Bck) xreg.Renewable {
+	custom := args.Custom.(*xreg.ECEncodeArgs)
+	p := &reencFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}, phase: custom.Phase}
+	return p
+}
+
+func (p *reencFactory) Start() error {
+	p.xctn = newXactBckReencode(p.Bck, p.T, p.UUID())
+	return nil
+}
+
+func (*reencFactory) Kind() string        { return apc.ActECReencode }
+func (p *reencFactory) Get() cluster.Xact { return p.xctn }
+
+func (p *reencFactory) WhenPrevIsRunning(prevEntry xreg.Renewable) (wpr xreg.WPR, err error) {
+	prev := prevEntry.(*reencFactory)
+	if prev.phase == apc.ActBegin && p.phase == apc.ActCommit {
+		prev.phase = apc.ActCommit // transition
+		wpr = xreg.WprUse
+		return
+	}
+	err = fmt.Errorf("%s(%s, phase %s): cannot %s", p.Kind(), prev.xctn.Bck().Name, prev.phase, p.phase)
+	return
+}
+
+/////////////////////
+// XactBckReencode //
+/////////////////////
+
+func newXactBckReencode(bck *cluster.Bck, t cluster.Target, uuid string) (r *XactBckReencode) {
+	r = &XactBckReencode{t: t, bck: bck, wg: &sync.WaitGroup{}, smap: t.Sowner().Get()}
+	r.InitBase(uuid, apc.ActECReencode, bck)
+	return
+}
+
+func (r *XactBckReencode) Run(wg *sync.WaitGroup) {
+	wg.Done()
+	bck := r.bck
+	if err := bck.Init(r.t.Bowner()); err != nil {
+		r.Finish(err)
+		return
+	}
+	if !bck.Props.EC.Enabled {
+		r.Finish(fmt.Errorf("bucket %q does not have EC enabled", r.bck.Name))
+		return
+	}
+
+	opts := &mpather.JoggerGroupOpts{
+		T:        r.t,
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.bckReencode,
+		DoLoad:   mpather.Load,
+		Prio:     xact.Prio(apc.ActECReencode),
+	}
+	opts.Bck.Copy(r.bck.Bucket())
+	jg := mpather.NewJoggerGroup(opts)
+	jg.Run()
+
+	var err error
+	select {
+	case errCause := <-r.ChanAbort():
+		jg.Stop()
+		err = cmn.NewErrAborted(r.Name(), "", errCause)
+	case <-jg.ListenFinished():
+		err = jg.Stop()
+	}
+	r.wg.Wait() // wait for all async actions to finish
+
+	if n := r.errs.Load(); n > 0 {
+		glog.Errorf("%s: failed to re-encode %d object%s", r, n, cos.Plural(int(n)))
+	}
+	r.Finish(err)
+}
+
+func (r *XactBckReencode) Snap() cluster.XactSnap {
+	snap := &xact.SnapExt{Ext: &ExtECReencodeStats{Errs: r.errs.Load()}}
+	r.ToSnap(&snap.Snap)
+	return snap
+}
+
+func (r *XactBckReencode) bckReencode(lom *cluster.LOM, _ []byte) error {
+	_, local, err := lom.HrwTarget(r.smap)
+	if err != nil {
+		glog.Errorf("%s: %s", lom, err)
+		return nil
+	}
+	// not the main replica - skip
+	if !local {
+		return nil
+	}
+	var (
+		ecConf = lom.Bprops().EC
+		ctMeta = cluster.NewCTFromLOM(lom, fs.ECMetaType)
+		prev   []string
+	)
+	md, err := LoadMetadata(ctMeta.FQN())
+	switch {
+	case err == nil:
+		if md.Data == ecConf.DataSlices && md.Parity == ecConf.ParitySlices {
+			return nil // already (re)encoded
+		}
+		prev = make([]string, 0, len(md.Daemons))
+		for tid := range md.Daemons {
+			if tid != r.t.SID() {
+				prev = append(prev, tid)
+			}
+		}
+	case os.IsNotExist(err):
+		// not erasure coded yet (e.g., put while EC was being enabled) - encode with the new layout
+	default:
+		glog.Warningf("%s: failed to load %s metadata: %v", r, lom, err)
+		return nil
+	}
+
+	if err = r.Reserve(lom.MpathInfo(), lom.SizeBytes()); err != nil {
+		return err
+	}
+	r.wg.Add(1)
+	cb := func(lom *cluster.LOM, err error) { r.afterReencode(lom, prev, err) }
+	if err = ECM.ReencodeObject(lom, cb); err != nil {
+		r.afterReencode(lom, nil, err) // (counted - keep going)
+	}
+	return nil
+}
+
+// upon success, ask the targets that are no longer part of the object's layout
+// to remove their (previous generation) slices and metafiles
+func (r *XactBckReencode) afterReencode(lom *cluster.LOM, prev []string, err error) {
+	defer r.wg.Done()
+	if err != nil {
+		if err != errSkipped {
+			r.errs.Inc()
+			glog.Errorf("%s: failed to re-encode %s: %v", r, lom, err)
+		}
+		return
+	}
+	r.LomAdd(lom)
+	if len(prev) == 0 {
+		return
+	}
+	ctMeta := cluster.NewCTFromLOM(lom, fs.ECMetaType)
+	md, err := LoadMetadata(ctMeta.FQN())
+	if err != nil {
+		glog.Errorf("%s: failed to load %s metadata: %v", r, lom, err)
+		return
+	}
+	var (
+		smap  = r.t.Sowner().Get()
+		nodes = make([]*cluster.Snode, 0, len(prev))
+	)
+	for _, tid := range prev {
+		if _, ok := md.Daemons[tid]; ok {
+			continue // new slice (replica) has overwritten the old one
+		}
+		if tsi := smap.GetTarget(tid); tsi != nil {
+			nodes = append(nodes, tsi)
+		}
+	}
+	if len(nodes) == 0 {
+		return
+	}
+	mm := r.t.ByteMM()
+	request := newIntraReq(reqDel, nil, lom.Bck()).NewPack(mm)
+	o := transport.AllocSend()
+	o.Hdr = transport.ObjHdr{ObjName: lom.ObjName, Opaque: request, Opcode: reqDel}
+	o.Hdr.Bck.Copy(lom.Bucket())
+	o.Callback = r.delSentCallback
+	r.wg.Add(1)
+	if err := ECM.req().Send(o, nil, nodes...); err != nil {
+		glog.Errorf("%s: failed to cleanup %s: %v", r, lom, err)
+	}
+}
+
+func (r *XactBckReencode) delSentCallback(hdr transport.ObjHdr, _ io.ReadCloser, _ any, err error) {
+	r.t.ByteMM().Free(hdr.Opaque)
+	if err != nil {
+		glog.Errorf("%s: failed to send o[%s]: %v", r, hdr.FullName(), err)
+	}
+	r.wg.Done()
+}
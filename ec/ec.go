@@ -130,10 +130,11 @@ type (
 		ErrCh    chan error  // for final EC result (used only in restore)
 		Callback cluster.OnFinishObj
 
-		putTime time.Time // time when the object is put into main queue
-		tm      time.Time // to measure different steps
-		IsCopy  bool      // replicate or use erasure coding
-		rebuild bool      // true - internal request to reencode, e.g., from ec-encode xaction
+		putTime  time.Time // time when the object is put into main queue
+		tm       time.Time // to measure different steps
+		IsCopy   bool      // replicate or use erasure coding
+		rebuild  bool      // true - internal request to reencode, e.g., from ec-encode xaction
+		reencode bool      // ec-reencode: keep the current generation until the new one is complete (see pendingCTs)
 	}
 
 	RequestsControlMsg struct {
@@ -279,6 +280,8 @@ func Init(t cluster.Target) {
 	xreg.RegBckXact(&putFactory{})
 	xreg.RegBckXact(&rspFactory{})
 	xreg.RegBckXact(&encFactory{})
+	xreg.RegBckXact(&reencFactory{})
+	xreg.RegBckXact(&scrubFactory{})
 
 	if err := initManager(t); err != nil {
 		cos.ExitLogf("Failed to init manager: %v", err)
@@ -319,6 +322,11 @@ func useDisk(objSize int64) bool {
 	}
 }
 
+// fields to attach to EC log lines in structured (JSON) logging mode (see glog.Fields)
+func logFields(xid string, lom *cluster.LOM) glog.Fields {
+	return glog.Fields{Comp: cmn.LogCompEC, Xid: xid, Bck: lom.Bucket().String(), Obj: lom.ObjName}
+}
+
 // Frees allocated memory if it is SGL or closes the file handle if regular file
 func freeObject(r any) {
 	if r == nil {
@@ -191,7 +191,7 @@ func (c *getJogger) copyMissingReplicas(ctx *restoreCtx, reader cos.ReadOpenClos
 	// Reason: memsys.Reader does not provide access to internal memsys.SGL that must be freed
 	cb := func(hdr transport.ObjHdr, _ io.ReadCloser, _ any, err error) {
 		if err != nil {
-			glog.Errorf("%s failed to send %s to %v: %v", c.parent.t, ctx.lom, daemons, err)
+			logFields(c.parent.ID(), ctx.lom).Errorf("%s failed to send %s to %v: %v", c.parent.t, ctx.lom, daemons, err)
 		}
 		freeObject(reader)
 	}
@@ -403,7 +403,7 @@ func (c *getJogger) requestSlices(ctx *restoreCtx) error {
 	}
 	conf := cmn.GCO.Get()
 	if wgSlices.WaitTimeout(conf.Timeout.SendFile.D()) {
-		glog.Errorf("%s timed out waiting for %s slices", c.parent.t, ctx.lom)
+		logFields(c.parent.ID(), ctx.lom).Errorf("%s timed out waiting for %s slices", c.parent.t, ctx.lom)
 	}
 	mm.Free(request)
 	return nil
@@ -764,7 +764,7 @@ func (c *getJogger) restoreEncoded(ctx *restoreCtx) error {
 	// Restore and save locally the main replica
 	restored, err := c.restoreMainObj(ctx)
 	if err != nil {
-		glog.Errorf("%s failed to restore main object %s: %v", c.parent.t, ctx.lom, err)
+		logFields(c.parent.ID(), ctx.lom).Errorf("%s failed to restore main object %s: %v", c.parent.t, ctx.lom, err)
 		c.freeDownloaded(ctx)
 		freeSlices(restored)
 		return err
@@ -774,9 +774,9 @@ func (c *getJogger) restoreEncoded(ctx *restoreCtx) error {
 
 	// main replica is ready to download by a client.
 	if err := c.uploadRestoredSlices(ctx, restored); err != nil {
-		glog.Errorf("Failed to upload restored slices of %s: %v", ctx.lom, err)
+		logFields(c.parent.ID(), ctx.lom).Errorf("Failed to upload restored slices of %s: %v", ctx.lom, err)
 	} else if glog.FastV(4, glog.SmoduleEC) {
-		glog.Infof("Slices %s restored successfully", ctx.lom)
+		logFields(c.parent.ID(), ctx.lom).Infof("Slices %s restored successfully", ctx.lom)
 	}
 
 	c.freeDownloaded(ctx)
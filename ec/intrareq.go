@@ -23,6 +23,15 @@ const (
 	// a target cleans up the object and notifies all other targets to do
 	// cleanup as well. Destinations do not have to respond
 	reqDel
+	// (re-encoding) same as reqPut except that the slice and its metafile are kept aside
+	// until the sender commits the new generation - see pendingCTs
+	reqPutPending
+	// (re-encoding) all the targets have stored the slices of the new generation
+	reqCommit
+	// (re-encoding) response to reqPutPending: Exists=true if the slice has been stored
+	respPending
+	// (re-encoding) the new generation failed: drop its pending slices
+	reqRollback
 )
 
 type (
@@ -19,6 +19,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/transport/bundle"
 	"github.com/NVIDIA/aistore/xact/xreg"
@@ -39,6 +40,9 @@ type Manager struct {
 	netResp       string      // network used to send/receive slices
 	reqBundle     atomic.Pointer
 	respBundle    atomic.Pointer
+
+	pending pendingCTs  // re-encoding: slices of the new generations (until committed)
+	acks    pendingAcks // re-encoding: sender waits for all targets to store the new generation
 }
 
 var (
@@ -61,6 +65,7 @@ func initManager(t cluster.Target) error {
 		bmd:       t.Bowner().Get(),
 		xacts:     make(map[string]*BckXacts),
 	}
+	hk.Reg("ec-pending"+hk.NameSuffix, ECM.pending.housekeep, pendingCTTimeout/2)
 
 	if ECM.bmd.IsECUsed() {
 		return ECM.initECBundles()
@@ -258,8 +263,14 @@ func (mgr *Manager) recvResponse(hdr transport.ObjHdr, object io.Reader, err err
 		}
 	}
 	switch hdr.Opcode {
-	case reqPut:
+	case reqPut, reqPutPending, reqCommit, reqRollback:
 		mgr.RestoreBckRespXact(bck).DispatchResp(iReq, &hdr, object)
+	case respPending:
+		if iReq.meta == nil {
+			glog.Errorf("%s: no metadata for %s", mgr.t, hdr.FullName())
+			return nil
+		}
+		mgr.acks.ack(hdr.Bck.MakeUname(hdr.ObjName), hdr.SID, iReq.meta.Generation, iReq.exists)
 	case respPut:
 		// Process the request even if the number of targets is insufficient
 		// (might've started when we had enough)
@@ -275,6 +286,16 @@ func (mgr *Manager) recvResponse(hdr transport.ObjHdr, object io.Reader, err err
 //   - intra - if true, it is internal request and has low priority
 //   - cb - optional callback that is called after the object is encoded
 func (mgr *Manager) EncodeObject(lom *cluster.LOM, cb ...cluster.OnFinishObj) error {
+	return mgr.encodeObject(lom, false /*reencode*/, cb...)
+}
+
+// ReencodeObject is EncodeObject that keeps the object's current slices (if any) intact
+// until all the slices of the new generation are in place (see pendingCTs)
+func (mgr *Manager) ReencodeObject(lom *cluster.LOM, cb cluster.OnFinishObj) error {
+	return mgr.encodeObject(lom, true /*reencode*/, cb)
+}
+
+func (mgr *Manager) encodeObject(lom *cluster.LOM, reencode bool, cb ...cluster.OnFinishObj) error {
 	if !lom.Bprops().EC.Enabled {
 		return ErrorECDisabled
 	}
@@ -297,6 +318,7 @@ func (mgr *Manager) EncodeObject(lom *cluster.LOM, cb ...cluster.OnFinishObj) er
 
 	req := allocateReq(ActSplit, lom.LIF())
 	req.IsCopy = IsECCopy(lom.SizeBytes(), &lom.Bprops().EC)
+	req.reencode = reencode
 	if len(cb) != 0 {
 		req.rebuild = true
 		req.Callback = cb[0]
@@ -0,0 +1,227 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/transport"
+)
+
+// Re-encoding (see XactBckReencode) keeps the current generation of the object's slices intact
+// until the new one is complete: the slices (and metafiles) of the new generation are received
+// into workfiles (reqPutPending), and each receiver acknowledges (respPending) that it has stored them.
+// Only when all the targets have acknowledged does the sender commit the new generation (reqCommit) and
+// switch its own metafile; otherwise, it rolls the new generation back (reqRollback).
+
+// uncommitted (e.g., the sender failed) slices get removed after a while - see housekeep
+const pendingCTTimeout = 10 * time.Minute
+
+type (
+	pendingCTs struct {
+		m  map[string]*pendingCT // uname => slice of the new generation
+		mu sync.Mutex
+	}
+	pendingCT struct {
+		sliceFQN   string // workfile
+		metaFQN    string // ditto
+		generation int64
+		added      int64 // mono time
+	}
+
+	// sender side: acks from the targets that received the slices of the new generation
+	pendingAcks struct {
+		m  map[string]*pendingAck // uname => acks
+		mu sync.Mutex
+	}
+	pendingAck struct {
+		waiting    cos.StrSet // targets yet to ack
+		failed     []string
+		done       chan struct{}
+		generation int64
+	}
+)
+
+func (pcs *pendingCTs) put(t cluster.Target, hdr *transport.ObjHdr, object io.Reader, md []byte, generation int64) error {
+	ct, err := cluster.NewCTFromBO(&hdr.Bck, hdr.ObjName, t.Bowner(), fs.ECSliceType)
+	if err != nil {
+		return err
+	}
+	pct := &pendingCT{
+		sliceFQN:   ct.Make(fs.WorkfileType, "ec-gen"),
+		metaFQN:    ct.Clone(fs.ECMetaType).Make(fs.WorkfileType, "ec-gen"),
+		generation: generation,
+		added:      mono.NanoTime(),
+	}
+	buf, slab := t.PageMM().Alloc()
+	_, err = cos.SaveReader(pct.sliceFQN, object, buf, cos.ChecksumNone, hdr.ObjAttrs.Size, "")
+	if err == nil {
+		_, err = cos.SaveReader(pct.metaFQN, bytes.NewReader(md), buf, cos.ChecksumNone, -1, "")
+	}
+	slab.Free(buf)
+	if err != nil {
+		pct.remove()
+		return err
+	}
+
+	uname := hdr.Bck.MakeUname(hdr.ObjName)
+	pcs.mu.Lock()
+	if pcs.m == nil {
+		pcs.m = make(map[string]*pendingCT, 64)
+	}
+	if prev, ok := pcs.m[uname]; ok {
+		prev.remove()
+	}
+	pcs.m[uname] = pct
+	pcs.mu.Unlock()
+	return nil
+}
+
+// drop the pending slice of the (failed to commit) generation
+func (pcs *pendingCTs) rollback(hdr *transport.ObjHdr, generation int64) {
+	uname := hdr.Bck.MakeUname(hdr.ObjName)
+	pcs.mu.Lock()
+	pct, ok := pcs.m[uname]
+	if ok && pct.generation == generation {
+		delete(pcs.m, uname)
+	}
+	pcs.mu.Unlock()
+	if ok && pct.generation == generation {
+		pct.remove()
+	}
+}
+
+// removes uncommitted slices (hk callback)
+func (pcs *pendingCTs) housekeep() time.Duration {
+	now := mono.NanoTime()
+	pcs.mu.Lock()
+	for uname, pct := range pcs.m {
+		if time.Duration(now-pct.added) > pendingCTTimeout {
+			glog.Warningf("removing uncommitted slice %s (generation %d)", pct.sliceFQN, pct.generation)
+			pct.remove()
+			delete(pcs.m, uname)
+		}
+	}
+	pcs.mu.Unlock()
+	return pendingCTTimeout / 2
+}
+
+// replace the current slice and metafile with the pending ones (metafile last)
+func (pcs *pendingCTs) commit(t cluster.Target, hdr *transport.ObjHdr, generation int64) error {
+	uname := hdr.Bck.MakeUname(hdr.ObjName)
+	pcs.mu.Lock()
+	pct, ok := pcs.m[uname]
+	if ok && pct.generation == generation {
+		delete(pcs.m, uname)
+	}
+	pcs.mu.Unlock()
+	if !ok || pct.generation != generation {
+		return fmt.Errorf("%s: no pending slice %s (generation %d)", t, hdr.FullName(), generation)
+	}
+
+	ct, err := cluster.NewCTFromBO(&hdr.Bck, hdr.ObjName, t.Bowner(), fs.ECSliceType)
+	if err != nil {
+		pct.remove()
+		return err
+	}
+	ctMeta := ct.Clone(fs.ECMetaType)
+	ct.Lock(true)
+	defer ct.Unlock(true)
+	if md, err := LoadMetadata(ctMeta.FQN()); err == nil && md.Generation > generation {
+		pct.remove() // (superseded)
+		return nil
+	}
+	if err := cos.Rename(pct.sliceFQN, ct.FQN()); err != nil {
+		pct.remove()
+		return err
+	}
+	return cos.Rename(pct.metaFQN, ctMeta.FQN())
+}
+
+func (pct *pendingCT) remove() {
+	for _, fqn := range []string{pct.sliceFQN, pct.metaFQN} {
+		if err := cos.RemoveFile(fqn); err != nil {
+			glog.Errorf("failed to remove %s: %v", fqn, err)
+		}
+	}
+}
+
+/////////////////
+// pendingAcks //
+/////////////////
+
+// must be called before sending the slices
+func (pas *pendingAcks) reg(uname string, generation int64, tids []string) *pendingAck {
+	pa := &pendingAck{
+		waiting:    cos.NewStrSet(tids...),
+		done:       make(chan struct{}),
+		generation: generation,
+	}
+	pas.mu.Lock()
+	if pas.m == nil {
+		pas.m = make(map[string]*pendingAck, 16)
+	}
+	pas.m[uname] = pa
+	pas.mu.Unlock()
+	return pa
+}
+
+func (pas *pendingAcks) unreg(uname string) {
+	pas.mu.Lock()
+	delete(pas.m, uname)
+	pas.mu.Unlock()
+}
+
+func (pas *pendingAcks) ack(uname, tid string, generation int64, ok bool) {
+	pas.mu.Lock()
+	defer pas.mu.Unlock()
+	pa, exists := pas.m[uname]
+	if !exists || pa.generation != generation {
+		return // (late or stray)
+	}
+	if !pa.waiting.Contains(tid) {
+		return
+	}
+	delete(pa.waiting, tid)
+	if !ok {
+		pa.failed = append(pa.failed, tid)
+	}
+	if len(pa.waiting) == 0 {
+		close(pa.done)
+	}
+}
+
+// waits for all the targets to ack; returns error if any of them failed or didn't respond in time
+func (pas *pendingAcks) wait(uname string, pa *pendingAck, timeout time.Duration, abortCh <-chan error) (err error) {
+	timer := time.NewTimer(timeout)
+	select {
+	case <-pa.done:
+	case <-timer.C:
+	case errCause := <-abortCh:
+		err = cmn.NewErrAborted(uname, "wait-acks", errCause)
+	}
+	timer.Stop()
+	pas.mu.Lock()
+	delete(pas.m, uname)
+	switch {
+	case err != nil:
+	case len(pa.failed) > 0:
+		err = fmt.Errorf("%s (generation %d): targets %v failed to store pending slices", uname, pa.generation, pa.failed)
+	case len(pa.waiting) > 0:
+		err = fmt.Errorf("%s (generation %d): timed out waiting for targets %v", uname, pa.generation, pa.waiting.ToSlice())
+	}
+	pas.mu.Unlock()
+	return
+}
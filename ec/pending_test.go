@@ -0,0 +1,180 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools"
+	"github.com/NVIDIA/aistore/transport"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ECPendingCTs", func() {
+	const (
+		testDir = "/tmp/ec-pending-test_q/"
+
+		testBucketName = "TEST_EC_PENDING_BUCKET"
+		mpath          = testDir + "ecpendingtest_mpath/111"
+
+		testObjectName = "ecpendingtestobj.ext"
+		curSize        = 100
+		newSize        = 200
+	)
+
+	var (
+		props = &cmn.BucketProps{
+			EC:  cmn.ECConf{Enabled: true, DataSlices: 2, ParitySlices: 1},
+			BID: 1,
+		}
+		bck     = cluster.Bck{Name: testBucketName, Provider: apc.AIS, Ns: cmn.NsGlobal, Props: props}
+		bmdMock = mock.NewBaseBownerMock(&bck)
+		mi      = fs.MountpathInfo{Path: mpath}
+		tMock   cluster.Target
+		pcs     *pendingCTs
+
+		sliceFQN = mi.MakePathFQN(bck.Bucket(), fs.ECSliceType, testObjectName)
+		metaFQN  = mi.MakePathFQN(bck.Bucket(), fs.ECMetaType, testObjectName)
+		hdr      = &transport.ObjHdr{Bck: *bck.Bucket(), ObjName: testObjectName}
+	)
+
+	BeforeEach(func() {
+		tools.InitTestFS(mpath)
+		tMock = mock.NewTarget(bmdMock)
+		pcs = &pendingCTs{}
+
+		// current generation
+		tools.CreateTestFile(filepath.Dir(sliceFQN), testObjectName, curSize)
+		md := &Metadata{MDVersion: MDVersionLast, Size: curSize, Data: 2, Parity: 1, SliceID: 1, Generation: 1}
+		Expect(cos.CreateDir(filepath.Dir(metaFQN))).NotTo(HaveOccurred())
+		Expect(os.WriteFile(metaFQN, md.NewPack(), cos.PermRWR)).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(testDir)
+	})
+
+	putPending := func(generation int64) {
+		md := &Metadata{MDVersion: MDVersionLast, Size: newSize, Data: 3, Parity: 1, SliceID: 2, Generation: generation}
+		hdr.ObjAttrs.Size = newSize
+		err := pcs.put(tMock, hdr, bytes.NewReader(make([]byte, newSize)), md.NewPack(), generation)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	expectGeneration := func(generation, size int64) {
+		md, err := LoadMetadata(metaFQN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(md.Generation).To(Equal(generation))
+		finfo, err := os.Stat(sliceFQN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(finfo.Size()).To(BeEquivalentTo(size))
+	}
+
+	It("should keep current slice until committed", func() {
+		putPending(2)
+		expectGeneration(1, curSize)
+
+		Expect(pcs.commit(tMock, hdr, 2)).NotTo(HaveOccurred())
+		expectGeneration(2, newSize)
+		Expect(pcs.m).To(BeEmpty())
+	})
+
+	It("should not commit another generation", func() {
+		putPending(2)
+		Expect(pcs.commit(tMock, hdr, 3)).To(HaveOccurred())
+		expectGeneration(1, curSize)
+
+		// and the pending one is still there
+		Expect(pcs.commit(tMock, hdr, 2)).NotTo(HaveOccurred())
+		expectGeneration(2, newSize)
+	})
+
+	It("should replace pending slice of previous attempt", func() {
+		putPending(2)
+		putPending(3)
+		Expect(pcs.commit(tMock, hdr, 2)).To(HaveOccurred())
+		Expect(pcs.commit(tMock, hdr, 3)).NotTo(HaveOccurred())
+		expectGeneration(3, newSize)
+	})
+
+	It("should not overwrite newer generation", func() {
+		putPending(2)
+		md := &Metadata{MDVersion: MDVersionLast, Size: curSize, Data: 2, Parity: 1, SliceID: 1, Generation: 5}
+		Expect(os.WriteFile(metaFQN, md.NewPack(), cos.PermRWR)).NotTo(HaveOccurred())
+
+		Expect(pcs.commit(tMock, hdr, 2)).NotTo(HaveOccurred())
+		expectGeneration(5, curSize)
+	})
+
+	It("should roll back pending slice", func() {
+		putPending(2)
+		pcs.rollback(hdr, 3)
+		Expect(pcs.m).To(HaveLen(1))
+
+		pcs.rollback(hdr, 2)
+		Expect(pcs.m).To(BeEmpty())
+		Expect(pcs.commit(tMock, hdr, 2)).To(HaveOccurred())
+		expectGeneration(1, curSize)
+	})
+
+	It("should remove expired pending slices", func() {
+		putPending(2)
+		pcs.housekeep()
+		Expect(pcs.m).To(HaveLen(1))
+
+		for _, pct := range pcs.m {
+			pct.added -= int64(pendingCTTimeout + time.Second)
+		}
+		pcs.housekeep()
+		Expect(pcs.m).To(BeEmpty())
+		expectGeneration(1, curSize)
+	})
+})
+
+var _ = Describe("ECPendingAcks", func() {
+	const (
+		uname   = "uname"
+		timeout = 100 * time.Millisecond
+	)
+	var pas *pendingAcks
+
+	BeforeEach(func() {
+		pas = &pendingAcks{}
+	})
+
+	It("should succeed when all targets ack", func() {
+		pa := pas.reg(uname, 2, []string{"t1", "t2"})
+		pas.ack(uname, "t1", 2, true)
+		pas.ack(uname, "t3", 2, true) // (stray)
+		pas.ack(uname, "t2", 1, true) // (another generation)
+		pas.ack(uname, "t2", 2, true)
+		Expect(pas.wait(uname, pa, timeout, nil)).NotTo(HaveOccurred())
+		Expect(pas.m).To(BeEmpty())
+	})
+
+	It("should fail when any target fails", func() {
+		pa := pas.reg(uname, 2, []string{"t1", "t2"})
+		pas.ack(uname, "t1", 2, true)
+		pas.ack(uname, "t2", 2, false)
+		Expect(pas.wait(uname, pa, timeout, nil)).To(HaveOccurred())
+	})
+
+	It("should time out when any target does not ack", func() {
+		pa := pas.reg(uname, 2, []string{"t1", "t2"})
+		pas.ack(uname, "t1", 2, true)
+		Expect(pas.wait(uname, pa, timeout, nil)).To(HaveOccurred())
+		Expect(pas.m).To(BeEmpty())
+	})
+})
@@ -16,6 +16,7 @@ import (
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/mono"
@@ -37,6 +38,7 @@ type (
 		cksums       []*cos.CksumHash // checksums of parity slices (filled by reed-solomon)
 		slices       []*slice         // all EC slices (in the order of slice IDs)
 		targets      []*cluster.Snode // target list (in the order of slice IDs: targets[i] receives slices[i])
+		reencode     bool             // see request.reencode
 	}
 
 	// a mountpath putJogger: processes PUT/DEL requests to one mountpath
@@ -131,7 +133,7 @@ func (c *putJogger) processRequest(req *request) {
 	c.parent.stats.updateWaitTime(time.Since(req.tm))
 	req.tm = time.Now()
 	if err = c.ec(req, lom); err != nil {
-		glog.Errorf("Failed to %s object %s (fqn: %q, err: %v)", req.Action, lom, lom.FQN, err)
+		logFields(c.parent.ID(), lom).Errorf("Failed to %s object %s (fqn: %q, err: %v)", req.Action, lom, lom.FQN, err)
 	}
 }
 
@@ -162,7 +164,7 @@ func (c *putJogger) stop() {
 func (c *putJogger) ec(req *request, lom *cluster.LOM) (err error) {
 	switch req.Action {
 	case ActSplit:
-		if err = c.encode(req, lom); err != nil {
+		if err = c.encode(req, lom); err != nil && !req.reencode {
 			ctMeta := cluster.NewCTFromLOM(lom, fs.ECMetaType)
 			errRm := cos.RemoveFile(ctMeta.FQN())
 			debug.AssertNoErr(errRm)
@@ -185,7 +187,9 @@ func (c *putJogger) replicate(ctx *encodeCtx) error {
 	err := c.createCopies(ctx)
 	if err != nil {
 		ctx.freeReplica()
-		c.cleanup(ctx.lom)
+		if !ctx.reencode {
+			c.cleanup(ctx.lom)
+		}
 	}
 	return err
 }
@@ -193,6 +197,9 @@ func (c *putJogger) replicate(ctx *encodeCtx) error {
 func (c *putJogger) splitAndDistribute(ctx *encodeCtx) error {
 	err := initializeSlices(ctx)
 	if err == nil {
+		if ctx.reencode {
+			return c.sendPending(ctx)
+		}
 		err = c.sendSlices(ctx)
 	}
 	if err != nil {
@@ -200,11 +207,59 @@ func (c *putJogger) splitAndDistribute(ctx *encodeCtx) error {
 		if err != errSliceSendFailed {
 			freeSlices(ctx.slices)
 		}
-		c.cleanup(ctx.lom)
+		if !ctx.reencode { // (the current generation stays intact)
+			c.cleanup(ctx.lom)
+		}
 	}
 	return err
 }
 
+// re-encoding: send the slices of the new generation and wait for all their targets to store them;
+// commit the new generation only if they all did - otherwise, roll it back
+// (either way, the current generation stays intact until committed)
+func (c *putJogger) sendPending(ctx *encodeCtx) error {
+	var (
+		uname = ctx.lom.Uname()
+		nodes = make([]string, 0, len(ctx.targets))
+		acks  = &c.parent.mgr.acks
+	)
+	for _, tgt := range ctx.targets {
+		nodes = append(nodes, tgt.ID())
+	}
+	pa := acks.reg(uname, ctx.meta.Generation, nodes)
+	err := c.sendSlices(ctx)
+	if err != nil {
+		acks.unreg(uname)
+		ctx.freeReplica()
+		if err != errSliceSendFailed {
+			freeSlices(ctx.slices)
+		}
+	} else {
+		// (the slices are now owned by their send callbacks)
+		err = acks.wait(uname, pa, cmn.GCO.Get().Timeout.SendFile.D(), c.parent.ChanAbort())
+	}
+	if err == nil {
+		return c.sendGen(ctx, nodes, reqCommit)
+	}
+	if errRb := c.sendGen(ctx, nodes, reqRollback); errRb != nil {
+		glog.Error(errRb)
+	}
+	return err
+}
+
+// (the same streams deliver the commit or rollback after the slices)
+func (c *putJogger) sendGen(ctx *encodeCtx, nodes []string, act intraReqType) error {
+	mm := c.parent.t.ByteMM()
+	hdr := transport.ObjHdr{
+		ObjName: ctx.lom.ObjName,
+		Opaque:  newIntraReq(act, ctx.meta, ctx.lom.Bck()).NewPack(mm),
+		Opcode:  act,
+	}
+	hdr.Bck.Copy(ctx.lom.Bucket())
+	c.parent.IncPending()
+	return c.parent.sendByDaemonID(nodes, hdr, nil, c.ctSendCallback, false /*isRequest*/)
+}
+
 // calculates and stores data and parity slices
 func (c *putJogger) encode(req *request, lom *cluster.LOM) error {
 	var (
@@ -249,6 +304,7 @@ func (c *putJogger) encode(req *request, lom *cluster.LOM) error {
 	if err != nil {
 		return err
 	}
+	ctx.reencode = req.reencode
 
 	targets, err := cluster.HrwTargetList(ctx.lom.Uname(), c.parent.smap.Get(), reqTargets)
 	if err != nil {
@@ -491,6 +547,9 @@ func (c *putJogger) sendSlice(ctx *encodeCtx, data *slice, node *cluster.Snode,
 		isSlice:  true,
 		reqType:  reqPut,
 	}
+	if ctx.reencode {
+		src.reqType = reqPutPending
+	}
 	sentCB := func(hdr transport.ObjHdr, _ io.ReadCloser, _ any, err error) {
 		if data != nil {
 			data.release()
@@ -536,11 +595,11 @@ func (c *putJogger) sendSlices(ctx *encodeCtx) (err error) {
 	}
 
 	if copyErr != nil {
-		glog.Errorf("Error while copying (data=%d, parity=%d) for %q: %v",
+		logFields(c.parent.ID(), ctx.lom).Errorf("Error while copying (data=%d, parity=%d) for %q: %v",
 			ctx.dataSlices, ctx.paritySlices, ctx.lom.ObjName, copyErr)
 		err = errSliceSendFailed
 	} else if glog.FastV(4, glog.SmoduleEC) {
-		glog.Infof("EC created (data=%d, parity=%d) for %q",
+		logFields(c.parent.ID(), ctx.lom).Infof("EC created (data=%d, parity=%d) for %q",
 			ctx.dataSlices, ctx.paritySlices, ctx.lom.ObjName)
 	}
 
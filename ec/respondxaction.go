@@ -187,7 +187,7 @@ func (r *XactRespond) DispatchResp(iReq intraReq, hdr *transport.ObjHdr, object
 	r.IncPending()
 	defer r.DecPending() // no async operation, so DecPending is deferred
 	switch hdr.Opcode {
-	case reqPut:
+	case reqPut, reqPutPending:
 		// a remote target sent a replica/slice while it was
 		// encoding or restoring an object. In this case it just saves
 		// the sent replica or slice to a local file along with its metadata
@@ -207,10 +207,14 @@ func (r *XactRespond) DispatchResp(iReq intraReq, hdr *transport.ObjHdr, object
 				iReq.meta.SliceID, hdr.FullName(), meta.ObjVersion, meta.CksumValue)
 		}
 		md := meta.NewPack()
-		if iReq.isSlice {
+		switch {
+		case iReq.isSlice && hdr.Opcode == reqPutPending:
+			err = r.mgr.pending.put(r.t, hdr, object, md, meta.Generation)
+			r.ackPending(hdr, meta.Generation, err == nil)
+		case iReq.isSlice:
 			args := &WriteArgs{Reader: object, MD: md, BID: iReq.bid, Generation: meta.Generation, Xact: r}
 			err = WriteSliceAndMeta(r.t, hdr, args)
-		} else {
+		default:
 			var lom *cluster.LOM
 			lom, err = cluster.AllocLomFromHdr(hdr)
 			if err == nil {
@@ -231,12 +235,46 @@ func (r *XactRespond) DispatchResp(iReq intraReq, hdr *transport.ObjHdr, object
 			return
 		}
 		r.ObjsAdd(1, hdr.ObjAttrs.Size)
+	case reqCommit:
+		if iReq.meta == nil {
+			glog.Errorf("%s: no metadata for %s", r.t, hdr.FullName())
+			return
+		}
+		if err := r.mgr.pending.commit(r.t, hdr, iReq.meta.Generation); err != nil {
+			glog.Error(err)
+		}
+	case reqRollback:
+		if iReq.meta == nil {
+			glog.Errorf("%s: no metadata for %s", r.t, hdr.FullName())
+			return
+		}
+		r.mgr.pending.rollback(hdr, iReq.meta.Generation)
 	default:
 		// should be unreachable
 		glog.Errorf("Invalid request type: %d", hdr.Opcode)
 	}
 }
 
+// tell the sender whether the slice of the new generation has been stored (see pendingAcks)
+func (r *XactRespond) ackPending(hdr *transport.ObjHdr, generation int64, ok bool) {
+	ireq := newIntraReq(respPending, &Metadata{Generation: generation}, nil)
+	ireq.exists = ok
+	rHdr := transport.ObjHdr{ObjName: hdr.ObjName, Opcode: respPending}
+	rHdr.Bck.Copy(&hdr.Bck)
+	rHdr.Opaque = ireq.NewPack(r.t.ByteMM())
+	r.IncPending()
+	cb := func(hdr transport.ObjHdr, _ io.ReadCloser, _ any, err error) {
+		r.t.ByteMM().Free(hdr.Opaque)
+		if err != nil {
+			glog.Errorf("Failed to ack %s: %v", hdr.FullName(), err)
+		}
+		r.DecPending()
+	}
+	if err := r.sendByDaemonID([]string{hdr.SID}, rHdr, nil, cb, false); err != nil {
+		glog.Error(err)
+	}
+}
+
 func (r *XactRespond) Stop(err error) { r.Abort(err) }
 
 func (r *XactRespond) stop(err error) {
@@ -0,0 +1,515 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// EC scrub: every target walks the bucket's objects for which it is the "main" target
+// (the one that holds the full replica and has encoded the object) and checks:
+// - the object's own content checksum;
+// - the object's metafile (exists, can be parsed, and describes the current version of the object);
+// - the metafiles of all the other targets that hold the object's slices (or replicas);
+//   the targets in question verify their respective slices (replicas) against their metafiles.
+// Repair:
+// - corrupted (remote) slices and replicas are removed by the targets that hold them;
+// - given intact main replica, the object gets re-encoded (which re-creates all its
+//   slices, parity included) when anything is missing, corrupted, or inconsistent;
+// - otherwise, the main replica gets restored from the remaining data and parity slices
+//   which also re-creates the missing ones; the corrupted replica is kept aside until then.
+// The walk also visits metafiles, to find the objects whose main replicas have gone missing.
+// Throttling: the joggers yield to higher-priority I/O every so many objects and charge
+// the objects' sizes to the respective mountpaths' budgets (see mpather.JoggerGroupOpts.Prio).
+
+type (
+	scrubFactory struct {
+		xreg.RenewBase
+		xctn *XactECScrub
+	}
+	XactECScrub struct {
+		xact.Base
+		t      cluster.Target
+		bck    *cluster.Bck
+		smap   *cluster.Smap
+		config *cmn.Config
+		client *http.Client
+		wg     sync.WaitGroup // pending (async) re-encodes
+		stats  scrubStats
+	}
+	scrubStats struct {
+		checked        atomic.Int64
+		metaMissing    atomic.Int64
+		metaDamaged    atomic.Int64
+		metaStale      atomic.Int64
+		objMissing     atomic.Int64
+		objCorrupted   atomic.Int64
+		ctMissing      atomic.Int64
+		ctCorrupted    atomic.Int64
+		ctInconsistent atomic.Int64
+		repaired       atomic.Int64
+		repairErrs     atomic.Int64
+		errs           atomic.Int64
+	}
+
+	// ec-scrub findings (this target, this bucket)
+	ExtECScrubStats struct {
+		Checked        int64 `json:"ec.scrub.checked.n,string"`
+		MetaMissing    int64 `json:"ec.scrub.meta.missing.n,string"`
+		MetaDamaged    int64 `json:"ec.scrub.meta.damaged.n,string"`
+		MetaStale      int64 `json:"ec.scrub.meta.stale.n,string"`
+		ObjMissing     int64 `json:"ec.scrub.obj.missing.n,string"` // main replica (given its metafile)
+		ObjCorrupted   int64 `json:"ec.scrub.obj.corrupted.n,string"`
+		CTMissing      int64 `json:"ec.scrub.ct.missing.n,string"`      // slices and replicas
+		CTCorrupted    int64 `json:"ec.scrub.ct.corrupted.n,string"`    // ditto
+		CTInconsistent int64 `json:"ec.scrub.ct.inconsistent.n,string"` // mismatched generation or checksum
+		Repaired       int64 `json:"ec.scrub.repaired.n,string"`
+		RepairErrs     int64 `json:"ec.scrub.repair.err.n,string"`
+		Errs           int64 `json:"ec.scrub.err.n,string"` // failed to check
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact   = (*XactECScrub)(nil)
+	_ xreg.Renewable = (*scrubFactory)(nil)
+)
+
+//////////////////
+// scrubFactory //
+//////////////////
+
+func (*scrubFactory) New(args xreg.Args, bck *cluster.Bck) xreg.Renewable {
+	return &scrubFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+}
+
+func (p *scrubFactory) Start() error {
+	p.xctn = newXactECScrub(p.Bck, p.T, p.UUID())
+	return nil
+}
+
+func (*scrubFactory) Kind() string        { return apc.ActECScrub }
+func (p *scrubFactory) Get() cluster.Xact { return p.xctn }
+
+func (p *scrubFactory) WhenPrevIsRunning(prevEntry xreg.Renewable) (wpr xreg.WPR, err error) {
+	err = fmt.Errorf("%s is currently running, cannot start a new %q", prevEntry.Get(), p.Str(p.Kind()))
+	return
+}
+
+/////////////////
+// XactECScrub //
+/////////////////
+
+func newXactECScrub(bck *cluster.Bck, t cluster.Target, uuid string) (r *XactECScrub) {
+	config := cmn.GCO.Get()
+	r = &XactECScrub{
+		t:      t,
+		bck:    bck,
+		smap:   t.Sowner().Get(),
+		config: config,
+		// (remote targets read their slices in their entirety - hence, long timeout)
+		client: cmn.NewClient(cmn.TransportArgs{
+			Timeout:    config.Client.TimeoutLong.D(),
+			UseHTTPS:   config.Net.HTTP.UseHTTPS,
+			SkipVerify: config.Net.HTTP.SkipVerify,
+		}),
+	}
+	r.InitBase(uuid, apc.ActECScrub, bck)
+	return
+}
+
+func (r *XactECScrub) Run(wg *sync.WaitGroup) {
+	wg.Done()
+	bck := r.bck
+	if err := bck.Init(r.t.Bowner()); err != nil {
+		r.Finish(err)
+		return
+	}
+	if !bck.Props.EC.Enabled {
+		r.Finish(fmt.Errorf("bucket %q does not have EC enabled", r.bck.Name))
+		return
+	}
+
+	opts := &mpather.JoggerGroupOpts{
+		T:        r.t,
+		CTs:      []string{fs.ObjectType, fs.ECMetaType},
+		VisitObj: r.scrubObj,
+		VisitCT:  r.scrubMeta,
+		DoLoad:   mpather.Load,
+		Prio:     xact.Prio(apc.ActECScrub),
+	}
+	opts.Bck.Copy(r.bck.Bucket())
+	jg := mpather.NewJoggerGroup(opts)
+	jg.Run()
+
+	var err error
+	select {
+	case errCause := <-r.ChanAbort():
+		jg.Stop()
+		err = cmn.NewErrAborted(r.Name(), "", errCause)
+	case <-jg.ListenFinished():
+		err = jg.Stop()
+	}
+	r.wg.Wait() // pending re-encodes
+
+	st := r.stats.ext()
+	glog.Infof("%s: %s %+v", r.t, r, *st)
+	r.Finish(err)
+}
+
+func (r *XactECScrub) Snap() cluster.XactSnap {
+	snap := &xact.SnapExt{Ext: r.stats.ext()}
+	r.ToSnap(&snap.Snap)
+	return snap
+}
+
+func (r *XactECScrub) scrubObj(lom *cluster.LOM, _ []byte) error {
+	_, local, err := lom.HrwTarget(r.smap)
+	if err != nil {
+		glog.Errorf("%s: %s", lom, err)
+		return nil
+	}
+	if !local { // replica - checked by its main target
+		return nil
+	}
+	r.stats.checked.Inc()
+	if err := r.Reserve(lom.MpathInfo(), lom.SizeBytes()); err != nil {
+		return err
+	}
+
+	// 1. main replica
+	lom.Lock(false)
+	errCksum := lom.ValidateContentChecksum()
+	lom.Unlock(false)
+	if errCksum != nil {
+		if !cos.IsErrBadCksum(errCksum) {
+			r.stats.errs.Inc()
+			glog.Errorf("%s: failed to validate %s: %v", r, lom, errCksum)
+			return nil
+		}
+		r.stats.objCorrupted.Inc()
+		glog.Errorf("%s: %v", r, errCksum)
+	}
+
+	// 2. metafile
+	md, errMeta := LoadMetadata(cluster.NewCTFromLOM(lom, fs.ECMetaType).FQN())
+	switch {
+	case errMeta == nil:
+		if errCksum == nil && (md.ObjVersion != lom.Version() || md.ObjCksum != lom.Checksum().Value()) {
+			r.stats.metaStale.Inc()
+			return r.reencode(lom, "stale metafile")
+		}
+	case os.IsNotExist(errMeta):
+		r.stats.metaMissing.Inc()
+	default:
+		r.stats.metaDamaged.Inc()
+		glog.Errorf("%s: %v", r, errMeta)
+	}
+	if errMeta != nil {
+		if errCksum != nil {
+			r.restore(lom) // (will look for slices cluster-wide)
+			return nil
+		}
+		return r.reencode(lom, "no metafile")
+	}
+
+	// 3. remote slices (replicas)
+	healthy := r.checkRemote(lom, md)
+	switch {
+	case errCksum != nil:
+		r.restore(lom)
+	case !healthy:
+		return r.reencode(lom, "missing or corrupted slices")
+	}
+	return nil
+}
+
+// returns false if any slice or replica is missing, corrupted, or inconsistent
+func (r *XactECScrub) checkRemote(lom *cluster.LOM, md *Metadata) (healthy bool) {
+	healthy = true
+	for tid := range md.Daemons {
+		if tid == r.t.SID() {
+			continue
+		}
+		tsi := r.smap.GetTarget(tid)
+		if tsi == nil {
+			r.stats.ctMissing.Inc()
+			healthy = false
+			continue
+		}
+		rmd, status, err := requestVerifiedMeta(lom.Bucket(), lom.ObjName, tsi, r.client)
+		switch {
+		case status == http.StatusNotFound:
+			r.stats.ctMissing.Inc()
+			healthy = false
+		case status == http.StatusUnprocessableEntity:
+			r.stats.ctCorrupted.Inc()
+			healthy = false
+		case err != nil:
+			r.stats.errs.Inc()
+			glog.Errorf("%s: failed to check %s at %s: %v", r, lom, tsi, err)
+		case rmd.Generation != md.Generation || rmd.ObjCksum != md.ObjCksum:
+			r.stats.ctInconsistent.Inc()
+			healthy = false
+		}
+	}
+	return
+}
+
+// (async) re-encode and re-distribute all slices given intact main replica
+func (r *XactECScrub) reencode(lom *cluster.LOM, reason string) error {
+	if glog.FastV(4, glog.SmoduleEC) {
+		glog.Infof("%s: re-encoding %s: %s", r, lom, reason)
+	}
+	r.wg.Add(1)
+	if err := ECM.EncodeObject(lom, r.afterEncode); err != nil {
+		r.afterEncode(lom, err)
+		if err != errSkipped && err != cmn.ErrNotEnoughTargets {
+			return err // abort
+		}
+	}
+	return nil
+}
+
+func (r *XactECScrub) afterEncode(lom *cluster.LOM, err error) {
+	switch {
+	case err == nil:
+		r.stats.repaired.Inc()
+		r.LomAdd(lom)
+	case err != errSkipped:
+		r.stats.repairErrs.Inc()
+		glog.Errorf("%s: failed to re-encode %s: %v", r, lom.FullName(), err)
+	}
+	r.wg.Done()
+}
+
+// the main replica is missing while its metafile is still there (otherwise, see scrubObj)
+func (r *XactECScrub) scrubMeta(ct *cluster.CT, _ []byte) error {
+	debug.Assert(ct.ContentType() == fs.ECMetaType)
+	lom := cluster.AllocLOM(ct.ObjectName())
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(ct.Bucket()); err != nil {
+		glog.Errorf("%s: %s: %v", r, ct.FQN(), err)
+		return nil
+	}
+	if _, local, err := lom.HrwTarget(r.smap); err != nil || !local {
+		return nil
+	}
+	if cluster.NewCTFromLOM(lom, fs.ECMetaType).FQN() != ct.FQN() {
+		return nil // (locally misplaced)
+	}
+	err := lom.Load(false /*cache it*/, false /*locked*/)
+	switch {
+	case err == nil:
+		return nil // (scrubObj)
+	case !cmn.IsObjNotExist(err):
+		r.stats.errs.Inc()
+		glog.Errorf("%s: failed to load %s: %v", r, lom, err)
+		return nil
+	}
+	r.stats.checked.Inc()
+	r.stats.objMissing.Inc()
+	if _, err := LoadMetadata(ct.FQN()); err != nil {
+		r.stats.metaDamaged.Inc()
+		glog.Errorf("%s: %v", r, err)
+	}
+	r.restore(lom) // (will look for slices cluster-wide if need be)
+	return nil
+}
+
+// restore (corrupted or missing) main replica from the remaining slices - also re-creates the missing ones;
+// the corrupted replica is kept aside and put back if restoring fails
+func (r *XactECScrub) restore(lom *cluster.LOM) {
+	aside, err := setAside(lom)
+	if err == nil {
+		err = ECM.RestoreObject(lom)
+		if aside != "" {
+			putBack(lom, aside, err != nil)
+		}
+	}
+	if err != nil {
+		r.stats.repairErrs.Inc()
+		glog.Errorf("%s: failed to restore %s: %v", r, lom, err)
+		return
+	}
+	r.stats.repaired.Inc()
+	r.ObjsAdd(1, lom.SizeBytes(true))
+}
+
+// move the main replica out of the way (so that it can be restored) into a workfile on the same mountpath;
+// returns empty string if there's nothing to set aside
+func setAside(lom *cluster.LOM) (aside string, err error) {
+	aside = fs.CSM.Gen(lom, fs.WorkfileType, "ec-scrub")
+	if err = cos.CreateDir(filepath.Dir(aside)); err != nil {
+		return "", err
+	}
+	lom.Lock(true)
+	lom.Uncache(true /*delDirty*/)
+	if err = os.Rename(lom.FQN, aside); err != nil {
+		aside = ""
+		if os.IsNotExist(err) {
+			err = nil
+		}
+	}
+	lom.Unlock(true)
+	return
+}
+
+// put the main replica back unless (`failed` is false and) it has been restored - or unless
+// the object has been written in the meantime
+func putBack(lom *cluster.LOM, aside string, failed bool) {
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if failed {
+		if err := cos.Stat(lom.FQN); os.IsNotExist(err) {
+			if err := os.Rename(aside, lom.FQN); err != nil {
+				glog.Errorf("failed to put back %s: %v", lom, err)
+			}
+			return
+		}
+	}
+	if err := cos.RemoveFile(aside); err != nil {
+		glog.Errorf("failed to remove %s (%s): %v", aside, lom, err)
+	}
+}
+
+////////////////
+// scrubStats //
+////////////////
+
+func (s *scrubStats) ext() *ExtECScrubStats {
+	return &ExtECScrubStats{
+		Checked:        s.checked.Load(),
+		MetaMissing:    s.metaMissing.Load(),
+		MetaDamaged:    s.metaDamaged.Load(),
+		MetaStale:      s.metaStale.Load(),
+		ObjMissing:     s.objMissing.Load(),
+		ObjCorrupted:   s.objCorrupted.Load(),
+		CTMissing:      s.ctMissing.Load(),
+		CTCorrupted:    s.ctCorrupted.Load(),
+		CTInconsistent: s.ctInconsistent.Load(),
+		Repaired:       s.repaired.Load(),
+		RepairErrs:     s.repairErrs.Load(),
+		Errs:           s.errs.Load(),
+	}
+}
+
+//////////////////////////////////////
+// verifying slices (and replicas) //
+//////////////////////////////////////
+
+// requestVerifiedMeta is RequestECMeta that also has the remote target verify its slice (replica);
+// returns http.StatusUnprocessableEntity when the latter is corrupted
+func requestVerifiedMeta(bck *cmn.Bck, objName string, si *cluster.Snode, client *http.Client) (md *Metadata,
+	status int, err error) {
+	path := apc.URLPathEC.Join(URLMeta, bck.Name, objName)
+	query := bck.AddToQuery(url.Values{apc.QparamECVerify: []string{"true"}})
+	rq, err := http.NewRequest(http.MethodGet, si.URL(cmn.NetIntraData)+path, http.NoBody)
+	if err != nil {
+		return nil, 0, err
+	}
+	rq.URL.RawQuery = query.Encode()
+	resp, err := client.Do(rq) //nolint:bodyclose // closed inside cos.Close
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cos.Close(resp.Body)
+	if status = resp.StatusCode; status != http.StatusOK {
+		return nil, status, fmt.Errorf("%s: failed to verify %s/%s, status %d", si, bck, objName, status)
+	}
+	md, err = MetaFromReader(resp.Body)
+	return
+}
+
+// VerifyCT validates local slice or replica of the object against its metafile (that's been loaded by the caller).
+// Corrupted content (and the metafile) gets removed, and the returned error is cos.ErrBadCksum.
+func VerifyCT(t cluster.Target, bck *cluster.Bck, objName string, md *Metadata) error {
+	if md.SliceID == 0 {
+		return verifyReplica(t, bck, objName)
+	}
+	ct, err := cluster.NewCTFromBO(bck.Bucket(), objName, t.Bowner(), fs.ECSliceType)
+	if err != nil {
+		return err
+	}
+	if md.CksumType == "" || md.CksumType == cos.ChecksumNone || md.CksumValue == "" {
+		return nil
+	}
+	ct.Lock(false)
+	cksum, err := ctChecksum(ct.FQN(), md.CksumType)
+	ct.Unlock(false)
+	if err != nil {
+		return err
+	}
+	expected := cos.NewCksum(md.CksumType, md.CksumValue)
+	if cksum.Equal(expected) {
+		return nil
+	}
+	ct.Lock(true)
+	if errRm := cos.RemoveFile(ct.FQN()); errRm != nil {
+		glog.Errorf("failed to remove corrupted slice %s: %v", ct.FQN(), errRm)
+	}
+	if errRm := cos.RemoveFile(ct.Clone(fs.ECMetaType).FQN()); errRm != nil {
+		glog.Errorf("failed to remove metafile of the corrupted slice %s: %v", ct.FQN(), errRm)
+	}
+	ct.Unlock(true)
+	return cos.NewBadDataCksumError(cksum, expected, ct.FQN())
+}
+
+func verifyReplica(t cluster.Target, bck *cluster.Bck, objName string) error {
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		return err
+	}
+	lom.Lock(false)
+	err := lom.Load(false /*cache it*/, true /*locked*/)
+	if err == nil {
+		err = lom.ValidateContentChecksum()
+	}
+	lom.Unlock(false)
+	if !cos.IsErrBadCksum(err) {
+		return err
+	}
+	lom.Lock(true)
+	if errRm := lom.Remove(); errRm != nil {
+		glog.Errorf("%s: failed to remove corrupted replica %s: %v", t, lom, errRm)
+	}
+	if errRm := cos.RemoveFile(cluster.NewCTFromLOM(lom, fs.ECMetaType).FQN()); errRm != nil {
+		glog.Errorf("%s: failed to remove metafile of the corrupted replica %s: %v", t, lom, errRm)
+	}
+	lom.Unlock(true)
+	return err
+}
+
+func ctChecksum(fqn, cksumType string) (*cos.Cksum, error) {
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return nil, err
+	}
+	_, cksum, err := cos.CopyAndChecksum(io.Discard, fh, nil, cksumType)
+	cos.Close(fh)
+	if err != nil {
+		return nil, err
+	}
+	return cksum.Clone(), nil
+}
@@ -0,0 +1,149 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ECScrub", func() {
+	const (
+		testDir = "/tmp/ec-scrub-test_q/"
+
+		testBucketName = "TEST_EC_SCRUB_BUCKET"
+		mpath          = testDir + "ecscrubtest_mpath/111"
+
+		testObjectName = "ecscrubtestobj.ext"
+		testObjectSize = 1234
+	)
+
+	var (
+		props = &cmn.BucketProps{
+			Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash},
+			EC:    cmn.ECConf{Enabled: true, DataSlices: 2, ParitySlices: 1},
+			BID:   1,
+		}
+		bck     = cluster.Bck{Name: testBucketName, Provider: apc.AIS, Ns: cmn.NsGlobal, Props: props}
+		bmdMock = mock.NewBaseBownerMock(&bck)
+		mi      = fs.MountpathInfo{Path: mpath}
+		tMock   cluster.Target
+
+		objFQN   = mi.MakePathFQN(bck.Bucket(), fs.ObjectType, testObjectName)
+		sliceFQN = mi.MakePathFQN(bck.Bucket(), fs.ECSliceType, testObjectName)
+		metaFQN  = mi.MakePathFQN(bck.Bucket(), fs.ECMetaType, testObjectName)
+	)
+
+	BeforeEach(func() {
+		tools.InitTestFS(mpath)
+		tMock = mock.NewTarget(bmdMock)
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(testDir)
+	})
+
+	createObj := func() *cluster.LOM {
+		tools.CreateTestFile(filepath.Dir(objFQN), testObjectName, testObjectSize)
+		lom := tools.NewBasicLom(objFQN)
+		lom.SetSize(testObjectSize)
+		lom.SetAtimeUnix(time.Now().UnixNano())
+		_, err := lom.ComputeSetCksum()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lom.Persist()).NotTo(HaveOccurred())
+		return tools.NewBasicLom(objFQN)
+	}
+
+	Describe("setAside and putBack", func() {
+		It("should put back main replica when restoring fails", func() {
+			lom := createObj()
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			cksum := lom.Checksum().Clone()
+
+			aside, err := setAside(lom)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(aside).To(BeARegularFile())
+			Expect(objFQN).NotTo(BeAnExistingFile())
+
+			putBack(lom, aside, true /*failed*/)
+			Expect(aside).NotTo(BeAnExistingFile())
+			lom = tools.NewBasicLom(objFQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(lom.Checksum().Equal(cksum)).To(BeTrue())
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+		})
+
+		It("should discard main replica once restored", func() {
+			lom := createObj()
+			aside, err := setAside(lom)
+			Expect(err).NotTo(HaveOccurred())
+			tools.CreateTestFile(filepath.Dir(objFQN), testObjectName, testObjectSize/2) // (restored)
+
+			putBack(lom, aside, false)
+			Expect(aside).NotTo(BeAnExistingFile())
+			finfo, err := os.Stat(objFQN)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(finfo.Size()).To(BeEquivalentTo(testObjectSize / 2))
+		})
+
+		It("should not overwrite object written in the meantime", func() {
+			lom := createObj()
+			aside, err := setAside(lom)
+			Expect(err).NotTo(HaveOccurred())
+			tools.CreateTestFile(filepath.Dir(objFQN), testObjectName, testObjectSize/2) // (new PUT)
+
+			putBack(lom, aside, true /*failed*/)
+			Expect(aside).NotTo(BeAnExistingFile())
+			finfo, err := os.Stat(objFQN)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(finfo.Size()).To(BeEquivalentTo(testObjectSize / 2))
+		})
+
+		It("should have nothing to set aside when main replica is missing", func() {
+			lom := tools.NewBasicLom(objFQN)
+			aside, err := setAside(lom)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(aside).To(BeEmpty())
+		})
+	})
+
+	Describe("VerifyCT", func() {
+		createSlice := func() *Metadata {
+			tools.CreateTestFile(filepath.Dir(sliceFQN), testObjectName, testObjectSize)
+			cksum, err := ctChecksum(sliceFQN, cos.ChecksumXXHash)
+			Expect(err).NotTo(HaveOccurred())
+			md := &Metadata{SliceID: 1, Data: 2, Parity: 1, CksumType: cksum.Ty(), CksumValue: cksum.Value()}
+			tools.CreateTestFile(filepath.Dir(metaFQN), testObjectName, 0)
+			return md
+		}
+
+		It("should accept intact slice", func() {
+			md := createSlice()
+			Expect(VerifyCT(tMock, &bck, testObjectName, md)).NotTo(HaveOccurred())
+			Expect(sliceFQN).To(BeARegularFile())
+			Expect(metaFQN).To(BeARegularFile())
+		})
+
+		It("should remove corrupted slice along with its metafile", func() {
+			md := createSlice()
+			tools.CorruptFile(sliceFQN)
+			err := VerifyCT(tMock, &bck, testObjectName, md)
+			Expect(cos.IsErrBadCksum(err)).To(BeTrue())
+			Expect(sliceFQN).NotTo(BeAnExistingFile())
+			Expect(metaFQN).NotTo(BeAnExistingFile())
+		})
+	})
+})
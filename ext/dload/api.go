@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -22,14 +23,24 @@ import (
 type Type string
 
 const (
-	TypeSingle  Type = "single"
-	TypeRange   Type = "range"
-	TypeMulti   Type = "multi"
-	TypeBackend Type = "backend"
+	TypeSingle   Type = "single"
+	TypeRange    Type = "range"
+	TypeMulti    Type = "multi"
+	TypeBackend  Type = "backend"
+	TypeManifest Type = "manifest"
+)
+
+// manifest formats (see ManifestBody)
+const (
+	ManifestCSV   = "csv"
+	ManifestJSONL = "jsonl"
 )
 
 const PrefixJobID = "dnl-"
 
+// zero share of a non-zero limit (as opposed to zero limit that means "no limit") - see Limits.Apportion
+const NoShare = -1
+
 const DownloadProgressInterval = 10 * time.Second
 
 type (
@@ -57,6 +68,7 @@ type (
 		Total         int       `json:"total"`          // total number of tasks, negative if unknown
 		AllDispatched bool      `json:"all_dispatched"` // if true, dispatcher has already scheduled all tasks for given job
 		Aborted       bool      `json:"aborted"`
+		Limits        Limits    `json:"limits"` // job's (cluster-wide) limits, if any
 	}
 
 	JobInfos []*Job
@@ -66,12 +78,53 @@ type (
 		CurrentTasks  []TaskDlInfo  `json:"current_tasks,omitempty"`
 		FinishedTasks []TaskDlInfo  `json:"finished_tasks,omitempty"`
 		Errs          []TaskErrInfo `json:"download_errors,omitempty"`
+		// origin host => number of tasks currently fetching (or waiting to fetch) from it;
+		// reported only for jobs with per-host limits
+		Hosts map[string]int `json:"hosts,omitempty"`
 	}
 
+	// Job limits are cluster-wide totals: the primary proxy keeps dividing them among the targets
+	// that have pending tasks (in proportion to the number of tasks) - see LimitsMsg.
+	// The sum of the shares never exceeds the limit; when the limit is smaller than the number of targets
+	// some of the targets get NoShare (and wait).
 	Limits struct {
+		Connections  int        `json:"connections"`
+		BytesPerHour int        `json:"bytes_per_hour"`
+		PerHost      HostLimits `json:"per_host"` // (web links only)
+	}
+	// ditto, per origin server (URL host)
+	HostLimits struct {
 		Connections  int `json:"connections"`
 		BytesPerHour int `json:"bytes_per_hour"`
 	}
+	// proxy => targets: respective shares of the job limits
+	LimitsMsg struct {
+		ID     string           `json:"id"`
+		Shares map[string]Share `json:"shares"` // target ID => share
+	}
+	Share struct {
+		Limits
+		// per-host limits of the hosts that are being fetched from are divided among the targets
+		// that fetch from them (see StatusResp.Hosts); `Limits.PerHost` applies to all other hosts
+		Hosts map[string]HostLimits `json:"hosts,omitempty"`
+	}
+
+	// Fetching large objects in parallel chunks (HTTP Range requests);
+	// applies to web links only (i.e., not to remote buckets - see BackendBody)
+	Chunks struct {
+		Size  string `json:"size,omitempty"`  // objects larger than that are fetched in chunks of this size (e.g., "64MiB")
+		Conns int    `json:"conns,omitempty"` // max number of parallel connections per object (must be > 1 to enable)
+	}
+
+	// Verification of the downloaded content (web links only): the expected checksum is either
+	// provided by the user (single-object download) or fetched from the "sidecar" file
+	// that resides next to the link and has the checksum type as its extension (e.g., "<link>.md5").
+	// Checksum mismatch is reported as the download (task) error.
+	Verify struct {
+		CksumType  string `json:"cksum_type,omitempty"`  // one of: md5, sha256, sha512 (standard, hex-encoded)
+		CksumValue string `json:"cksum_value,omitempty"` // expected checksum (single-object download only)
+		Sidecar    bool   `json:"sidecar,omitempty"`     // fetch expected checksum from "<link>.<cksum_type>"
+	}
 
 	Base struct {
 		Description      string  `json:"description"`
@@ -79,6 +132,8 @@ type (
 		Timeout          string  `json:"timeout"`
 		ProgressInterval string  `json:"progress_interval"`
 		Limits           Limits  `json:"limits"`
+		Chunks           Chunks  `json:"chunks"`
+		Verify           Verify  `json:"verify"`
 	}
 
 	SingleObj struct {
@@ -131,11 +186,23 @@ type (
 		Base
 		ObjectsPayload any `json:"objects"`
 	}
+
+	// Manifest object (already stored in the cluster) that lists objects to download, one per row:
+	// - CSV: the first line is the header that names the columns: "name" and "link" (required),
+	//   "size", "cksum_type", "cksum_value" (optional); all other columns are custom metadata
+	// - JSONL: one JSON object per line (see `manifestRow`)
+	// The manifest is streamed - each target processes the rows it owns (see also `Verify`).
+	ManifestBody struct {
+		Base
+		Manifest    string  `json:"manifest"`                  // manifest object name
+		ManifestBck cmn.Bck `json:"manifest_bucket,omitempty"` // bucket that contains the manifest (default: destination bucket)
+		Format      string  `json:"format,omitempty"`          // one of: csv, jsonl (default: manifest name extension)
+	}
 )
 
 func IsType(a string) bool {
 	b := Type(a)
-	return b == TypeMulti || b == TypeBackend || b == TypeSingle || b == TypeRange
+	return b == TypeMulti || b == TypeBackend || b == TypeSingle || b == TypeRange || b == TypeManifest
 }
 
 /////////
@@ -278,6 +345,12 @@ func (d *StatusResp) Aggregate(rhs StatusResp) *StatusResp {
 	d.CurrentTasks = append(d.CurrentTasks, rhs.CurrentTasks...)
 	d.FinishedTasks = append(d.FinishedTasks, rhs.FinishedTasks...)
 	d.Errs = append(d.Errs, rhs.Errs...)
+	for host, n := range rhs.Hosts {
+		if d.Hosts == nil {
+			d.Hosts = make(map[string]int, len(rhs.Hosts))
+		}
+		d.Hosts[host] += n
+	}
 	return d
 }
 
@@ -300,6 +373,168 @@ func (b *Base) Validate() error {
 	if b.Limits.BytesPerHour < 0 {
 		return fmt.Errorf("'limit.bytes_per_hour' must be non-negative (got: %d)", b.Limits.BytesPerHour)
 	}
+	if b.Limits.PerHost.Connections < 0 || b.Limits.PerHost.BytesPerHour < 0 {
+		return fmt.Errorf("'limit.per_host' must be non-negative (got: %+v)", b.Limits.PerHost)
+	}
+	if err := b.Chunks.Validate(); err != nil {
+		return err
+	}
+	return b.Verify.Validate()
+}
+
+////////////
+// Limits //
+////////////
+
+func (l *Limits) IsZero() bool {
+	return l.Connections == 0 && l.BytesPerHour == 0 && l.PerHost.Connections == 0 && l.PerHost.BytesPerHour == 0
+}
+
+// Apportion divides the (cluster-wide) limits among the targets in proportion to their respective
+// weights (e.g., numbers of pending tasks). Non-zero limits never become "unlimited": each target
+// gets at least 1 or, when the limit is smaller than the number of targets, NoShare.
+func (l *Limits) Apportion(weights map[string]int) map[string]Limits {
+	var (
+		tids   = _tids(weights)
+		conns  = apportion(l.Connections, tids, weights)
+		bph    = apportion(l.BytesPerHour, tids, weights)
+		hconns = apportion(l.PerHost.Connections, tids, weights)
+		hbph   = apportion(l.PerHost.BytesPerHour, tids, weights)
+		shares = make(map[string]Limits, len(tids))
+	)
+	for i, tid := range tids {
+		shares[tid] = Limits{
+			Connections:  conns[i],
+			BytesPerHour: bph[i],
+			PerHost:      HostLimits{Connections: hconns[i], BytesPerHour: hbph[i]},
+		}
+	}
+	return shares
+}
+
+// ditto, for a single origin host
+func (hl *HostLimits) Apportion(weights map[string]int) map[string]HostLimits {
+	var (
+		tids   = _tids(weights)
+		conns  = apportion(hl.Connections, tids, weights)
+		bph    = apportion(hl.BytesPerHour, tids, weights)
+		shares = make(map[string]HostLimits, len(tids))
+	)
+	for i, tid := range tids {
+		shares[tid] = HostLimits{Connections: conns[i], BytesPerHour: bph[i]}
+	}
+	return shares
+}
+
+func _tids(weights map[string]int) []string {
+	tids := make([]string, 0, len(weights))
+	for tid, w := range weights {
+		debug.Assert(w > 0, tid, w)
+		tids = append(tids, tid)
+	}
+	sort.Strings(tids) // (deterministic)
+	return tids
+}
+
+// largest remainder method, with each share >= 1 when possible; the shares always add up to the limit
+func apportion(limit int, tids []string, weights map[string]int) (shares []int) {
+	var (
+		n      = len(tids)
+		order  = make([]int, n)
+		rems   = make([]int64, n)
+		total  int64
+		remain int
+	)
+	shares = make([]int, n)
+	if limit == 0 || n == 0 {
+		return
+	}
+	for i := range tids {
+		order[i] = i
+		total += int64(weights[tids[i]])
+	}
+	if limit < n {
+		// not enough to go around: 1 apiece to the targets with the most weight, nothing to the rest
+		sort.SliceStable(order, func(i, j int) bool { return weights[tids[order[i]]] > weights[tids[order[j]]] })
+		for k, i := range order {
+			if k < limit {
+				shares[i] = 1
+			} else {
+				shares[i] = NoShare
+			}
+		}
+		return
+	}
+	remain = limit - n // (1 apiece, plus)
+	distributed := 0
+	for i, tid := range tids {
+		x := int64(remain) * int64(weights[tid])
+		shares[i] = 1 + int(x/total)
+		rems[i] = x % total
+		distributed += int(x / total)
+	}
+	sort.SliceStable(order, func(i, j int) bool { return rems[order[i]] > rems[order[j]] })
+	for k := 0; k < remain-distributed; k++ {
+		shares[order[k]]++
+	}
+	return
+}
+
+////////////
+// Chunks //
+////////////
+
+func (c *Chunks) Validate() error {
+	if c.Conns < 0 {
+		return fmt.Errorf("'chunks.conns' must be non-negative (got: %d)", c.Conns)
+	}
+	if _, err := c.size(); err != nil {
+		return fmt.Errorf("invalid 'chunks.size' %q: %v", c.Size, err)
+	}
+	return nil
+}
+
+func (c *Chunks) size() (int64, error) {
+	if c.Size == "" {
+		return 0, nil
+	}
+	size, err := cos.S2B(c.Size)
+	if err == nil && size <= 0 {
+		err = errors.New("must be positive")
+	}
+	return size, err
+}
+
+////////////
+// Verify //
+////////////
+
+func (v *Verify) Validate() error {
+	switch v.CksumType {
+	case "":
+		if v.CksumValue != "" || v.Sidecar {
+			return errors.New("'verify.cksum_type' is required to verify downloaded content")
+		}
+		return nil
+	case cos.ChecksumMD5, cos.ChecksumSHA256, cos.ChecksumSHA512:
+	default:
+		return fmt.Errorf("unsupported 'verify.cksum_type' %q (expecting one of: %s, %s, %s)",
+			v.CksumType, cos.ChecksumMD5, cos.ChecksumSHA256, cos.ChecksumSHA512)
+	}
+	if v.CksumValue != "" && v.Sidecar {
+		return errors.New("'verify.cksum_value' and 'verify.sidecar' are mutually exclusive")
+	}
+	if v.CksumValue == "" && !v.Sidecar {
+		return errors.New("either 'verify.cksum_value' or 'verify.sidecar' must be specified")
+	}
+	return nil
+}
+
+// user-supplied checksum can only be used with single-object downloads
+func (v *Verify) validateMulti() error {
+	if v.CksumValue != "" {
+		return errors.New("'verify.cksum_value' is only supported with single-object downloads (use 'verify.sidecar')")
+	}
 	return nil
 }
 
@@ -392,7 +627,7 @@ func (b *RangeBody) Validate() error {
 	if b.Template == "" {
 		return errors.New("missing 'template' in the request body")
 	}
-	return nil
+	return b.Verify.validateMulti()
 }
 
 func (b *RangeBody) Describe() string {
@@ -414,7 +649,10 @@ func (b *MultiBody) Validate() error {
 	if b.ObjectsPayload == nil {
 		return errors.New("body should not be empty")
 	}
-	return b.Base.Validate()
+	if err := b.Base.Validate(); err != nil {
+		return err
+	}
+	return b.Verify.validateMulti()
 }
 
 func (b *MultiBody) ExtractPayload() (cos.StrKVs, error) {
@@ -466,7 +704,15 @@ func (b *MultiBody) String() string {
 // BackendBody //
 /////////////////
 
-func (b *BackendBody) Validate() error { return b.Base.Validate() }
+func (b *BackendBody) Validate() error {
+	if err := b.Base.Validate(); err != nil {
+		return err
+	}
+	if b.Verify.CksumType != "" || b.Chunks.Conns > 1 {
+		return errors.New("'verify' and 'chunks' are not supported with remote bucket downloads")
+	}
+	return nil
+}
 
 func (b *BackendBody) Describe() string {
 	if b.Description != "" {
@@ -474,3 +720,37 @@ func (b *BackendBody) Describe() string {
 	}
 	return fmt.Sprintf("remote bucket prefetch -> %s", b.Bck)
 }
+
+//////////////////
+// ManifestBody //
+//////////////////
+
+func (b *ManifestBody) Validate() error {
+	if err := b.Base.Validate(); err != nil {
+		return err
+	}
+	if b.Manifest == "" {
+		return errors.New("missing 'manifest' in the request body")
+	}
+	if b.ManifestBck.Name == "" {
+		b.ManifestBck = b.Bck
+	}
+	if b.Format == "" {
+		b.Format = strings.TrimPrefix(path.Ext(b.Manifest), ".")
+	}
+	if b.Format != ManifestCSV && b.Format != ManifestJSONL {
+		return fmt.Errorf("invalid manifest format %q (expecting %q or %q)", b.Format, ManifestCSV, ManifestJSONL)
+	}
+	return b.Verify.validateMulti()
+}
+
+func (b *ManifestBody) Describe() string {
+	if b.Description != "" {
+		return b.Description
+	}
+	return fmt.Sprintf("manifest %s/%s -> %s", b.ManifestBck, b.Manifest, b.Bck)
+}
+
+func (b *ManifestBody) String() string {
+	return fmt.Sprintf("bucket: %q, manifest: %q/%q, format: %q", b.Bck, b.ManifestBck, b.Manifest, b.Format)
+}
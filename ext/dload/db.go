@@ -6,16 +6,21 @@ package dload
 
 import (
 	"errors"
+	"fmt"
 	"path"
+	"sort"
 	"sync"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/kvdb"
+	jsoniter "github.com/json-iterator/go"
 )
 
 const (
 	downloaderErrors     = "errors"
 	downloaderTasks      = "tasks"
+	downloaderJobs       = "jobs"
 	downloaderCollection = "downloads"
 
 	// Number of errors stored in memory. When the number of errors exceeds
@@ -23,28 +28,47 @@ const (
 	errCacheSize = 100
 
 	// Number of tasks stored in memory. When the number of tasks exceeds
-	// this number, then all errors will be flushed to disk
+	// this number, the tasks are flushed to disk as a separate chunk
+	// (so that persisting a multi-million-object job remains linear).
 	taskInfoCacheSize = 1000
 )
 
 var errJobNotFound = errors.New("job not found")
 
-type downloaderDB struct {
-	mtx    sync.RWMutex
-	driver kvdb.Driver
+type (
+	downloaderDB struct {
+		mtx    sync.RWMutex
+		driver kvdb.Driver
 
-	errCache      map[string][]TaskErrInfo // memory cache for errors, see: errCacheSize
-	taskInfoCache map[string][]TaskDlInfo  // memory cache for tasks, see: taskInfoCacheSize
-}
+		errCache      map[string][]TaskErrInfo // memory cache for errors, see: errCacheSize
+		taskInfoCache map[string][]TaskDlInfo  // memory cache for tasks, see: taskInfoCacheSize
+		taskChunks    map[string]int           // number of already persisted task chunks
+	}
+
+	// PersistedJob is the job's definition (the original request) along with
+	// its last recorded state - everything that's needed to restore the job
+	// after the target restarts.
+	PersistedJob struct {
+		Job
+		Bck  cmn.Bck `json:"bck"`
+		Body Body    `json:"body"`
+	}
+)
 
 func newDownloadDB(driver kvdb.Driver) *downloaderDB {
 	return &downloaderDB{
 		driver:        driver,
 		errCache:      make(map[string][]TaskErrInfo, 10),
 		taskInfoCache: make(map[string][]TaskDlInfo, 10),
+		taskChunks:    make(map[string]int, 10),
 	}
 }
 
+func tasksPrefix(id string) string { return path.Join(downloaderTasks, id) + "/" }
+
+// (older versions persist all the job's tasks under a single key)
+func tasksLegacyKey(id string) string { return path.Join(downloaderTasks, id) }
+
 func (db *downloaderDB) errors(id string) (errors []TaskErrInfo, err error) {
 	key := path.Join(downloaderErrors, id)
 	if err := db.driver.Get(downloaderCollection, key, &errors); err != nil {
@@ -93,44 +117,64 @@ func (db *downloaderDB) persistError(id, objName, errMsg string) {
 }
 
 func (db *downloaderDB) tasks(id string) (tasks []TaskDlInfo, err error) {
-	key := path.Join(downloaderTasks, id)
-	if err := db.driver.Get(downloaderCollection, key, &tasks); err != nil {
-		if !kvdb.IsErrNotFound(err) {
+	if err := db.driver.Get(downloaderCollection, tasksLegacyKey(id), &tasks); err != nil && !kvdb.IsErrNotFound(err) {
+		glog.Error(err)
+		return nil, err
+	}
+	chunks, err := db.driver.GetAll(downloaderCollection, tasksPrefix(id))
+	if err != nil && !kvdb.IsErrNotFound(err) {
+		glog.Error(err)
+		return nil, err
+	}
+	keys := make([]string, 0, len(chunks))
+	for key := range chunks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var chunk []TaskDlInfo
+		if err := jsoniter.UnmarshalFromString(chunks[key], &chunk); err != nil {
 			glog.Error(err)
 			return nil, err
 		}
-		// If there was nothing in DB, return empty list
-		return db.taskInfoCache[id], nil
+		tasks = append(tasks, chunk...)
 	}
 	tasks = append(tasks, db.taskInfoCache[id]...)
 	return
 }
 
-func (db *downloaderDB) persistTaskInfo(id string, task TaskDlInfo) error {
-	db.mtx.Lock()
-	defer db.mtx.Unlock()
-
-	if len(db.taskInfoCache[id]) < taskInfoCacheSize { // if possible store task in cache
-		db.taskInfoCache[id] = append(db.taskInfoCache[id], task)
-		return nil
-	}
-
-	persistedTasks, err := db.tasks(id) // it will also append tasks from cache
-	if err != nil {
-		return err
+// writes cached tasks as the next chunk
+func (db *downloaderDB) flushTasks(id string) error {
+	n, ok := db.taskChunks[id]
+	if !ok {
+		keys, err := db.driver.List(downloaderCollection, tasksPrefix(id))
+		if err != nil && !kvdb.IsErrNotFound(err) {
+			glog.Error(err)
+			return err
+		}
+		n = len(keys)
 	}
-	persistedTasks = append(persistedTasks, task)
-
-	key := path.Join(downloaderTasks, id)
-	if err := db.driver.Set(downloaderCollection, key, persistedTasks); err != nil {
+	key := tasksPrefix(id) + fmt.Sprintf("%08d", n)
+	if err := db.driver.Set(downloaderCollection, key, db.taskInfoCache[id]); err != nil {
 		glog.Error(err)
 		return err
 	}
-
+	db.taskChunks[id] = n + 1
 	db.taskInfoCache[id] = db.taskInfoCache[id][:0] // clear cache
 	return nil
 }
 
+func (db *downloaderDB) persistTaskInfo(id string, task TaskDlInfo) error {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	db.taskInfoCache[id] = append(db.taskInfoCache[id], task)
+	if len(db.taskInfoCache[id]) < taskInfoCacheSize {
+		return nil
+	}
+	return db.flushTasks(id)
+}
+
 func (db *downloaderDB) getTasks(id string) (tasks []TaskDlInfo, err error) {
 	db.mtx.RLock()
 	defer db.mtx.RUnlock()
@@ -158,18 +202,7 @@ func (db *downloaderDB) flush(id string) error {
 	}
 
 	if len(db.taskInfoCache[id]) > 0 {
-		persistedTasks, err := db.tasks(id) // it will also append tasks from cache
-		if err != nil {
-			return err
-		}
-
-		key := path.Join(downloaderTasks, id)
-		if err := db.driver.Set(downloaderCollection, key, persistedTasks); err != nil {
-			glog.Error(err)
-			return err
-		}
-
-		db.taskInfoCache[id] = db.taskInfoCache[id][:0] // clear cache
+		return db.flushTasks(id)
 	}
 	return nil
 }
@@ -178,7 +211,44 @@ func (db *downloaderDB) delete(id string) {
 	db.mtx.Lock()
 	key := path.Join(downloaderErrors, id)
 	db.driver.Delete(downloaderCollection, key)
-	key = path.Join(downloaderTasks, id)
+	db.driver.Delete(downloaderCollection, tasksLegacyKey(id))
+	if chunks, err := db.driver.GetAll(downloaderCollection, tasksPrefix(id)); err == nil {
+		for key := range chunks {
+			db.driver.Delete(downloaderCollection, key)
+		}
+	}
+	db.deleteDone(id)
+	key = path.Join(downloaderJobs, id)
 	db.driver.Delete(downloaderCollection, key)
+	delete(db.errCache, id)
+	delete(db.taskInfoCache, id)
+	delete(db.taskChunks, id)
 	db.mtx.Unlock()
 }
+
+func (db *downloaderDB) persistJob(pj *PersistedJob) {
+	key := path.Join(downloaderJobs, pj.ID)
+	if err := db.driver.Set(downloaderCollection, key, pj); err != nil {
+		glog.Errorf("failed to persist download job %q: %v", pj.ID, err)
+	}
+}
+
+func (db *downloaderDB) jobs() (pjs []*PersistedJob, err error) {
+	all, err := db.driver.GetAll(downloaderCollection, downloaderJobs+"/")
+	if err != nil {
+		if kvdb.IsErrNotFound(err) {
+			err = nil
+		}
+		return nil, err
+	}
+	pjs = make([]*PersistedJob, 0, len(all))
+	for key, val := range all {
+		pj := &PersistedJob{}
+		if err := jsoniter.UnmarshalFromString(val, pj); err != nil {
+			glog.Errorf("failed to load download job %q: %v", key, err)
+			continue
+		}
+		pjs = append(pjs, pj)
+	}
+	return pjs, nil
+}
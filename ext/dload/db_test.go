@@ -0,0 +1,149 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestPersistTasksAcrossRestart(t *testing.T) {
+	const (
+		id  = PrefixJobID + "tasks"
+		num = 2*taskInfoCacheSize + taskInfoCacheSize/2
+	)
+	driver := mock.NewDBDriver()
+	dldb := newDownloadDB(driver)
+	for i := 0; i < num; i++ {
+		err := dldb.persistTaskInfo(id, TaskDlInfo{Name: fmt.Sprintf("obj-%06d", i)})
+		tassert.CheckFatal(t, err)
+	}
+	tasks, err := dldb.getTasks(id)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(tasks) == num, "expected %d tasks, got %d", num, len(tasks))
+	tassert.CheckFatal(t, dldb.flush(id))
+
+	// restart: new instance over the same (persistent) driver
+	dldb = newDownloadDB(driver)
+	tassert.CheckFatal(t, dldb.persistTaskInfo(id, TaskDlInfo{Name: "last"}))
+	tassert.CheckFatal(t, dldb.flush(id))
+	tasks, err = dldb.getTasks(id)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(tasks) == num+1, "expected %d tasks after restart, got %d", num+1, len(tasks))
+	for i := 0; i < num; i++ {
+		tassert.Fatalf(t, tasks[i].Name == fmt.Sprintf("obj-%06d", i), "unexpected order: %q at %d", tasks[i].Name, i)
+	}
+	tassert.Errorf(t, tasks[num].Name == "last", "expected the last task to be appended, got %q", tasks[num].Name)
+
+	dldb.delete(id)
+	tasks, err = dldb.getTasks(id)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(tasks) == 0, "expected no tasks after delete, got %d", len(tasks))
+}
+
+// tasks persisted by older versions (under a single key) precede the chunks
+func TestLegacyTasks(t *testing.T) {
+	const id = PrefixJobID + "legacy"
+	var (
+		driver = mock.NewDBDriver()
+		dldb   = newDownloadDB(driver)
+	)
+	legacy := []TaskDlInfo{{Name: "old-1"}, {Name: "old-2"}}
+	tassert.CheckFatal(t, driver.Set(downloaderCollection, tasksLegacyKey(id), legacy))
+	tassert.CheckFatal(t, dldb.persistTaskInfo(id, TaskDlInfo{Name: "new"}))
+	tassert.CheckFatal(t, dldb.flush(id))
+
+	tasks, err := dldb.getTasks(id)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(tasks) == 3, "expected 3 tasks, got %d", len(tasks))
+	tassert.Errorf(t, tasks[0].Name == "old-1" && tasks[2].Name == "new", "unexpected order: %+v", tasks)
+
+	dldb.delete(id)
+	tasks, err = dldb.getTasks(id)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(tasks) == 0, "expected no tasks after delete, got %d", len(tasks))
+}
+
+func TestLoadJobs(t *testing.T) {
+	var (
+		is      = &infoStore{downloaderDB: newDownloadDB(mock.NewDBDriver()), dljobs: make(map[string]*dljob)}
+		bck     = cmn.Bck{Name: "bck", Provider: apc.AIS}
+		body    = Body{Type: TypeRange, RawMessage: []byte(`{"template":"http://a/b-{0..9}"}`)}
+		started = time.Now().Add(-time.Hour)
+	)
+	is.persistJob(&PersistedJob{Job: Job{ID: "running", StartedTime: started}, Bck: bck, Body: body})
+	is.persistJob(&PersistedJob{Job: Job{ID: "finished", StartedTime: started, FinishedTime: time.Now(),
+		AllDispatched: true, ScheduledCnt: 10, FinishedCnt: 10, Total: 10}, Bck: bck, Body: body})
+	is.persistJob(&PersistedJob{Job: Job{ID: "aborted", StartedTime: started, Aborted: true}, Bck: bck, Body: body})
+
+	resume, err := is.loadJobs()
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(resume) == 1 && resume[0].ID == "running", "expected to resume exactly one job, got %v", resume)
+	tassert.Errorf(t, resume[0].Body.Type == TypeRange && resume[0].Bck.Equal(&bck),
+		"request not restored: %+v", resume[0])
+
+	finished, err := is.getJob("finished")
+	tassert.CheckFatal(t, err)
+	job := finished.clone()
+	tassert.Errorf(t, job.JobFinished() && job.FinishedCnt == 10, "finished job not restored: %+v", job)
+
+	aborted, err := is.getJob("aborted")
+	tassert.CheckFatal(t, err)
+	job = aborted.clone()
+	tassert.Errorf(t, job.Aborted && job.JobFinished(), "aborted job must be restored as finished: %+v", job)
+
+	_, err = is.getJob("running")
+	tassert.Errorf(t, err != nil, "job to be resumed must not be restored as is")
+}
+
+func TestDoneRecordAcrossRestart(t *testing.T) {
+	const id = PrefixJobID + "done"
+	var (
+		driver = mock.NewDBDriver()
+		dldb   = newDownloadDB(driver)
+		rec    = newDoneRecord()
+		marked = []int64{0, 1, 63, doneSegObjs - 1, doneSegObjs, 5*doneSegObjs + 7}
+	)
+	for i, idx := range marked {
+		rec.mark(idx, i%3)
+	}
+	tassert.Errorf(t, !rec.mark(1, doneFinished), "must not flush upon marking twice")
+	tassert.Errorf(t, !rec.mark(-1, doneFinished), "not indexed objects must be ignored")
+	tassert.CheckFatal(t, dldb.persistDone(id, rec))
+	tassert.Errorf(t, len(rec.dirty) == 0, "expected no dirty segments after flush, got %d", len(rec.dirty))
+
+	// restart
+	dldb = newDownloadDB(driver)
+	loaded, err := dldb.loadDone(id)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(loaded.segs) == 3, "expected 3 (sparse) segments, got %d", len(loaded.segs))
+	tassert.Errorf(t, loaded.count() == len(marked), "expected %d marked, got %d", len(marked), loaded.count())
+	tassert.Errorf(t, loaded.cnt == doneCnt{Finished: 2, Skipped: 2, Errors: 2}, "unexpected counters: %+v", loaded.cnt)
+	for _, idx := range marked {
+		tassert.Errorf(t, loaded.has(idx), "expected %d to be done", idx)
+	}
+	for _, idx := range []int64{-1, 2, 62, 64, doneSegObjs + 1, 3 * doneSegObjs} {
+		tassert.Errorf(t, !loaded.has(idx), "expected %d not to be done", idx)
+	}
+
+	// only the modified segment gets written
+	loaded.mark(doneSegObjs+1, doneFinished)
+	tassert.Errorf(t, len(loaded.dirty) == 1, "expected a single dirty segment, got %d", len(loaded.dirty))
+	tassert.CheckFatal(t, dldb.persistDone(id, loaded))
+	loaded, err = dldb.loadDone(id)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, loaded.has(doneSegObjs+1) && loaded.cnt.Finished == 3, "update not persisted: %+v", loaded.cnt)
+
+	dldb.delete(id)
+	loaded, err = dldb.loadDone(id)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, loaded.count() == 0 && len(loaded.segs) == 0, "expected empty record after delete")
+}
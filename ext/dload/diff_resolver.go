@@ -47,12 +47,16 @@ type (
 	WebResource struct {
 		ObjName string
 		Link    string
+		idx     int64    // (see `dlObj.idx`)
+		meta    *rowMeta // (see `dlObj.meta`)
 	}
 
 	DstElement struct {
 		ObjName string
 		Version string
 		Link    string
+		idx     int64
+		meta    *rowMeta
 	}
 
 	DiffResolverResult struct {
@@ -163,11 +167,14 @@ func (dr *DiffResolver) PushDst(v any) {
 	case *BackendResource:
 		d = &DstElement{
 			ObjName: x.ObjName,
+			idx:     -1,
 		}
 	case *WebResource:
 		d = &DstElement{
 			ObjName: x.ObjName,
 			Link:    x.Link,
+			idx:     x.idx,
+			meta:    x.meta,
 		}
 	default:
 		debug.FailTypeCast(v)
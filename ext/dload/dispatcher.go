@@ -30,8 +30,9 @@ type (
 		xdl         *Xact
 		startupSema startupSema            // Semaphore which synchronizes goroutines at dispatcher startup.
 		joggers     map[string]*jogger     // mpath -> jogger
-		mtx         sync.RWMutex           // Protects map defined below.
+		mtx         sync.RWMutex           // Protects maps defined below.
 		abortJob    map[string]*cos.StopCh // jobID -> abort job chan
+		jobs        map[string]jobif       // jobID -> job being dispatched (see handleLimits)
 		workCh      chan jobif
 		stopCh      *cos.StopCh
 	}
@@ -55,6 +56,7 @@ func newDispatcher(xdl *Xact) *dispatcher {
 		workCh:      make(chan jobif),
 		stopCh:      cos.NewStopCh(),
 		abortJob:    make(map[string]*cos.StopCh, 100),
+		jobs:        make(map[string]jobif, 100),
 	}
 }
 
@@ -88,6 +90,7 @@ mloop:
 			// may not saturate the full downloader throughput).
 			d.mtx.Lock()
 			d.abortJob[job.ID()] = cos.NewStopCh()
+			d.jobs[job.ID()] = job
 			d.mtx.Unlock()
 
 			select {
@@ -138,6 +141,7 @@ func (d *dispatcher) cleanupJob(jobID string) {
 		ch.Close()
 		delete(d.abortJob, jobID)
 	}
+	delete(d.jobs, jobID)
 	d.mtx.Unlock()
 }
 
@@ -212,6 +216,9 @@ func (d *dispatcher) dispatchDownload(job jobif) (ok bool) {
 			}
 
 			for _, obj := range objs {
+				if !job.Sync() && dlStore.wasDone(job.ID(), obj.idx) {
+					continue // resumed job: already processed prior to restart
+				}
 				if d.checkAborted() {
 					err := cmn.NewErrAborted(job.String(), "", nil)
 					diffResolver.Abort(err)
@@ -236,6 +243,8 @@ func (d *dispatcher) dispatchDownload(job jobif) (ok bool) {
 					diffResolver.PushDst(&WebResource{
 						ObjName: obj.objName,
 						Link:    obj.link,
+						idx:     obj.idx,
+						meta:    obj.meta,
 					})
 				} else {
 					diffResolver.PushDst(&BackendResource{
@@ -259,6 +268,8 @@ func (d *dispatcher) dispatchDownload(job jobif) (ok bool) {
 					objName:    dst.ObjName,
 					link:       dst.Link,
 					fromRemote: dst.Link == "",
+					idx:        dst.idx,
+					meta:       dst.meta,
 				}
 			} else {
 				src := result.Src
@@ -267,6 +278,7 @@ func (d *dispatcher) dispatchDownload(job jobif) (ok bool) {
 					objName:    src.ObjName,
 					link:       "",
 					fromRemote: true,
+					idx:        -1,
 				}
 			}
 
@@ -274,6 +286,7 @@ func (d *dispatcher) dispatchDownload(job jobif) (ok bool) {
 
 			if result.Action == DiffResolverSkip {
 				dlStore.incSkipped(job.ID())
+				dlStore.markDone(job.ID(), obj.idx, doneSkipped)
 				continue
 			}
 
@@ -397,6 +410,8 @@ func (d *dispatcher) adminReq(req *request) (resp any, statusCode int, err error
 		d.handleAbort(req)
 	case actRemove:
 		d.handleRemove(req)
+	case actLimits:
+		d.handleLimits(req)
 	default:
 		debug.Assertf(false, "%v; %v", req, req.action)
 	}
@@ -437,6 +452,20 @@ func (d *dispatcher) handleAbort(req *request) {
 	req.okRsp(nil)
 }
 
+// apply this target's (new) share of the job's cluster-wide limits
+func (d *dispatcher) handleLimits(req *request) {
+	if _, err := d.xdl.checkJob(req); err != nil {
+		return
+	}
+	d.mtx.RLock()
+	job, ok := d.jobs[req.id]
+	d.mtx.RUnlock()
+	if ok {
+		job.throttler().setLimits(req.limits)
+	}
+	req.okRsp(nil)
+}
+
 func (d *dispatcher) handleStatus(req *request) {
 	var (
 		finishedTasks []TaskDlInfo
@@ -448,6 +477,13 @@ func (d *dispatcher) handleStatus(req *request) {
 	}
 
 	currentTasks := d.activeTasks(req.id)
+	d.mtx.RLock()
+	job, ok := d.jobs[req.id]
+	d.mtx.RUnlock()
+	var hosts map[string]int
+	if ok {
+		hosts = job.throttler().hostLoad()
+	}
 	if !req.onlyActive {
 		finishedTasks, err = dlStore.getTasks(req.id)
 		if err != nil {
@@ -468,6 +504,7 @@ func (d *dispatcher) handleStatus(req *request) {
 		CurrentTasks:  currentTasks,
 		FinishedTasks: finishedTasks,
 		Errs:          dlErrors,
+		Hosts:         hosts,
 	})
 }
 
@@ -0,0 +1,181 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"fmt"
+	"math/bits"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Compact per-job completion record: a bitmap indexed by the object's position
+// in the job's deterministic sequence of objects (see `dlObj.idx`), along with
+// the per-outcome counters. The bitmap is persisted in fixed-size segments and
+// only the modified ones get written. Resuming a job requires (number of
+// objects)/8 bytes - processed objects are never materialized by name.
+// Jobs without deterministic order (bucket listing) are not indexed.
+
+const (
+	downloaderDone = "done"
+
+	doneSegObjs = 64 * 1024       // objects per persisted segment
+	doneSegSize = doneSegObjs / 8 // (bytes)
+)
+
+// outcomes
+const (
+	doneFinished = iota
+	doneSkipped
+	doneError
+)
+
+type (
+	doneRecord struct {
+		segs  map[int64][]byte
+		dirty map[int64]struct{}
+		cnt   doneCnt
+		marks int // since the last flush
+		mtx   sync.Mutex
+	}
+	doneCnt struct {
+		Finished int32 `json:"finished"`
+		Skipped  int32 `json:"skipped"`
+		Errors   int32 `json:"errors"`
+	}
+)
+
+func donePrefix(id string) string { return path.Join(downloaderDone, id) + "/" }
+
+func newDoneRecord() *doneRecord {
+	return &doneRecord{segs: make(map[int64][]byte, 4), dirty: make(map[int64]struct{}, 4)}
+}
+
+func (rec *doneRecord) has(idx int64) (yes bool) {
+	if idx < 0 {
+		return
+	}
+	rec.mtx.Lock()
+	if seg, ok := rec.segs[idx/doneSegObjs]; ok {
+		off := idx % doneSegObjs
+		yes = seg[off/8]&(1<<(off%8)) != 0
+	}
+	rec.mtx.Unlock()
+	return
+}
+
+// returns true when it's time to flush
+func (rec *doneRecord) mark(idx int64, outcome int) (flush bool) {
+	if idx < 0 {
+		return
+	}
+	rec.mtx.Lock()
+	defer rec.mtx.Unlock()
+	n := idx / doneSegObjs
+	seg, ok := rec.segs[n]
+	if !ok {
+		seg = make([]byte, doneSegSize)
+		rec.segs[n] = seg
+	}
+	off := idx % doneSegObjs
+	if seg[off/8]&(1<<(off%8)) != 0 {
+		return // (already marked)
+	}
+	seg[off/8] |= 1 << (off % 8)
+	rec.dirty[n] = struct{}{}
+	switch outcome {
+	case doneFinished:
+		rec.cnt.Finished++
+	case doneSkipped:
+		rec.cnt.Skipped++
+	default:
+		rec.cnt.Errors++
+	}
+	rec.marks++
+	return rec.marks >= taskInfoCacheSize
+}
+
+func (rec *doneRecord) count() (n int) {
+	rec.mtx.Lock()
+	for _, seg := range rec.segs {
+		for _, b := range seg {
+			n += bits.OnesCount8(b)
+		}
+	}
+	rec.mtx.Unlock()
+	return
+}
+
+//////////////////
+// downloaderDB //
+//////////////////
+
+// writes the modified segments, followed by the counters
+func (db *downloaderDB) persistDone(id string, rec *doneRecord) error {
+	rec.mtx.Lock()
+	defer rec.mtx.Unlock()
+	if len(rec.dirty) == 0 {
+		return nil
+	}
+	prefix := donePrefix(id)
+	for n := range rec.dirty {
+		if err := db.driver.Set(downloaderCollection, prefix+fmt.Sprintf("%08d", n), rec.segs[n]); err != nil {
+			glog.Error(err)
+			return err
+		}
+		delete(rec.dirty, n)
+	}
+	rec.marks = 0
+	if err := db.driver.Set(downloaderCollection, path.Join(downloaderDone, id), &rec.cnt); err != nil {
+		glog.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (db *downloaderDB) loadDone(id string) (*doneRecord, error) {
+	rec := newDoneRecord()
+	if err := db.driver.Get(downloaderCollection, path.Join(downloaderDone, id), &rec.cnt); err != nil {
+		if kvdb.IsErrNotFound(err) {
+			return rec, nil
+		}
+		return nil, err
+	}
+	prefix := donePrefix(id)
+	segs, err := db.driver.GetAll(downloaderCollection, prefix)
+	if err != nil && !kvdb.IsErrNotFound(err) {
+		return nil, err
+	}
+	for key, val := range segs {
+		n, err := strconv.ParseInt(strings.TrimPrefix(key, prefix), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid completion record %q: %v", key, err)
+		}
+		var seg []byte // (base64)
+		if err := jsoniter.UnmarshalFromString(val, &seg); err != nil {
+			return nil, err
+		}
+		if len(seg) != doneSegSize {
+			return nil, fmt.Errorf("invalid completion record %q: size %d", key, len(seg))
+		}
+		rec.segs[n] = seg
+	}
+	return rec, nil
+}
+
+func (db *downloaderDB) deleteDone(id string) {
+	db.driver.Delete(downloaderCollection, path.Join(downloaderDone, id))
+	if segs, err := db.driver.GetAll(downloaderCollection, donePrefix(id)); err == nil {
+		for key := range segs {
+			db.driver.Delete(downloaderCollection, key)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"golang.org/x/sync/errgroup"
+)
+
+// Resumable download of a web link into a local (work) file:
+// - download that was interrupted by the target's restart continues from the
+//   work file's current size (see `dlWorkFQN` and `dlXattrIfRange`);
+// - transient errors are retried with exponential backoff; each retry resumes the
+//   transfer from the last written offset via HTTP Range request conditional on
+//   the object being unchanged (If-Range: strong ETag or Last-Modified); if the
+//   server responds with the entire object (the object has changed or the server
+//   ignores ranges), the download restarts from scratch;
+// - objects larger than the configured chunk size are fetched in parallel chunks,
+//   provided the server supports byte ranges (see `Chunks`);
+// - the result can be verified against the expected checksum (see `Verify`).
+
+const (
+	retryBackoff    = time.Second
+	maxRetryBackoff = 30 * time.Second
+
+	maxSidecarSize = cos.KiB
+)
+
+type (
+	fetcher struct {
+		ctx     context.Context
+		client  *http.Client
+		wrap    func(ctx context.Context, r io.ReadCloser) io.ReadCloser // progress & throttling
+		restart func()                                                   // download restarts from scratch
+		link    string
+		name    string // (for logging)
+		timeout time.Duration
+		backoff time.Duration
+		chunk   int64 // chunk size (parallel chunked fetching when conns > 1)
+		conns   int
+		// learned by the sequential (probing) requests and read-only thereafter,
+		// while the remaining chunks are being fetched in parallel
+		hdr   *http.Response // the first received response (headers only)
+		total int64          // total size, if known (-1 otherwise)
+		// If-Range validator: learned from the first response or, when resuming
+		// after restart, set by the caller (empty when the server provides none)
+		ifRange string
+	}
+
+	// byte range [off, end) of the object; end < 0: until EOF
+	chunk struct {
+		off, end int64
+	}
+
+	offsetWriter struct {
+		w   io.WriterAt
+		off int64
+	}
+)
+
+func newFetcher(ctx context.Context, link, name string, timeout time.Duration) *fetcher {
+	return &fetcher{
+		ctx:     ctx,
+		client:  clientForURL(link),
+		wrap:    func(_ context.Context, r io.ReadCloser) io.ReadCloser { return r },
+		restart: func() {},
+		link:    link,
+		name:    name,
+		timeout: timeout,
+		backoff: retryBackoff,
+		total:   -1,
+	}
+}
+
+// fetch downloads the link into the file, starting from the given offset
+func (f *fetcher) fetch(fh *os.File, off int64) error {
+	first := &chunk{off: off, end: -1}
+	if f.conns > 1 && f.chunk > 0 {
+		first.end = off + f.chunk // probe: if the server supports ranges we'll also learn the total size
+	}
+	if err := f.retry(func(timeout time.Duration) (bool, error) { return f.get(fh, first, true, timeout) }); err != nil {
+		return err
+	}
+	if first.end < 0 {
+		return nil // done: fetched as a whole
+	}
+	if f.total < 0 {
+		// unknown total size - the rest sequentially
+		rest := &chunk{off: first.end, end: -1}
+		return f.retry(func(timeout time.Duration) (bool, error) { return f.get(fh, rest, true, timeout) })
+	}
+	if f.total <= first.end {
+		return nil // done: a single chunk
+	}
+
+	// the rest - in parallel
+	var (
+		sema  = cos.NewSemaphore(f.conns)
+		group = errgroup.Group{}
+	)
+	for off := first.end; off < f.total; off += f.chunk {
+		c := &chunk{off: off, end: cos.MinI64(off+f.chunk, f.total)}
+		sema.Acquire()
+		group.Go(func() error {
+			defer sema.Release()
+			return f.retry(func(timeout time.Duration) (bool, error) { return f.get(fh, c, false, timeout) })
+		})
+	}
+	return group.Wait()
+}
+
+// get performs a single (ranged) GET request and writes the response body into the file;
+// only sequential requests (`seq`) update the fetcher - parallel chunks treat it as read-only
+func (f *fetcher) get(fh *os.File, c *chunk, seq bool, timeout time.Duration) (bool /*err is fatal*/, error) {
+	ctx, cancel := context.WithTimeout(f.ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.link, http.NoBody)
+	if err != nil {
+		return true, err
+	}
+	// Set "User-Agent" header when doing requests to Google Cloud Storage.
+	// This should increase the number of connections to GCS.
+	if cos.IsGoogleStorageURL(req.URL) {
+		req.Header.Add("User-Agent", gcsUA)
+	}
+	if c.off > 0 || c.end >= 0 {
+		rng := fmt.Sprintf("bytes=%d-", c.off)
+		if c.end >= 0 {
+			rng += strconv.FormatInt(c.end-1, 10)
+		}
+		req.Header.Set(cos.HdrRange, rng)
+		if f.ifRange != "" {
+			req.Header.Set(cos.HdrIfRange, f.ifRange)
+		}
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer cos.Close(resp.Body)
+
+	if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && c.end < 0 && seq {
+		total := f.total
+		if total < 0 {
+			total = contentRangeTotal(resp.Header.Get(cos.HdrContentRange)) // "bytes */<total>"
+		}
+		if total >= 0 && c.off >= total {
+			f.total = total
+			return false, nil // nothing left to read (e.g., resumed download that was already complete)
+		}
+	}
+	switch {
+	case resp.StatusCode >= http.StatusBadRequest:
+		return false, cmn.NewErrHTTP(req, errors.New("nil error w/ bad status"), resp.StatusCode)
+	case resp.StatusCode == http.StatusPartialContent:
+		if seq {
+			if total := contentRangeTotal(resp.Header.Get(cos.HdrContentRange)); total >= 0 {
+				f.total = total
+			}
+		}
+		if c.end > f.total && f.total >= 0 {
+			c.end = f.total
+		}
+	default: // the entire object
+		if !seq {
+			return true, fmt.Errorf("%s: expected partial content for range [%d, %d), got %q", f.name, c.off, c.end, resp.Status)
+		}
+		if c.off > 0 {
+			glog.Warningf("%s: changed or ignored range request - restarting from scratch", f.name)
+			if err := fh.Truncate(0); err != nil {
+				return true, err
+			}
+			f.restart()
+		}
+		c.off, c.end = 0, -1
+		f.total = resp.ContentLength
+	}
+	if seq && f.hdr == nil {
+		f.hdr = &http.Response{Header: resp.Header, ContentLength: f.total, StatusCode: resp.StatusCode}
+	}
+	if seq && (f.ifRange == "" || resp.StatusCode == http.StatusOK) {
+		f.ifRange = ifRangeValidator(resp.Header)
+	}
+
+	r := f.wrap(ctx, resp.Body)
+	n, err := io.Copy(&offsetWriter{w: fh, off: c.off}, r)
+	c.off += n
+	if err != nil {
+		return false, err
+	}
+	if (c.end >= 0 && c.off < c.end) || (c.end < 0 && f.total >= 0 && c.off < f.total) {
+		return false, io.ErrUnexpectedEOF // (resume)
+	}
+	return false, nil
+}
+
+// retry with exponential backoff, increasing the timeout when exceeded
+func (f *fetcher) retry(cb func(timeout time.Duration) (bool, error)) (err error) {
+	var (
+		fatal   bool
+		timeout = f.timeout
+		backoff = f.backoff
+	)
+	for i := 0; i < retryCnt; i++ {
+		fatal, err = cb(timeout)
+		if err == nil || fatal {
+			return err
+		}
+		if errors.Is(err, context.Canceled) || errors.Is(err, errThrottlerStopped) {
+			// Download was canceled or stopped, so just return.
+			return err
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			glog.Warningf("%s [retries: %d/%d]: timeout (%v) - increasing and retrying...", f.name, i, retryCnt, timeout)
+			timeout = time.Duration(float64(timeout) * reqTimeoutFactor)
+		} else if herr := cmn.Err2HTTPErr(err); herr != nil {
+			glog.Warningf("%s [retries: %d/%d]: failed to perform request: %v (code: %d)", f.name, i, retryCnt, err, herr.Status)
+			if _, exists := terminalStatuses[herr.Status]; exists {
+				// Nothing we can do...
+				return err
+			}
+			// Otherwise retry...
+		} else if cos.IsRetriableConnErr(err) || errors.Is(err, io.ErrUnexpectedEOF) {
+			glog.Warningf("%s [retries: %d/%d]: connection failed with (%v), retrying...", f.name, i, retryCnt, err)
+		} else {
+			glog.Warningf("%s [retries: %d/%d]: unexpected error (%v), retrying...", f.name, i, retryCnt, err)
+		}
+		select {
+		case <-f.ctx.Done():
+			return f.ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+	return err
+}
+
+// fetchSidecar returns the checksum from the "<link>.<cksum_type>" file, formatted
+// as per md5sum/sha256sum utilities: "<hex-value> [*]<filename>" or just "<hex-value>"
+func (f *fetcher) fetchSidecar(ty string) (value string, err error) {
+	link := f.link + "." + ty
+	err = f.retry(func(timeout time.Duration) (bool, error) {
+		ctx, cancel := context.WithTimeout(f.ctx, timeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, http.NoBody)
+		if err != nil {
+			return true, err
+		}
+		resp, err := f.client.Do(req)
+		if err != nil {
+			return false, err
+		}
+		defer cos.Close(resp.Body)
+		if resp.StatusCode >= http.StatusBadRequest {
+			return false, cmn.NewErrHTTP(req, errors.New("nil error w/ bad status"), resp.StatusCode)
+		}
+		b, err := io.ReadAll(io.LimitReader(resp.Body, maxSidecarSize))
+		if err != nil {
+			return false, err
+		}
+		fields := strings.Fields(string(b))
+		if len(fields) == 0 {
+			return true, fmt.Errorf("checksum sidecar %q is empty", link)
+		}
+		value = strings.ToLower(fields[0])
+		return false, nil
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to fetch checksum sidecar: %w", err)
+	}
+	return
+}
+
+// strong ETag or, if there's none, Last-Modified (weak ETags cannot be used with If-Range)
+func ifRangeValidator(hdr http.Header) string {
+	if etag := hdr.Get(cos.HdrETag); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return hdr.Get(cos.HdrLastModified)
+}
+
+// "bytes 0-1023/4096" => 4096 (or -1 if unknown)
+func contentRangeTotal(cr string) int64 {
+	i := strings.LastIndexByte(cr, '/')
+	if i < 0 {
+		return -1
+	}
+	total, err := strconv.ParseInt(cr[i+1:], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return total
+}
+
+// checksum computes the checksum (of the given type) of the file and, in the same pass,
+// verifies the expected one (if any)
+func checksum(fh *os.File, ty string, expected *cos.Cksum) (*cos.CksumHash, error) {
+	if _, err := fh.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	var (
+		w      = io.Discard
+		vcksum *cos.CksumHash
+	)
+	if expected != nil && expected.Type() != ty {
+		vcksum = cos.NewCksumHash(expected.Type())
+		w = vcksum.H
+	}
+	_, cksum, err := cos.CopyAndChecksum(w, fh, nil, ty)
+	if err != nil {
+		return nil, err
+	}
+	if expected == nil {
+		return cksum, nil
+	}
+	computed := cksum
+	if vcksum != nil {
+		vcksum.Finalize()
+		computed = vcksum
+	}
+	if !computed.Equal(expected) {
+		return nil, cos.NewBadDataCksumError(&computed.Cksum, expected)
+	}
+	return cksum, nil
+}
+
+//////////////////
+// offsetWriter //
+//////////////////
+
+func (ow *offsetWriter) Write(p []byte) (n int, err error) {
+	n, err = ow.w.WriteAt(p, ow.off)
+	ow.off += int64(n)
+	return
+}
@@ -0,0 +1,241 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+const fetchTestSize = 100*cos.KiB + 123
+
+// serves `content` with (or without) byte ranges; the first `drops` responses
+// get interrupted midway (connection closed)
+func newFetchServer(t *testing.T, content []byte, ranges bool, drops int32) (*httptest.Server, *atomic.Int32) {
+	var reqs atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/obj", func(w http.ResponseWriter, r *http.Request) {
+		n := reqs.Add(1)
+		if !ranges {
+			r.Header.Del(cos.HdrRange)
+			w.Header().Set(cos.HdrContentLength, strconv.Itoa(len(content)))
+		}
+		if n <= drops {
+			// write part of the response and drop the connection
+			rw := &halfWriter{ResponseWriter: w, left: len(content) / 4}
+			http.ServeContent(rw, r, "obj", time.Time{}, bytes.NewReader(content))
+			panic(http.ErrAbortHandler)
+		}
+		http.ServeContent(w, r, "obj", time.Time{}, bytes.NewReader(content))
+	})
+	mux.HandleFunc("/obj.md5", func(w http.ResponseWriter, _ *http.Request) {
+		sum := md5.Sum(content)
+		w.Write([]byte(hex.EncodeToString(sum[:]) + "  obj\n"))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, &reqs
+}
+
+type halfWriter struct {
+	http.ResponseWriter
+	left int
+}
+
+func (hw *halfWriter) Write(p []byte) (int, error) {
+	if hw.left <= 0 {
+		return 0, http.ErrAbortHandler
+	}
+	if len(p) > hw.left {
+		p = p[:hw.left]
+	}
+	hw.left -= len(p)
+	n, err := hw.ResponseWriter.Write(p)
+	hw.ResponseWriter.(http.Flusher).Flush()
+	return n, err
+}
+
+func testFetch(t *testing.T, link string, chunk int64, conns int, partial []byte) ([]byte, *fetcher, error) {
+	f := newFetcher(context.Background(), link, "test-fetch", 10*time.Second)
+	f.client = http.DefaultClient
+	f.backoff = time.Millisecond
+	f.chunk, f.conns = chunk, conns
+	fh, err := os.Create(filepath.Join(t.TempDir(), "work"))
+	tassert.CheckFatal(t, err)
+	defer fh.Close()
+	_, err = fh.Write(partial) // (downloaded prior to restart)
+	tassert.CheckFatal(t, err)
+	if err := f.fetch(fh, int64(len(partial))); err != nil {
+		return nil, f, err
+	}
+	b, err := os.ReadFile(fh.Name())
+	tassert.CheckFatal(t, err)
+	return b, f, nil
+}
+
+func TestFetch(t *testing.T) {
+	content := make([]byte, fetchTestSize)
+	rand.Read(content)
+
+	tests := []struct {
+		name   string
+		ranges bool
+		drops  int32
+		chunk  int64
+		conns  int
+		resume int // bytes downloaded prior to restart
+	}{
+		{name: "whole", ranges: true},
+		{name: "resume", ranges: true, drops: 3},
+		{name: "no-ranges-restart", ranges: false, drops: 2},
+		{name: "chunked", ranges: true, chunk: 8 * cos.KiB, conns: 4},
+		{name: "chunked-resume", ranges: true, drops: 5, chunk: 16 * cos.KiB, conns: 3},
+		{name: "chunked-no-ranges", ranges: false, chunk: 8 * cos.KiB, conns: 4},
+		{name: "single-chunk", ranges: true, chunk: 2 * fetchTestSize, conns: 4},
+		{name: "restart-resume", ranges: true, resume: fetchTestSize / 3},
+		{name: "restart-resume-drops", ranges: true, drops: 2, resume: fetchTestSize / 3},
+		{name: "restart-complete", ranges: true, resume: fetchTestSize},
+		{name: "restart-no-ranges", ranges: false, resume: fetchTestSize / 3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv, reqs := newFetchServer(t, content, test.ranges, test.drops)
+			b, f, err := testFetch(t, srv.URL+"/obj", test.chunk, test.conns, content[:test.resume])
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, bytes.Equal(b, content), "content mismatch: got %d bytes, expected %d", len(b), len(content))
+			tassert.Errorf(t, f.total == int64(len(content)), "expected total %d, got %d", len(content), f.total)
+			if test.drops > 0 {
+				tassert.Errorf(t, reqs.Load() > test.drops, "expected more than %d requests, got %d", test.drops, reqs.Load())
+			}
+		})
+	}
+}
+
+func TestFetchVerify(t *testing.T) {
+	content := make([]byte, fetchTestSize)
+	rand.Read(content)
+	srv, _ := newFetchServer(t, content, true, 0)
+
+	f := newFetcher(context.Background(), srv.URL+"/obj", "test-fetch", 10*time.Second)
+	f.client = http.DefaultClient
+	value, err := f.fetchSidecar(cos.ChecksumMD5)
+	tassert.CheckFatal(t, err)
+	sum := md5.Sum(content)
+	tassert.Fatalf(t, value == hex.EncodeToString(sum[:]), "unexpected sidecar checksum %q", value)
+
+	fh, err := os.Create(filepath.Join(t.TempDir(), "work"))
+	tassert.CheckFatal(t, err)
+	defer fh.Close()
+	tassert.CheckFatal(t, f.fetch(fh, 0))
+	// same type (one checksum) and different type (two, in one pass)
+	cksum, err := checksum(fh, cos.ChecksumMD5, cos.NewCksum(cos.ChecksumMD5, value))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, cksum.Value() == value, "unexpected checksum %s", cksum)
+	cksum, err = checksum(fh, cos.ChecksumXXHash, cos.NewCksum(cos.ChecksumMD5, value))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, cksum.Type() == cos.ChecksumXXHash, "unexpected checksum %s", cksum)
+
+	_, err = checksum(fh, cos.ChecksumXXHash, cos.NewCksum(cos.ChecksumMD5, "0123456789abcdef0123456789abcdef"))
+	tassert.Fatalf(t, err != nil, "expected checksum mismatch")
+	_, ok := err.(*cos.ErrBadCksum)
+	tassert.Errorf(t, ok, "expected bad-checksum error, got %v (%T)", err, err)
+}
+
+// the object changes (new content and ETag) after the first request
+func TestFetchIfRange(t *testing.T) {
+	v1, v2 := make([]byte, fetchTestSize), make([]byte, fetchTestSize)
+	rand.Read(v1)
+	rand.Read(v2)
+	var (
+		reqs    atomic.Int32
+		ifRange atomic.Int32 // requests with If-Range
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/obj", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(cos.HdrIfRange) != "" {
+			ifRange.Add(1)
+		}
+		if reqs.Add(1) == 1 {
+			w.Header().Set(cos.HdrETag, `"v1"`)
+			rw := &halfWriter{ResponseWriter: w, left: len(v1) / 4}
+			http.ServeContent(rw, r, "obj", time.Time{}, bytes.NewReader(v1))
+			panic(http.ErrAbortHandler)
+		}
+		w.Header().Set(cos.HdrETag, `"v2"`)
+		http.ServeContent(w, r, "obj", time.Time{}, bytes.NewReader(v2))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	run := func(partial []byte, validator string) ([]byte, int) {
+		var restarts int
+		f := newFetcher(context.Background(), srv.URL+"/obj", "test-fetch", 10*time.Second)
+		f.client = http.DefaultClient
+		f.backoff = time.Millisecond
+		f.restart = func() { restarts++ }
+		f.ifRange = validator
+		fh, err := os.Create(filepath.Join(t.TempDir(), "work"))
+		tassert.CheckFatal(t, err)
+		defer fh.Close()
+		_, err = fh.Write(partial)
+		tassert.CheckFatal(t, err)
+		tassert.CheckFatal(t, f.fetch(fh, int64(len(partial))))
+		b, err := os.ReadFile(fh.Name())
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, f.ifRange == `"v2"`, "expected validator %q, got %q", `"v2"`, f.ifRange)
+		return b, restarts
+	}
+
+	// changed while being downloaded: v1 (interrupted) => v2 from scratch
+	b, restarts := run(nil, "")
+	tassert.Fatalf(t, bytes.Equal(b, v2), "expected new content")
+	tassert.Errorf(t, restarts == 1 && ifRange.Load() == 1, "expected 1 restart and 1 If-Range request, got %d, %d",
+		restarts, ifRange.Load())
+
+	// changed while the target was restarting
+	b, restarts = run(v1[:fetchTestSize/3], `"v1"`)
+	tassert.Fatalf(t, bytes.Equal(b, v2), "expected new content")
+	tassert.Errorf(t, restarts == 1, "expected restart, got %d", restarts)
+
+	// unchanged - resumed
+	b, restarts = run(v2[:fetchTestSize/3], `"v2"`)
+	tassert.Fatalf(t, bytes.Equal(b, v2), "expected new content")
+	tassert.Errorf(t, restarts == 0, "expected no restarts, got %d", restarts)
+}
+
+func TestIfRangeValidator(t *testing.T) {
+	for _, test := range []struct {
+		etag, lastModified, expected string
+	}{
+		{etag: `"abc"`, lastModified: "Wed, 21 Oct 2015 07:28:00 GMT", expected: `"abc"`},
+		{etag: `W/"abc"`, lastModified: "Wed, 21 Oct 2015 07:28:00 GMT", expected: "Wed, 21 Oct 2015 07:28:00 GMT"},
+		{etag: `W/"abc"`},
+		{},
+	} {
+		hdr := make(http.Header)
+		if test.etag != "" {
+			hdr.Set(cos.HdrETag, test.etag)
+		}
+		if test.lastModified != "" {
+			hdr.Set(cos.HdrLastModified, test.lastModified)
+		}
+		v := ifRangeValidator(hdr)
+		tassert.Errorf(t, v == test.expected, "%+v: got %q", test, v)
+	}
+}
@@ -8,6 +8,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/kvdb"
 	"github.com/NVIDIA/aistore/hk"
@@ -18,16 +21,46 @@ var (
 	db          kvdb.Driver
 	dlStore     *infoStore
 	dlStoreOnce sync.Once
+
+	stopping *atomic.Bool // true when the node is shutting down
 )
 
-// TODO: stored only in memory, should be persisted at some point (powercycle)
+// Job definitions and their states are persisted in the kvdb (see `PersistedJob`),
+// along with per-task results (see downloaderDB) and compact completion records
+// (see `doneRecord`) - upon restart, the target restores all jobs and resumes
+// the ones that were interrupted by the node's shutdown (see `LoadJobs` and `Xact.Resume`).
 type infoStore struct {
 	*downloaderDB
 	dljobs map[string]*dljob
 	sync.RWMutex
 }
 
-func SetDB(dbdrv kvdb.Driver) { db = dbdrv }
+func Init(dbdrv kvdb.Driver, nodeStopping *atomic.Bool) { db, stopping = dbdrv, nodeStopping }
+
+func nodeStopping() bool { return stopping != nil && stopping.Load() }
+
+// LoadJobs restores all download jobs persisted by the target prior to its
+// restart and returns those that were interrupted - to be resumed via `Xact.Resume`.
+func LoadJobs() ([]*PersistedJob, error) {
+	initInfoStore(db)
+	return dlStore.loadJobs()
+}
+
+// FailResume marks the job that could not be resumed as aborted (and finished).
+func FailResume(pj *PersistedJob, err error) {
+	dljob, errN := dlStore.getJob(pj.ID)
+	if errN != nil {
+		dljob = pj.dljob()
+		dlStore.Lock()
+		dlStore.dljobs[pj.ID] = dljob
+		dlStore.Unlock()
+	}
+	dlStore.persistError(pj.ID, "", "failed to resume: "+err.Error())
+	dlStore.flush(pj.ID)
+	dljob.aborted.Store(true)
+	dljob.finishedTime.Store(time.Now())
+	dlStore.persistJob(dljob.record())
+}
 
 func initInfoStore(db kvdb.Driver) {
 	dlStoreOnce.Do(func() {
@@ -69,20 +102,110 @@ func (is *infoStore) getList(req *request) (jobs []*dljob) {
 	return
 }
 
-func (is *infoStore) setJob(job jobif) (njob *dljob) {
-	njob = &dljob{
+func newDljob(job jobif) *dljob {
+	return &dljob{
 		id:          job.ID(),
 		xactID:      job.XactID(),
 		total:       job.Len(),
 		description: job.Description(),
 		startedTime: time.Now(),
+		bck:         *job.Bck(),
+		dlb:         job.body(),
+		limits:      job.limits(),
+		done:        newDoneRecord(),
 	}
+}
+
+func (is *infoStore) setJob(job jobif) (njob *dljob) {
+	njob = newDljob(job)
+	is.addJob(njob)
+	return
+}
+
+func (is *infoStore) addJob(njob *dljob) {
 	is.Lock()
-	is.dljobs[job.ID()] = njob
+	is.dljobs[njob.id] = njob
 	is.Unlock()
+	is.persistJob(njob.record())
+}
+
+// loadJobs restores persisted jobs and returns those that must be resumed
+func (is *infoStore) loadJobs() (resume []*PersistedJob, err error) {
+	pjs, err := is.jobs()
+	if err != nil {
+		return nil, err
+	}
+	for _, pj := range pjs {
+		if _, err := is.getJob(pj.ID); err == nil {
+			continue // (already loaded)
+		}
+		if _isRunning(pj.FinishedTime) && !pj.Aborted {
+			resume = append(resume, pj)
+			continue
+		}
+		j := pj.dljob()
+		if _isRunning(pj.FinishedTime) {
+			// aborted but never finished (e.g., the target was killed while aborting)
+			j.finishedTime.Store(time.Now())
+			is.persistJob(j.record())
+		}
+		is.Lock()
+		is.dljobs[j.id] = j
+		is.Unlock()
+	}
 	return
 }
 
+// resumeJob registers interrupted job, restoring its completion record and
+// counters; the objects that were processed prior to the restart (successfully
+// or otherwise) won't be dispatched again.
+func (is *infoStore) resumeJob(job jobif, pj *PersistedJob) (njob *dljob, err error) {
+	rec, err := is.loadDone(pj.ID)
+	if err != nil {
+		return nil, err
+	}
+	njob = newDljob(job)
+	njob.startedTime = pj.StartedTime
+	njob.done = rec
+	njob.finishedCnt.Store(rec.cnt.Finished + rec.cnt.Skipped)
+	njob.skippedCnt.Store(rec.cnt.Skipped)
+	njob.errorCnt.Store(rec.cnt.Errors)
+	njob.scheduledCnt.Store(rec.cnt.Finished + rec.cnt.Skipped + rec.cnt.Errors)
+	is.addJob(njob)
+	n := rec.count()
+	glog.Infof("resuming download job %q: %d object%s already processed (%d error%s)", pj.ID,
+		n, cos.Plural(n), rec.cnt.Errors, cos.Plural(int(rec.cnt.Errors)))
+	return njob, nil
+}
+
+// returns true if the object was processed prior to the job's resumption
+func (is *infoStore) wasDone(id string, idx int64) bool {
+	dljob, err := is.getJob(id)
+	if err != nil {
+		return false
+	}
+	return dljob.done != nil && dljob.done.has(idx)
+}
+
+// records the object as processed (see `doneRecord`)
+func (is *infoStore) markDone(id string, idx int64, outcome int) {
+	dljob, err := is.getJob(id)
+	debug.AssertNoErr(err)
+	if dljob.done != nil && dljob.done.mark(idx, outcome) {
+		is.persistDone(id, dljob.done)
+	}
+}
+
+// flushes caches and the completion record into the disk
+func (is *infoStore) flush(id string) error {
+	if dljob, err := is.getJob(id); err == nil && dljob.done != nil {
+		if err := is.persistDone(id, dljob.done); err != nil {
+			return err
+		}
+	}
+	return is.downloaderDB.flush(id)
+}
+
 func (is *infoStore) incFinished(id string) {
 	dljob, err := is.getJob(id)
 	debug.AssertNoErr(err)
@@ -121,13 +244,24 @@ func (is *infoStore) markFinished(id string) error {
 		return err
 	}
 	dljob.finishedTime.Store(time.Now())
+	is.persistJob(dljob.record())
 	return dljob.valid()
 }
 
+// the job was interrupted by the node's shutdown and will be resumed
+// upon restart - update its state in memory only
+func (is *infoStore) markInterrupted(id string) {
+	dljob, err := is.getJob(id)
+	debug.AssertNoErr(err)
+	dljob.aborted.Store(true)
+	dljob.finishedTime.Store(time.Now())
+}
+
 func (is *infoStore) setAborted(id string) {
 	dljob, err := is.getJob(id)
 	debug.AssertNoErr(err)
 	dljob.aborted.Store(true)
+	is.persistJob(dljob.record())
 	// NOTE: Don't set `FinishedTime` yet as we are not fully done.
 	//       The job now can be removed but there's no guarantee
 	//       that all tasks have been stopped and all resources were freed.
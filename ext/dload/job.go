@@ -38,6 +38,8 @@ type (
 		objName    string
 		link       string
 		fromRemote bool
+		idx        int64    // position in the job's sequence (see `doneRecord`), -1 if not deterministic
+		meta       *rowMeta // manifest jobs only (see `manifestDlJob`)
 	}
 
 	jobif interface {
@@ -67,6 +69,14 @@ type (
 		// via tryAcquire and release
 		throttler() *throttler
 
+		// original request (persisted to resume the job after restart)
+		body() Body
+		limits() Limits
+
+		// web links only: content verification and parallel chunked fetching
+		verify() Verify
+		chunking() (size int64, conns int)
+
 		// job cleanup
 		cleanup()
 	}
@@ -79,6 +89,10 @@ type (
 		description string
 		timeout     time.Duration
 		throt       throttler
+		dlb         Body
+		verif       Verify
+		chunkSize   int64 // parallel chunked fetching (when chunkConns > 1)
+		chunkConns  int
 	}
 
 	sliceDlJob struct {
@@ -100,6 +114,7 @@ type (
 		pt    cos.ParsedTemplate // range template
 		dir   string             // objects directory(prefix) from request
 		count int                // total number object to download by a target
+		idx   int64              // position of the next link in the template
 		done  bool               // true when iterator is finished, nothing left to read
 	}
 
@@ -127,6 +142,10 @@ type (
 		total         int
 		aborted       atomic.Bool
 		allDispatched atomic.Bool
+		bck           cmn.Bck     // (persisted) job's bucket
+		dlb           Body        // (persisted) original request
+		limits        Limits      // (persisted) job's limits
+		done          *doneRecord // objects processed so far (persisted - see `resumeJob`)
 	}
 )
 
@@ -134,20 +153,33 @@ type (
 // baseDlJob //
 ///////////////
 
-func (j *baseDlJob) init(t cluster.Target, id string, bck *cluster.Bck, timeout, desc string, limits Limits, xdl *Xact) {
-	// TODO: this might be inaccurate if we download 1 or 2 objects because then
-	//  other targets will have limits but will not use them.
-	if limits.BytesPerHour > 0 {
-		limits.BytesPerHour /= t.Sowner().Get().CountActiveTargets()
+func (j *baseDlJob) init(t cluster.Target, id string, bck *cluster.Bck, base *Base, desc string, xdl *Xact) {
+	// initial (even) share of the job's limits - to be subsequently adjusted by the proxy
+	// based on the number of tasks pending on each target (see LimitsMsg)
+	var (
+		smap    = t.Sowner().Get()
+		weights = make(map[string]int, len(smap.Tmap))
+	)
+	for tid, tsi := range smap.Tmap {
+		if !tsi.IsAnySet(cluster.NodeFlagsMaintDecomm) {
+			weights[tid] = 1
+		}
 	}
-	td, _ := time.ParseDuration(timeout)
+	weights[t.SID()] = 1
+	share := base.Limits.Apportion(weights)[t.SID()]
+	td, _ := time.ParseDuration(base.Timeout)
+	chunkSize, _ := base.Chunks.size() // (validated)
 	{
 		j.id = id
 		j.bck = bck
 		j.timeout = td
 		j.description = desc
-		j.throt.init(limits)
+		j.throt.init(base.Limits, share)
 		j.xdl = xdl
+		j.verif = base.Verify
+		if base.Chunks.Conns > 1 {
+			j.chunkSize, j.chunkConns = chunkSize, base.Chunks.Conns
+		}
 	}
 }
 
@@ -157,6 +189,10 @@ func (j *baseDlJob) Bck() *cmn.Bck          { return j.bck.Bucket() }
 func (j *baseDlJob) Timeout() time.Duration { return j.timeout }
 func (j *baseDlJob) Description() string    { return j.description }
 func (*baseDlJob) Sync() bool               { return false }
+func (j *baseDlJob) body() Body             { return j.dlb }
+func (j *baseDlJob) limits() Limits         { return j.throt.total }
+func (j *baseDlJob) verify() Verify         { return j.verif }
+func (j *baseDlJob) chunking() (int64, int) { return j.chunkSize, j.chunkConns }
 
 func (j *baseDlJob) String() (s string) {
 	s = fmt.Sprintf("dl-job[%s]-%s", j.ID(), j.Bck())
@@ -193,6 +229,16 @@ func (j *baseDlJob) throttler() *throttler { return &j.throt }
 
 func (j *baseDlJob) cleanup() {
 	j.throttler().stop()
+	if j.xdl.dispatcher.checkAborted() {
+		if nodeStopping() {
+			// node shutdown - keep the job persisted as running, to resume upon restart
+			dlStore.markInterrupted(j.ID())
+			dlStore.flush(j.ID())
+			return
+		}
+		// downloader aborted (e.g., by user) - the job won't be resumed
+		dlStore.setAborted(j.ID())
+	}
 	err := dlStore.markFinished(j.ID())
 	if err != nil {
 		glog.Errorf("%s: %v", j, err)
@@ -235,7 +281,7 @@ func newMultiDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *Multi
 	var objs cos.StrKVs
 
 	mj = &multiDlJob{}
-	mj.baseDlJob.init(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	mj.baseDlJob.init(t, id, bck, &payload.Base, payload.Describe(), xdl)
 
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
@@ -250,7 +296,7 @@ func newSingleDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *Sing
 	var objs cos.StrKVs
 
 	sj = &singleDlJob{}
-	sj.baseDlJob.init(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	sj.baseDlJob.init(t, id, bck, &payload.Base, payload.Describe(), xdl)
 
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
@@ -273,7 +319,7 @@ func newRangeDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *Range
 	if rj.pt, err = cos.ParseBashTemplate(payload.Template); err != nil {
 		return nil, err
 	}
-	rj.baseDlJob.init(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	rj.baseDlJob.init(t, id, bck, &payload.Base, payload.Describe(), xdl)
 
 	if rj.count, err = countObjects(t, rj.pt, payload.Subdir, rj.bck); err != nil {
 		return nil, err
@@ -314,7 +360,8 @@ func (j *rangeDlJob) getNextObjs() error {
 			break
 		}
 		name := path.Join(j.dir, path.Base(link))
-		obj, err := makeDlObj(smap, sid, j.bck, name, link)
+		obj, err := makeDlObj(smap, sid, j.bck, name, link, j.idx)
+		j.idx++
 		if err != nil {
 			if err == errInvalidTarget {
 				continue
@@ -337,7 +384,7 @@ func newBackendDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *Bac
 		return nil, errors.New("bucket download does not support HTTP buckets")
 	}
 	bj = &backendDlJob{}
-	bj.baseDlJob.init(t, id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	bj.baseDlJob.init(t, id, bck, &payload.Base, payload.Describe(), xdl)
 	{
 		bj.t = t
 		bj.sync = payload.Sync
@@ -392,7 +439,8 @@ func (j *backendDlJob) getNextObjs() error {
 			if !j.checkObj(entry.Name) {
 				continue
 			}
-			obj, err := makeDlObj(smap, sid, j.bck, entry.Name, "")
+			// (listing order may change across restarts - not indexed)
+			obj, err := makeDlObj(smap, sid, j.bck, entry.Name, "", -1)
 			if err != nil {
 				if err == errInvalidTarget {
 					continue
@@ -427,9 +475,35 @@ func (j *dljob) clone() Job {
 		Aborted:       j.aborted.Load(),
 		StartedTime:   j.startedTime,
 		FinishedTime:  j.finishedTime.Load(),
+		Limits:        j.limits,
 	}
 }
 
+func (pj *PersistedJob) dljob() *dljob {
+	j := &dljob{
+		id:          pj.ID,
+		xactID:      pj.XactID,
+		description: pj.Description,
+		startedTime: pj.StartedTime,
+		total:       pj.Total,
+		bck:         pj.Bck,
+		dlb:         pj.Body,
+		limits:      pj.Limits,
+	}
+	j.finishedTime.Store(pj.FinishedTime)
+	j.finishedCnt.Store(int32(pj.FinishedCnt))
+	j.scheduledCnt.Store(int32(pj.ScheduledCnt))
+	j.skippedCnt.Store(int32(pj.SkippedCnt))
+	j.errorCnt.Store(int32(pj.ErrorCnt))
+	j.aborted.Store(pj.Aborted)
+	j.allDispatched.Store(pj.AllDispatched)
+	return j
+}
+
+func (j *dljob) record() *PersistedJob {
+	return &PersistedJob{Job: j.clone(), Bck: j.bck, Body: j.dlb}
+}
+
 // Used for debugging purposes to ensure integrity of the struct.
 func (j *dljob) valid() (err error) {
 	if j.aborted.Load() {
@@ -0,0 +1,487 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Manifest job: streams the manifest object (stored in the cluster) row by row.
+// Every target reads the entire manifest and downloads the objects it owns (HRW);
+// invalid rows are reported (as job errors) by the owner of the row or, when
+// the row has no valid object name, by the owner of the manifest.
+// Row numbers index the job's completion record (see `doneRecord`) - the
+// manifest must not be modified while the job is running (or being resumed).
+
+const (
+	manifestBufSize   = 64 * cos.KiB
+	manifestRangeSize = 4 * cos.MiB // remote manifest is read in ranges of this size
+)
+
+// CSV columns
+const (
+	mcolName       = "name"
+	mcolLink       = "link"
+	mcolSize       = "size"
+	mcolCksumType  = "cksum_type"
+	mcolCksumValue = "cksum_value"
+)
+
+// (hex-encoded) checksums supported by `Verify`
+var cksumSizes = map[string]int{
+	cos.ChecksumMD5:    md5.Size,
+	cos.ChecksumSHA256: sha256.Size,
+	cos.ChecksumSHA512: sha512.Size,
+}
+
+// interface guard
+var _ jobif = (*manifestDlJob)(nil)
+
+type (
+	// JSONL manifest row (and parsed CSV row)
+	manifestRow struct {
+		Custom     cos.StrKVs `json:"custom,omitempty"`
+		Name       string     `json:"name"`
+		Link       string     `json:"link"`
+		CksumType  string     `json:"cksum_type,omitempty"`
+		CksumValue string     `json:"cksum_value,omitempty"`
+		Size       int64      `json:"size,omitempty"`
+	}
+
+	// per-object expectations and custom metadata (see `singleTask.finalize`)
+	rowMeta struct {
+		cksum  *cos.Cksum
+		custom cos.StrKVs
+		size   int64
+	}
+
+	// returns io.EOF when there are no more rows, and `errManifestRow` when
+	// the current row cannot be parsed; any other error is fatal
+	manifestReader interface {
+		next() (*manifestRow, error)
+	}
+	errManifestRow struct {
+		err error
+	}
+
+	csvManifest struct {
+		r    *csv.Reader
+		cols map[string]int
+		hdr  []string
+	}
+	jsonlManifest struct {
+		r *bufio.Reader
+	}
+
+	// reads the manifest from its owner target, range by range, so that
+	// the (possibly, long-running) job does not hold the object open
+	rangeManifest struct {
+		client *http.Client
+		body   io.ReadCloser
+		req    cmn.HreqArgs
+		off    int64
+		size   int64 // -1 until learned from Content-Range
+		got    int64 // read from the current range
+		whole  bool  // ranges not supported - reading the entire object
+	}
+
+	manifestDlJob struct {
+		baseDlJob
+		t        cluster.Target
+		mbck     *cluster.Bck
+		manifest string
+		src      io.ReadCloser
+		rd       manifestReader
+		objs     []dlObj // objects' metas which are ready to be downloaded
+		idx      int64   // number of the next row
+		owner    bool    // true if this target owns the manifest (reports rows w/o valid name)
+		done     bool
+	}
+)
+
+func (e *errManifestRow) Error() string { return e.err.Error() }
+
+/////////////////
+// manifestRow //
+/////////////////
+
+func (row *manifestRow) meta() (*rowMeta, error) {
+	if row.Link == "" {
+		return nil, errors.New("missing link")
+	}
+	if row.Size < 0 {
+		return nil, fmt.Errorf("invalid size %d", row.Size)
+	}
+	meta := &rowMeta{size: row.Size}
+	switch {
+	case row.CksumType == "" && row.CksumValue == "":
+	case row.CksumType == "" || row.CksumValue == "":
+		return nil, errors.New("checksum requires both type and value")
+	default:
+		v := Verify{CksumType: row.CksumType, CksumValue: row.CksumValue}
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+		value := strings.ToLower(row.CksumValue)
+		if b, err := hex.DecodeString(value); err != nil || len(b) != cksumSizes[row.CksumType] {
+			return nil, fmt.Errorf("invalid %s checksum %q", row.CksumType, row.CksumValue)
+		}
+		meta.cksum = cos.NewCksum(row.CksumType, value)
+	}
+	for key := range row.Custom {
+		if key == "" {
+			return nil, errors.New("empty custom metadata key")
+		}
+	}
+	meta.custom = row.Custom
+	return meta, nil
+}
+
+/////////////////
+// csvManifest //
+/////////////////
+
+func newCSVManifest(r io.Reader) (*csvManifest, error) {
+	m := &csvManifest{r: csv.NewReader(r), cols: make(map[string]int, 8)}
+	m.r.TrimLeadingSpace = true
+	m.r.ReuseRecord = true
+	hdr, err := m.r.Read() // (sets the number of fields per record)
+	if err != nil {
+		if err == io.EOF {
+			err = errors.New("empty manifest")
+		}
+		return nil, fmt.Errorf("failed to read manifest header: %w", err)
+	}
+	m.hdr = make([]string, len(hdr))
+	for i, col := range hdr {
+		if col == "" {
+			return nil, fmt.Errorf("manifest header: empty column name (column %d)", i+1)
+		}
+		if _, ok := m.cols[col]; ok {
+			return nil, fmt.Errorf("manifest header: duplicate column %q", col)
+		}
+		m.cols[col] = i
+		m.hdr[i] = col
+	}
+	if _, ok := m.cols[mcolName]; !ok {
+		return nil, fmt.Errorf("manifest header: missing %q column", mcolName)
+	}
+	if _, ok := m.cols[mcolLink]; !ok {
+		return nil, fmt.Errorf("manifest header: missing %q column", mcolLink)
+	}
+	return m, nil
+}
+
+func (m *csvManifest) next() (*manifestRow, error) {
+	rec, err := m.r.Read()
+	if err != nil {
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			return nil, &errManifestRow{err}
+		}
+		return nil, err
+	}
+	row := &manifestRow{}
+	for i, val := range rec {
+		switch col := m.hdr[i]; col {
+		case mcolName:
+			row.Name = val
+		case mcolLink:
+			row.Link = val
+		case mcolCksumType:
+			row.CksumType = val
+		case mcolCksumValue:
+			row.CksumValue = val
+		case mcolSize:
+			if val == "" {
+				continue
+			}
+			if row.Size, err = strconv.ParseInt(val, 10, 64); err != nil {
+				return row, &errManifestRow{fmt.Errorf("invalid size %q", val)}
+			}
+		default:
+			if val == "" {
+				continue
+			}
+			if row.Custom == nil {
+				row.Custom = make(cos.StrKVs, len(rec))
+			}
+			row.Custom[col] = val
+		}
+	}
+	return row, nil
+}
+
+///////////////////
+// jsonlManifest //
+///////////////////
+
+func (m *jsonlManifest) next() (*manifestRow, error) {
+	for {
+		line, err := m.r.ReadBytes('\n')
+		if err != nil && (err != io.EOF || len(line) == 0) {
+			return nil, err
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue // (empty lines are not counted)
+		}
+		row := &manifestRow{}
+		if err := jsoniter.Unmarshal(line, row); err != nil {
+			return nil, &errManifestRow{err}
+		}
+		return row, nil
+	}
+}
+
+///////////////////
+// rangeManifest //
+///////////////////
+
+func (m *rangeManifest) Read(p []byte) (n int, err error) {
+	for {
+		if m.body == nil {
+			if m.size >= 0 && m.off >= m.size {
+				return 0, io.EOF
+			}
+			if err = m.open(); err != nil {
+				return 0, err
+			}
+		}
+		n, err = m.body.Read(p)
+		m.off += int64(n)
+		m.got += int64(n)
+		if err != io.EOF {
+			return n, err
+		}
+		cos.Close(m.body)
+		m.body = nil
+		switch {
+		case m.whole:
+			return n, io.EOF
+		case m.got == 0:
+			return n, io.ErrUnexpectedEOF
+		case n > 0:
+			return n, nil
+		}
+	}
+}
+
+func (m *rangeManifest) open() error {
+	m.req.Header.Set(cos.HdrRange, fmt.Sprintf("bytes=%d-%d", m.off, m.off+manifestRangeSize-1))
+	req, err := m.req.Req()
+	if err != nil {
+		return err
+	}
+	resp, err := m.client.Do(req) //nolint:bodyclose // closed upon reading the range
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		m.size = contentRangeTotal(resp.Header.Get(cos.HdrContentRange))
+	case http.StatusOK:
+		if m.off > 0 {
+			cos.Close(resp.Body)
+			return fmt.Errorf("failed to read manifest %s: range request not supported", m.req.Path)
+		}
+		m.whole = true
+	case http.StatusRequestedRangeNotSatisfiable:
+		cos.Close(resp.Body)
+		m.size = m.off
+		return io.EOF
+	case http.StatusNotFound:
+		cos.Close(resp.Body)
+		return cmn.NewErrNotFound("manifest %s", m.req.Path)
+	default:
+		cos.Close(resp.Body)
+		return fmt.Errorf("failed to read manifest %s: %s", m.req.Path, resp.Status)
+	}
+	m.body, m.got = resp.Body, 0
+	return nil
+}
+
+func (m *rangeManifest) Close() error {
+	if m.body != nil {
+		cos.Close(m.body)
+		m.body = nil
+	}
+	return nil
+}
+
+///////////////////
+// manifestDlJob //
+///////////////////
+
+// NOTE: the number of objects to download is unknown (the manifest is never loaded in its entirety).
+func newManifestDlJob(t cluster.Target, id string, bck *cluster.Bck, payload *ManifestBody, xdl *Xact) (*manifestDlJob, error) {
+	mbck := cluster.CloneBck(&payload.ManifestBck)
+	if err := mbck.Init(t.Bowner()); err != nil {
+		return nil, err
+	}
+	j := &manifestDlJob{t: t, mbck: mbck, manifest: payload.Manifest}
+	j.baseDlJob.init(t, id, bck, &payload.Base, payload.Describe(), xdl)
+
+	// open the manifest (and read the header) right away to fail the request if need be
+	if err := j.open(); err != nil {
+		return nil, err
+	}
+	var err error
+	if payload.Format == ManifestCSV {
+		j.rd, err = newCSVManifest(bufio.NewReaderSize(j.src, manifestBufSize))
+	} else {
+		j.rd = &jsonlManifest{r: bufio.NewReaderSize(j.src, manifestBufSize)}
+	}
+	if err != nil {
+		cos.Close(j.src)
+		return nil, err
+	}
+	return j, nil
+}
+
+func (j *manifestDlJob) open() error {
+	lom := cluster.AllocLOM(j.manifest)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(j.mbck.Bucket()); err != nil {
+		return err
+	}
+	tsi, local, err := lom.HrwTarget(j.t.Sowner().Get())
+	if err != nil {
+		return err
+	}
+	j.owner = local
+	if local {
+		if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+			return err
+		}
+		fh, err := os.Open(lom.FQN)
+		if err != nil {
+			return err
+		}
+		j.src = fh
+		return nil
+	}
+	m := &rangeManifest{client: j.t.DataClient(), size: -1}
+	{
+		m.req.Method = http.MethodGet
+		m.req.Base = tsi.URL(cmn.NetIntraData)
+		m.req.Path = apc.URLPathObjects.Join(lom.Bck().Name, lom.ObjName)
+		m.req.Query = lom.Bck().AddToQuery(nil)
+		m.req.Header = http.Header{apc.HdrCallerID: []string{j.t.SID()}}
+		m.req.BodyR = http.NoBody
+	}
+	// read the first range (e.g., fail if the manifest does not exist)
+	if err := m.open(); err != nil && err != io.EOF {
+		return err
+	}
+	j.src = m
+	return nil
+}
+
+func (*manifestDlJob) Len() int { return -1 }
+
+func (j *manifestDlJob) String() (s string) {
+	return fmt.Sprintf("manifest-%s-%s", &j.baseDlJob, j.mbck.String()+"/"+j.manifest)
+}
+
+func (j *manifestDlJob) genNext() ([]dlObj, bool, error) {
+	if j.done {
+		return nil, false, nil
+	}
+	if err := j.getNextObjs(); err != nil {
+		return nil, false, err
+	}
+	return j.objs, true, nil
+}
+
+func (j *manifestDlJob) getNextObjs() error {
+	var (
+		smap = j.t.Sowner().Get()
+		sid  = j.t.SID()
+	)
+	j.objs = j.objs[:0]
+	for len(j.objs) < downloadBatchSize {
+		row, err := j.rd.next()
+		if err == io.EOF {
+			j.done = true
+			break
+		}
+		idx := j.idx
+		j.idx++
+		if err != nil {
+			var erow *errManifestRow
+			if !errors.As(err, &erow) {
+				return fmt.Errorf("%s: failed to read manifest: %w", j, err)
+			}
+			if row == nil || row.Name == "" {
+				j.rowErr(idx, "", erow.err)
+				continue
+			}
+		}
+		obj, errO := makeDlObj(smap, sid, j.bck, row.Name, row.Link, idx)
+		if errO != nil {
+			if errO != errInvalidTarget {
+				j.rowErr(idx, "", fmt.Errorf("invalid object name %q: %v", row.Name, errO))
+			}
+			continue
+		}
+		if err != nil {
+			j.rowErr(idx, obj.objName, err)
+			continue
+		}
+		if obj.meta, err = row.meta(); err != nil {
+			j.rowErr(idx, obj.objName, err)
+			continue
+		}
+		j.objs = append(j.objs, obj)
+	}
+	return nil
+}
+
+// report invalid row (if not reported yet - e.g., prior to restart)
+// NOTE: rows without valid object name are reported by the manifest's owner
+func (j *manifestDlJob) rowErr(idx int64, objName string, err error) {
+	if objName == "" {
+		if !j.owner {
+			return
+		}
+		objName = fmt.Sprintf("%s[row %d]", j.manifest, idx+1)
+	}
+	if dlStore.wasDone(j.id, idx) {
+		return
+	}
+	if glog.V(4) {
+		glog.Infof("%s: invalid row %d: %v", j, idx+1, err)
+	}
+	dlStore.incScheduled(j.id)
+	dlStore.persistError(j.id, objName, "invalid manifest row: "+err.Error())
+	dlStore.markDone(j.id, idx, doneError)
+	dlStore.incErrorCnt(j.id)
+}
+
+func (j *manifestDlJob) cleanup() {
+	cos.Close(j.src)
+	j.baseDlJob.cleanup()
+}
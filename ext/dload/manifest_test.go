@@ -0,0 +1,152 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func bufioReader(s string) *bufio.Reader { return bufio.NewReader(strings.NewReader(s)) }
+
+// reads all rows; invalid rows are returned as nil (along with the error)
+func readManifest(t *testing.T, rd manifestReader) (rows []*manifestRow, errs []error) {
+	for {
+		row, err := rd.next()
+		if err == io.EOF {
+			return
+		}
+		var erow *errManifestRow
+		if err != nil && !errors.As(err, &erow) {
+			t.Fatal(err)
+		}
+		if err == nil {
+			if _, err = row.meta(); err != nil {
+				row = nil
+			}
+		} else {
+			row = nil
+		}
+		rows, errs = append(rows, row), append(errs, err)
+	}
+}
+
+func TestManifestCSV(t *testing.T) {
+	const manifest = `name,link,size,cksum_type,cksum_value,label,split
+a.jpg,http://x/a.jpg,10,md5,0CC175B9C0F1B6A831C399E269772661,cat,train
+b.jpg, http://x/b.jpg,,,,dog,
+c.jpg,http://x/c.jpg,ten,,,,
+d.jpg,http://x/d.jpg
+,http://x/e.jpg,,,,,
+f.jpg,http://x/f.jpg,,md5,,,
+g.jpg,,,,,,
+"h,1.jpg",http://x/h.jpg,1,sha256,abc,,val
+`
+	rd, err := newCSVManifest(strings.NewReader(manifest))
+	tassert.CheckFatal(t, err)
+	rows, errs := readManifest(t, rd)
+	tassert.Fatalf(t, len(rows) == 8, "expected 8 rows, got %d", len(rows))
+
+	a, err := rows[0].meta()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, rows[0].Name == "a.jpg" && rows[0].Link == "http://x/a.jpg" && a.size == 10, "row 1: %+v", rows[0])
+	tassert.Errorf(t, a.cksum != nil && a.cksum.Value() == "0cc175b9c0f1b6a831c399e269772661", "row 1: %v", a.cksum)
+	tassert.Errorf(t, len(a.custom) == 2 && a.custom["label"] == "cat" && a.custom["split"] == "train",
+		"row 1: %v", a.custom)
+
+	b, err := rows[1].meta()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, rows[1].Link == "http://x/b.jpg" && b.size == 0 && b.cksum == nil, "row 2: %+v", rows[1])
+	tassert.Errorf(t, len(b.custom) == 1 && b.custom["label"] == "dog", "row 2: %v", b.custom)
+
+	for i, what := range map[int]string{2: "size", 3: "field count", 5: "checksum", 6: "link", 7: "checksum value"} {
+		tassert.Errorf(t, rows[i] == nil && errs[i] != nil, "row %d (%s): expected error", i+1, what)
+	}
+	tassert.Errorf(t, rows[4] != nil && rows[4].Name == "", "row 5: expected valid row without name")
+
+	_, err = newCSVManifest(strings.NewReader("name,size\na,1\n"))
+	tassert.Errorf(t, err != nil, "expected missing 'link' column error")
+	_, err = newCSVManifest(strings.NewReader("name,link,name\n"))
+	tassert.Errorf(t, err != nil, "expected duplicate column error")
+	_, err = newCSVManifest(strings.NewReader(""))
+	tassert.Errorf(t, err != nil, "expected empty manifest error")
+}
+
+func TestManifestJSONL(t *testing.T) {
+	const manifest = `{"name": "a.jpg", "link": "http://x/a.jpg", "size": 10, "custom": {"label": "cat"}}
+
+{"name": "b.jpg", "link": "http://x/b.jpg", "cksum_type": "sha512"}
+not json
+{"name": "c.jpg", "link": "http://x/c.jpg", "size": -1}
+{"name": "d.jpg", "link": "http://x/d.jpg", "cksum_type": "md5", "cksum_value": "0cc175b9c0f1b6a831c399e269772661"}`
+
+	rows, errs := readManifest(t, &jsonlManifest{r: bufioReader(manifest)})
+	tassert.Fatalf(t, len(rows) == 5, "expected 5 rows (empty lines not counted), got %d", len(rows))
+	a, err := rows[0].meta()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, a.size == 10 && a.custom["label"] == "cat", "row 1: %+v", rows[0])
+	for i := 1; i < 4; i++ {
+		tassert.Errorf(t, rows[i] == nil && errs[i] != nil, "row %d: expected error", i+1)
+	}
+	d, err := rows[4].meta()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, d.cksum != nil && d.cksum.Type() == cos.ChecksumMD5, "row 5: %+v", rows[4])
+}
+
+func TestManifestRanges(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789abcdef"), manifestRangeSize/8+3) // (2 ranges and change)
+	for _, ranges := range []bool{true, false} {
+		t.Run(fmt.Sprintf("ranges=%t", ranges), func(t *testing.T) {
+			var reqs atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reqs.Add(1)
+				if !ranges {
+					r.Header.Del(cos.HdrRange)
+				}
+				http.ServeContent(w, r, "manifest", time.Time{}, bytes.NewReader(content))
+			}))
+			defer srv.Close()
+
+			m := &rangeManifest{client: srv.Client(), size: -1}
+			m.req = cmn.HreqArgs{Method: http.MethodGet, Base: srv.URL, Path: "/manifest", Header: http.Header{}}
+			got, err := io.ReadAll(m)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, bytes.Equal(got, content), "read %d bytes, expected %d", len(got), len(content))
+			if ranges {
+				tassert.Errorf(t, reqs.Load() == 3, "expected 3 range requests, got %d", reqs.Load())
+			} else {
+				tassert.Errorf(t, reqs.Load() == 1, "expected single request, got %d", reqs.Load())
+			}
+			m.Close()
+		})
+	}
+}
+
+func TestManifestBody(t *testing.T) {
+	bck := cmn.Bck{Name: "dst", Provider: "ais"}
+	body := &ManifestBody{Base: Base{Bck: bck}, Manifest: "meta/train.jsonl"}
+	tassert.CheckFatal(t, body.Validate())
+	tassert.Errorf(t, body.Format == ManifestJSONL && body.ManifestBck.Equal(&bck), "%s", body)
+
+	body = &ManifestBody{Base: Base{Bck: bck}, Manifest: "train.txt"}
+	tassert.Errorf(t, body.Validate() != nil, "expected unknown format error")
+	body.Format = ManifestCSV
+	tassert.CheckError(t, body.Validate())
+	body = &ManifestBody{Base: Base{Bck: bck}}
+	tassert.Errorf(t, body.Validate() != nil, "expected missing manifest error")
+}
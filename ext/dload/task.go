@@ -6,11 +6,13 @@ package dload
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
@@ -18,12 +20,16 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/stats"
 )
 
 const (
 	gcsUA = "gcloud-golang-storage/20151204" // from cloud.google.com/go/storage/storage.go (userAgent).
+
+	// work file's xattr: validator of the partial download (see `fetcher.ifRange`)
+	dlXattrIfRange = "user.ais.dl_if_range"
 )
 
 const (
@@ -42,6 +48,7 @@ type singleTask struct {
 	totalSize   atomic.Int64       // total size (nonzero iff Content-Length header was provided by the source)
 	downloadCtx context.Context    // w/ cancel function
 	cancel      context.CancelFunc // to cancel the download after the request commences
+	host        *hostLimiter       // per-origin limits (web links only; nil if none)
 }
 
 // List of HTTP status codes which we shouldn'task retry (just report the job failed).
@@ -98,6 +105,7 @@ func (task *singleTask) download() {
 	}
 
 	dlStore.incFinished(task.jobID())
+	dlStore.markDone(task.jobID(), task.obj.idx, doneFinished)
 
 	task.xdl.statsT.AddMany(
 		cos.NamedVal64{Name: stats.DownloadSize, Value: task.currentSize.Load()},
@@ -106,88 +114,134 @@ func (task *singleTask) download() {
 	task.xdl.ObjsAdd(1, task.currentSize.Load())
 }
 
-func (task *singleTask) tryDownloadLocal(lom *cluster.LOM, timeout time.Duration) (bool /*err is fatal*/, error) {
-	ctx, cancel := context.WithTimeout(task.downloadCtx, timeout)
-	defer cancel()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, task.obj.link, http.NoBody)
-	if err != nil {
-		return true, err
+func (task *singleTask) downloadLocal(lom *cluster.LOM) (err error) {
+	var (
+		expected *cos.Cksum
+		off      int64
+		verify   = task.job.verify()
+		f        = newFetcher(task.downloadCtx, task.obj.link, task.String(), task.initialTimeout())
+		workFQN  = dlWorkFQN(lom, task.jobID())
+		flags    = os.O_CREATE | os.O_RDWR
+	)
+	f.wrap, f.restart = task.wrapReader, task.reset
+	f.chunk, f.conns = task.job.chunking()
+
+	// per-host limits: one connection per object (parallel chunks notwithstanding)
+	if u, errU := url.Parse(task.obj.link); errU == nil {
+		throt := task.job.throttler()
+		if task.host = throt.host(u.Host); task.host != nil {
+			defer throt.putHost(task.host)
+			if err = throt.acquireHost(task.downloadCtx, task.host); err != nil {
+				return err
+			}
+			defer throt.releaseHost(task.host)
+		}
 	}
 
-	// Set "User-Agent" header when doing requests to Google Cloud Storage.
-	// This should increase the number of connections to GCS.
-	if cos.IsGoogleStorageURL(req.URL) {
-		req.Header.Add("User-Agent", gcsUA)
+	switch {
+	case task.obj.meta != nil && task.obj.meta.cksum != nil:
+		expected = task.obj.meta.cksum // (manifest row)
+	case verify.CksumValue != "":
+		expected = cos.NewCksum(verify.CksumType, strings.ToLower(verify.CksumValue))
+	case verify.Sidecar:
+		value, err := f.fetchSidecar(verify.CksumType)
+		if err != nil {
+			return err
+		}
+		expected = cos.NewCksum(verify.CksumType, value)
 	}
 
-	resp, err := clientForURL(task.obj.link).Do(req)
+	if f.conns > 1 {
+		flags |= os.O_TRUNC // (parallel chunks may leave holes - no resuming)
+	}
+	fh, err := os.OpenFile(workFQN, flags, cos.PermRWR)
 	if err != nil {
-		return false, err
+		return err
 	}
-	defer cos.Close(resp.Body)
-
-	if resp.StatusCode >= http.StatusBadRequest {
-		return false, cmn.NewErrHTTP(req, errors.New("nil error w/ bad status"), resp.StatusCode)
+	if finfo, errS := fh.Stat(); errS == nil && finfo.Size() > 0 {
+		// resume only if the object can be validated as unchanged (If-Range)
+		if b, errX := fs.GetXattr(workFQN, dlXattrIfRange); errX == nil && len(b) > 0 {
+			off, f.ifRange = finfo.Size(), string(b)
+			glog.Infof("%s: resuming partial download from offset %d", task, off)
+			task.currentSize.Store(off)
+		} else {
+			glog.Warningf("%s: cannot validate partial download (%d bytes) - restarting from scratch", task, finfo.Size())
+			if err = fh.Truncate(0); err != nil {
+				cos.Close(fh)
+				return err
+			}
+		}
 	}
-
-	r := task.wrapReader(ctx, resp.Body)
-	size := attrsFromLink(task.obj.link, resp, lom)
-	task.setTotalSize(size)
-
-	params := cluster.AllocPutObjParams()
-	{
-		params.WorkTag = "dl"
-		params.Reader = r
-		params.OWT = cmn.OwtPut
-		params.Atime = task.started.Load()
-		params.Xact = task.xdl
+	if err = f.fetch(fh, off); err == nil {
+		err = task.finalize(lom, fh, f, expected)
 	}
-	erp := task.xdl.t.PutObject(lom, params)
-	cluster.FreePutObjParams(params)
-	if erp != nil {
-		return true, erp
+	cos.Close(fh)
+	if err != nil {
+		if task.xdl.dispatcher.checkAborted() && nodeStopping() {
+			// keep the partial download to resume upon restart
+			if f.ifRange != "" {
+				if errX := fs.SetXattr(workFQN, dlXattrIfRange, []byte(f.ifRange)); errX != nil {
+					glog.Errorf("%s: failed to store %s: %v", task, cos.HdrIfRange, errX)
+				}
+			}
+			return err
+		}
+		if errRm := cos.RemoveFile(workFQN); errRm != nil {
+			glog.Errorf("%s: failed to remove %s: %v", task, workFQN, errRm)
+		}
+		return err
 	}
-	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
-		return true, err
+	if _, err = task.xdl.t.FinalizeObj(lom, workFQN, task.xdl); err != nil {
+		return err
 	}
-	return false, nil
+	return lom.Load(true /*cache it*/, false /*locked*/)
 }
 
-func (task *singleTask) downloadLocal(lom *cluster.LOM) (err error) {
-	var (
-		timeout = task.initialTimeout()
-		fatal   bool
-	)
-	for i := 0; i < retryCnt; i++ {
-		fatal, err = task.tryDownloadLocal(lom, timeout)
-		if err == nil || fatal {
-			return err
-		}
-		if errors.Is(err, context.Canceled) || errors.Is(err, errThrottlerStopped) {
-			// Download was canceled or stopped, so just return.
-			return err
-		}
-		if errors.Is(err, context.DeadlineExceeded) {
-			glog.Warningf("%s [retries: %d/%d]: timeout (%v) - increasing and retrying...",
-				task, i, retryCnt, timeout)
-			timeout = time.Duration(float64(timeout) * reqTimeoutFactor)
-		} else if herr := cmn.Err2HTTPErr(err); herr != nil {
-			glog.Warningf("%s [retries: %d/%d]: failed to perform request: %v (code: %d)", task, i, retryCnt, err, herr.Status)
-			if _, exists := terminalStatuses[herr.Status]; exists {
-				// Nothing we can do...
-				return err
-			}
-			// Otherwise retry...
-		} else if cos.IsRetriableConnErr(err) {
-			glog.Warningf("%s [retries: %d/%d]: connection failed with (%v), retrying...", task, i, retryCnt, err)
-		} else {
-			glog.Warningf("%s [retries: %d/%d]: unexpected error (%v), retrying...", task, i, retryCnt, err)
-		}
+// dlWorkFQN returns deterministic (per job and object) work file that survives the target's restart:
+// an interrupted download continues from the file's current size (see `fetcher`).
+// The name is formatted as any other workfile, with the job ID in place of the
+// tie-breaker and zero PID - unless resumed, it gets eventually removed by space cleanup.
+func dlWorkFQN(lom *cluster.LOM, jobID string) string {
+	dir, fname := filepath.Split(lom.ObjName)
+	base := filepath.Join(dir, fs.WorkfileDownload+"."+fname) + "." + jobID + ".0"
+	return lom.MpathInfo().MakePathFQN(lom.Bucket(), fs.WorkfileType, base)
+}
 
-		task.reset()
+// verify the downloaded content (if requested) and fill in object's metadata
+func (task *singleTask) finalize(lom *cluster.LOM, fh *os.File, f *fetcher, expected *cos.Cksum) error {
+	finfo, err := fh.Stat()
+	if err != nil {
+		return err
 	}
-	return err
+	size := finfo.Size()
+	if f.total >= 0 && size != f.total {
+		return fmt.Errorf("%s: size mismatch: downloaded %d, expected %d", task, size, f.total)
+	}
+	meta := task.obj.meta
+	if meta != nil && meta.size > 0 && size != meta.size {
+		return fmt.Errorf("%s: size mismatch: downloaded %d, manifest %d", task, size, meta.size)
+	}
+	cksum, err := checksum(fh, lom.CksumType(), expected)
+	if err != nil {
+		return err
+	}
+	if f.hdr != nil {
+		attrsFromLink(task.obj.link, f.hdr, lom)
+	}
+	if meta != nil {
+		for key, value := range meta.custom {
+			lom.SetCustomKey(key, value)
+		}
+	}
+	if cksum != nil {
+		lom.SetCksum(cksum.Clone())
+	} else {
+		lom.SetCksum(cos.NoneCksum)
+	}
+	lom.SetSize(size)
+	lom.SetAtimeUnix(task.started.Load().UnixNano())
+	task.setTotalSize(size)
+	return nil
 }
 
 func (task *singleTask) wrapReader(ctx context.Context, r io.ReadCloser) io.ReadCloser {
@@ -200,7 +254,7 @@ func (task *singleTask) wrapReader(ctx context.Context, r io.ReadCloser) io.Read
 		},
 	}
 	// Wrap around throttler reader (noop if throttling is disabled).
-	r = task.job.throttler().wrapReader(ctx, r)
+	r = task.job.throttler().wrapReader(ctx, r, task.host)
 	return r
 }
 
@@ -241,11 +295,18 @@ func (task *singleTask) initialTimeout() time.Duration {
 // also information about specific tasks.
 func (task *singleTask) markFailed(statusMsg string) {
 	task.xdl.statsT.Add(stats.ErrDownloadCount, 1)
-	dlStore.persistError(task.jobID(), task.obj.objName, statusMsg)
+	if !task.xdl.dispatcher.checkAborted() {
+		// (not persisting failures caused by the downloader's stop - those will be retried upon restart)
+		dlStore.persistError(task.jobID(), task.obj.objName, statusMsg)
+		dlStore.markDone(task.jobID(), task.obj.idx, doneError)
+	}
 	dlStore.incErrorCnt(task.jobID())
 }
 
 func (task *singleTask) persist() {
+	if task.xdl.dispatcher.checkAborted() {
+		return // ditto
+	}
 	_ = dlStore.persistTaskInfo(task.jobID(), task.ToTaskDlInfo())
 }
 
@@ -1,6 +1,6 @@
 // Package dload implements functionality to download resources into AIS cluster from external source.
 /*
- * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
  */
 package dload
 
@@ -8,150 +8,359 @@ import (
 	"context"
 	"errors"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn/cos"
 )
 
+// Per-job throttling: connections and throughput (bytes per hour), plus the same two limits
+// per origin server (URL host). The limits this target enforces are its current share
+// of the job's cluster-wide limits - the share can change at any time (see setLimits).
+
 var errThrottlerStopped = errors.New("throttler has been stopped")
 
+const (
+	rateBurstSeconds = 1           // max allowance that can be accumulated while idle (seconds worth of bytes)
+	pausedRecheck    = time.Second // NoShare: interval to check whether it's still the case
+)
+
 type (
 	throttler struct {
-		sema    *cos.Semaphore
-		emptyCh chan struct{} // Empty, closed channel (set only if `sema == nil`).
+		conns   *connLimiter // nil when the job has no connection limit
+		rate    rateLimiter
+		emptyCh chan struct{} // empty, closed channel (when `conns == nil`)
+		stopCh  *cos.StopCh
+		total   Limits // job's (cluster-wide) limits
+
+		mu         sync.Mutex
+		perHost    HostLimits              // current share of per-host limits (hosts not in `hostShares`)
+		hostShares map[string]HostLimits   // current share of per-host limits of the hosts being fetched from
+		hosts      map[string]*hostLimiter // URL host => limiters
+	}
+	hostLimiter struct {
+		conns *connLimiter
+		rate  rateLimiter
+		users int // number of tasks fetching (or waiting to fetch) from the host
+	}
 
-		maxBytesPerMinute int
-		capacityCh        chan int
-		giveBackCh        chan int
-		ticker            *time.Ticker
-		stopCh            *cos.StopCh
+	// resizable semaphore with channel-based acquisition:
+	// shrinking takes (available) tokens out of circulation and, if there are not enough of those,
+	// accumulates "debt" that gets paid off upon release
+	connLimiter struct {
+		tokens chan struct{}
+		mu     sync.Mutex
+		limit  int
+		debt   int
 	}
 
-	throughputThrottler interface {
-		acquireAllowance(ctx context.Context, n int) error
+	// token bucket; zero rate means no limit
+	rateLimiter struct {
+		mu     sync.Mutex
+		bps    float64 // bytes per second
+		avail  float64
+		last   time.Time
+		paused bool // NoShare
 	}
 
 	throttledReader struct {
-		t   throughputThrottler
+		t   *throttler
+		h   *hostLimiter // nil when there's no per-host throughput limit
 		ctx context.Context
 		r   io.ReadCloser
 	}
 )
 
-func (t *throttler) init(limits Limits) {
-	if limits.Connections > 0 {
-		t.sema = cos.NewSemaphore(limits.Connections)
+///////////////
+// throttler //
+///////////////
+
+// `total` is the job's (cluster-wide) limits, `share` - this target's initial share
+func (t *throttler) init(total, share Limits) {
+	t.stopCh = cos.NewStopCh()
+	t.total = total
+	if total.Connections > 0 {
+		t.conns = newConnLimiter(total.Connections, _conns(share.Connections))
 	} else {
 		t.emptyCh = make(chan struct{})
 		close(t.emptyCh)
 	}
-	if limits.BytesPerHour > 0 {
-		t.initThroughputThrottling(limits.BytesPerHour / 60)
+	t.rate.setBytesPerHour(share.BytesPerHour)
+	if total.PerHost.Connections > 0 || total.PerHost.BytesPerHour > 0 {
+		t.perHost = share.PerHost
+		t.hosts = make(map[string]*hostLimiter, 4)
 	}
 }
 
-func (t *throttler) initThroughputThrottling(maxBytesPerMinute int) {
-	t.maxBytesPerMinute = maxBytesPerMinute
-	t.capacityCh = make(chan int, 1)
-	t.giveBackCh = make(chan int, 1)
-	t.ticker = time.NewTicker(time.Minute)
-	t.stopCh = cos.NewStopCh()
-	go func() {
-		defer func() {
-			t.ticker.Stop()
-			close(t.capacityCh)
-		}()
-		t.capacityCh <- t.maxBytesPerMinute
-
-		// LOOP-INVARIANT: `t.capacityCh` has 1 element and `t.giveBackCh` has 0 elements.
-		// LOOP-INVARIANT: `t.capacityCh` and `t.giveBackCh` can't have size > 0 at the same time.
-		// Readers start to compete for resources on `t.capacityCh`.
-		for {
-			select {
-			case <-t.stopCh.Listen():
-				return
-			case leftover := <-t.giveBackCh:
-				// Reader took value from `t.capacityCh` and put it to `t.giveBackCh`.
-				// `t.capacityCh` has 0 elements and `t.giveBackCh` has 0 elements
-				// (we've just read from `t.giveBackCh`).
-				if leftover > 0 {
-					select {
-					// By default put leftover to capacity channel.
-					case t.capacityCh <- leftover:
-						break
-					// But if time has passed, put a big chunk.
-					case <-t.ticker.C:
-						t.capacityCh <- t.maxBytesPerMinute
-					}
-				} else {
-					// Readers are faster than bandwidth, throttle here.
-					<-t.ticker.C
-					t.capacityCh <- t.maxBytesPerMinute
-				}
-				// Regardless of chosen if-branch we put 1 element to `t.capacityCh`.
+// apply new share of the job's limits (limits that were zero at job creation remain unlimited)
+func (t *throttler) setLimits(share *Share) {
+	if t.conns != nil && share.Connections != 0 {
+		t.conns.setLimit(_conns(share.Connections))
+	}
+	if t.total.BytesPerHour > 0 && share.BytesPerHour != 0 {
+		t.rate.setBytesPerHour(share.BytesPerHour)
+	}
+	t.mu.Lock()
+	if t.hosts != nil {
+		if t.total.PerHost.Connections > 0 && share.PerHost.Connections != 0 {
+			t.perHost.Connections = share.PerHost.Connections
+		}
+		if t.total.PerHost.BytesPerHour > 0 && share.PerHost.BytesPerHour != 0 {
+			t.perHost.BytesPerHour = share.PerHost.BytesPerHour
+		}
+		t.hostShares = share.Hosts
+		for host, h := range t.hosts {
+			hl := t.hostShare(host)
+			if h.conns != nil && hl.Connections != 0 {
+				h.conns.setLimit(_conns(hl.Connections))
+			}
+			if t.total.PerHost.BytesPerHour > 0 && hl.BytesPerHour != 0 {
+				h.rate.setBytesPerHour(hl.BytesPerHour)
 			}
 		}
-	}()
+	}
+	t.mu.Unlock()
+}
+
+// under lock
+func (t *throttler) hostShare(host string) HostLimits {
+	if hl, ok := t.hostShares[host]; ok {
+		return hl
+	}
+	return t.perHost
 }
 
 func (t *throttler) tryAcquire() <-chan struct{} {
-	if t.sema == nil {
+	if t.conns == nil {
 		return t.emptyCh
 	}
-	return t.sema.TryAcquire()
+	return t.conns.tokens
 }
 
 func (t *throttler) release() {
-	if t.sema == nil {
-		return
+	if t.conns != nil {
+		t.conns.release()
 	}
-	t.sema.Release()
 }
 
-func (t *throttler) wrapReader(ctx context.Context, r io.ReadCloser) io.ReadCloser {
-	if t.maxBytesPerMinute == 0 {
+// returns nil when there are no per-host limits; otherwise, the caller must `putHost` when done
+func (t *throttler) host(host string) (h *hostLimiter) {
+	t.mu.Lock()
+	if t.hosts != nil {
+		if h = t.hosts[host]; h == nil {
+			hl := t.hostShare(host)
+			h = &hostLimiter{}
+			if t.total.PerHost.Connections > 0 {
+				h.conns = newConnLimiter(t.total.PerHost.Connections, _conns(hl.Connections))
+			}
+			h.rate.setBytesPerHour(hl.BytesPerHour)
+			t.hosts[host] = h
+		}
+		h.users++
+	}
+	t.mu.Unlock()
+	return
+}
+
+func (t *throttler) putHost(h *hostLimiter) {
+	t.mu.Lock()
+	h.users--
+	t.mu.Unlock()
+}
+
+// returns the number of tasks fetching (or waiting to fetch) from each host (see StatusResp.Hosts)
+func (t *throttler) hostLoad() (load map[string]int) {
+	t.mu.Lock()
+	for host, h := range t.hosts {
+		if h.users == 0 {
+			continue
+		}
+		if load == nil {
+			load = make(map[string]int, len(t.hosts))
+		}
+		load[host] = h.users
+	}
+	t.mu.Unlock()
+	return
+}
+
+// blocking: wait for a connection to the (origin) host
+func (t *throttler) acquireHost(ctx context.Context, h *hostLimiter) error {
+	if h == nil || h.conns == nil {
+		return nil
+	}
+	select {
+	case <-h.conns.tokens:
+		return nil
+	case <-ctx.Done():
+		return context.Canceled
+	case <-t.stopCh.Listen():
+		return errThrottlerStopped
+	}
+}
+
+func (*throttler) releaseHost(h *hostLimiter) {
+	if h != nil && h.conns != nil {
+		h.conns.release()
+	}
+}
+
+func (t *throttler) wrapReader(ctx context.Context, r io.ReadCloser, h *hostLimiter) io.ReadCloser {
+	if h != nil && !h.rate.limited() {
+		h = nil
+	}
+	if !t.rate.limited() && h == nil {
 		return r
 	}
-	return &throttledReader{
-		t:   t,
-		ctx: ctx,
-		r:   r,
+	return &throttledReader{t: t, h: h, ctx: ctx, r: r}
+}
+
+func (t *throttler) stop() { t.stopCh.Close() }
+
+func _conns(share int) int {
+	if share == NoShare {
+		return 0 // (all tokens out of circulation)
+	}
+	return share
+}
+
+/////////////////
+// connLimiter //
+/////////////////
+
+func newConnLimiter(capacity, limit int) *connLimiter {
+	limit = cos.Min(limit, capacity)
+	cl := &connLimiter{tokens: make(chan struct{}, capacity), limit: limit}
+	for i := 0; i < limit; i++ {
+		cl.tokens <- struct{}{}
 	}
+	return cl
 }
 
-func (t *throttler) stop() {
-	if t.ticker != nil {
-		t.ticker.Stop()
+func (cl *connLimiter) setLimit(limit int) {
+	cl.mu.Lock()
+	limit = cos.Min(limit, cap(cl.tokens))
+	diff := limit - cl.limit
+	cl.limit = limit
+	for ; diff > 0 && cl.debt > 0; diff-- {
+		cl.debt--
 	}
-	if t.stopCh != nil {
-		t.stopCh.Close()
+	for ; diff > 0; diff-- {
+		cl.tokens <- struct{}{} // (never blocks: available + acquired <= limit <= capacity)
 	}
+	for ; diff < 0; diff++ {
+		select {
+		case <-cl.tokens:
+		default:
+			cl.debt++
+		}
+	}
+	cl.mu.Unlock()
 }
 
-func (t *throttler) giveBack(leftoverSize int) {
-	// Never waits. If we took value from `t.capacityCh`, it means that
-	// `t.giveBack` was empty.
-	t.giveBackCh <- leftoverSize
+func (cl *connLimiter) release() {
+	cl.mu.Lock()
+	if cl.debt > 0 {
+		cl.debt--
+	} else {
+		cl.tokens <- struct{}{}
+	}
+	cl.mu.Unlock()
 }
 
-func (t *throttler) acquireAllowance(ctx context.Context, n int) error {
-	select {
-	case size, ok := <-t.capacityCh:
-		if !ok {
-			return errThrottlerStopped
+/////////////////
+// rateLimiter //
+/////////////////
+
+func (rl *rateLimiter) setBytesPerHour(bph int) {
+	rl.mu.Lock()
+	rl.paused = bph == NoShare
+	if rl.paused {
+		bph = 0
+	}
+	rl.bps = float64(bph) / float64(time.Hour/time.Second)
+	if rl.avail > rl.bps*rateBurstSeconds {
+		rl.avail = rl.bps * rateBurstSeconds
+	}
+	rl.mu.Unlock()
+}
+
+func (rl *rateLimiter) limited() bool {
+	rl.mu.Lock()
+	limited := rl.bps > 0 || rl.paused
+	rl.mu.Unlock()
+	return limited
+}
+
+func (rl *rateLimiter) isPaused() bool {
+	rl.mu.Lock()
+	paused := rl.paused
+	rl.mu.Unlock()
+	return paused
+}
+
+// account for `n` bytes that have been read and return the time to wait
+func (rl *rateLimiter) reserve(n int) (delay time.Duration) {
+	rl.mu.Lock()
+	if rl.bps > 0 {
+		now := time.Now()
+		if !rl.last.IsZero() {
+			rl.avail += now.Sub(rl.last).Seconds() * rl.bps
+		} else {
+			rl.avail = rl.bps * rateBurstSeconds
+		}
+		if burst := rl.bps * rateBurstSeconds; rl.avail > burst {
+			rl.avail = burst
+		}
+		rl.last = now
+		rl.avail -= float64(n)
+		if rl.avail < 0 {
+			delay = time.Duration(-rl.avail / rl.bps * float64(time.Second))
 		}
-		t.giveBack(size - n)
-		return nil
-	case <-ctx.Done():
-		return context.Canceled
 	}
+	rl.mu.Unlock()
+	return
 }
 
+/////////////////////
+// throttledReader //
+/////////////////////
+
 func (tr *throttledReader) Read(p []byte) (n int, err error) {
-	if err := tr.t.acquireAllowance(tr.ctx, len(p)); err != nil {
-		return 0, err
+	// NoShare: not a single byte until (re)shared
+	for tr.t.rate.isPaused() || (tr.h != nil && tr.h.rate.isPaused()) {
+		if err = tr.wait(pausedRecheck); err != nil {
+			return
+		}
+	}
+	n, err = tr.r.Read(p)
+	if n == 0 {
+		return
+	}
+	delay := tr.t.rate.reserve(n)
+	if tr.h != nil {
+		delay = cos.MaxDuration(delay, tr.h.rate.reserve(n))
+	}
+	if delay > 0 {
+		if errW := tr.wait(delay); errW != nil {
+			err = errW
+		}
+	}
+	return
+}
+
+func (tr *throttledReader) wait(delay time.Duration) (err error) {
+	timer := time.NewTimer(delay)
+	select {
+	case <-timer.C:
+	case <-tr.ctx.Done():
+		timer.Stop()
+		err = context.Canceled
+	case <-tr.t.stopCh.Listen():
+		timer.Stop()
+		err = errThrottlerStopped
 	}
-	return tr.r.Read(p)
+	return
 }
 
 func (tr *throttledReader) Close() (err error) {
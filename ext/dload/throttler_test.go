@@ -0,0 +1,154 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestLimitsApportion(t *testing.T) {
+	limits := Limits{Connections: 10, BytesPerHour: 1000, PerHost: HostLimits{Connections: 3}}
+	tests := []struct {
+		weights map[string]int
+		shares  map[string]Limits
+	}{
+		{map[string]int{"a": 1}, map[string]Limits{"a": limits}},
+		{
+			map[string]int{"a": 1, "b": 1},
+			map[string]Limits{
+				"a": {Connections: 5, BytesPerHour: 500, PerHost: HostLimits{Connections: 2}},
+				"b": {Connections: 5, BytesPerHour: 500, PerHost: HostLimits{Connections: 1}},
+			},
+		},
+		{
+			map[string]int{"a": 3, "b": 1},
+			map[string]Limits{
+				"a": {Connections: 7, BytesPerHour: 750, PerHost: HostLimits{Connections: 2}},
+				"b": {Connections: 3, BytesPerHour: 250, PerHost: HostLimits{Connections: 1}},
+			},
+		},
+		{
+			map[string]int{"a": 1, "b": 5, "c": 1, "d": 2},
+			map[string]Limits{
+				"a": {Connections: 2, BytesPerHour: 112, PerHost: HostLimits{Connections: 1}},
+				"b": {Connections: 4, BytesPerHour: 554, PerHost: HostLimits{Connections: 1}},
+				"c": {Connections: 2, BytesPerHour: 112, PerHost: HostLimits{Connections: NoShare}},
+				"d": {Connections: 2, BytesPerHour: 222, PerHost: HostLimits{Connections: 1}},
+			},
+		},
+	}
+	for _, test := range tests {
+		shares := limits.Apportion(test.weights)
+		for tid, share := range test.shares {
+			tassert.Errorf(t, shares[tid] == share, "%v: %s: expected %+v, got %+v", test.weights, tid, share, shares[tid])
+		}
+	}
+
+	// the shares never add up to more than the limit (and never become "unlimited")
+	for _, n := range []int{1, 2, 3, 7, 10, 11, 100} {
+		weights := make(map[string]int, n)
+		for i := 0; i < n; i++ {
+			weights[strconv.Itoa(i)] = i%5 + 1
+		}
+		var conns, bph, hconns int
+		for _, share := range limits.Apportion(weights) {
+			tassert.Errorf(t, share.Connections != 0 && share.PerHost.Connections != 0, "unlimited share %+v", share)
+			conns += cos.Max(share.Connections, 0)
+			bph += cos.Max(share.BytesPerHour, 0)
+			hconns += cos.Max(share.PerHost.Connections, 0)
+			tassert.Errorf(t, share.PerHost.BytesPerHour == 0, "expected no per-host rate limit")
+		}
+		tassert.Errorf(t, conns == limits.Connections && bph == limits.BytesPerHour && hconns == limits.PerHost.Connections,
+			"%d targets: shares add up to %d, %d, %d", n, conns, bph, hconns)
+	}
+	tassert.Errorf(t, (&Limits{}).IsZero(), "expected zero limits")
+	tassert.Errorf(t, !limits.IsZero(), "expected non-zero limits")
+}
+
+func TestConnLimiter(t *testing.T) {
+	cl := newConnLimiter(10, 4)
+	for i := 0; i < 4; i++ {
+		<-cl.tokens
+	}
+	tassert.Errorf(t, len(cl.tokens) == 0, "expected no tokens, got %d", len(cl.tokens))
+
+	// shrink while all 4 are in use: 2 releases must be swallowed
+	cl.setLimit(2)
+	cl.release()
+	cl.release()
+	tassert.Errorf(t, len(cl.tokens) == 0, "expected no tokens, got %d", len(cl.tokens))
+	cl.release()
+	tassert.Errorf(t, len(cl.tokens) == 1, "expected 1 token, got %d", len(cl.tokens))
+
+	// grow beyond capacity
+	cl.setLimit(100)
+	tassert.Errorf(t, len(cl.tokens) == 9, "expected 9 tokens, got %d", len(cl.tokens))
+	cl.release()
+	tassert.Errorf(t, len(cl.tokens) == 10, "expected 10 tokens, got %d", len(cl.tokens))
+}
+
+func TestRateLimiter(t *testing.T) {
+	var rl rateLimiter
+	tassert.Errorf(t, rl.reserve(1<<30) == 0, "expected no delay when unlimited")
+
+	rl.setBytesPerHour(3600 * 1000) // 1000 bytes/s
+	tassert.Errorf(t, rl.reserve(1000) == 0, "expected burst to go through")
+	delay := rl.reserve(500)
+	tassert.Errorf(t, delay > 400*time.Millisecond && delay <= 500*time.Millisecond, "unexpected delay %v", delay)
+}
+
+func TestThrottlerHostShares(t *testing.T) {
+	var (
+		throt throttler
+		total = Limits{PerHost: HostLimits{Connections: 4, BytesPerHour: 3600 * 1000}}
+	)
+	throt.init(total, Limits{PerHost: HostLimits{Connections: 2, BytesPerHour: 3600 * 500}})
+	defer throt.stop()
+
+	h := throt.host("a.com")
+	tassert.Errorf(t, len(h.conns.tokens) == 2, "expected 2 tokens, got %d", len(h.conns.tokens))
+	load := throt.hostLoad()
+	tassert.Errorf(t, len(load) == 1 && load["a.com"] == 1, "unexpected load %v", load)
+
+	// "a.com" is all ours, while "b.com" (not being fetched from) gets NoShare
+	throt.setLimits(&Share{
+		Limits: Limits{PerHost: HostLimits{Connections: NoShare, BytesPerHour: NoShare}},
+		Hosts:  map[string]HostLimits{"a.com": total.PerHost},
+	})
+	tassert.Errorf(t, len(h.conns.tokens) == 4, "expected 4 tokens, got %d", len(h.conns.tokens))
+	tassert.Errorf(t, !h.rate.isPaused(), "a.com: not expecting pause")
+	hb := throt.host("b.com")
+	tassert.Errorf(t, len(hb.conns.tokens) == 0, "expected no tokens, got %d", len(hb.conns.tokens))
+	tassert.Errorf(t, hb.rate.isPaused(), "b.com: expecting pause")
+
+	throt.putHost(h)
+	throt.putHost(hb)
+	tassert.Errorf(t, len(throt.hostLoad()) == 0, "expected no load, got %v", throt.hostLoad())
+}
+
+func TestThrottledReaderNoShare(t *testing.T) {
+	var throt throttler
+	throt.init(Limits{BytesPerHour: 3600 * 1000}, Limits{BytesPerHour: NoShare})
+	defer throt.stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), pausedRecheck/2)
+	defer cancel()
+	r := throt.wrapReader(ctx, io.NopCloser(strings.NewReader("data")), nil)
+	n, err := r.Read(make([]byte, 4))
+	tassert.Errorf(t, n == 0 && err == context.Canceled, "expected nothing read while paused, got %d, %v", n, err)
+
+	throt.setLimits(&Share{Limits: Limits{BytesPerHour: 3600 * 1000}})
+	r = throt.wrapReader(context.Background(), io.NopCloser(strings.NewReader("data")), nil)
+	n, err = r.Read(make([]byte, 4))
+	tassert.Errorf(t, n == 4 && err == nil, "expected data, got %d, %v", n, err)
+}
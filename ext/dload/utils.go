@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"sort"
 	"strings"
 	"time"
 
@@ -58,15 +59,22 @@ func countObjects(t cluster.Target, pt cos.ParsedTemplate, dir string, bck *clus
 }
 
 // buildDlObjs returns list of objects that must be downloaded by target.
+// The objects are sorted by name - the resulting (deterministic) positions
+// index the job's completion record (see `doneRecord`).
 func buildDlObjs(t cluster.Target, bck *cluster.Bck, objects cos.StrKVs) ([]dlObj, error) {
 	var (
-		smap = t.Sowner().Get()
-		sid  = t.SID()
+		smap  = t.Sowner().Get()
+		sid   = t.SID()
+		names = make([]string, 0, len(objects))
 	)
+	for name := range objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
 	objs := make([]dlObj, 0, len(objects))
-	for name, link := range objects {
-		obj, err := makeDlObj(smap, sid, bck, name, link)
+	for i, name := range names {
+		obj, err := makeDlObj(smap, sid, bck, name, objects[name], int64(i))
 		if err != nil {
 			if err == errInvalidTarget {
 				continue
@@ -78,7 +86,8 @@ func buildDlObjs(t cluster.Target, bck *cluster.Bck, objects cos.StrKVs) ([]dlOb
 	return objs, nil
 }
 
-func makeDlObj(smap *cluster.Smap, sid string, bck *cluster.Bck, objName, link string) (dlObj, error) {
+// `idx` is the object's position in the job's sequence (or -1 if the order is not deterministic)
+func makeDlObj(smap *cluster.Smap, sid string, bck *cluster.Bck, objName, link string, idx int64) (dlObj, error) {
 	objName, err := NormalizeObjName(objName)
 	if err != nil {
 		return dlObj{}, err
@@ -97,6 +106,7 @@ func makeDlObj(smap *cluster.Smap, sid string, bck *cluster.Bck, objName, link s
 		// Make sure that link contains protocol (absence of protocol can result in errors).
 		link:       cmn.PrependProtocol(link),
 		fromRemote: link == "",
+		idx:        idx,
 	}, nil
 }
 
@@ -116,50 +126,75 @@ func NormalizeObjName(objName string) (string, error) {
 }
 
 func ParseStartRequest(t cluster.Target, bck *cluster.Bck, id string, dlb Body, xdl *Xact) (jobif, error) {
+	var (
+		job jobif
+		err error
+	)
 	switch dlb.Type {
 	case TypeBackend:
 		dp := &BackendBody{}
-		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
-		if err != nil {
+		if err = jsoniter.Unmarshal(dlb.RawMessage, dp); err != nil {
 			return nil, err
 		}
-		if err := dp.Validate(); err != nil {
+		if err = dp.Validate(); err != nil {
 			return nil, err
 		}
-		return newBackendDlJob(t, id, bck, dp, xdl)
+		var bj *backendDlJob
+		if bj, err = newBackendDlJob(t, id, bck, dp, xdl); err == nil {
+			bj.dlb, job = dlb, bj
+		}
 	case TypeMulti:
 		dp := &MultiBody{}
-		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
-		if err != nil {
+		if err = jsoniter.Unmarshal(dlb.RawMessage, dp); err != nil {
 			return nil, err
 		}
-		if err := dp.Validate(); err != nil {
+		if err = dp.Validate(); err != nil {
 			return nil, err
 		}
-		return newMultiDlJob(t, id, bck, dp, xdl)
+		var mj *multiDlJob
+		if mj, err = newMultiDlJob(t, id, bck, dp, xdl); err == nil {
+			mj.dlb, job = dlb, mj
+		}
 	case TypeRange:
 		dp := &RangeBody{}
-		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
-		if err != nil {
+		if err = jsoniter.Unmarshal(dlb.RawMessage, dp); err != nil {
 			return nil, err
 		}
-		if err := dp.Validate(); err != nil {
+		if err = dp.Validate(); err != nil {
 			return nil, err
 		}
-		return newRangeDlJob(t, id, bck, dp, xdl)
+		var rj *rangeDlJob
+		if rj, err = newRangeDlJob(t, id, bck, dp, xdl); err == nil {
+			rj.dlb, job = dlb, rj
+		}
 	case TypeSingle:
 		dp := &SingleBody{}
-		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
-		if err != nil {
+		if err = jsoniter.Unmarshal(dlb.RawMessage, dp); err != nil {
 			return nil, err
 		}
-		if err := dp.Validate(); err != nil {
+		if err = dp.Validate(); err != nil {
 			return nil, err
 		}
-		return newSingleDlJob(t, id, bck, dp, xdl)
+		var sj *singleDlJob
+		if sj, err = newSingleDlJob(t, id, bck, dp, xdl); err == nil {
+			sj.dlb, job = dlb, sj
+		}
+	case TypeManifest:
+		dp := &ManifestBody{}
+		if err = jsoniter.Unmarshal(dlb.RawMessage, dp); err != nil {
+			return nil, err
+		}
+		if err = dp.Validate(); err != nil {
+			return nil, err
+		}
+		var mj *manifestDlJob
+		if mj, err = newManifestDlJob(t, id, bck, dp, xdl); err == nil {
+			mj.dlb, job = dlb, mj
+		}
 	default:
-		return nil, errors.New("input does not match any of the supported formats (single, range, multi, backend)")
+		err = errors.New("input does not match any of the supported formats (single, range, multi, backend, manifest)")
 	}
+	return job, err
 }
 
 // Given URL (link) and response header parse object attrs for GCP, S3 and Azure.
@@ -6,6 +6,7 @@ package dload
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"regexp"
@@ -112,6 +113,7 @@ const (
 	actAbort  = "ABORT"
 	actStatus = "STATUS"
 	actList   = "LIST"
+	actLimits = "LIMITS"
 )
 
 // Downloader cannot use global HTTP client because it must work with
@@ -154,6 +156,7 @@ type (
 		id         string         // id of the job task
 		regex      *regexp.Regexp // regex of descriptions to return if id is empty
 		response   *response      // where the outcome of the request is written
+		limits     *Share         // this target's share of the job limits (actLimits)
 		onlyActive bool           // request status of only active tasks
 	}
 
@@ -238,6 +241,25 @@ func (xld *Xact) Download(job jobif) (resp any, statusCode int, err error) {
 	}
 }
 
+// Resume re-submits the job that was interrupted by the target's restart or shutdown
+// (see LoadJobs); objects processed prior to the interruption are not downloaded again.
+func (xld *Xact) Resume(job jobif, pj *PersistedJob) error {
+	xld.IncPending()
+	defer xld.DecPending()
+
+	if _, err := dlStore.resumeJob(job, pj); err != nil {
+		return err
+	}
+	select {
+	case xld.dispatcher.workCh <- job:
+		return nil
+	case <-time.After(cmn.Timeout.CplaneOperation()):
+		err := fmt.Errorf("%s: failed to resume %s: downloader job queue is full", xld.t, job)
+		FailResume(pj, err)
+		return err
+	}
+}
+
 func (xld *Xact) AbortJob(id string) (resp any, statusCode int, err error) {
 	xld.IncPending()
 	req := &request{action: actAbort, id: id}
@@ -262,6 +284,19 @@ func (xld *Xact) JobStatus(id string, onlyActive bool) (resp any, statusCode int
 	return
 }
 
+// SetLimits applies this target's share, if any, of the job's (cluster-wide) limits
+func (xld *Xact) SetLimits(msg *LimitsMsg) (resp any, statusCode int, err error) {
+	share, ok := msg.Shares[xld.t.SID()]
+	if !ok {
+		return nil, http.StatusOK, nil
+	}
+	xld.IncPending()
+	req := &request{action: actLimits, id: msg.ID, limits: &share}
+	resp, statusCode, err = xld.dispatcher.adminReq(req)
+	xld.DecPending()
+	return
+}
+
 func (xld *Xact) checkJob(req *request) (*dljob, error) {
 	dljob, err := dlStore.getJob(req.id)
 	if err != nil {
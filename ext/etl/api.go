@@ -51,6 +51,26 @@ type (
 		Flags int64 `json:"flags"`
 	}
 
+	// InitProcMsg runs the transformer outside Kubernetes: either as a local
+	// process (`Command`) or as a Docker container (`Image`) started via the local
+	// Docker socket - one instance per target.
+	InitProcMsg struct {
+		InitMsgBase
+		// local process: executable and its arguments;
+		// Docker: (optional) command to run in the container instead of the image's default
+		Command []string   `json:"command,omitempty"`
+		Image   string     `json:"image,omitempty"` // Docker container image
+		Env     cos.StrKVs `json:"env,omitempty"`
+		// HTTP path to poll until the transformer is ready (and "/health" if omitted)
+		HealthPath string `json:"health_path,omitempty"`
+		// (Docker only) port the containerized transformer listens on;
+		// a local process, on the other hand, must listen on $AIS_ETL_PORT
+		Port int `json:"port,omitempty"`
+		// number of times the transformer gets restarted after having exited
+		// (or crashed) - once exhausted, the ETL is stopped
+		MaxRestarts int `json:"max_restarts,omitempty"`
+	}
+
 	InfoList []Info
 	Info     struct {
 		ID string `json:"id"`
@@ -66,6 +86,14 @@ type (
 		Logs     []byte `json:"logs"`
 	}
 
+	// (re)start status - one per target
+	StartStatusList []*StartStatus
+	StartStatus     struct {
+		TargetID string `json:"target_id"`
+		Status   string `json:"status"` // one of the `Status*` enum below
+		Err      string `json:"error,omitempty"`
+	}
+
 	PodsHealthMsg []*PodHealthMsg
 	PodHealthMsg  struct {
 		TargetID string  `json:"target_id"`
@@ -101,7 +129,14 @@ const (
 	HpushStdin = "io://"
 )
 
-var commTypes = []string{Hpush, Hpull, Hrev, HpushStdin} // NOTE: must contain all
+var commTypes = []string{Hpush, Hpull, Hrev, HpushStdin, Wasm} // NOTE: must contain all
+
+// StartStatus enum
+const (
+	StatusStarted = "started" // (re)started from EtlMD
+	StatusRunning = "running" // already running - nothing to do
+	StatusFailed  = "failed"
+)
 
 ////////////////
 // InitMsg*** //
@@ -111,6 +146,7 @@ var commTypes = []string{Hpush, Hpull, Hrev, HpushStdin} // NOTE: must contain a
 var (
 	_ InitMsg = (*InitCodeMsg)(nil)
 	_ InitMsg = (*InitSpecMsg)(nil)
+	_ InitMsg = (*InitProcMsg)(nil)
 )
 
 func (m InitMsgBase) CommType() string { return m.CommTypeX }
@@ -131,6 +167,18 @@ func UnmarshalInitMsg(b []byte) (msg InitMsg, err error) {
 		err = jsoniter.Unmarshal(b, msg)
 		return
 	}
+	if _, ok := msgInf["module"]; ok {
+		msg = &InitWasmMsg{}
+		err = jsoniter.Unmarshal(b, msg)
+		return
+	}
+	_, okc := msgInf["command"]
+	_, oki := msgInf["image"]
+	if okc || oki {
+		msg = &InitProcMsg{}
+		err = jsoniter.Unmarshal(b, msg)
+		return
+	}
 	err = fmt.Errorf("invalid response body: %s", b)
 	return
 }
@@ -151,7 +199,7 @@ func (m *InitCodeMsg) Validate() error {
 	if m.CommTypeX == "" {
 		cos.Warningf("empty comm-type, defaulting to %q (%q)", Hpush, m.Runtime)
 		m.CommTypeX = Hpush
-	} else if !cos.StringInSlice(m.CommTypeX, commTypes) {
+	} else if !cos.StringInSlice(m.CommTypeX, commTypes) || m.CommTypeX == Wasm {
 		return fmt.Errorf("unsupported comm-type %q (%q)", m.CommTypeX, m.Runtime)
 	}
 	if m.Funcs.Transform == "" {
@@ -166,6 +214,56 @@ func (m *InitCodeMsg) Validate() error {
 
 func (*InitCodeMsg) InitType() string { return apc.ETLInitCode }
 func (*InitSpecMsg) InitType() string { return apc.ETLInitSpec }
+func (*InitProcMsg) InitType() string { return apc.ETLInitProc }
+
+func (m *InitProcMsg) Validate() error {
+	if err := cos.ValidateEtlID(m.IDX); err != nil {
+		return fmt.Errorf("invalid etl ID: %v", err)
+	}
+	if err := validateCommType(m.CommTypeX); err != nil {
+		return err
+	}
+	if m.CommTypeX == "" {
+		m.CommTypeX = Hpush
+	}
+	switch {
+	case len(m.Command) == 0 && m.Image == "":
+		return fmt.Errorf("ETL %q: either command or (Docker) image must be specified", m.IDX)
+	case m.Image == "" && m.CommTypeX == HpushStdin:
+		return fmt.Errorf("ETL %q: comm-type %q requires a container (image)", m.IDX, HpushStdin)
+	case m.Image != "" && m.CommTypeX == HpushStdin && len(m.Command) == 0:
+		return fmt.Errorf("ETL %q: comm-type %q requires a command to run in the container", m.IDX, HpushStdin)
+	case m.Image != "" && m.Port <= 0:
+		return fmt.Errorf("ETL %q: container port must be specified (image %q)", m.IDX, m.Image)
+	}
+	if _, err := cmn.ValidatePort(m.Port); m.Port != 0 && err != nil {
+		return fmt.Errorf("ETL %q: %v", m.IDX, err)
+	}
+	if m.HealthPath == "" {
+		m.HealthPath = dfltHealthPath
+	} else if m.HealthPath[0] != '/' {
+		m.HealthPath = "/" + m.HealthPath
+	}
+	if m.MaxRestarts < 0 {
+		return fmt.Errorf("ETL %q: invalid max-restarts %d", m.IDX, m.MaxRestarts)
+	}
+	return nil
+}
+
+// IsDocker returns true if the transformer is to run in a local container.
+func (m *InitProcMsg) IsDocker() bool { return m.Image != "" }
+
+// Allowed checks the executable (or Docker image) against cluster config (see cmn.ETLConf).
+func (m *InitProcMsg) Allowed(c *cmn.ETLConf) error {
+	name := m.Image
+	if !m.IsDocker() {
+		name = m.Command[0]
+	}
+	if err := c.LocalAllowed(name); err != nil {
+		return fmt.Errorf("ETL %q: %v", m.IDX, err)
+	}
+	return nil
+}
 
 func ParsePodSpec(errCtx *cmn.ETLErrorContext, spec []byte) (*corev1.Pod, error) {
 	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(spec, nil, nil)
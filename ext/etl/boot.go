@@ -215,6 +215,9 @@ func validateCommType(commType string) error {
 	if commType != "" && !cos.StringInSlice(commType, commTypes) {
 		return fmt.Errorf("unknown communication type: %q", commType)
 	}
+	if commType == Wasm {
+		return fmt.Errorf("communication type %q requires WebAssembly module (see InitWasmMsg)", commType)
+	}
 	return nil
 }
 
@@ -0,0 +1,162 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// ETL chain (pipeline) is an ordered list of ETLs applied to the same object,
+// whereby the output of each stage gets streamed into the next one.
+// The first stage reads the original object and can, therefore, utilize any
+// communication type. All subsequent stages must be able to transform an arbitrary
+// stream - see `readerTransformer` (currently: hpush://, io://, and wasm://).
+
+type (
+	// implemented by communicators that can transform (and always close) the given reader
+	readerTransformer interface {
+		transformReader(r io.ReadCloser, size int64, bck *cluster.Bck, objName string,
+			timeout time.Duration) (cos.ReadCloseSizer, error)
+	}
+
+	Chain struct {
+		comms  []Communicator
+		stages []*stageStats
+	}
+	// implemented by the (inline) ETL xaction to keep per-stage stats of chain GETs
+	dpStatsAdder interface {
+		AddDPStats(stats []cluster.DPStageStats)
+	}
+	stageStats struct {
+		objs     atomic.Int64
+		inBytes  atomic.Int64 // (first stage only - the rest is computed)
+		outBytes atomic.Int64
+		errs     atomic.Int64
+	}
+)
+
+// NewChain resolves ETL IDs (in the order of the stages) to local communicators.
+func NewChain(ids []string, lsnode *cluster.Snode) (*Chain, error) {
+	if len(ids) == 0 {
+		return nil, apc.ErrETLMissingUUID
+	}
+	c := &Chain{comms: make([]Communicator, 0, len(ids)), stages: make([]*stageStats, 0, len(ids))}
+	for i, id := range ids {
+		comm, err := GetCommunicator(id, lsnode)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := comm.(readerTransformer); i > 0 && !ok {
+			return nil, fmt.Errorf("%s: ETL %q cannot be used at stage %d of the chain %v (expecting one of: %s, %s, %s)",
+				lsnode, id, i+1, ids, Hpush, HpushStdin, Wasm)
+		}
+		c.comms = append(c.comms, comm)
+		c.stages = append(c.stages, &stageStats{})
+	}
+	return c, nil
+}
+
+func (c *Chain) Len() int { return len(c.comms) }
+
+// first (or the only) stage
+func (c *Chain) Head() Communicator { return c.comms[0] }
+
+// Transform runs all the stages and returns the reader of the last one.
+// `size` is the size of the original object (used for stats).
+func (c *Chain) Transform(bck *cluster.Bck, objName string, size int64, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	r, err := c.comms[0].OfflineTransform(bck, objName, timeout)
+	if err != nil {
+		c.stages[0].errs.Inc()
+		return nil, err
+	}
+	c.stages[0].inBytes.Add(size)
+	r = c.stages[0].wrap(r)
+	for i := 1; i < len(c.comms); i++ {
+		rt := c.comms[i].(readerTransformer)
+		if r, err = rt.transformReader(r, r.Size(), bck, objName, timeout); err != nil {
+			c.stages[i].errs.Inc()
+			return nil, err
+		}
+		r = c.stages[i].wrap(r)
+	}
+	return r, nil
+}
+
+// OnlineTransform is the multi-stage variant of the `Communicator.OnlineTransform`.
+// Upon return, per-stage stats are added to those of the head ETL's xaction.
+func (c *Chain) OnlineTransform(w http.ResponseWriter, bck *cluster.Bck, objName string) error {
+	size, err := determineSize(bck, objName)
+	if err != nil {
+		return err
+	}
+	if a, ok := c.Head().Xact().(dpStatsAdder); ok {
+		defer func() { a.AddDPStats(c.Stats()) }()
+	}
+	r, err := c.Transform(bck, objName, size, 0 /*timeout*/)
+	if err != nil {
+		return err
+	}
+	if size = r.Size(); size >= 0 {
+		w.Header().Set(cos.HdrContentLength, fmt.Sprintf("%d", size))
+	} else {
+		size = memsys.DefaultBufSize
+	}
+	buf, slab := memsys.PageMM().AllocSize(size)
+	_, err = io.CopyBuffer(w, r, buf)
+	slab.Free(buf)
+	r.Close()
+	return err
+}
+
+// per-stage stats reported as part of the xaction's snapshot
+func (c *Chain) Stats() []cluster.DPStageStats {
+	var (
+		stats = make([]cluster.DPStageStats, len(c.comms))
+		names = make([]string, len(c.comms))
+	)
+	for i, comm := range c.comms {
+		names[i] = comm.Name()
+	}
+	chain := strings.Join(names, ",")
+	for i, comm := range c.comms {
+		s := c.stages[i]
+		stats[i] = cluster.DPStageStats{
+			Chain:    chain,
+			Name:     comm.Name(),
+			Objs:     s.objs.Load(),
+			OutBytes: s.outBytes.Load(),
+			Errs:     s.errs.Load(),
+		}
+		if i == 0 {
+			stats[i].InBytes = s.inBytes.Load()
+		} else {
+			stats[i].InBytes = stats[i-1].OutBytes
+		}
+	}
+	return stats
+}
+
+////////////////
+// stageStats //
+////////////////
+
+func (s *stageStats) wrap(r cos.ReadCloseSizer) cos.ReadCloseSizer {
+	return cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:       r,
+		Size:    r.Size(),
+		ReadCb:  func(n int, _ error) { s.outBytes.Add(int64(n)) },
+		DeferCb: func() { s.objs.Inc() },
+	})
+}
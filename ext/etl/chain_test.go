@@ -0,0 +1,61 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// first stage: "transforms" object name into its content
+type nameComm struct {
+	baseComm
+}
+
+func (*nameComm) OnlineTransform(http.ResponseWriter, *http.Request, *cluster.Bck, string) error {
+	return errors.New("not implemented")
+}
+
+func (*nameComm) OfflineTransform(_ *cluster.Bck, objName string, _ time.Duration) (cos.ReadCloseSizer, error) {
+	if objName == "" {
+		return nil, errors.New("empty name")
+	}
+	return cos.NewReaderWithArgs(cos.ReaderArgs{R: strings.NewReader(objName), Size: int64(len(objName))}), nil
+}
+
+var _ = Describe("Chain", func() {
+	It("should run all stages and maintain per-stage stats", func() {
+		wc := newTestWasmComm(&InitWasmMsg{})
+		defer wc.rt.Close(context.Background())
+		c := &Chain{
+			comms:  []Communicator{&nameComm{baseComm{name: "name"}}, wc},
+			stages: []*stageStats{{}, {}},
+		}
+		for _, objName := range []string{"abc", "defgh"} {
+			r, err := c.Transform(nil, objName, 100, 0)
+			Expect(err).NotTo(HaveOccurred())
+			b, err := io.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+			r.Close()
+			Expect(string(b)).To(Equal(strings.ToUpper(objName)))
+		}
+		_, err := c.Transform(nil, "", 100, 0)
+		Expect(err).To(HaveOccurred())
+
+		stats := c.Stats()
+		Expect(stats).To(HaveLen(2))
+		Expect(stats[0]).To(Equal(cluster.DPStageStats{Chain: "name,wasm-test", Name: "name", Objs: 2, InBytes: 200, OutBytes: 8, Errs: 1}))
+		Expect(stats[1]).To(Equal(cluster.DPStageStats{Chain: "name,wasm-test", Name: "wasm-test", Objs: 2, InBytes: 8, OutBytes: 8}))
+	})
+})
@@ -5,11 +5,15 @@
 package etl
 
 import (
+	"bytes"
 	"crypto/rand"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
 	"os"
 	"path/filepath"
 	"time"
@@ -20,7 +24,9 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -111,17 +117,11 @@ var _ = Describe("CommunicatorTest", func() {
 
 			xctn := mock.NewXact(apc.ActETLInline)
 			comm = makeCommunicator(commArgs{
-				bootstrapper: &etlBootstrapper{
-					t: tMock,
-					msg: InitSpecMsg{
-						InitMsgBase: InitMsgBase{
-							CommTypeX: commType,
-						},
-					},
-					pod:  pod,
-					uri:  transformerServer.URL,
-					xctn: xctn,
-				},
+				t:        tMock,
+				xctn:     xctn,
+				podName:  pod.GetName(),
+				commType: commType,
+				uri:      transformerServer.URL,
 			})
 			resp, err := http.Get(proxyServer.URL)
 			Expect(err).NotTo(HaveOccurred())
@@ -133,6 +133,176 @@ var _ = Describe("CommunicatorTest", func() {
 			Expect(b).To(Equal(transformData))
 		})
 	}
+
+	It("should perform chained transformation", func() {
+		// second stage: echo
+		echoServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = w.Write(b)
+			Expect(err).NotTo(HaveOccurred())
+		}))
+		defer echoServer.Close()
+
+		chain := &Chain{}
+		for i, uri := range []string{transformerServer.URL, echoServer.URL} {
+			chain.comms = append(chain.comms, makeCommunicator(commArgs{
+				t:        tMock,
+				xctn:     mock.NewXact(apc.ActETLInline),
+				name:     fmt.Sprintf("stage-%d", i),
+				commType: Hpush,
+				uri:      uri,
+			}))
+			chain.stages = append(chain.stages, &stageStats{})
+		}
+		r, err := chain.Transform(clusterBck, objName, dataSize, 0 /*timeout*/)
+		Expect(err).NotTo(HaveOccurred())
+		b, err := io.ReadAll(r)
+		Expect(err).NotTo(HaveOccurred())
+		r.Close()
+		Expect(b).To(Equal(transformData))
+
+		stats := chain.Stats()
+		Expect(stats).To(HaveLen(2))
+		for i, st := range stats {
+			Expect(st.Name).To(Equal(fmt.Sprintf("stage-%d", i)))
+			Expect(st.Chain).To(Equal("stage-0,stage-1"))
+			Expect(st.Objs).To(Equal(int64(1)))
+			Expect(st.InBytes).To(Equal(dataSize))
+			Expect(st.OutBytes).To(Equal(dataSize))
+		}
+	})
+
+	It("should perform one-to-many and many-to-one transformations", func() {
+		// splits single input into 3 named outputs; concatenates batch into one
+		multiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mediaType, params, _ := mime.ParseMediaType(r.Header.Get(cos.HdrContentType))
+			if mediaType == cos.ContentMultipartMixed {
+				var (
+					out bytes.Buffer
+					mpr = multipart.NewReader(r.Body, params["boundary"])
+				)
+				for {
+					part, err := mpr.NextPart()
+					if err == io.EOF {
+						break
+					}
+					Expect(err).NotTo(HaveOccurred())
+					_, err = io.Copy(&out, part)
+					Expect(err).NotTo(HaveOccurred())
+				}
+				_, err := w.Write(out.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			b, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			mpw := multipart.NewWriter(w)
+			w.Header().Set(cos.HdrContentType, mime.FormatMediaType(cos.ContentMultipartMixed,
+				map[string]string{"boundary": mpw.Boundary()}))
+			third := len(b) / 3
+			for i := 0; i < 3; i++ {
+				hdr := make(textproto.MIMEHeader)
+				hdr.Set(cos.HdrContentDisposition, fmt.Sprintf("attachment; filename=\"dir/part-%d\"", i))
+				part, err := mpw.CreatePart(hdr)
+				Expect(err).NotTo(HaveOccurred())
+				end := (i + 1) * third
+				if i == 2 {
+					end = len(b)
+				}
+				_, err = part.Write(b[i*third : end])
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(mpw.Close()).NotTo(HaveOccurred())
+		}))
+		defer multiServer.Close()
+
+		pc := makeCommunicator(commArgs{
+			t:        tMock,
+			xctn:     mock.NewXact(apc.ActETLInline),
+			commType: Hpush,
+			uri:      multiServer.URL,
+		}).(*pushComm)
+
+		emitted := make(map[string]int64)
+		emit := func(objName string, sgl *memsys.SGL) error {
+			emitted[objName] = sgl.Size()
+			sgl.Free()
+			return nil
+		}
+
+		// one-to-many
+		err := pc.transformMulti(clusterBck, []string{objName}, 0 /*timeout*/, emit)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(emitted).To(HaveLen(3))
+		var total int64
+		for i := 0; i < 3; i++ {
+			size, ok := emitted[fmt.Sprintf("dir/part-%d", i)]
+			Expect(ok).To(BeTrue())
+			total += size
+		}
+		Expect(total).To(Equal(dataSize))
+		Expect(pc.InBytes()).To(Equal(dataSize))
+
+		// many-to-one
+		emitted = make(map[string]int64)
+		err = pc.transformMulti(clusterBck, []string{objName, objName}, 0 /*timeout*/, emit)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(emitted).To(HaveLen(1))
+		Expect(emitted[objName]).To(Equal(2 * dataSize))
+		Eventually(pc.InBytes).Should(Equal(3 * dataSize))
+	})
+
+	DescribeTable("should reject hostile output object names",
+		func(outName string) {
+			// responds with a single part named `outName`
+			hostileServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				cos.DrainReader(r.Body)
+				mpw := multipart.NewWriter(w)
+				w.Header().Set(cos.HdrContentType, mime.FormatMediaType(cos.ContentMultipartMixed,
+					map[string]string{"boundary": mpw.Boundary()}))
+				hdr := make(textproto.MIMEHeader)
+				hdr.Set(cos.HdrContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": outName}))
+				part, err := mpw.CreatePart(hdr)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = part.Write([]byte("pwned"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mpw.Close()).NotTo(HaveOccurred())
+			}))
+			defer hostileServer.Close()
+
+			pc := makeCommunicator(commArgs{
+				t:        tMock,
+				xctn:     mock.NewXact(apc.ActETLInline),
+				commType: Hpush,
+				uri:      hostileServer.URL,
+			}).(*pushComm)
+			var emitted []string
+			err := pc.transformMulti(clusterBck, []string{objName}, 0 /*timeout*/, func(name string, sgl *memsys.SGL) error {
+				emitted = append(emitted, name)
+				sgl.Free()
+				return nil
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(emitted).To(BeEmpty())
+		},
+		Entry("parent directory", "../../x"),
+		Entry("parent directory in the middle", "a/../../x"),
+		Entry("trailing parent directory", "a/.."),
+		Entry("absolute path", "/etc/passwd"),
+		Entry("not clean", "a//b"),
+		Entry("current directory", "./a"),
+		Entry("NUL", "a\x00b"),
+	)
+
+	It("should validate output object names", func() {
+		for _, name := range []string{"a", "dir/part-0", "a.b/c..d", "..a", "a/...", "a..", "x/..y/z"} {
+			Expect(validateOutName(name)).To(Succeed(), name)
+		}
+		for _, name := range []string{"", ".", "..", "../a", "a/../b", "/a", "a/", "a//b", "a/./b", "a\x00"} {
+			Expect(validateOutName(name)).NotTo(Succeed(), name)
+		}
+	})
 })
 
 // Creates a file with random content.
@@ -36,6 +36,7 @@ type (
 		Name() string
 		PodName() string
 		SvcName() string
+		Xact() cluster.Xact // (inline) ETL xaction
 
 		// OnlineTransform uses one of the two ETL container endpoints:
 		//  - Method "PUT", Path "/"
@@ -53,8 +54,14 @@ type (
 	}
 
 	commArgs struct {
-		listener     cluster.Slistener
-		bootstrapper *etlBootstrapper
+		listener cluster.Slistener
+		t        cluster.Target
+		xctn     cluster.Xact
+		name     string   // ETL name as specified by the user
+		podName  string   // K8s pod (or, outside Kubernetes, local process/container) name
+		commType string   // one of the `commTypes`
+		uri      string   // transformer's URI
+		command  []string // original command (io:// only)
 	}
 
 	baseComm struct {
@@ -94,6 +101,8 @@ var (
 	_ Communicator = (*redirectComm)(nil)
 	_ Communicator = (*revProxyComm)(nil)
 
+	_ readerTransformer = (*pushComm)(nil)
+
 	_ io.Writer = (*cbWriter)(nil)
 )
 
@@ -104,23 +113,23 @@ var (
 func makeCommunicator(args commArgs) Communicator {
 	baseComm := baseComm{
 		Slistener: args.listener,
-		t:         args.bootstrapper.t,
-		name:      args.bootstrapper.originalPodName,
-		podName:   args.bootstrapper.pod.Name,
-		xctn:      args.bootstrapper.xctn,
+		t:         args.t,
+		name:      args.name,
+		podName:   args.podName,
+		xctn:      args.xctn,
 	}
 
-	switch args.bootstrapper.msg.CommTypeX {
+	switch args.commType {
 	case Hpush:
 		return &pushComm{
 			baseComm: baseComm,
-			mem:      args.bootstrapper.t.PageMM(),
-			uri:      args.bootstrapper.uri,
+			mem:      args.t.PageMM(),
+			uri:      args.uri,
 		}
 	case Hpull:
-		return &redirectComm{baseComm: baseComm, uri: args.bootstrapper.uri}
+		return &redirectComm{baseComm: baseComm, uri: args.uri}
 	case Hrev:
-		transformerURL, err := url.Parse(args.bootstrapper.uri)
+		transformerURL, err := url.Parse(args.uri)
 		cos.AssertNoErr(err)
 		rp := &httputil.ReverseProxy{
 			Director: func(req *http.Request) {
@@ -134,23 +143,24 @@ func makeCommunicator(args commArgs) Communicator {
 				}
 			},
 		}
-		return &revProxyComm{baseComm: baseComm, rp: rp, uri: args.bootstrapper.uri}
+		return &revProxyComm{baseComm: baseComm, rp: rp, uri: args.uri}
 	case HpushStdin:
 		return &pushComm{
 			baseComm: baseComm,
-			mem:      args.bootstrapper.t.PageMM(),
-			uri:      args.bootstrapper.uri,
-			command:  args.bootstrapper.originalCommand,
+			mem:      args.t.PageMM(),
+			uri:      args.uri,
+			command:  args.command,
 		}
 	default:
-		cos.AssertMsg(false, args.bootstrapper.msg.CommTypeX)
+		cos.AssertMsg(false, args.commType)
 	}
 	return nil
 }
 
-func (c baseComm) Name() string    { return c.name }
-func (c baseComm) PodName() string { return c.podName }
-func (c baseComm) SvcName() string { return c.podName /*pod name is same as service name*/ }
+func (c baseComm) Name() string       { return c.name }
+func (c baseComm) PodName() string    { return c.podName }
+func (c baseComm) SvcName() string    { return c.podName /*pod name is same as service name*/ }
+func (c baseComm) Xact() cluster.Xact { return c.xctn }
 
 func (c baseComm) ObjCount() int64 { return c.xctn.Objs() }
 func (c baseComm) InBytes() int64  { return c.xctn.InBytes() }
@@ -160,63 +170,85 @@ func (c *baseComm) Stop() {
 	c.xctn.Finish(nil)
 }
 
-//////////////
-// pushComm //
-//////////////
-
-func (pc *pushComm) doRequest(bck *cluster.Bck, objName string, timeout time.Duration) (r cos.ReadCloseSizer, err error) {
+// opens local object (cold-GETting it if need be) for reading
+func (c *baseComm) openObj(bck *cluster.Bck, objName string) (fh *cos.FileHandle, size int64, err error) {
 	lom := cluster.AllocLOM(objName)
 	defer cluster.FreeLOM(lom)
-
-	if err := lom.InitBck(bck.Bucket()); err != nil {
-		return nil, err
+	if err = lom.InitBck(bck.Bucket()); err != nil {
+		return
 	}
-
-	r, err = pc.tryDoRequest(lom, timeout)
+	lom.Lock(false)
+	err = lom.Load(false /*cache it*/, true /*locked*/)
 	if err != nil && cmn.IsObjNotExist(err) && bck.IsRemote() {
-		_, err = pc.t.GetCold(context.Background(), lom, cmn.OwtGetLock)
-		if err != nil {
-			return nil, err
+		lom.Unlock(false)
+		if _, err = c.t.GetCold(context.Background(), lom, cmn.OwtGetLock); err != nil {
+			return
 		}
-		r, err = pc.tryDoRequest(lom, timeout)
+		lom.Lock(false)
+		err = lom.Load(false, true)
 	}
+	if err == nil {
+		size = lom.SizeBytes()
+		fh, err = cos.NewFileHandle(lom.FQN)
+	}
+	lom.Unlock(false)
 	return
 }
 
-func (pc *pushComm) tryDoRequest(lom *cluster.LOM, timeout time.Duration) (cos.ReadCloseSizer, error) {
+//////////////
+// pushComm //
+//////////////
+
+func (pc *pushComm) doRequest(bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
 	if err := pc.xctn.AbortErr(); err != nil {
 		return nil, cmn.NewErrAborted(pc.xctn.Name(), "try-push-comm", err)
 	}
-
-	lom.Lock(false)
-	defer lom.Unlock(false)
-
-	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+	fh, size, err := pc.openObj(bck, objName)
+	if err != nil {
 		return nil, err
 	}
-	size := lom.SizeBytes()
-
 	// `fh` is closed by Do(req).
-	fh, err := cos.NewFileHandle(lom.FQN)
+	return pc.put(fh, size, bck.Name+"/"+objName, timeout)
+}
+
+// PUT `body` to the transformer and return the response (`body` is always closed)
+func (pc *pushComm) put(body io.ReadCloser, size int64, path string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	resp, cancel, err := pc.do(body, size, path, cos.ContentBinary, timeout)
 	if err != nil {
 		return nil, err
 	}
+	if size < 0 {
+		size = 0 // unsized
+	}
+	return cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:      resp.Body,
+		Size:   resp.ContentLength,
+		ReadCb: func(i int, err error) { pc.xctn.OutObjsAdd(1, int64(i)) },
+		DeferCb: func() {
+			if cancel != nil {
+				cancel()
+			}
+			pc.xctn.InObjsAdd(1, size)
+		},
+	}), nil
+}
 
+// NOTE: the caller must close the response body and call `cancel` (if not nil)
+func (pc *pushComm) do(body io.ReadCloser, size int64, path, contentType string,
+	timeout time.Duration) (resp *http.Response, cancel func(), err error) {
 	var (
-		req    *http.Request
-		resp   *http.Response
-		cancel func()
-		url    = pc.uri + "/" + lom.Bck().Name + "/" + lom.ObjName
+		req *http.Request
+		url = pc.uri + "/" + path
 	)
 	if timeout != 0 {
 		var ctx context.Context
 		ctx, cancel = context.WithTimeout(context.Background(), timeout)
-		req, err = http.NewRequestWithContext(ctx, http.MethodPut, url, fh)
+		req, err = http.NewRequestWithContext(ctx, http.MethodPut, url, body)
 	} else {
-		req, err = http.NewRequest(http.MethodPut, url, fh)
+		req, err = http.NewRequest(http.MethodPut, url, body)
 	}
 	if err != nil {
-		cos.Close(fh)
+		cos.Close(body)
 		goto finish
 	}
 	if len(pc.command) != 0 {
@@ -225,27 +257,24 @@ func (pc *pushComm) tryDoRequest(lom *cluster.LOM, timeout time.Duration) (cos.R
 		req.URL.RawQuery = q.Encode()
 	}
 	req.ContentLength = size
-	req.Header.Set(cos.HdrContentType, cos.ContentBinary)
+	req.Header.Set(cos.HdrContentType, contentType)
 	resp, err = pc.t.DataClient().Do(req) //nolint:bodyclose // Closed by the caller.
 finish:
-	if err != nil {
-		if cancel != nil {
-			cancel()
-		}
-		return nil, err
+	if err != nil && cancel != nil {
+		cancel()
+		cancel = nil
 	}
+	return
+}
 
-	return cos.NewReaderWithArgs(cos.ReaderArgs{
-		R:      resp.Body,
-		Size:   resp.ContentLength,
-		ReadCb: func(i int, err error) { pc.xctn.OutObjsAdd(1, int64(i)) },
-		DeferCb: func() {
-			if cancel != nil {
-				cancel()
-			}
-			pc.xctn.InObjsAdd(1, size)
-		},
-	}), nil
+// (next stage in the ETL chain)
+func (pc *pushComm) transformReader(r io.ReadCloser, size int64, bck *cluster.Bck, objName string,
+	timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if err := pc.xctn.AbortErr(); err != nil {
+		cos.Close(r)
+		return nil, cmn.NewErrAborted(pc.xctn.Name(), "chain-push-comm", err)
+	}
+	return pc.put(r, size, bck.Name+"/"+objName, timeout)
 }
 
 func (pc *pushComm) OnlineTransform(w http.ResponseWriter, _ *http.Request, bck *cluster.Bck, objName string) error {
@@ -0,0 +1,241 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Minimal Docker Engine API client that talks to the local daemon via its unix socket.
+// See https://docs.docker.com/engine/api for the endpoints used below.
+
+const (
+	dockerSockEnv  = "DOCKER_HOST" // e.g. "unix:///var/run/docker.sock"
+	dfltDockerSock = "/var/run/docker.sock"
+	dockerAPIVer   = "/v1.41"
+
+	dockerLogsTail = "1000"
+)
+
+type (
+	dockerClient struct {
+		hc *http.Client
+	}
+
+	dockerPortBinding struct {
+		HostIP   string `json:"HostIp"`
+		HostPort string `json:"HostPort"`
+	}
+	dockerHostConfig struct {
+		PortBindings map[string][]dockerPortBinding `json:"PortBindings,omitempty"`
+	}
+	dockerCreateReq struct {
+		Image        string              `json:"Image"`
+		Cmd          []string            `json:"Cmd,omitempty"`
+		Env          []string            `json:"Env,omitempty"`
+		Labels       cos.StrKVs          `json:"Labels,omitempty"`
+		ExposedPorts map[string]struct{} `json:"ExposedPorts,omitempty"`
+		HostConfig   dockerHostConfig    `json:"HostConfig"`
+	}
+	dockerCreateResp struct {
+		ID string `json:"Id"`
+	}
+	dockerInspectResp struct {
+		State struct {
+			Running  bool `json:"Running"`
+			ExitCode int  `json:"ExitCode"`
+		} `json:"State"`
+	}
+	dockerStatsResp struct {
+		CPU    dockerCPUStats `json:"cpu_stats"`
+		PreCPU dockerCPUStats `json:"precpu_stats"`
+		Memory struct {
+			Usage int64 `json:"usage"`
+		} `json:"memory_stats"`
+	}
+	dockerCPUStats struct {
+		Usage struct {
+			Total uint64 `json:"total_usage"`
+		} `json:"cpu_usage"`
+		System     uint64 `json:"system_cpu_usage"`
+		OnlineCPUs int    `json:"online_cpus"`
+	}
+)
+
+func newDockerClient() *dockerClient {
+	sock := dfltDockerSock
+	if host := os.Getenv(dockerSockEnv); host != "" {
+		if u, err := url.Parse(host); err == nil && u.Scheme == "unix" {
+			sock = u.Path
+		}
+	}
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	return &dockerClient{
+		hc: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return dialer.DialContext(ctx, "unix", sock)
+				},
+			},
+		},
+	}
+}
+
+// NOTE: host part of the URL is ignored - all requests go to the unix socket
+func (dc *dockerClient) do(method, path string, query url.Values, body any, out any) (int, error) {
+	var (
+		rdr io.Reader
+		u   = "http://docker" + dockerAPIVer + path
+	)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	if body != nil {
+		rdr = bytes.NewReader(cos.MustMarshal(body))
+	}
+	req, err := http.NewRequest(method, u, rdr)
+	if err != nil {
+		return 0, err
+	}
+	if body != nil {
+		req.Header.Set(cos.HdrContentType, cos.ContentJSON)
+	}
+	resp, err := dc.hc.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, fmt.Errorf("docker %s %s: %s (%q)", method, path, resp.Status, bytes.TrimSpace(b))
+	}
+	if out != nil {
+		if b, ok := out.(*[]byte); ok {
+			*b, err = io.ReadAll(resp.Body)
+		} else {
+			err = jsoniter.NewDecoder(resp.Body).Decode(out)
+		}
+	}
+	return resp.StatusCode, err
+}
+
+func (dc *dockerClient) pull(image string) error {
+	// the response is a stream of progress messages - read it to completion
+	var progress []byte
+	_, err := dc.do(http.MethodPost, "/images/create", url.Values{"fromImage": []string{image}}, nil, &progress)
+	return err
+}
+
+func (dc *dockerClient) create(name string, creq *dockerCreateReq) (id string, err error) {
+	var (
+		resp   dockerCreateResp
+		query  = url.Values{"name": []string{name}}
+		status int
+	)
+	status, err = dc.do(http.MethodPost, "/containers/create", query, creq, &resp)
+	if status == http.StatusNotFound {
+		// no such image
+		if err = dc.pull(creq.Image); err != nil {
+			return
+		}
+		_, err = dc.do(http.MethodPost, "/containers/create", query, creq, &resp)
+	}
+	return resp.ID, err
+}
+
+func (dc *dockerClient) start(id string) error {
+	_, err := dc.do(http.MethodPost, "/containers/"+id+"/start", nil, nil, nil)
+	return err
+}
+
+func (dc *dockerClient) stop(id string, timeout time.Duration) error {
+	q := url.Values{"t": []string{strconv.Itoa(int(timeout.Seconds()))}}
+	status, err := dc.do(http.MethodPost, "/containers/"+id+"/stop", q, nil, nil)
+	if status == http.StatusNotModified {
+		err = nil // already stopped
+	}
+	return err
+}
+
+func (dc *dockerClient) remove(idOrName string) error {
+	status, err := dc.do(http.MethodDelete, "/containers/"+idOrName, url.Values{"force": []string{"true"}}, nil, nil)
+	if status == http.StatusNotFound {
+		err = nil
+	}
+	return err
+}
+
+// blocks until the container stops
+func (dc *dockerClient) wait(id string) error {
+	var resp struct {
+		StatusCode int `json:"StatusCode"`
+	}
+	if _, err := dc.do(http.MethodPost, "/containers/"+id+"/wait", nil, nil, &resp); err != nil {
+		return err
+	}
+	return fmt.Errorf("container exited with status %d", resp.StatusCode)
+}
+
+func (dc *dockerClient) running(id string) bool {
+	var resp dockerInspectResp
+	if _, err := dc.do(http.MethodGet, "/containers/"+id+"/json", nil, nil, &resp); err != nil {
+		return false
+	}
+	return resp.State.Running
+}
+
+func (dc *dockerClient) logs(id string) ([]byte, error) {
+	var (
+		raw []byte
+		q   = url.Values{"stdout": []string{"1"}, "stderr": []string{"1"}, "tail": []string{dockerLogsTail}}
+	)
+	if _, err := dc.do(http.MethodGet, "/containers/"+id+"/logs", q, nil, &raw); err != nil {
+		return nil, err
+	}
+	return demuxDockerLogs(raw), nil
+}
+
+func (dc *dockerClient) stats(id string) (cpuCores float64, mem int64, err error) {
+	var resp dockerStatsResp
+	if _, err = dc.do(http.MethodGet, "/containers/"+id+"/stats", url.Values{"stream": []string{"false"}}, nil, &resp); err != nil {
+		return
+	}
+	mem = resp.Memory.Usage
+	sysDelta := float64(resp.CPU.System) - float64(resp.PreCPU.System)
+	if sysDelta > 0 && resp.CPU.OnlineCPUs > 0 {
+		cpuDelta := float64(resp.CPU.Usage.Total) - float64(resp.PreCPU.Usage.Total)
+		cpuCores = cpuDelta / sysDelta * float64(resp.CPU.OnlineCPUs)
+	}
+	return
+}
+
+// Without TTY, container logs are multiplexed: each frame is prefixed with
+// an 8-byte header [stream-type, 0, 0, 0, size (big-endian uint32)].
+func demuxDockerLogs(raw []byte) []byte {
+	out := make([]byte, 0, len(raw))
+	for len(raw) >= 8 {
+		size := int(binary.BigEndian.Uint32(raw[4:8]))
+		raw = raw[8:]
+		if size > len(raw) {
+			size = len(raw)
+		}
+		out = append(out, raw[:size]...)
+		raw = raw[size:]
+	}
+	return out
+}
@@ -0,0 +1,126 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"encoding/binary"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// docker multiplexed stream frame (see `demuxDockerLogs`)
+func dockerFrame(stream byte, payload string) []byte {
+	hdr := make([]byte, 8)
+	hdr[0] = stream
+	binary.BigEndian.PutUint32(hdr[4:], uint32(len(payload)))
+	return append(hdr, payload...)
+}
+
+var _ = Describe("Docker", func() {
+	Describe("demuxDockerLogs", func() {
+		It("should strip frame headers", func() {
+			var raw []byte
+			raw = append(raw, dockerFrame(1, "hello ")...)
+			raw = append(raw, dockerFrame(2, "from stderr\n")...)
+			raw = append(raw, dockerFrame(1, "")...)
+			raw = append(raw, dockerFrame(1, "bye\n")...)
+			Expect(string(demuxDockerLogs(raw))).To(Equal("hello from stderr\nbye\n"))
+		})
+
+		It("should handle truncated input", func() {
+			raw := dockerFrame(1, "complete;")
+			raw = append(raw, dockerFrame(1, "truncated")[:12]...)
+			Expect(string(demuxDockerLogs(raw))).To(Equal("complete;trun"))
+
+			Expect(demuxDockerLogs(nil)).To(BeEmpty())
+			Expect(demuxDockerLogs([]byte{1, 0, 0})).To(BeEmpty())
+		})
+	})
+
+	Describe("dockerClient", func() {
+		var (
+			srv    *httptest.Server
+			mtx    sync.Mutex
+			calls  []string
+			pulled bool
+			sock   string
+		)
+
+		BeforeEach(func() {
+			calls, pulled = nil, false
+			dir, err := os.MkdirTemp("", "docker") // (short path: unix socket)
+			Expect(err).NotTo(HaveOccurred())
+			sock = filepath.Join(dir, "docker.sock")
+			l, err := net.Listen("unix", sock)
+			Expect(err).NotTo(HaveOccurred())
+
+			srv = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path := strings.TrimPrefix(r.URL.Path, dockerAPIVer)
+				mtx.Lock()
+				calls = append(calls, r.Method+" "+path)
+				mtx.Unlock()
+				switch {
+				case path == "/containers/create":
+					if !pulled {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					w.Write([]byte(`{"Id": "c0ffee"}`))
+				case path == "/images/create":
+					pulled = true
+					w.Write([]byte(`{"status": "pulled"}`))
+				case path == "/containers/c0ffee/json":
+					w.Write([]byte(`{"State": {"Running": true}}`))
+				case path == "/containers/c0ffee/stop":
+					w.WriteHeader(http.StatusNotModified)
+				case path == "/containers/c0ffee/logs":
+					w.Write(dockerFrame(1, "log line\n"))
+				case r.Method == http.MethodDelete:
+					w.WriteHeader(http.StatusNotFound)
+				default:
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			}))
+			srv.Listener = l
+			srv.Start()
+			os.Setenv(dockerSockEnv, "unix://"+sock)
+		})
+		AfterEach(func() {
+			os.Unsetenv(dockerSockEnv)
+			srv.Close()
+			os.RemoveAll(filepath.Dir(sock))
+		})
+
+		It("should pull missing image and create container", func() {
+			dc := newDockerClient()
+			id, err := dc.create("test", &dockerCreateReq{Image: "img"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal("c0ffee"))
+			Expect(calls).To(Equal([]string{
+				"POST /containers/create", "POST /images/create", "POST /containers/create",
+			}))
+		})
+
+		It("should inspect, stop, remove, and fetch logs", func() {
+			dc := newDockerClient()
+			Expect(dc.running("c0ffee")).To(BeTrue())
+			Expect(dc.running("unknown")).To(BeFalse())
+			Expect(dc.stop("c0ffee", localStopTimeout)).To(Succeed()) // (304: already stopped)
+			Expect(dc.remove("unknown")).To(Succeed())                // (404: nothing to remove)
+			logs, err := dc.logs("c0ffee")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(logs)).To(Equal("log line\n"))
+			Expect(dc.start("unknown")).NotTo(Succeed())
+		})
+	})
+})
@@ -5,6 +5,9 @@
 package etl
 
 import (
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -12,23 +15,49 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
 )
 
-type OfflineDataProvider struct {
-	tcbMsg         *apc.TCBMsg
-	comm           Communicator
-	requestTimeout time.Duration
-}
+type (
+	OfflineDataProvider struct {
+		tcbMsg         *apc.TCBMsg
+		chain          *Chain
+		requestTimeout time.Duration
+		// many-to-one
+		batch struct {
+			bck   *cluster.Bck
+			names []string
+			mu    sync.Mutex
+		}
+	}
+	// (one-shot) provides emitted object
+	emitDP struct {
+		r     cos.ReadOpenCloser
+		oah   *cmn.ObjAttrs
+		taken bool
+	}
+)
 
 // interface guard
-var _ cluster.DP = (*OfflineDataProvider)(nil)
+var (
+	_ cluster.DP      = (*OfflineDataProvider)(nil)
+	_ cluster.DPStats = (*OfflineDataProvider)(nil)
+	_ cluster.DPM     = (*OfflineDataProvider)(nil)
+	_ cluster.DP      = (*emitDP)(nil)
+)
 
 func NewOfflineDataProvider(msg *apc.TCBMsg, lsnode *cluster.Snode) (*OfflineDataProvider, error) {
-	comm, err := GetCommunicator(msg.ID, lsnode)
+	chain, err := NewChain(msg.ETLs(), lsnode)
 	if err != nil {
 		return nil, err
 	}
-	pr := &OfflineDataProvider{tcbMsg: msg, comm: comm}
+	if msg.Multi() {
+		if _, ok := chain.Head().(multiTransformer); !ok {
+			return nil, fmt.Errorf("%s: ETL %q does not support multiple inputs or outputs (expecting %s or %s)",
+				lsnode, chain.Head().Name(), Hpush, HpushStdin)
+		}
+	}
+	pr := &OfflineDataProvider{tcbMsg: msg, chain: chain}
 	pr.requestTimeout = time.Duration(msg.RequestTimeout)
 	return pr, nil
 }
@@ -41,13 +70,13 @@ func (dp *OfflineDataProvider) Reader(lom *cluster.LOM) (cos.ReadOpenCloser, cmn
 	)
 	debug.Assert(dp.tcbMsg != nil)
 	call := func() (int, error) {
-		r, err = dp.comm.OfflineTransform(lom.Bck(), lom.ObjName, dp.requestTimeout)
+		r, err = dp.chain.Transform(lom.Bck(), lom.ObjName, lom.SizeBytes(true /*not loaded*/), dp.requestTimeout)
 		return 0, err
 	}
 	// TODO: Check if ETL pod is healthy and wait some more if not (yet).
 	err = cmn.NetworkCallWithRetry(&cmn.RetryArgs{
 		Call:      call,
-		Action:    "read [" + dp.tcbMsg.ID + "]-transformed " + lom.FullName(),
+		Action:    "read [" + strings.Join(dp.tcbMsg.ETLs(), ",") + "]-transformed " + lom.FullName(),
 		SoftErr:   5,
 		HardErr:   2,
 		Sleep:     50 * time.Millisecond,
@@ -65,3 +94,79 @@ func (dp *OfflineDataProvider) Reader(lom *cluster.LOM) (cos.ReadOpenCloser, cmn
 	}
 	return cos.NopOpener(r), oah, nil
 }
+
+// per-stage stats (see `cluster.DPStats`)
+func (dp *OfflineDataProvider) DPStats() []cluster.DPStageStats { return dp.chain.Stats() }
+
+//
+// one-to-many and many-to-one (see `cluster.DPM` and multi.go)
+//
+
+func (dp *OfflineDataProvider) Emit(lom *cluster.LOM, cb cluster.EmitCb) error {
+	if dp.tcbMsg.BatchSize <= 1 {
+		return dp.emit(lom.Bck(), []string{lom.ObjName}, cb)
+	}
+	dp.batch.mu.Lock()
+	dp.batch.bck = lom.Bck()
+	dp.batch.names = append(dp.batch.names, lom.ObjName)
+	if len(dp.batch.names) < dp.tcbMsg.BatchSize {
+		dp.batch.mu.Unlock()
+		return nil
+	}
+	names := dp.batch.names
+	dp.batch.names = make([]string, 0, dp.tcbMsg.BatchSize)
+	dp.batch.mu.Unlock()
+	return dp.emit(lom.Bck(), names, cb)
+}
+
+func (dp *OfflineDataProvider) Flush(cb cluster.EmitCb) error {
+	dp.batch.mu.Lock()
+	bck, names := dp.batch.bck, dp.batch.names
+	dp.batch.names = nil
+	dp.batch.mu.Unlock()
+	if len(names) == 0 {
+		return nil
+	}
+	return dp.emit(bck, names, cb)
+}
+
+func (dp *OfflineDataProvider) emit(bck *cluster.Bck, objNames []string, cb cluster.EmitCb) error {
+	mt, ok := dp.chain.Head().(multiTransformer)
+	if !ok {
+		return fmt.Errorf("ETL %q does not support multiple inputs or outputs", dp.chain.Head().Name())
+	}
+	lom := cluster.AllocLOM(objNames[len(objNames)-1])
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		return err
+	}
+	return mt.transformMulti(bck, objNames, dp.requestTimeout, func(objName string, sgl *memsys.SGL) error {
+		var (
+			size = sgl.Size()
+			r    = cos.NewReaderWithArgs(cos.ReaderArgs{R: memsys.NewReader(sgl), Size: size, DeferCb: sgl.Free})
+			edp  = &emitDP{
+				r:   cos.NopOpener(r),
+				oah: &cmn.ObjAttrs{Size: size, Cksum: cos.NoneCksum, Atime: time.Now().UnixNano()},
+			}
+		)
+		objNameTo := dp.tcbMsg.ToName(objName)
+		err := validateOutName(objNameTo)
+		if err == nil {
+			err = cb(lom, objNameTo, edp)
+		}
+		if !edp.taken {
+			sgl.Free()
+		}
+		return err
+	})
+}
+
+////////////
+// emitDP //
+////////////
+
+func (edp *emitDP) Reader(*cluster.LOM) (cos.ReadOpenCloser, cmn.ObjAttrsHolder, error) {
+	debug.Assert(!edp.taken)
+	edp.taken = true
+	return edp.r, edp.oah, nil
+}
@@ -29,12 +29,14 @@ type (
 	MD struct {
 		Version int64
 		ETLs    ETLs
+		Stopped cos.StrSet // IDs of the ETLs that must not be (re)started upon node restart
 		Ext     any
 	}
 
 	jsonETL struct {
-		Type string              `json:"type,string"`
-		Msg  jsoniter.RawMessage `json:"msg"`
+		Type    string              `json:"type,string"`
+		Msg     jsoniter.RawMessage `json:"msg"`
+		Stopped bool                `json:"stopped,omitempty"`
 	}
 	jsonMD struct {
 		Version int64              `json:"version"`
@@ -57,7 +59,7 @@ var (
 // MD //
 ////////
 
-func (e *MD) Init(l int)         { e.ETLs = make(ETLs, l) }
+func (e *MD) Init(l int)         { e.ETLs, e.Stopped = make(ETLs, l), make(cos.StrSet) }
 func (e *MD) Add(spec InitMsg)   { e.ETLs[spec.ID()] = spec }
 func (*MD) JspOpts() jsp.Options { return etlMDJspOpts }
 
@@ -74,9 +76,22 @@ func (e *MD) Del(id string) (deleted bool) {
 		return
 	}
 	delete(e.ETLs, id)
+	delete(e.Stopped, id)
 	return true
 }
 
+func (e *MD) IsStopped(id string) bool { return e.Stopped.Contains(id) }
+
+// stopped (by user or by the runtime that gave up) ETLs remain in EtlMD
+// but are not restarted upon node restart
+func (e *MD) SetStopped(id string, stopped bool) {
+	if stopped {
+		e.Stopped.Add(id)
+	} else {
+		delete(e.Stopped, id)
+	}
+}
+
 func (e *MD) String() string {
 	if e == nil {
 		return "EtlMD <nil>"
@@ -91,7 +106,7 @@ func (e *MD) MarshalJSON() ([]byte, error) {
 		Ext:     e.Ext,
 	}
 	for k, v := range e.ETLs {
-		jsonMD.ETLs[k] = jsonETL{v.InitType(), cos.MustMarshal(v)}
+		jsonMD.ETLs[k] = jsonETL{v.InitType(), cos.MustMarshal(v), e.Stopped.Contains(k)}
 	}
 	return jsoniter.Marshal(jsonMD)
 }
@@ -102,12 +117,22 @@ func (e *MD) UnmarshalJSON(data []byte) (err error) {
 		return
 	}
 	e.Version, e.Ext = jsonMD.Version, jsonMD.Ext
-	e.ETLs = make(ETLs, len(jsonMD.ETLs))
+	e.ETLs, e.Stopped = make(ETLs, len(jsonMD.ETLs)), make(cos.StrSet)
 	for k, v := range jsonMD.ETLs {
-		if v.Type == apc.ETLInitCode {
+		if v.Stopped {
+			e.Stopped.Add(k)
+		}
+		switch v.Type {
+		case apc.ETLInitCode:
 			e.ETLs[k] = &InitCodeMsg{}
-		} else if v.Type == apc.ETLInitSpec {
+		case apc.ETLInitSpec:
 			e.ETLs[k] = &InitSpecMsg{}
+		case apc.ETLInitProc:
+			e.ETLs[k] = &InitProcMsg{}
+		case apc.ETLInitWasm:
+			e.ETLs[k] = &InitWasmMsg{}
+		default:
+			return fmt.Errorf("%s: unknown ETL init type %q (ETL %q)", e, v.Type, k)
 		}
 		if err = jsoniter.Unmarshal(v.Msg, e.ETLs[k]); err != nil {
 			break
@@ -0,0 +1,648 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/k8s"
+	"github.com/NVIDIA/aistore/sys"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Local (non-Kubernetes) ETL runtime: each target runs its own transformer
+// either as a child process or as a Docker container (see docker.go).
+// In both cases the target:
+// - selects a free local port and waits for the transformer to become ready
+//   (by polling its health path);
+// - supervises the transformer: restarts it upon exit or after a number of
+//   consecutive failed health checks, and stops the ETL when `MaxRestarts`
+//   is exhausted (the count resets once the transformer stays up for
+//   `localStableTime`); giving up is reported via `OnGiveUp`;
+// - communicates with it via the same hpush/hpull/hrev/io:// communicators
+//   (see communicator.go).
+
+const (
+	// local process must listen on $AIS_ETL_PORT (all interfaces in case of hpull://)
+	envPort = "AIS_ETL_PORT"
+
+	dfltHealthPath   = "/health"
+	dfltMaxRestarts  = 3
+	dfltLocalTimeout = 2 * time.Minute
+
+	localStopTimeout   = 10 * time.Second
+	localProbeInterval = 10 * time.Second
+	localProbeTimeout  = 5 * time.Second
+	localProbeFailures = 3                // consecutive failures prior to restarting
+	localStableTime    = 10 * time.Minute // up and running that long resets the restart count
+
+	maxProcLogs = cos.MiB // (process only) the size of the retained stdout/stderr tail
+)
+
+type (
+	// localDriver starts, stops, and monitors a single transformer instance
+	localDriver interface {
+		start(env []string) error
+		wait() error // blocks until the transformer exits
+		alive() bool
+		stop() error    // graceful, with fallback to forceful
+		destroy() error // stop and cleanup all associated resources
+		logs() ([]byte, error)
+		health() (cpuCores float64, mem int64, err error)
+	}
+
+	procDriver struct {
+		cmdline []string
+		out     *tailBuf
+		mtx     sync.Mutex
+		run     *procRun
+	}
+	procRun struct {
+		cmd  *exec.Cmd
+		done chan struct{}
+		err  error
+	}
+
+	dockerDriver struct {
+		dc   *dockerClient
+		creq *dockerCreateReq
+		name string
+		id   string
+	}
+
+	localRunner struct {
+		t        cluster.Target
+		msg      *InitProcMsg
+		errCtx   *cmn.ETLErrorContext
+		drv      localDriver
+		name     string
+		uri      string
+		env      []string
+		mtx      sync.Mutex // serializes (re)start vs stop
+		restarts atomic.Int32
+		stopping atomic.Bool
+	}
+
+	localRegistry struct {
+		mtx sync.Mutex
+		m   map[string]*localRunner
+	}
+
+	// retains the last (up to) `max` bytes written
+	tailBuf struct {
+		mtx sync.Mutex
+		b   []byte
+		max int
+	}
+)
+
+// interface guard
+var (
+	_ localDriver = (*procDriver)(nil)
+	_ localDriver = (*dockerDriver)(nil)
+)
+
+var lreg = &localRegistry{m: make(map[string]*localRunner, 4)}
+
+// OnGiveUp (if set) is called after the supervisor gives up on the transformer
+// and stops the ETL - the target then asks the primary to mark it stopped in EtlMD
+var OnGiveUp func(etlID string)
+
+// InitProc starts the transformer as a local process or Docker container
+// and registers the corresponding communicator.
+func InitProc(t cluster.Target, msg *InitProcMsg) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	if err := msg.Allowed(&cmn.GCO.Get().ETL); err != nil {
+		return err
+	}
+	r := newLocalRunner(t, msg)
+	if err := r.start(); err != nil {
+		return cmn.NewErrETL(r.errCtx, err.Error())
+	}
+
+	rns := xreg.RenewETL(t, msg)
+	debug.AssertNoErr(rns.Err)
+	var command []string
+	if msg.CommTypeX == HpushStdin {
+		command = msg.Command
+	}
+	c := makeCommunicator(commArgs{
+		listener: newAborter(t, msg.IDX),
+		t:        t,
+		xctn:     rns.Entry.Get(),
+		name:     msg.IDX,
+		podName:  r.name,
+		commType: msg.CommTypeX,
+		uri:      r.uri,
+		command:  command,
+	})
+	if err := reg.put(msg.IDX, c); err != nil {
+		if errS := r.stop(); errS != nil {
+			glog.Error(errS)
+		}
+		c.Stop()
+		return err
+	}
+	lreg.put(msg.IDX, r)
+	t.Sowner().Listeners().Reg(c)
+
+	go r.supervise()
+	return nil
+}
+
+// Converts K8s pod spec into the equivalent local (Docker) configuration.
+// Only a subset of the spec is supported: single container and no init containers.
+func specToProc(msg *InitSpecMsg, opts StartOpts) (*InitProcMsg, error) {
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+	errCtx := &cmn.ETLErrorContext{UUID: msg.IDX}
+	pod, err := ParsePodSpec(errCtx, msg.Spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(pod.Spec.InitContainers) > 0 {
+		return nil, cmn.NewErrETL(errCtx, "init containers are not supported outside Kubernetes")
+	}
+	var (
+		c    = pod.Spec.Containers[0]
+		pmsg = &InitProcMsg{
+			InitMsgBase: msg.InitMsgBase,
+			Image:       c.Image,
+			Port:        int(c.Ports[0].ContainerPort),
+			HealthPath:  c.ReadinessProbe.HTTPGet.Path,
+			Env:         make(cos.StrKVs, len(c.Env)+len(opts.Env)),
+		}
+	)
+	pmsg.Command = append(pmsg.Command, c.Command...)
+	pmsg.Command = append(pmsg.Command, c.Args...)
+	for _, e := range c.Env {
+		if e.ValueFrom == nil {
+			pmsg.Env[e.Name] = e.Value
+		}
+	}
+	for k, v := range opts.Env {
+		pmsg.Env[k] = v
+	}
+	return pmsg, pmsg.Validate()
+}
+
+/////////////////
+// localRunner //
+/////////////////
+
+func newLocalRunner(t cluster.Target, msg *InitProcMsg) (r *localRunner) {
+	r = &localRunner{
+		t:      t,
+		msg:    msg,
+		errCtx: &cmn.ETLErrorContext{TID: t.SID(), UUID: msg.IDX},
+		name:   k8s.CleanName(msg.IDX + "-" + t.SID()),
+	}
+	r.errCtx.PodName = r.name
+	if msg.IsDocker() {
+		r.drv = &dockerDriver{dc: newDockerClient(), name: r.name}
+	} else {
+		r.drv = &procDriver{cmdline: msg.Command, out: &tailBuf{max: maxProcLogs}}
+	}
+	return
+}
+
+func (r *localRunner) String() string { return "local-etl[" + r.name + "]" }
+
+func (r *localRunner) maxRestarts() int32 {
+	if r.msg.MaxRestarts == 0 {
+		return dfltMaxRestarts
+	}
+	return int32(r.msg.MaxRestarts)
+}
+
+func (r *localRunner) start() error {
+	port, err := freePort()
+	if err != nil {
+		return err
+	}
+	// hpull redirects clients to the transformer - must be reachable from outside
+	host := "127.0.0.1"
+	if r.msg.CommTypeX == Hpull {
+		host = r.t.Snode().PubNet.Hostname
+	}
+	r.uri = "http://" + net.JoinHostPort(host, strconv.Itoa(port))
+	r.env = make([]string, 0, len(r.msg.Env)+2)
+	r.env = append(r.env, "AIS_TARGET_URL="+r.t.Snode().URL(cmn.NetPublic)+apc.URLPathETLObject.Join(reqSecret))
+	for k, v := range r.msg.Env {
+		r.env = append(r.env, k+"="+v)
+	}
+
+	if d, ok := r.drv.(*dockerDriver); ok {
+		d.creq = r.containerSpec(port)
+	} else {
+		r.env = append(r.env, envPort+"="+strconv.Itoa(port))
+	}
+	if err := r.drv.start(r.env); err != nil {
+		return err
+	}
+	if err := r.waitReady(); err != nil {
+		if errD := r.drv.destroy(); errD != nil {
+			glog.Error(errD)
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *localRunner) containerSpec(hostPort int) *dockerCreateReq {
+	var (
+		cport = strconv.Itoa(r.msg.Port) + "/tcp"
+		creq  = &dockerCreateReq{
+			Image:        r.msg.Image,
+			Cmd:          r.msg.Command,
+			ExposedPorts: map[string]struct{}{cport: {}},
+			Labels: cos.StrKVs{
+				appLabel:       "ais",
+				podNameLabel:   r.name,
+				podTargetLabel: r.t.SID(),
+			},
+		}
+	)
+	if r.msg.CommTypeX == HpushStdin {
+		// same as in K8s: the container's `/server` runs the original command upon each request
+		creq.Cmd = []string{"sh", "-c", "/server"}
+	}
+	hostIP := "127.0.0.1"
+	if r.msg.CommTypeX == Hpull {
+		hostIP = "" // all interfaces
+	}
+	creq.HostConfig.PortBindings = map[string][]dockerPortBinding{
+		cport: {{HostIP: hostIP, HostPort: strconv.Itoa(hostPort)}},
+	}
+	return creq
+}
+
+func (r *localRunner) waitReady() (err error) {
+	timeout := time.Duration(r.msg.Timeout)
+	if timeout == 0 {
+		timeout = dfltLocalTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		if !r.drv.alive() {
+			return fmt.Errorf("%s exited before becoming ready", r)
+		}
+		if err = r.probe(); err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%s failed to become ready in %v: %v", r, timeout, err)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+func (r *localRunner) probe() error {
+	ctx, cancel := context.WithTimeout(context.Background(), localProbeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.uri+r.msg.HealthPath, http.NoBody)
+	if err != nil {
+		return err
+	}
+	resp, err := r.t.DataClient().Do(req)
+	if err != nil {
+		return err
+	}
+	cos.DrainReader(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check %q: %s", r.msg.HealthPath, resp.Status)
+	}
+	return nil
+}
+
+// runs for the lifetime of the ETL
+func (r *localRunner) supervise() {
+	for {
+		started := time.Now()
+		err := r.watch()
+		if time.Since(started) > localStableTime {
+			r.restarts.Store(0)
+		}
+		for {
+			if r.stopping.Load() {
+				return
+			}
+			n := r.restarts.Inc()
+			if n > r.maxRestarts() {
+				err = cmn.NewErrETL(r.errCtx, "giving up after %d restart(s): %v", n-1, err)
+				glog.Error(err)
+				if errS := Stop(r.t, r.msg.IDX, err); errS != nil {
+					glog.Error(errS)
+				}
+				if OnGiveUp != nil {
+					OnGiveUp(r.msg.IDX)
+				}
+				return
+			}
+			glog.Warningf("%s: restarting (%d/%d): %v", r, n, r.maxRestarts(), err)
+			time.Sleep(time.Duration(n) * time.Second)
+			var stopped bool
+			if stopped, err = r.restart(); stopped {
+				return
+			}
+			if err == nil {
+				if err = r.waitReady(); err == nil {
+					break
+				}
+				if errS := r.drv.stop(); errS != nil {
+					glog.Error(errS)
+				}
+			}
+		}
+	}
+}
+
+// checks and starts under the same lock as `stop` - otherwise, the transformer
+// started right after (or while) stopping would never be terminated
+func (r *localRunner) restart() (stopped bool, err error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.stopping.Load() {
+		return true, nil
+	}
+	return false, r.drv.start(r.env)
+}
+
+// returns when the transformer exits or when it fails (`localProbeFailures`) consecutive health checks
+func (r *localRunner) watch() error {
+	var (
+		fails  int
+		exited = make(chan error, 1)
+		ticker = time.NewTicker(localProbeInterval)
+	)
+	defer ticker.Stop()
+	go func() { exited <- r.drv.wait() }()
+	for {
+		select {
+		case err := <-exited:
+			return err
+		case <-ticker.C:
+			if r.stopping.Load() {
+				continue
+			}
+			err := r.probe()
+			if err == nil {
+				fails = 0
+				continue
+			}
+			if fails++; fails < localProbeFailures {
+				continue
+			}
+			if errS := r.drv.stop(); errS != nil {
+				glog.Error(errS)
+			}
+			<-exited
+			return fmt.Errorf("failed %d consecutive health checks: %v", fails, err)
+		}
+	}
+}
+
+func (r *localRunner) stop() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.stopping.Store(true)
+	if err := r.drv.destroy(); err != nil {
+		return cmn.NewErrETL(r.errCtx, err.Error())
+	}
+	return nil
+}
+
+func (r *localRunner) podLogs() (PodLogsMsg, error) {
+	b, err := r.drv.logs()
+	return PodLogsMsg{TargetID: r.t.SID(), Logs: b}, err
+}
+
+func (r *localRunner) podHealth() (*PodHealthMsg, error) {
+	cpu, mem, err := r.drv.health()
+	if err != nil {
+		return nil, err
+	}
+	return &PodHealthMsg{TargetID: r.t.SID(), CPU: cpu, Mem: mem}, nil
+}
+
+///////////////////
+// localRegistry //
+///////////////////
+
+func (lr *localRegistry) put(id string, r *localRunner) {
+	lr.mtx.Lock()
+	lr.m[id] = r
+	lr.mtx.Unlock()
+}
+
+func (lr *localRegistry) get(id string) (r *localRunner) {
+	lr.mtx.Lock()
+	r = lr.m[id]
+	lr.mtx.Unlock()
+	return
+}
+
+func (lr *localRegistry) del(id string) (r *localRunner) {
+	lr.mtx.Lock()
+	if r = lr.m[id]; r != nil {
+		delete(lr.m, id)
+	}
+	lr.mtx.Unlock()
+	return
+}
+
+////////////////
+// procDriver //
+////////////////
+
+func (d *procDriver) start(env []string) error {
+	cmd := exec.Command(d.cmdline[0], d.cmdline[1:]...) //nolint:gosec // user-defined transformer
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout, cmd.Stderr = d.out, d.out
+	// new process group, to terminate the transformer along with its children (see stop)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	run := &procRun{cmd: cmd, done: make(chan struct{})}
+	go func() {
+		run.err = cmd.Wait()
+		close(run.done)
+	}()
+	d.mtx.Lock()
+	d.run = run
+	d.mtx.Unlock()
+	return nil
+}
+
+func (d *procDriver) current() (run *procRun) {
+	d.mtx.Lock()
+	run = d.run
+	d.mtx.Unlock()
+	return
+}
+
+func (d *procDriver) wait() error {
+	run := d.current()
+	if run == nil {
+		return nil
+	}
+	<-run.done
+	if run.err == nil {
+		return fmt.Errorf("process %v exited", d.cmdline)
+	}
+	return fmt.Errorf("process %v exited: %v", d.cmdline, run.err)
+}
+
+func (d *procDriver) alive() bool {
+	run := d.current()
+	if run == nil {
+		return false
+	}
+	select {
+	case <-run.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (d *procDriver) stop() error {
+	run := d.current()
+	if run == nil || !d.alive() {
+		return nil
+	}
+	pgid := run.cmd.Process.Pid // (Setpgid)
+	if err := syscall.Kill(-pgid, syscall.SIGTERM); err != nil {
+		return killGroup(pgid, run)
+	}
+	select {
+	case <-run.done:
+		// the group leader is gone - make sure the rest of the group is gone as well
+		if err := syscall.Kill(-pgid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+			return err
+		}
+	case <-time.After(localStopTimeout):
+		return killGroup(pgid, run)
+	}
+	return nil
+}
+
+func killGroup(pgid int, run *procRun) error {
+	if err := syscall.Kill(-pgid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+		return err
+	}
+	<-run.done
+	return nil
+}
+
+func (d *procDriver) destroy() error { return d.stop() }
+
+func (d *procDriver) logs() ([]byte, error) { return d.out.get(), nil }
+
+func (d *procDriver) health() (float64, int64, error) {
+	run := d.current()
+	if run == nil || !d.alive() {
+		return 0, 0, fmt.Errorf("process %v is not running", d.cmdline)
+	}
+	stats, err := sys.ProcessStats(run.cmd.Process.Pid)
+	if err != nil {
+		return 0, 0, err
+	}
+	return stats.CPU.Percent / 100, int64(stats.Mem.Resident), nil
+}
+
+//////////////////
+// dockerDriver //
+//////////////////
+
+func (d *dockerDriver) start(env []string) (err error) {
+	if d.id == "" {
+		// remove leftovers (if any) from a previous run
+		if err = d.dc.remove(d.name); err != nil {
+			return
+		}
+		d.creq.Env = env
+		if d.id, err = d.dc.create(d.name, d.creq); err != nil {
+			return
+		}
+	}
+	return d.dc.start(d.id)
+}
+
+func (d *dockerDriver) wait() error { return d.dc.wait(d.id) }
+func (d *dockerDriver) alive() bool { return d.id != "" && d.dc.running(d.id) }
+
+func (d *dockerDriver) stop() error {
+	if d.id == "" {
+		return nil
+	}
+	return d.dc.stop(d.id, localStopTimeout)
+}
+
+func (d *dockerDriver) destroy() error {
+	if d.id == "" {
+		return nil
+	}
+	return d.dc.remove(d.id)
+}
+
+func (d *dockerDriver) logs() ([]byte, error)           { return d.dc.logs(d.id) }
+func (d *dockerDriver) health() (float64, int64, error) { return d.dc.stats(d.id) }
+
+/////////////
+// tailBuf //
+/////////////
+
+func (tb *tailBuf) Write(p []byte) (int, error) {
+	tb.mtx.Lock()
+	tb.b = append(tb.b, p...)
+	if over := len(tb.b) - tb.max; over > 0 {
+		tb.b = append(tb.b[:0], tb.b[over:]...)
+	}
+	tb.mtx.Unlock()
+	return len(p), nil
+}
+
+func (tb *tailBuf) get() (b []byte) {
+	tb.mtx.Lock()
+	b = append(b, tb.b...)
+	tb.mtx.Unlock()
+	return
+}
+
+///////////
+// utils //
+///////////
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	cos.Close(l)
+	return port, nil
+}
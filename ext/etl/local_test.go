@@ -0,0 +1,311 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+const localTestSpec = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: local-test
+spec:
+  containers:
+    - name: server
+      image: aistore/transformer_md5:latest
+      command: ["python", "server.py"]
+      args: ["--listen", "0.0.0.0"]
+      ports:
+        - name: default
+          containerPort: 8000
+      readinessProbe:
+        httpGet:
+          path: /ready
+          port: default
+      env:
+        - name: FOO
+          value: "bar"
+        - name: FROM_SECRET
+          valueFrom:
+            secretKeyRef:
+              name: secret
+              key: key
+`
+
+// mock target with a (non-nil) node - the supervisor stops the ETL upon giving up
+type localTestTarget struct {
+	mock.TargetMock
+	si *cluster.Snode
+}
+
+func (t *localTestTarget) Snode() *cluster.Snode { return t.si }
+
+// counts (re)starts
+type countingDriver struct {
+	*procDriver
+	starts int
+}
+
+func (d *countingDriver) start(env []string) error {
+	d.starts++
+	return d.procDriver.start(env)
+}
+
+func newTestRunner(uri string, maxRestarts int, cmdline ...string) (*localRunner, *countingDriver) {
+	var (
+		msg = &InitProcMsg{
+			InitMsgBase: InitMsgBase{IDX: "local-test"},
+			Command:     cmdline,
+			HealthPath:  dfltHealthPath,
+			MaxRestarts: maxRestarts,
+		}
+		drv = &countingDriver{procDriver: &procDriver{cmdline: cmdline, out: &tailBuf{max: cos.KiB}}}
+	)
+	r := &localRunner{
+		t:      &localTestTarget{si: &cluster.Snode{DaeID: "local-test-target"}},
+		msg:    msg,
+		errCtx: &cmn.ETLErrorContext{UUID: msg.IDX},
+		drv:    drv,
+		name:   msg.IDX,
+		uri:    uri,
+	}
+	return r, drv
+}
+
+var _ = Describe("LocalRuntime", func() {
+	DescribeTable("InitProcMsg.Validate",
+		func(msg *InitProcMsg, valid bool) {
+			err := msg.Validate()
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(msg.CommTypeX).NotTo(BeEmpty())
+				Expect(msg.HealthPath).To(HavePrefix("/"))
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("process", &InitProcMsg{InitMsgBase: InitMsgBase{IDX: "etl-proc"}, Command: []string{"./server"}}, true),
+		Entry("container", &InitProcMsg{InitMsgBase: InitMsgBase{IDX: "etl-docker"}, Image: "img", Port: 80}, true),
+		Entry("health path w/o slash", &InitProcMsg{
+			InitMsgBase: InitMsgBase{IDX: "etl-proc"}, Command: []string{"./server"}, HealthPath: "ready",
+		}, true),
+		Entry("invalid ID", &InitProcMsg{InitMsgBase: InitMsgBase{IDX: "1invalid"}, Command: []string{"./server"}}, false),
+		Entry("invalid comm-type", &InitProcMsg{
+			InitMsgBase: InitMsgBase{IDX: "etl-proc", CommTypeX: "ftp://"}, Command: []string{"./server"},
+		}, false),
+		Entry("neither command nor image", &InitProcMsg{InitMsgBase: InitMsgBase{IDX: "etl-proc"}}, false),
+		Entry("process w/ io://", &InitProcMsg{
+			InitMsgBase: InitMsgBase{IDX: "etl-proc", CommTypeX: HpushStdin}, Command: []string{"./server"},
+		}, false),
+		Entry("container w/ io:// and no command", &InitProcMsg{
+			InitMsgBase: InitMsgBase{IDX: "etl-docker", CommTypeX: HpushStdin}, Image: "img", Port: 80,
+		}, false),
+		Entry("container w/o port", &InitProcMsg{InitMsgBase: InitMsgBase{IDX: "etl-docker"}, Image: "img"}, false),
+		Entry("invalid port", &InitProcMsg{InitMsgBase: InitMsgBase{IDX: "etl-docker"}, Image: "img", Port: 1 << 20}, false),
+		Entry("negative max-restarts", &InitProcMsg{
+			InitMsgBase: InitMsgBase{IDX: "etl-proc"}, Command: []string{"./server"}, MaxRestarts: -1,
+		}, false),
+	)
+
+	DescribeTable("InitProcMsg.Allowed",
+		func(msg *InitProcMsg, conf cmn.ETLConf, allowed bool) {
+			Expect(conf.Validate()).To(Succeed())
+			if err := msg.Allowed(&conf); allowed {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("disabled", &InitProcMsg{Command: []string{"/opt/etl/server"}},
+			cmn.ETLConf{LocalAllow: []string{"/opt/etl/server"}}, false),
+		Entry("exact", &InitProcMsg{Command: []string{"/opt/etl/server", "-v"}},
+			cmn.ETLConf{LocalEnabled: true, LocalAllow: []string{"/opt/etl/server"}}, true),
+		Entry("prefix", &InitProcMsg{Command: []string{"/opt/etl/bin/server"}},
+			cmn.ETLConf{LocalEnabled: true, LocalAllow: []string{"/opt/etl/bin/*"}}, true),
+		Entry("not listed", &InitProcMsg{Command: []string{"/bin/sh", "-c", "rm -rf /"}},
+			cmn.ETLConf{LocalEnabled: true, LocalAllow: []string{"/opt/etl/bin/*"}}, false),
+		Entry("empty list", &InitProcMsg{Command: []string{"/opt/etl/server"}},
+			cmn.ETLConf{LocalEnabled: true}, false),
+		Entry("image", &InitProcMsg{Image: "docker.io/myorg/md5:latest", Port: 80},
+			cmn.ETLConf{LocalEnabled: true, LocalAllow: []string{"docker.io/myorg/*"}}, true),
+		Entry("image not listed", &InitProcMsg{Image: "docker.io/other/md5:latest", Port: 80},
+			cmn.ETLConf{LocalEnabled: true, LocalAllow: []string{"docker.io/myorg/*"}}, false),
+	)
+
+	It("should reject invalid allowlist", func() {
+		for _, s := range []string{"", "*", "/opt/*/server"} {
+			conf := cmn.ETLConf{LocalAllow: []string{s}}
+			Expect(conf.Validate()).NotTo(Succeed(), s)
+		}
+	})
+
+	Describe("specToProc", func() {
+		It("should convert pod spec into container config", func() {
+			msg := &InitSpecMsg{InitMsgBase: InitMsgBase{IDX: "spec-test"}, Spec: []byte(localTestSpec)}
+			pmsg, err := specToProc(msg, StartOpts{Env: map[string]string{"EXTRA": "1"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pmsg.IDX).To(Equal("spec-test"))
+			Expect(pmsg.CommTypeX).To(Equal(Hpush))
+			Expect(pmsg.IsDocker()).To(BeTrue())
+			Expect(pmsg.Image).To(Equal("aistore/transformer_md5:latest"))
+			Expect(pmsg.Port).To(Equal(8000))
+			Expect(pmsg.HealthPath).To(Equal("/ready"))
+			Expect(pmsg.Command).To(Equal([]string{"python", "server.py", "--listen", "0.0.0.0"}))
+			Expect(pmsg.Env).To(Equal(cos.StrKVs{"FOO": "bar", "EXTRA": "1"}))
+		})
+
+		It("should reject init containers", func() {
+			spec := strings.Replace(localTestSpec, "  containers:", "  initContainers:\n    - name: init\n      image: busybox\n  containers:", 1)
+			msg := &InitSpecMsg{InitMsgBase: InitMsgBase{IDX: "spec-test"}, Spec: []byte(spec)}
+			_, err := specToProc(msg, StartOpts{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject invalid spec", func() {
+			spec := strings.Replace(localTestSpec, "      readinessProbe:\n        httpGet:\n          path: /ready\n          port: default\n", "", 1)
+			msg := &InitSpecMsg{InitMsgBase: InitMsgBase{IDX: "spec-test"}, Spec: []byte(spec)}
+			_, err := specToProc(msg, StartOpts{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("procDriver", func() {
+		It("should start, report alive, and stop", func() {
+			d := &procDriver{cmdline: []string{"sleep", "30"}, out: &tailBuf{max: cos.KiB}}
+			Expect(d.alive()).To(BeFalse())
+			Expect(d.start(nil)).To(Succeed())
+			Expect(d.alive()).To(BeTrue())
+
+			exited := make(chan error, 1)
+			go func() { exited <- d.wait() }()
+			Consistently(exited, 200*time.Millisecond).ShouldNot(Receive())
+
+			Expect(d.stop()).To(Succeed())
+			Expect(d.alive()).To(BeFalse())
+			Eventually(exited, 5*time.Second).Should(Receive(HaveOccurred()))
+			Expect(d.stop()).To(Succeed()) // idempotent
+		})
+
+		It("should pass environment and capture output", func() {
+			d := &procDriver{cmdline: []string{"sh", "-c", "echo port=$" + envPort}, out: &tailBuf{max: cos.KiB}}
+			Expect(d.start([]string{envPort + "=12345"})).To(Succeed())
+			Expect(d.wait()).To(HaveOccurred()) // (any exit is reported as error)
+			Expect(d.alive()).To(BeFalse())
+			logs, err := d.logs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(logs)).To(Equal("port=12345\n"))
+		})
+
+		It("should terminate the entire process group", func() {
+			d := &procDriver{cmdline: []string{"sh", "-c", "sleep 30 & echo $!; wait"}, out: &tailBuf{max: cos.KiB}}
+			Expect(d.start(nil)).To(Succeed())
+			Eventually(func() []byte { b, _ := d.logs(); return b }, 5*time.Second).ShouldNot(BeEmpty())
+			logs, _ := d.logs()
+			child, err := strconv.Atoi(strings.TrimSpace(string(logs)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(syscall.Kill(child, 0)).To(Succeed())
+
+			Expect(d.stop()).To(Succeed())
+			Eventually(func() error { return syscall.Kill(child, 0) }, 5*time.Second).Should(Equal(syscall.ESRCH))
+		})
+
+		It("should fail to start non-existing executable", func() {
+			d := &procDriver{cmdline: []string{"/non/existing/transformer"}, out: &tailBuf{max: cos.KiB}}
+			Expect(d.start(nil)).NotTo(Succeed())
+			Expect(d.alive()).To(BeFalse())
+		})
+	})
+
+	Describe("supervise", func() {
+		var healthSrv *httptest.Server
+
+		BeforeEach(func() {
+			xreg.TestReset() // (giving up entails stopping the ETL)
+			healthSrv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+		})
+		AfterEach(func() {
+			healthSrv.Close()
+		})
+
+		It("should restart exited transformer and give up after max-restarts", func() {
+			r, drv := newTestRunner(healthSrv.URL, 2, "sleep", "0.5")
+			Expect(r.drv.start(nil)).To(Succeed())
+
+			done := make(chan struct{})
+			go func() {
+				r.supervise()
+				close(done)
+			}()
+			Eventually(done, 20*time.Second).Should(BeClosed())
+			Expect(drv.starts).To(Equal(3)) // initial + 2 restarts
+			Expect(r.restarts.Load()).To(BeEquivalentTo(3))
+			Expect(r.drv.alive()).To(BeFalse())
+		})
+
+		It("should not restart when stopping", func() {
+			r, drv := newTestRunner(healthSrv.URL, 2, "sleep", "30")
+			Expect(r.drv.start(nil)).To(Succeed())
+
+			done := make(chan struct{})
+			go func() {
+				r.supervise()
+				close(done)
+			}()
+			Expect(r.stop()).To(Succeed())
+			Eventually(done, 5*time.Second).Should(BeClosed())
+			Expect(drv.starts).To(Equal(1))
+			Expect(r.drv.alive()).To(BeFalse())
+
+			stopped, err := r.restart()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stopped).To(BeTrue())
+			Expect(drv.starts).To(Equal(1))
+		})
+	})
+
+	Describe("tailBuf", func() {
+		It("should retain the tail", func() {
+			tb := &tailBuf{max: 8}
+			n, err := tb.Write([]byte("0123"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(4))
+			Expect(string(tb.get())).To(Equal("0123"))
+
+			n, err = tb.Write([]byte("456789ab"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(8))
+			Expect(string(tb.get())).To(Equal("456789ab"))
+
+			tb.Write([]byte("cdefghijklmnop"))
+			Expect(string(tb.get())).To(Equal("ijklmnop"))
+		})
+
+		It("should return a copy", func() {
+			tb := &tailBuf{max: 8}
+			tb.Write([]byte("abc"))
+			b := tb.get()
+			b[0] = 'x'
+			Expect(string(tb.get())).To(Equal("abc"))
+		})
+	})
+})
@@ -0,0 +1,183 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Multi-output (one-to-many) and multi-input (many-to-one) offline transformations,
+// currently supported by the `hpush://` and `io://` communicators.
+//
+// Request:
+// - single input object: PUT /<bucket>/<object> with the object's content (as usual);
+// - batch of input objects: PUT /<bucket>/ with `multipart/mixed` body, one part per object,
+//   with the object name carried by the part's `Content-Disposition` (as `filename`).
+// Response:
+// - `multipart/mixed` body: each part is a separate output object named by its `filename`
+//   (zero parts is a valid response - nothing to write);
+// - otherwise, a single output object named after the (first) input.
+
+type (
+	// consumes (and eventually frees) the SGL containing transformed object
+	emitFn func(objName string, sgl *memsys.SGL) error
+
+	multiTransformer interface {
+		transformMulti(bck *cluster.Bck, objNames []string, timeout time.Duration, emit emitFn) error
+	}
+)
+
+// interface guard
+var _ multiTransformer = (*pushComm)(nil)
+
+func (pc *pushComm) transformMulti(bck *cluster.Bck, objNames []string, timeout time.Duration, emit emitFn) error {
+	if err := pc.xctn.AbortErr(); err != nil {
+		return cmn.NewErrAborted(pc.xctn.Name(), "multi-push-comm", err)
+	}
+	var (
+		resp   *http.Response
+		cancel func()
+		inSize int64
+		err    error
+	)
+	if len(objNames) == 1 {
+		var fh *cos.FileHandle
+		if fh, inSize, err = pc.openObj(bck, objNames[0]); err != nil {
+			return err
+		}
+		resp, cancel, err = pc.do(fh, inSize, bck.Name+"/"+objNames[0], cos.ContentBinary, timeout)
+	} else {
+		var (
+			pr, pw = io.Pipe()
+			mpw    = multipart.NewWriter(pw)
+		)
+		go pc.writeParts(pw, mpw, bck, objNames)
+		ctype := mime.FormatMediaType(cos.ContentMultipartMixed, map[string]string{"boundary": mpw.Boundary()})
+		resp, cancel, err = pc.do(pr, -1, bck.Name+"/", ctype, timeout)
+	}
+	if err != nil {
+		return err
+	}
+	if cancel != nil {
+		defer cancel()
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, cos.KiB))
+		return fmt.Errorf("%s: transformer responded with %q: %s", pc.name, resp.Status, b)
+	}
+	if len(objNames) == 1 {
+		pc.xctn.InObjsAdd(1, inSize) // (batches are accounted for by `writeParts`)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get(cos.HdrContentType))
+	if err != nil || mediaType != cos.ContentMultipartMixed {
+		// single output
+		return pc.emitPart(objNames[0], resp.Body, resp.ContentLength, emit)
+	}
+	mpr := multipart.NewReader(resp.Body, params["boundary"])
+	for {
+		part, err := mpr.NextPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// NOTE: not using `part.FileName()` as it strips the directory (and object names may contain slashes)
+		_, dparams, _ := mime.ParseMediaType(part.Header.Get(cos.HdrContentDisposition))
+		objName := dparams["filename"]
+		if objName == "" {
+			part.Close()
+			return fmt.Errorf("%s: multipart response: missing output object name (%s)", pc.name, part.Header)
+		}
+		if err := validateOutName(objName); err != nil {
+			part.Close()
+			return fmt.Errorf("%s: multipart response: %v", pc.name, err)
+		}
+		err = pc.emitPart(objName, part, -1, emit)
+		part.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (pc *pushComm) emitPart(objName string, r io.Reader, size int64, emit emitFn) error {
+	if size < 0 {
+		size = 0
+	}
+	sgl := pc.mem.NewSGL(size)
+	if _, err := io.Copy(sgl, r); err != nil {
+		sgl.Free()
+		return err
+	}
+	pc.xctn.OutObjsAdd(1, sgl.Size())
+	return emit(objName, sgl)
+}
+
+// output object name (as named by the transformer) must be clean and relative -
+// otherwise, it could end up outside its bucket (and mountpath)
+func validateOutName(objName string) error {
+	switch {
+	case objName == "" || objName == "." || strings.IndexByte(objName, 0) >= 0:
+		return fmt.Errorf("invalid output object name %q", objName)
+	case objName[0] == '/':
+		return fmt.Errorf("invalid output object name %q: absolute path", objName)
+	case filepath.Clean(objName) != objName:
+		return fmt.Errorf("invalid output object name %q: not a clean path", objName)
+	}
+	for _, elem := range strings.Split(objName, "/") {
+		if elem == ".." {
+			return fmt.Errorf("invalid output object name %q: parent directory reference", objName)
+		}
+	}
+	return nil
+}
+
+// writes batch of objects as `multipart/mixed` request body
+func (pc *pushComm) writeParts(pw *io.PipeWriter, mpw *multipart.Writer, bck *cluster.Bck, objNames []string) {
+	var err error
+	for _, objName := range objNames {
+		var (
+			fh   *cos.FileHandle
+			part io.Writer
+			hdr  = make(textproto.MIMEHeader, 3)
+			size int64
+		)
+		if fh, size, err = pc.openObj(bck, objName); err != nil {
+			break
+		}
+		hdr.Set(cos.HdrContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": objName}))
+		hdr.Set(cos.HdrContentType, cos.ContentBinary)
+		hdr.Set(cos.HdrContentLength, fmt.Sprintf("%d", size))
+		if part, err = mpw.CreatePart(hdr); err == nil {
+			_, err = io.Copy(part, fh)
+		}
+		cos.Close(fh)
+		if err != nil {
+			break
+		}
+		pc.xctn.InObjsAdd(1, size)
+	}
+	if err == nil {
+		err = mpw.Close()
+	}
+	pw.CloseWithError(err)
+}
@@ -145,7 +145,16 @@ func (e *Aborter) ListenSmapChanged() {
 	}()
 }
 
+// InitSpec starts ETL pod (container) as per the user-provided K8s spec;
+// outside Kubernetes, the same spec (subset thereof) is used to run local Docker container.
 func InitSpec(t cluster.Target, msg *InitSpecMsg, opts StartOpts) (err error) {
+	if k8s.Detect() != nil {
+		var pmsg *InitProcMsg
+		if pmsg, err = specToProc(msg, opts); err != nil {
+			return err
+		}
+		return InitProc(t, pmsg)
+	}
 	errCtx, podName, svcName, err := tryStart(t, msg, opts)
 	if err != nil {
 		glog.Warning(cmn.NewErrETL(errCtx, "Performing cleanup after unsuccessful Start"))
@@ -161,6 +170,9 @@ func InitSpec(t cluster.Target, msg *InitSpecMsg, opts StartOpts) (err error) {
 // in the etl/runtime/podspec.yaml spec and run the container.
 // See also: etl/runtime/podspec.yaml
 func InitCode(t cluster.Target, msg *InitCodeMsg) error {
+	if err := k8s.Detect(); err != nil {
+		return fmt.Errorf("ETL %q: %v (to run transformers locally, use %q)", msg.IDX, err, apc.ETLInitProc)
+	}
 	var (
 		ftp      = fromToPairs(msg)
 		replacer = strings.NewReplacer(ftp...)
@@ -279,8 +291,14 @@ func tryStart(t cluster.Target, msg *InitSpecMsg, opts StartOpts) (errCtx *cmn.E
 	boot.setupXaction()
 
 	c := makeCommunicator(commArgs{
-		listener:     newAborter(t, msg.IDX),
-		bootstrapper: boot,
+		listener: newAborter(t, msg.IDX),
+		t:        t,
+		xctn:     boot.xctn,
+		name:     boot.originalPodName,
+		podName:  boot.pod.Name,
+		commType: msg.CommTypeX,
+		uri:      boot.uri,
+		command:  boot.originalCommand,
 	})
 	// NOTE: Communicator is put to registry only if the whole tryStart was successful.
 	if err = reg.put(msg.IDX, c); err != nil {
@@ -308,7 +326,13 @@ func Stop(t cluster.Target, id string, errCause error) error {
 	errCtx.PodName = c.PodName()
 	errCtx.SvcName = c.SvcName()
 
-	if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
+	if lr := lreg.del(id); lr != nil {
+		if err := lr.stop(); err != nil {
+			glog.Error(err) // proceed anyway
+		}
+	} else if _, ok := c.(*wasmComm); ok {
+		// in-process: nothing to cleanup (the runtime is closed by `c.Stop` below)
+	} else if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
 		return err
 	}
 
@@ -323,9 +347,6 @@ func Stop(t cluster.Target, id string, errCause error) error {
 
 // StopAll terminates all running ETLs.
 func StopAll(t cluster.Target) {
-	if k8s.Detect() != nil {
-		return
-	}
 	for _, e := range List() {
 		if err := Stop(t, e.ID, nil); err != nil {
 			glog.Error(err)
@@ -348,6 +369,12 @@ func PodLogs(t cluster.Target, transformID string) (logs PodLogsMsg, err error)
 	if err != nil {
 		return logs, err
 	}
+	if lr := lreg.get(transformID); lr != nil {
+		return lr.podLogs()
+	}
+	if wc, ok := c.(*wasmComm); ok {
+		return wc.podLogs(), nil
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return logs, err
@@ -370,6 +397,13 @@ func PodHealth(t cluster.Target, etlID string) (stats *PodHealthMsg, err error)
 	if c, err = GetCommunicator(etlID, t.Snode()); err != nil {
 		return
 	}
+	if lr := lreg.get(etlID); lr != nil {
+		return lr.podHealth()
+	}
+	if _, ok := c.(*wasmComm); ok {
+		// runs within the target process - see target's own CPU and memory stats
+		return &PodHealthMsg{TargetID: t.SID()}, nil
+	}
 	if client, err = k8s.GetClient(); err != nil {
 		return
 	}
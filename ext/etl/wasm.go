@@ -0,0 +1,344 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/sys"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	wsys "github.com/tetratelabs/wazero/sys"
+)
+
+// In-process ETL: user-provided WebAssembly (WASI) module gets compiled once
+// and then instantiated by the target for each transformed object - no network
+// hops and no containers. The contract is stdin => stdout: the module reads the
+// original object from its standard input and writes the result to standard output.
+// Each instance runs in its own sandbox (no filesystem, no network) subject to:
+// - memory limit (`MemLimit`, rounded up to 64KiB wasm pages);
+// - per-object execution time (`CPULimit`): time spent running the module, not counting
+//   the time it is blocked reading its stdin; enforced via the runtime's interrupt
+//   (context-done) support, terminating the instance once exhausted;
+// - per-object wall-clock timeout (`ObjTimeout`) - elapsed time, including waiting for stdin;
+// - max number of instances executing concurrently (`MaxConcurrency`).
+
+const (
+	Wasm = "wasm://" // in-process communication type
+
+	wasmPageSize       = 64 * cos.KiB
+	dfltWasmMemLimit   = 256 * cos.MiB
+	dfltWasmObjTimeout = time.Minute
+	dfltWasmCPULimit   = 30 * time.Second
+	maxWasmStderr      = 64 * cos.KiB // retained tail of the modules' stderr ("logs")
+	maxWasmMemLimit    = 4 * cos.GiB  // 32-bit address space
+)
+
+type (
+	InitWasmMsg struct {
+		InitMsgBase
+		Module         []byte       `json:"module"`                    // compiled WebAssembly (WASI) binary
+		Args           []string     `json:"args,omitempty"`            // command-line arguments passed to the module
+		MemLimit       int64        `json:"mem_limit,omitempty"`       // bytes (default: 256MiB)
+		ObjTimeout     cos.Duration `json:"obj_timeout,omitempty"`     // per-object (default: 1m)
+		CPULimit       cos.Duration `json:"cpu_limit,omitempty"`       // per-object, excluding stdin waits (default: 30s)
+		MaxConcurrency int          `json:"max_concurrency,omitempty"` // (default: number of CPUs)
+	}
+
+	wasmComm struct {
+		baseComm
+		mem     *memsys.MMSA
+		rt      wazero.Runtime
+		cmod    wazero.CompiledModule
+		args    []string
+		timeout time.Duration
+		cpu     time.Duration
+		sema    *cos.Semaphore
+		stderr  *tailBuf
+	}
+
+	// measures instance's execution time (see above) - wraps its stdin
+	wasmMeter struct {
+		r       io.Reader
+		started int64
+		ioWait  atomic.Int64 // total time blocked reading stdin
+		ioSince atomic.Int64 // when the current read started (0 when not reading)
+	}
+)
+
+// interface guards
+var (
+	_ InitMsg           = (*InitWasmMsg)(nil)
+	_ Communicator      = (*wasmComm)(nil)
+	_ readerTransformer = (*wasmComm)(nil)
+)
+
+/////////////////
+// InitWasmMsg //
+/////////////////
+
+func (*InitWasmMsg) InitType() string { return apc.ETLInitWasm }
+
+func (m *InitWasmMsg) Validate() error {
+	if err := cos.ValidateEtlID(m.IDX); err != nil {
+		return fmt.Errorf("invalid etl ID: %v", err)
+	}
+	if m.CommTypeX == "" {
+		m.CommTypeX = Wasm
+	} else if m.CommTypeX != Wasm {
+		return fmt.Errorf("ETL %q: WebAssembly module requires comm-type %q (got %q)", m.IDX, Wasm, m.CommTypeX)
+	}
+	if len(m.Module) == 0 {
+		return fmt.Errorf("ETL %q: WebAssembly module is empty", m.IDX)
+	}
+	if m.MemLimit < 0 || m.MemLimit > maxWasmMemLimit {
+		return fmt.Errorf("ETL %q: invalid memory limit %d (expecting 0 <= limit <= 4GiB)", m.IDX, m.MemLimit)
+	}
+	if m.ObjTimeout < 0 || m.CPULimit < 0 || m.MaxConcurrency < 0 {
+		return fmt.Errorf("ETL %q: object timeout, CPU limit, and max concurrency cannot be negative", m.IDX)
+	}
+	return nil
+}
+
+func (m *InitWasmMsg) memLimitPages() uint32 {
+	limit := m.MemLimit
+	if limit == 0 {
+		limit = dfltWasmMemLimit
+	}
+	return uint32((limit + wasmPageSize - 1) / wasmPageSize)
+}
+
+// InitWasm compiles the module and registers in-process communicator.
+func InitWasm(t cluster.Target, msg *InitWasmMsg) (err error) {
+	if err = msg.Validate(); err != nil {
+		return
+	}
+	errCtx := &cmn.ETLErrorContext{TID: t.SID(), UUID: msg.IDX}
+	rt, cmod, err := compileWasm(msg)
+	if err != nil {
+		return cmn.NewErrETL(errCtx, err.Error())
+	}
+
+	rns := xreg.RenewETL(t, msg)
+	debug.AssertNoErr(rns.Err)
+	c := &wasmComm{
+		baseComm: baseComm{
+			Slistener: newAborter(t, msg.IDX),
+			t:         t,
+			xctn:      rns.Entry.Get(),
+			name:      msg.IDX,
+			podName:   msg.IDX + "-" + t.SID(),
+		},
+		mem:     t.PageMM(),
+		rt:      rt,
+		cmod:    cmod,
+		args:    append([]string{msg.IDX}, msg.Args...),
+		timeout: time.Duration(msg.ObjTimeout),
+		cpu:     time.Duration(msg.CPULimit),
+		stderr:  &tailBuf{max: maxWasmStderr},
+	}
+	if c.timeout == 0 {
+		c.timeout = dfltWasmObjTimeout
+	}
+	if c.cpu == 0 {
+		c.cpu = dfltWasmCPULimit
+	}
+	concurrency := msg.MaxConcurrency
+	if concurrency == 0 {
+		concurrency = sys.NumCPU()
+	}
+	c.sema = cos.NewSemaphore(concurrency)
+
+	if err = reg.put(msg.IDX, c); err != nil {
+		c.Stop()
+		return
+	}
+	t.Sowner().Listeners().Reg(c)
+	return
+}
+
+// creates the runtime (that enforces memory limit and timeout) and compiles the module
+func compileWasm(msg *InitWasmMsg) (wazero.Runtime, wazero.CompiledModule, error) {
+	var (
+		ctx   = context.Background()
+		rtcfg = wazero.NewRuntimeConfig().
+			WithMemoryLimitPages(msg.memLimitPages()).
+			WithCloseOnContextDone(true) // enforce `ObjTimeout` and `CPULimit`
+		rt = wazero.NewRuntimeWithConfig(ctx, rtcfg)
+	)
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, rt); err != nil {
+		rt.Close(ctx)
+		return nil, nil, fmt.Errorf("failed to instantiate WASI: %v", err)
+	}
+	cmod, err := rt.CompileModule(ctx, msg.Module)
+	if err != nil {
+		rt.Close(ctx)
+		return nil, nil, fmt.Errorf("failed to compile WebAssembly module: %v", err)
+	}
+	return rt, cmod, nil
+}
+
+//////////////
+// wasmComm //
+//////////////
+
+func (wc *wasmComm) Stop() {
+	wc.rt.Close(context.Background())
+	wc.baseComm.Stop()
+}
+
+// runs a new module instance: `r` => stdin, stdout => returned SGL
+func (wc *wasmComm) run(r io.Reader, size int64, timeout time.Duration) (*memsys.SGL, error) {
+	if err := wc.xctn.AbortErr(); err != nil {
+		return nil, cmn.NewErrAborted(wc.xctn.Name(), "wasm-comm", err)
+	}
+	if timeout == 0 || timeout > wc.timeout {
+		timeout = wc.timeout
+	}
+	wc.sema.Acquire()
+	defer wc.sema.Release()
+
+	var (
+		sgl         = wc.mem.NewSGL(size)
+		meter       = &wasmMeter{r: r, started: mono.NanoTime()}
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+		cpuCtx, stp = context.WithCancel(ctx)
+		exceeded    = make(chan struct{})
+		cfg         = wazero.NewModuleConfig().
+				WithName(""). // anonymous - allows concurrent instances
+				WithArgs(wc.args...).
+				WithStdin(meter).
+				WithStdout(sgl).
+				WithStderr(wc.stderr)
+	)
+	defer cancel()
+	go meter.watch(cpuCtx, wc.cpu, stp, exceeded)
+	mod, err := wc.rt.InstantiateModule(cpuCtx, wc.cmod, cfg)
+	stp()
+	if mod != nil {
+		mod.Close(ctx)
+	}
+	if err != nil {
+		var exitErr *wsys.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 0 {
+			sgl.Free()
+			select {
+			case <-exceeded:
+				err = fmt.Errorf("exceeded CPU limit %v: %v", wc.cpu, err)
+			default:
+				if ctx.Err() != nil {
+					err = fmt.Errorf("timed out after %v: %v", timeout, err)
+				}
+			}
+			return nil, err
+		}
+	}
+	return sgl, nil
+}
+
+func (wc *wasmComm) transform(bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	fh, size, err := wc.openObj(bck, objName)
+	if err != nil {
+		return nil, err
+	}
+	sgl, err := wc.run(fh, size, timeout)
+	cos.Close(fh)
+	if err != nil {
+		return nil, err
+	}
+	wc.xctn.InObjsAdd(1, size)
+	wc.xctn.OutObjsAdd(1, sgl.Size())
+	return cos.NewReaderWithArgs(cos.ReaderArgs{R: sgl, Size: sgl.Size(), DeferCb: sgl.Free}), nil
+}
+
+// (next stage in the ETL chain)
+func (wc *wasmComm) transformReader(r io.ReadCloser, size int64, _ *cluster.Bck, _ string,
+	timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if size < 0 {
+		size = 0 // unsized
+	}
+	sgl, err := wc.run(r, size, timeout)
+	cos.Close(r)
+	if err != nil {
+		return nil, err
+	}
+	wc.xctn.InObjsAdd(1, size)
+	wc.xctn.OutObjsAdd(1, sgl.Size())
+	return cos.NewReaderWithArgs(cos.ReaderArgs{R: sgl, Size: sgl.Size(), DeferCb: sgl.Free}), nil
+}
+
+func (wc *wasmComm) OnlineTransform(w http.ResponseWriter, _ *http.Request, bck *cluster.Bck, objName string) error {
+	r, err := wc.transform(bck, objName, 0 /*timeout*/)
+	if err != nil {
+		return err
+	}
+	w.Header().Set(cos.HdrContentLength, fmt.Sprintf("%d", r.Size()))
+	_, err = io.Copy(w, r)
+	r.Close()
+	return err
+}
+
+func (wc *wasmComm) OfflineTransform(bck *cluster.Bck, objName string, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	return wc.transform(bck, objName, timeout)
+}
+
+func (wc *wasmComm) podLogs() PodLogsMsg {
+	return PodLogsMsg{TargetID: wc.t.SID(), Logs: wc.stderr.get()}
+}
+
+///////////////
+// wasmMeter //
+///////////////
+
+func (m *wasmMeter) Read(p []byte) (int, error) {
+	since := mono.NanoTime()
+	m.ioSince.Store(since)
+	n, err := m.r.Read(p)
+	m.ioWait.Add(mono.SinceNano(since))
+	m.ioSince.Store(0)
+	return n, err
+}
+
+func (m *wasmMeter) used() time.Duration {
+	wait := m.ioWait.Load()
+	if since := m.ioSince.Load(); since != 0 {
+		wait += mono.SinceNano(since)
+	}
+	return mono.Since(m.started) - time.Duration(wait)
+}
+
+// cancels (interrupts) the instance once it runs out of its CPU budget
+func (m *wasmMeter) watch(ctx context.Context, limit time.Duration, cancel context.CancelFunc, exceeded chan struct{}) {
+	timer := time.NewTimer(limit)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			used := m.used()
+			if used >= limit {
+				close(exceeded)
+				cancel()
+				return
+			}
+			timer.Reset(limit - used)
+		}
+	}
+}
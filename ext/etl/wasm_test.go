@@ -0,0 +1,247 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/memsys"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	wsys "github.com/tetratelabs/wazero/sys"
+)
+
+// Minimal WASI module (hand-assembled) that copies stdin to stdout converting
+// [a-z] to upper case. The first byte of the input selects special behavior:
+// '!' - exit with status 3; '*' - spin forever; '+' - grow memory by 1MiB (trap if cannot).
+//
+//	(module
+//	  (import "wasi_snapshot_preview1" "fd_read" (func $fd_read (param i32 i32 i32 i32) (result i32)))
+//	  (import "wasi_snapshot_preview1" "fd_write" (func $fd_write (param i32 i32 i32 i32) (result i32)))
+//	  (import "wasi_snapshot_preview1" "proc_exit" (func $proc_exit (param i32)))
+//	  (memory (export "memory") 1)
+//	  (func (export "_start") (local $n i32) (local $i i32) (local $first i32) (local $c i32)
+//	    (local.set $first (i32.const 1))
+//	    (block $done
+//	      (loop $read
+//	        ;; iovec{buf: 64, len: 1024} at 0, nread/nwritten at 8
+//	        (i32.store (i32.const 0) (i32.const 64))
+//	        (i32.store (i32.const 4) (i32.const 1024))
+//	        (if (call $fd_read (i32.const 0) (i32.const 0) (i32.const 1) (i32.const 8))
+//	          (then (call $proc_exit (i32.const 5))))
+//	        (local.set $n (i32.load (i32.const 8)))
+//	        (br_if $done (i32.eqz (local.get $n)))
+//	        (if (local.get $first)
+//	          (then
+//	            (local.set $first (i32.const 0))
+//	            (local.set $c (i32.load8_u (i32.const 64)))
+//	            (if (i32.eq (local.get $c) (i32.const 33)) (then (call $proc_exit (i32.const 3))))
+//	            (if (i32.eq (local.get $c) (i32.const 42)) (then (loop $spin (br $spin))))
+//	            (if (i32.eq (local.get $c) (i32.const 43))
+//	              (then (if (i32.eq (memory.grow (i32.const 16)) (i32.const -1)) (then unreachable))))))
+//	        (local.set $i (i32.const 0))
+//	        (block $ub
+//	          (loop $ul
+//	            (br_if $ub (i32.ge_u (local.get $i) (local.get $n)))
+//	            (local.set $c (i32.load8_u (i32.add (local.get $i) (i32.const 64))))
+//	            (if (i32.and (i32.ge_u (local.get $c) (i32.const 97)) (i32.le_u (local.get $c) (i32.const 122)))
+//	              (then (i32.store8 (i32.add (local.get $i) (i32.const 64)) (i32.sub (local.get $c) (i32.const 32)))))
+//	            (local.set $i (i32.add (local.get $i) (i32.const 1)))
+//	            (br $ul)))
+//	        (i32.store (i32.const 4) (local.get $n))
+//	        (if (call $fd_write (i32.const 1) (i32.const 0) (i32.const 1) (i32.const 8))
+//	          (then (call $proc_exit (i32.const 6))))
+//	        (br $read)))))
+var wasmUpper = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, 0x01, 0x10, 0x03, 0x60, 0x04, 0x7f, 0x7f, 0x7f,
+	0x7f, 0x01, 0x7f, 0x60, 0x01, 0x7f, 0x00, 0x60, 0x00, 0x00, 0x02, 0x67, 0x03, 0x16, 0x77, 0x61,
+	0x73, 0x69, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x65, 0x76,
+	0x69, 0x65, 0x77, 0x31, 0x07, 0x66, 0x64, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x00, 0x00, 0x16, 0x77,
+	0x61, 0x73, 0x69, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x65,
+	0x76, 0x69, 0x65, 0x77, 0x31, 0x08, 0x66, 0x64, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x00, 0x00,
+	0x16, 0x77, 0x61, 0x73, 0x69, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70,
+	0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x31, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x5f, 0x65, 0x78, 0x69,
+	0x74, 0x00, 0x01, 0x03, 0x02, 0x01, 0x02, 0x05, 0x03, 0x01, 0x00, 0x01, 0x07, 0x13, 0x02, 0x06,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x02, 0x00, 0x06, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x00,
+	0x03, 0x0a, 0xd8, 0x01, 0x01, 0xd5, 0x01, 0x01, 0x04, 0x7f, 0x41, 0x01, 0x21, 0x02, 0x02, 0x40,
+	0x03, 0x40, 0x41, 0x00, 0x41, 0xc0, 0x00, 0x36, 0x02, 0x00, 0x41, 0x04, 0x41, 0x80, 0x08, 0x36,
+	0x02, 0x00, 0x41, 0x00, 0x41, 0x00, 0x41, 0x01, 0x41, 0x08, 0x10, 0x00, 0x04, 0x40, 0x41, 0x05,
+	0x10, 0x02, 0x0b, 0x41, 0x08, 0x28, 0x02, 0x00, 0x21, 0x00, 0x20, 0x00, 0x45, 0x0d, 0x01, 0x20,
+	0x02, 0x04, 0x40, 0x41, 0x00, 0x21, 0x02, 0x41, 0xc0, 0x00, 0x2d, 0x00, 0x00, 0x21, 0x03, 0x20,
+	0x03, 0x41, 0x21, 0x46, 0x04, 0x40, 0x41, 0x03, 0x10, 0x02, 0x0b, 0x20, 0x03, 0x41, 0x2a, 0x46,
+	0x04, 0x40, 0x03, 0x40, 0x0c, 0x00, 0x0b, 0x0b, 0x20, 0x03, 0x41, 0x2b, 0x46, 0x04, 0x40, 0x41,
+	0x10, 0x40, 0x00, 0x41, 0x7f, 0x46, 0x04, 0x40, 0x00, 0x0b, 0x0b, 0x0b, 0x41, 0x00, 0x21, 0x01,
+	0x02, 0x40, 0x03, 0x40, 0x20, 0x01, 0x20, 0x00, 0x4f, 0x0d, 0x01, 0x20, 0x01, 0x41, 0xc0, 0x00,
+	0x6a, 0x2d, 0x00, 0x00, 0x21, 0x03, 0x20, 0x03, 0x41, 0xe1, 0x00, 0x4f, 0x20, 0x03, 0x41, 0xfa,
+	0x00, 0x4d, 0x71, 0x04, 0x40, 0x20, 0x01, 0x41, 0xc0, 0x00, 0x6a, 0x20, 0x03, 0x41, 0x20, 0x6b,
+	0x3a, 0x00, 0x00, 0x0b, 0x20, 0x01, 0x41, 0x01, 0x6a, 0x21, 0x01, 0x0c, 0x00, 0x0b, 0x0b, 0x41,
+	0x04, 0x20, 0x00, 0x36, 0x02, 0x00, 0x41, 0x01, 0x41, 0x00, 0x41, 0x01, 0x41, 0x08, 0x10, 0x01,
+	0x04, 0x40, 0x41, 0x06, 0x10, 0x02, 0x0b, 0x0c, 0x00, 0x0b, 0x0b, 0x0b}
+
+func newTestWasmComm(msg *InitWasmMsg) *wasmComm {
+	msg.IDX, msg.Module = "wasm-test", wasmUpper
+	Expect(msg.Validate()).To(Succeed())
+	rt, cmod, err := compileWasm(msg)
+	Expect(err).NotTo(HaveOccurred())
+	c := &wasmComm{
+		baseComm: baseComm{xctn: mock.NewXact(apc.ActETLInline), name: msg.IDX},
+		mem:      memsys.PageMM(),
+		rt:       rt,
+		cmod:     cmod,
+		args:     []string{msg.IDX},
+		timeout:  time.Duration(msg.ObjTimeout),
+		cpu:      time.Duration(msg.CPULimit),
+		sema:     cos.NewSemaphore(2),
+		stderr:   &tailBuf{max: maxWasmStderr},
+	}
+	if c.timeout == 0 {
+		c.timeout = dfltWasmObjTimeout
+	}
+	if c.cpu == 0 {
+		c.cpu = dfltWasmCPULimit
+	}
+	return c
+}
+
+func wasmRun(c *wasmComm, input string, timeout time.Duration) (string, error) {
+	sgl, err := c.run(strings.NewReader(input), int64(len(input)), timeout)
+	if err != nil {
+		return "", err
+	}
+	defer sgl.Free()
+	b, err := io.ReadAll(sgl)
+	return string(b), err
+}
+
+// delays each read (and returns at most 1KiB)
+type slowReader struct {
+	r     io.Reader
+	delay time.Duration
+}
+
+func (sr *slowReader) Read(p []byte) (int, error) {
+	time.Sleep(sr.delay)
+	if len(p) > cos.KiB {
+		p = p[:cos.KiB]
+	}
+	return sr.r.Read(p)
+}
+
+var _ = Describe("WasmComm", func() {
+	It("should transform stdin to stdout", func() {
+		c := newTestWasmComm(&InitWasmMsg{})
+		defer c.rt.Close(context.Background())
+
+		out, err := wasmRun(c, "hello, WASM world!", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("HELLO, WASM WORLD!"))
+
+		// larger than the module's (1KiB) buffer
+		input := strings.Repeat("abc-xyz;", 10*cos.KiB)
+		out, err = wasmRun(c, input, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal(strings.ToUpper(input)))
+
+		out, err = wasmRun(c, "", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(BeEmpty())
+	})
+
+	It("should fail upon non-zero exit", func() {
+		c := newTestWasmComm(&InitWasmMsg{})
+		defer c.rt.Close(context.Background())
+
+		_, err := wasmRun(c, "!exit", 0)
+		Expect(err).To(HaveOccurred())
+		var exitErr *wsys.ExitError
+		Expect(errors.As(err, &exitErr)).To(BeTrue())
+		Expect(exitErr.ExitCode()).To(BeEquivalentTo(3))
+
+		// the module's still usable
+		out, err := wasmRun(c, "ok", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("OK"))
+	})
+
+	It("should enforce memory limit", func() {
+		c := newTestWasmComm(&InitWasmMsg{}) // default (256MiB) limit
+		out, err := wasmRun(c, "+grow", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("+GROW"))
+		c.rt.Close(context.Background())
+
+		c = newTestWasmComm(&InitWasmMsg{MemLimit: wasmPageSize}) // single page
+		defer c.rt.Close(context.Background())
+		_, err = wasmRun(c, "+grow", 0)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unreachable"))
+	})
+
+	It("should terminate upon timeout", func() {
+		c := newTestWasmComm(&InitWasmMsg{ObjTimeout: cos.Duration(time.Second)})
+		defer c.rt.Close(context.Background())
+
+		started := time.Now()
+		_, err := wasmRun(c, "*spin", 0)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("timed out"))
+		Expect(time.Since(started)).To(BeNumerically("<", 10*time.Second))
+
+		// per-request timeout cannot exceed the configured one, but can be shorter
+		started = time.Now()
+		_, err = wasmRun(c, "*spin", 100*time.Millisecond)
+		Expect(err).To(HaveOccurred())
+		Expect(time.Since(started)).To(BeNumerically("<", time.Second))
+	})
+
+	It("should terminate upon exceeding CPU limit", func() {
+		c := newTestWasmComm(&InitWasmMsg{CPULimit: cos.Duration(200 * time.Millisecond)})
+		defer c.rt.Close(context.Background())
+
+		started := time.Now()
+		_, err := wasmRun(c, "*spin", 0)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("CPU limit"))
+		Expect(time.Since(started)).To(BeNumerically("<", 5*time.Second))
+	})
+
+	It("should not count time blocked on stdin towards CPU limit", func() {
+		c := newTestWasmComm(&InitWasmMsg{CPULimit: cos.Duration(200 * time.Millisecond)})
+		defer c.rt.Close(context.Background())
+
+		// 5 reads, each blocked for longer than the entire budget
+		r := &slowReader{r: strings.NewReader(strings.Repeat("a", 4*cos.KiB)), delay: 300 * time.Millisecond}
+		sgl, err := c.run(r, 4*cos.KiB, 0)
+		Expect(err).NotTo(HaveOccurred())
+		defer sgl.Free()
+		Expect(sgl.Size()).To(BeEquivalentTo(4 * cos.KiB))
+	})
+
+	It("should validate init message", func() {
+		msg := &InitWasmMsg{InitMsgBase: InitMsgBase{IDX: "wasm-test"}, Module: wasmUpper}
+		Expect(msg.Validate()).To(Succeed())
+		Expect(msg.CommTypeX).To(Equal(Wasm))
+
+		msg = &InitWasmMsg{InitMsgBase: InitMsgBase{IDX: "wasm-test", CommTypeX: Hpush}, Module: wasmUpper}
+		Expect(msg.Validate()).NotTo(Succeed())
+		msg = &InitWasmMsg{InitMsgBase: InitMsgBase{IDX: "wasm-test"}}
+		Expect(msg.Validate()).NotTo(Succeed())
+		msg = &InitWasmMsg{InitMsgBase: InitMsgBase{IDX: "wasm-test"}, Module: wasmUpper, MemLimit: 8 * cos.GiB}
+		Expect(msg.Validate()).NotTo(Succeed())
+		msg = &InitWasmMsg{InitMsgBase: InitMsgBase{IDX: "wasm-test"}, Module: wasmUpper, CPULimit: -1}
+		Expect(msg.Validate()).NotTo(Succeed())
+
+		_, _, err := compileWasm(&InitWasmMsg{Module: bytes.Repeat([]byte{0xba, 0xd0}, 16)})
+		Expect(err).To(HaveOccurred())
+	})
+})
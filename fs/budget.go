@@ -0,0 +1,119 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// Per-mountpath I/O budget shared by user GET/PUT and xactions (see xact.Descriptor.Prio).
+// The budget is a token bucket (bytes) that refills at the configured `disk.mpath_budget`
+// rate and holds at most one second worth of tokens:
+// - foreground (user I/O and the xactions that serve it) takes from the budget but never waits;
+// - normal priority waits when the budget is exhausted;
+// - background waits while the budget is below half, thus keeping headroom for foreground.
+// In addition (and regardless of the configured budget) normal and background work yields
+// to disk utilization - see Yield below.
+
+// priority classes
+const (
+	PrioNormal = iota // (default)
+	PrioBackground
+	PrioForeground
+)
+
+const (
+	budgetMaxWait = time.Second
+	yieldShort    = time.Millisecond
+	yieldLong     = 10 * time.Millisecond
+)
+
+type budget struct {
+	mu     sync.Mutex
+	tokens float64
+	last   int64 // mono time
+}
+
+func PrioName(prio int) string {
+	switch prio {
+	case PrioBackground:
+		return "background"
+	case PrioForeground:
+		return "foreground"
+	default:
+		return "normal"
+	}
+}
+
+// returns the time to wait before doing `size` bytes of I/O at a given priority
+func (b *budget) reserve(prio int, size, rate int64, now int64) (d time.Duration) {
+	r := float64(rate)
+	b.mu.Lock()
+	if b.last == 0 {
+		b.tokens = r
+	} else {
+		b.tokens += float64(now-b.last) / float64(time.Second) * r
+		if b.tokens > r {
+			b.tokens = r
+		}
+	}
+	b.last = now
+	b.tokens -= float64(size)
+	if b.tokens < -r {
+		b.tokens = -r // bound the debt
+	}
+	switch prio {
+	case PrioForeground:
+	case PrioBackground:
+		if low := r / 2; b.tokens < low {
+			d = time.Duration((low - b.tokens) / r * float64(time.Second))
+		}
+	default:
+		if b.tokens < 0 {
+			d = time.Duration(-b.tokens / r * float64(time.Second))
+		}
+	}
+	b.mu.Unlock()
+	return cos.MinDuration(d, budgetMaxWait)
+}
+
+// Reserve takes `size` bytes from the mountpath's budget and returns the time
+// the caller (of a given priority) must wait before doing the I/O.
+func (mi *MountpathInfo) Reserve(prio int, size int64) time.Duration {
+	rate := cmn.GCO.Get().Disk.MpathBudget
+	if rate <= 0 || size <= 0 {
+		return 0
+	}
+	return mi.budget.reserve(prio, size, rate, mono.NanoTime())
+}
+
+// Consume is Reserve for foreground I/O (user GET and PUT) that never waits.
+func (mi *MountpathInfo) Consume(size int64) { mi.Reserve(PrioForeground, size) }
+
+// Yield returns the time to yield to other (higher-priority) I/O given the mountpath's
+// current utilization: background yields above `disk.disk_util_low_wm`, normal -
+// above `disk.disk_util_high_wm`.
+func (mi *MountpathInfo) Yield(prio int, config *cmn.Config) time.Duration {
+	if prio == PrioForeground {
+		return 0
+	}
+	util := GetMpathUtil(mi.Path)
+	switch {
+	case util >= config.Disk.DiskUtilHighWM:
+		if prio == PrioBackground {
+			return yieldLong
+		}
+		return yieldShort
+	case util >= config.Disk.DiskUtilLowWM && prio == PrioBackground:
+		return yieldShort
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,45 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBudgetReserve(t *testing.T) {
+	const rate = 100 * 1024 * 1024 // 100MiB/s
+	var (
+		b   budget
+		now = int64(time.Hour)
+	)
+	// full bucket: normal does not wait, background waits once below half
+	if d := b.reserve(PrioNormal, rate/4, rate, now); d != 0 {
+		t.Fatalf("normal: unexpected wait %v", d)
+	}
+	if d := b.reserve(PrioBackground, rate/2, rate, now); d != 250*time.Millisecond {
+		t.Fatalf("background: expected 250ms wait, got %v", d)
+	}
+	// foreground takes the rest and never waits
+	if d := b.reserve(PrioForeground, rate, rate, now); d != 0 {
+		t.Fatalf("foreground: unexpected wait %v", d)
+	}
+	// now everyone else must wait
+	if d := b.reserve(PrioNormal, 1, rate, now); d <= 0 {
+		t.Fatalf("normal: expected to wait")
+	}
+	// debt is bounded, waits are capped
+	for i := 0; i < 10; i++ {
+		b.reserve(PrioForeground, rate, rate, now)
+	}
+	if d := b.reserve(PrioBackground, rate, rate, now); d != budgetMaxWait {
+		t.Fatalf("background: expected max wait %v, got %v", budgetMaxWait, d)
+	}
+	// refill: one second later normal is good to go again
+	now += int64(3 * time.Second)
+	if d := b.reserve(PrioNormal, rate/2, rate, now); d != 0 {
+		t.Fatalf("normal: unexpected wait %v after refill", d)
+	}
+}
@@ -33,10 +33,11 @@ import (
 const (
 	contentTypeLen = 2
 
-	ObjectType   = "ob"
-	WorkfileType = "wk"
-	ECSliceType  = "ec"
-	ECMetaType   = "mt"
+	ObjectType     = "ob"
+	WorkfileType   = "wk"
+	ECSliceType    = "ec"
+	ECMetaType     = "mt"
+	QuarantineType = "qt" // corrupted objects and copies (that could not be repaired) - see scrub
 )
 
 type (
@@ -167,6 +168,7 @@ type (
 	WorkfileContentResolver struct{}
 	ECSliceContentResolver  struct{}
 	ECMetaContentResolver   struct{}
+	QuarantineResolver      struct{}
 )
 
 func (*ObjectContentResolver) PermToMove() bool                   { return true }
@@ -235,3 +237,13 @@ func (*ECMetaContentResolver) GenUniqueFQN(base, _ string) string { return base
 func (*ECMetaContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
 	return base, false, true
 }
+
+func (*QuarantineResolver) PermToMove() bool    { return false }
+func (*QuarantineResolver) PermToEvict() bool   { return false }
+func (*QuarantineResolver) PermToProcess() bool { return false }
+
+func (*QuarantineResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*QuarantineResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
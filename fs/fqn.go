@@ -29,6 +29,7 @@ const (
 	WorkfileAppend       = "append"         // APPEND to object (as file)
 	WorkfileAppendToArch = "append-to-arch" // APPEND to existing archive
 	WorkfileCreateArch   = "create-arch"    // CREATE multi-object archive
+	WorkfileDownload     = "dl"             // download (see ext/dload)
 )
 
 type ParsedFQN struct {
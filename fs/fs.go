@@ -32,6 +32,8 @@ const nodeXattrID = "user.ais.daemon_id"
 const (
 	FlagBeingDisabled uint64 = 1 << iota
 	FlagBeingDetached
+	FlagDegraded     // slow or failing disk(s) - see health.FSHC
+	FlagBeingDrained // readable, takes no new objects, gets detached when evacuated
 )
 
 const FlagWaitingDD = FlagBeingDisabled | FlagBeingDetached
@@ -54,7 +56,12 @@ type (
 			m map[uint64]string
 			sync.RWMutex
 		}
+		drained struct { // drain progress (see BeginDrain)
+			objs  atomic.Int64
+			bytes atomic.Int64
+		}
 		capacity   Capacity
+		budget     budget // I/O budget shared by user GET/PUT and xactions
 		flags      uint64 // bit flags (set/get atomic)
 		PathDigest uint64 // (HRW logic)
 		cmu        sync.RWMutex
@@ -152,11 +159,50 @@ func (mi *MountpathInfo) String() string {
 			mi.info = fmt.Sprintf("mp[%s, %v]", mi.Path, mi.Disks)
 		}
 	}
-	if !mi.IsAnySet(FlagWaitingDD) {
+	switch {
+	case mi.IsAnySet(FlagWaitingDD):
+		l := len(mi.info)
+		return mi.info[:l-1] + ", waiting-dd]"
+	case mi.IsAnySet(FlagBeingDrained):
+		l := len(mi.info)
+		return mi.info[:l-1] + ", draining]"
+	case mi.IsAnySet(FlagDegraded):
+		l := len(mi.info)
+		return mi.info[:l-1] + ", degraded]"
+	default:
 		return mi.info
 	}
-	l := len(mi.info)
-	return mi.info[:l-1] + ", waiting-dd]"
+}
+
+// SetDegraded marks (or unmarks) mountpath as degraded; returns false if already in the requested state.
+// Degraded mountpath remains available and keeps its HRW share of objects, but takes no new
+// copies (see cluster.LOM.LeastUtilNoCopy), and reads prefer its mirrors (if any).
+func (mi *MountpathInfo) SetDegraded(degraded bool) bool {
+	if mi.IsAnySet(FlagDegraded) == degraded {
+		return false
+	}
+	if degraded {
+		return cos.SetfAtomic(&mi.flags, FlagDegraded)
+	}
+	return cos.ClearfAtomic(&mi.flags, FlagDegraded)
+}
+
+// AnyDraining returns true if any of the available mountpaths is being drained.
+func AnyDraining() bool { return anySet(FlagBeingDrained) }
+
+func anySet(flags uint64) bool {
+	for _, mi := range GetAvail() {
+		if mi.IsAnySet(flags) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddDrained is called by resilver for each object moved off the draining mountpath.
+func (mi *MountpathInfo) AddDrained(size int64) {
+	mi.drained.objs.Inc()
+	mi.drained.bytes.Add(size)
 }
 
 func (mi *MountpathInfo) LomCache(idx int) *sync.Map { return mi.lomCaches.Get(idx) }
@@ -527,7 +573,7 @@ func (mi *MountpathInfo) CheckDisks() (err error) {
 }
 
 func (mi *MountpathInfo) ClearDD() {
-	cos.ClearfAtomic(&mi.flags, FlagWaitingDD)
+	cos.ClearfAtomic(&mi.flags, FlagWaitingDD|FlagBeingDrained)
 }
 
 /////////////////////
@@ -607,11 +653,23 @@ func MountpathsToLists() (mpl *apc.MountpathList) {
 		Disabled:  make([]string, 0, len(disabledPaths)),
 	}
 	for _, mi := range availablePaths {
-		if mi.IsAnySet(FlagWaitingDD) {
+		switch {
+		case mi.IsAnySet(FlagWaitingDD):
 			mpl.WaitingDD = append(mpl.WaitingDD, mi.Path)
-		} else {
+		case mi.IsAnySet(FlagBeingDrained):
+			c, _ := mi.getCapacity(nil, false /*refresh*/)
+			mpl.Draining = append(mpl.Draining, apc.MountpathDrain{
+				Path:    mi.Path,
+				Objs:    mi.drained.objs.Load(),
+				Bytes:   mi.drained.bytes.Load(),
+				PctUsed: c.PctUsed,
+			})
+		default:
 			mpl.Available = append(mpl.Available, mi.Path)
 		}
+		if mi.IsAnySet(FlagDegraded) {
+			mpl.Degraded = append(mpl.Degraded, mi.Path)
+		}
 	}
 	for mpath := range disabledPaths {
 		mpl.Disabled = append(mpl.Disabled, mpath)
@@ -619,6 +677,7 @@ func MountpathsToLists() (mpl *apc.MountpathList) {
 	sort.Strings(mpl.Available)
 	sort.Strings(mpl.WaitingDD)
 	sort.Strings(mpl.Disabled)
+	sort.Slice(mpl.Draining, func(i, j int) bool { return mpl.Draining[i].Path < mpl.Draining[j].Path })
 	return
 }
 
@@ -853,6 +912,48 @@ func BeginDD(action string, flags uint64, mpath string) (mi *MountpathInfo, numA
 	return
 }
 
+// begin drain: mark the mountpath that stays available (and readable) but takes no new objects;
+// returns nil if the mountpath is already being drained, detached, or disabled
+func BeginDrain(mpath string) (mi *MountpathInfo, err error) {
+	var (
+		cleanMpath string
+		exists     bool
+		numAvail   int
+	)
+	if cleanMpath, err = cmn.ValidateMpath(mpath); err != nil {
+		return
+	}
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+
+	availablePaths, disabledPaths := Get()
+	if mi, exists = availablePaths[cleanMpath]; !exists {
+		_, disabled := disabledPaths[cleanMpath]
+		err = cmn.NewErrMountpathNotFound(mpath, "" /*fqn*/, disabled)
+		return
+	}
+	if mi.IsAnySet(FlagWaitingDD | FlagBeingDrained) {
+		glog.Infof("%s is already transitioning - nothing to do", mi)
+		mi = nil
+		return
+	}
+	for _, other := range availablePaths {
+		if other != mi && !other.IsAnySet(FlagWaitingDD|FlagBeingDrained) {
+			numAvail++
+		}
+	}
+	if numAvail == 0 {
+		err = fmt.Errorf("cannot drain %s: no other mountpaths to evacuate its content to", mi)
+		mi = nil
+		return
+	}
+	mi.drained.objs.Store(0)
+	mi.drained.bytes.Store(0)
+	ok := mi.setFlags(FlagBeingDrained)
+	debug.Assert(ok, mi.String()) // NOTE: under lock
+	return
+}
+
 // Disables a mountpath, i.e., removes it from usage but keeps in the volume
 // (for possible future re-enablement). If successful, returns the disabled mountpath.
 // Otherwise, returns nil (also in the case if the mountpath was already disabled).
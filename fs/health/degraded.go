@@ -0,0 +1,184 @@
+// Package health provides a basic mountpath health monitor.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package health
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+)
+
+// In addition to testing a mountpath upon I/O error (see runMpathTest), FSHC watches
+// rolling (monWindow * monIval) per-mountpath disk latency and I/O errors.
+// A mountpath is marked degraded when its p90 latency or the number of errors over the window
+// reaches the configured limit (see cmn.FSHCConf), and it gets unmarked when both
+// drop well below (hysteresis).
+
+const (
+	monIval       = 10 * time.Second
+	monWindow     = 30 // samples (5 minutes)
+	monMinSamples = 6  // (1 minute)
+)
+
+type (
+	mpathMon struct {
+		lat     [monWindow]int64 // ms
+		errs    [monWindow]int64
+		idx     int
+		cnt     int
+		pending int64 // errors since the last sample
+	}
+	// rolling stats - see mpathMon.stats
+	mpathHealth struct {
+		LatP50 time.Duration
+		LatP90 time.Duration
+		Errs   int64
+	}
+)
+
+func (m *mpathMon) add(lat int64) {
+	m.lat[m.idx], m.errs[m.idx] = lat, m.pending
+	m.pending = 0
+	m.idx = (m.idx + 1) % monWindow
+	if m.cnt < monWindow {
+		m.cnt++
+	}
+}
+
+func (m *mpathMon) stats() (h mpathHealth) {
+	lat := make([]int64, m.cnt)
+	copy(lat, m.lat[:m.cnt])
+	sort.Slice(lat, func(i, j int) bool { return lat[i] < lat[j] })
+	h.LatP50 = time.Duration(pctile(lat, 50)) * time.Millisecond
+	h.LatP90 = time.Duration(pctile(lat, 90)) * time.Millisecond
+	for i := 0; i < m.cnt; i++ {
+		h.Errs += m.errs[i]
+	}
+	return
+}
+
+// nearest-rank percentile of the sorted slice
+func pctile(sorted []int64, p int) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100 // ceil
+	if rank > 0 {
+		rank--
+	}
+	return sorted[rank]
+}
+
+func (h *mpathHealth) String() string {
+	return fmt.Sprintf("latency p50=%v, p90=%v, %d I/O error%s", h.LatP50, h.LatP90, h.Errs, cos.Plural(int(h.Errs)))
+}
+
+// is degraded as per configured limits
+func (h *mpathHealth) degraded(config *cmn.FSHCConf) bool {
+	lat, errs := config.DegradedLatency.D(), int64(config.DegradedErrors)
+	return (lat > 0 && h.LatP90 >= lat) || (errs > 0 && h.Errs >= errs)
+}
+
+// is healthy enough to clear the degraded state
+func (h *mpathHealth) recovered(config *cmn.FSHCConf) bool {
+	lat, errs := config.DegradedLatency.D(), int64(config.DegradedErrors)
+	return (lat == 0 || h.LatP90 < lat*3/4) && (errs == 0 || h.Errs*2 < errs)
+}
+
+//////////
+// FSHC //
+//////////
+
+func (f *FSHC) monitor() {
+	ticker := time.NewTicker(monIval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			f.sample()
+		case <-f.stopCh.Listen():
+			return
+		}
+	}
+}
+
+func (f *FSHC) countErr(mpath string) {
+	f.mu.Lock()
+	f.mon(mpath).pending++
+	f.mu.Unlock()
+}
+
+// (is called under lock)
+func (f *FSHC) mon(mpath string) (m *mpathMon) {
+	if m = f.mons[mpath]; m == nil {
+		m = &mpathMon{}
+		f.mons[mpath] = m
+	}
+	return
+}
+
+// (is called periodically by the monitor goroutine)
+func (f *FSHC) sample() {
+	config := &cmn.GCO.Get().FSHC
+	if !config.Enabled {
+		return
+	}
+	type change struct {
+		mi       *fs.MountpathInfo
+		h        mpathHealth
+		degraded bool
+	}
+	var (
+		changes []change
+		avail   = fs.GetAvail()
+	)
+	fs.FillDiskStats(f.disks)
+	f.mu.Lock()
+	for mpath := range f.mons {
+		if _, ok := avail[mpath]; !ok {
+			delete(f.mons, mpath)
+		}
+	}
+	for mpath, mi := range avail {
+		m := f.mon(mpath)
+		m.add(mpathLatency(mi, f.disks))
+		if m.cnt < monMinSamples {
+			continue
+		}
+		h := m.stats()
+		switch {
+		case !mi.IsAnySet(fs.FlagDegraded) && h.degraded(config):
+			changes = append(changes, change{mi, h, true})
+		case mi.IsAnySet(fs.FlagDegraded) && h.recovered(config):
+			changes = append(changes, change{mi, h, false})
+		}
+	}
+	f.mu.Unlock()
+
+	for _, c := range changes {
+		if c.degraded {
+			glog.Errorf("%s is degraded: %s", c.mi, c.h.String())
+		} else {
+			glog.Warningf("%s recovered: %s", c.mi, c.h.String())
+		}
+		f.dispatcher.DegradeMpath(c.mi.Path, c.h.String(), c.degraded)
+	}
+}
+
+// the slowest of the mountpath's disks
+func mpathLatency(mi *fs.MountpathInfo, disks ios.AllDiskStats) (lat int64) {
+	for _, disk := range mi.Disks {
+		if ds, ok := disks[disk]; ok {
+			lat = cos.MaxI64(lat, cos.MaxI64(ds.Rlat, ds.Wlat))
+		}
+	}
+	return
+}
@@ -11,11 +11,13 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
 )
 
 const (
@@ -28,16 +30,21 @@ const (
 // When an IO error is triggered, it runs a few tests to make sure that the
 // failed mountpath is healthy. Once the mountpath is considered faulty the
 // mountpath is disabled and removed from the list.
+// Separately, FSHC marks slow or erroring mountpaths as degraded - see degraded.go.
 //
 // for mountpath definition, see fs/mountfs.go
 type (
 	fspathDispatcher interface {
 		DisableMpath(mpath, reason string) (err error)
+		DegradeMpath(mpath, reason string, degraded bool)
 	}
 	FSHC struct {
 		dispatcher fspathDispatcher // listener is notified upon mountpath events (disabled, etc.)
 		fileListCh chan string
 		stopCh     cos.StopCh
+		mons       map[string]*mpathMon // mountpath => rolling latency and errors
+		disks      ios.AllDiskStats
+		mu         sync.Mutex // protects mons
 	}
 )
 
@@ -49,7 +56,12 @@ type (
 var _ cos.Runner = (*FSHC)(nil)
 
 func NewFSHC(dispatcher fspathDispatcher) (f *FSHC) {
-	f = &FSHC{dispatcher: dispatcher, fileListCh: make(chan string, 100)}
+	f = &FSHC{
+		dispatcher: dispatcher,
+		fileListCh: make(chan string, 100),
+		mons:       make(map[string]*mpathMon, 4),
+		disks:      make(ios.AllDiskStats, 4),
+	}
 	f.stopCh.Init()
 	return
 }
@@ -59,6 +71,8 @@ func (*FSHC) Name() string { return "fshc" }
 func (f *FSHC) Run() error {
 	glog.Infof("Starting %s", f.Name())
 
+	// sampling runs separately, so that it's never held back by mountpath tests (and vice versa)
+	go f.monitor()
 	for {
 		select {
 		case filePath := <-f.fileListCh:
@@ -67,6 +81,7 @@ func (f *FSHC) Run() error {
 				glog.Error(err)
 				break
 			}
+			f.countErr(mpathInfo.Path)
 
 			f.runMpathTest(mpathInfo.Path, filePath)
 		case <-f.stopCh.Listen():
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -64,6 +65,8 @@ func newMockFSDispatcher(mpathsToFail ...string) *MockFSDispatcher {
 	}
 }
 
+func (*MockFSDispatcher) DegradeMpath(string, string, bool) {}
+
 func (d *MockFSDispatcher) DisableMpath(mpath, reason string) (err error) {
 	d.faultDetected = cos.StringInSlice(mpath, d.faultyPaths)
 	if d.faultDetected {
@@ -155,3 +158,44 @@ func TestFSCheckerTryWriteFile(t *testing.T) {
 	err := tryWriteFile(mpath, cos.KiB)
 	tassert.CheckFatal(t, err)
 }
+
+func TestFSCheckerDegraded(t *testing.T) {
+	config := &cmn.FSHCConf{DegradedLatency: cos.Duration(100 * time.Millisecond), DegradedErrors: 4}
+
+	m := &mpathMon{}
+	for i := 0; i < monWindow; i++ {
+		m.add(10)
+	}
+	h := m.stats()
+	tassert.Errorf(t, h.LatP90 == 10*time.Millisecond, "expected p90=10ms, got %v", h.LatP90)
+	tassert.Errorf(t, !h.degraded(config), "healthy mountpath degraded: %s", h.String())
+
+	// a few spikes do not count
+	for i := 0; i < 2; i++ {
+		m.add(500)
+	}
+	h = m.stats()
+	tassert.Errorf(t, !h.degraded(config), "degraded by spikes: %s", h.String())
+
+	// sustained latency does
+	for i := 0; i < monWindow/5; i++ {
+		m.add(150)
+	}
+	h = m.stats()
+	tassert.Errorf(t, h.degraded(config), "expected degraded: %s", h.String())
+	tassert.Errorf(t, !h.recovered(config), "unexpected recovery: %s", h.String())
+
+	for i := 0; i < monWindow; i++ {
+		m.add(10)
+	}
+	h = m.stats()
+	tassert.Errorf(t, h.recovered(config), "expected recovery: %s", h.String())
+
+	// I/O errors
+	m.pending = 3
+	m.add(10)
+	m.pending = 1
+	m.add(10)
+	h = m.stats()
+	tassert.Errorf(t, h.Errs == 4 && h.degraded(config), "expected degraded: %s", h.String())
+}
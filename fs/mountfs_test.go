@@ -152,6 +152,37 @@ func TestMountpathDisableAlreadyDisabled(t *testing.T) {
 	tools.AssertMountpathCount(t, 0, 1)
 }
 
+func TestMountpathDrain(t *testing.T) {
+	initFS()
+
+	mpath := "/tmp/abc"
+	tools.AddMpath(t, mpath)
+
+	// the last (and only) mountpath cannot be drained
+	mi, err := fs.BeginDrain(mpath)
+	tassert.Errorf(t, err != nil && mi == nil, "draining the last mountpath should fail")
+
+	tools.AddMpath(t, "/tmp/def")
+	mi, err = fs.BeginDrain(mpath)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, mi != nil && mi.IsAnySet(fs.FlagBeingDrained), "expected %q to be draining", mpath)
+	tassert.Errorf(t, fs.AnyDraining(), "expected draining mountpath")
+
+	mi.AddDrained(cos.KiB)
+	mpl := fs.MountpathsToLists()
+	tassert.Fatalf(t, len(mpl.Draining) == 1 && len(mpl.Available) == 1, "unexpected %+v", mpl)
+	tassert.Errorf(t, mpl.Draining[0].Path == mpath && mpl.Draining[0].Objs == 1 && mpl.Draining[0].Bytes == cos.KiB,
+		"unexpected drain progress %+v", mpl.Draining[0])
+
+	// draining again is a no-op
+	again, err := fs.BeginDrain(mpath)
+	tassert.Errorf(t, err == nil && again == nil, "already draining mountpath should not be drained again")
+
+	mi.ClearDD()
+	tassert.Errorf(t, !fs.AnyDraining(), "expected no draining mountpaths")
+	tools.AssertMountpathCount(t, 2, 0)
+}
+
 func TestMountpathEnableNonExisting(t *testing.T) {
 	fs.TestNew(nil)
 	_, err := fs.Enable("/tmp")
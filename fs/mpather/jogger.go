@@ -54,6 +54,7 @@ type (
 		IncludeCopy           bool     // visit copies (aka replicas)
 		SkipGloballyMisplaced bool     // skip globally misplaced
 		Throttle              bool     // true: pace itself depending on disk utilization
+		Prio                  int      // priority class (fs.PrioNormal, etc.) - see fs.MountpathInfo.Yield
 	}
 
 	// JoggerGroup runs jogger per mountpath which walk the entire bucket and
@@ -373,10 +374,10 @@ func (sg *joggerSyncGroup) abortAsyncTasks() error {
 	return sg.waitForAsyncTasks()
 }
 
+// yield to higher-priority I/O depending on disk utilization
 func (j *jogger) throttle() {
-	curUtil := fs.GetMpathUtil(j.mi.Path)
-	if curUtil >= j.config.Disk.DiskUtilHighWM {
-		time.Sleep(ThrottleMinDur)
+	if d := j.mi.Yield(j.opts.Prio, j.config); d > 0 {
+		time.Sleep(d)
 	}
 }
 
@@ -22,6 +22,7 @@ require (
 	github.com/prometheus/client_golang v1.14.0
 	github.com/seiflotfy/cuckoofilter v0.0.0-20220411075957-e3b120b3f5fb
 	github.com/teris-io/shortid v0.0.0-20220617161101-71ec9f2aa569
+	github.com/tetratelabs/wazero v1.0.0
 	github.com/tidwall/buntdb v1.2.10
 	github.com/tinylib/msgp v1.1.7
 	github.com/valyala/fasthttp v1.43.0
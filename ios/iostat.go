@@ -28,8 +28,9 @@ type (
 		FillDiskStats(m AllDiskStats)
 	}
 
-	FsDisks      map[string]int64 // disk name => sector size
-	DiskStats    struct{ RBps, Ravg, WBps, Wavg, Util int64 }
+	FsDisks map[string]int64 // disk name => sector size
+	// (Rlat and Wlat are average read and write latencies in milliseconds)
+	DiskStats    struct{ RBps, Ravg, WBps, Wavg, Util, Rlat, Wlat int64 }
 	AllDiskStats map[string]DiskStats
 
 	MpathUtil sync.Map
@@ -47,6 +48,8 @@ type (
 		writes map[string]int64 // completed write requests
 		wbps   map[string]int64 // write B/s
 		wavg   map[string]int64 // average write size
+		rlat   map[string]int64 // average read latency (ms)
+		wlat   map[string]int64 // average write latency (ms)
 
 		mpathUtil   map[string]int64 // Average utilization of the disks, range [0, 100].
 		mpathUtilRO MpathUtil        // Read-only copy of `mpathUtil`.
@@ -118,6 +121,8 @@ func newIostatCache() *cache {
 		writes:    make(map[string]int64, 4),
 		wbps:      make(map[string]int64, 4),
 		wavg:      make(map[string]int64, 4),
+		rlat:      make(map[string]int64, 4),
+		wlat:      make(map[string]int64, 4),
 		mpathUtil: make(map[string]int64, 4),
 	}
 }
@@ -231,6 +236,8 @@ func (ios *ios) FillDiskStats(m AllDiskStats) {
 			WBps: cache.wbps[disk],
 			Wavg: cache.wavg[disk],
 			Util: cache.util[disk],
+			Rlat: cache.rlat[disk],
+			Wlat: cache.wlat[disk],
 		}
 	}
 	for disk := range m {
@@ -313,6 +320,8 @@ func (ios *ios) _refresh(config *cmn.Config) (ncache *cache, maxUtil int64, miss
 		ncache.util[disk] = 0
 		ncache.ravg[disk] = 0
 		ncache.wavg[disk] = 0
+		ncache.rlat[disk] = 0
+		ncache.wlat[disk] = 0
 		osDisk, ok := osDiskStats[disk]
 		if !ok {
 			glog.Errorf("no block stats for disk %s", disk) // TODO: remove
@@ -333,6 +342,8 @@ func (ios *ios) _refresh(config *cmn.Config) (ncache *cache, maxUtil int64, miss
 		// deltas
 		var (
 			ioMs       = ncache.ioms[disk] - statsCache.ioms[disk]
+			readMs     = ncache.rms[disk] - statsCache.rms[disk]
+			writeMs    = ncache.wms[disk] - statsCache.wms[disk]
 			reads      = ncache.reads[disk] - statsCache.reads[disk]
 			writes     = ncache.writes[disk] - statsCache.writes[disk]
 			readBytes  = ncache.rbytes[disk] - statsCache.rbytes[disk]
@@ -361,6 +372,7 @@ func (ios *ios) _refresh(config *cmn.Config) (ncache *cache, maxUtil int64, miss
 		}
 		if reads > 0 {
 			ncache.ravg[disk] = cos.DivRound(readBytes, reads)
+			ncache.rlat[disk] = cos.DivRound(readMs, reads)
 		} else if elapsedSeconds == 0 {
 			ncache.ravg[disk] = statsCache.ravg[disk]
 		} else {
@@ -368,6 +380,7 @@ func (ios *ios) _refresh(config *cmn.Config) (ncache *cache, maxUtil int64, miss
 		}
 		if writes > 0 {
 			ncache.wavg[disk] = cos.DivRound(writeBytes, writes)
+			ncache.wlat[disk] = cos.DivRound(writeMs, writes)
 		} else if elapsedSeconds == 0 {
 			ncache.wavg[disk] = statsCache.wavg[disk]
 		} else {
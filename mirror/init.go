@@ -6,12 +6,16 @@ package mirror
 
 import (
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/xact/xreg"
 )
 
-func Init() {
+func Init(t cluster.Target, statsT stats.Tracker) {
 	xreg.RegBckXact(&tcbFactory{kind: apc.ActCopyBck})
 	xreg.RegBckXact(&tcbFactory{kind: apc.ActETLBck})
 	xreg.RegBckXact(&mncFactory{})
 	xreg.RegBckXact(&putFactory{})
+
+	initNodeMirror(t, statsT)
 }
@@ -99,11 +99,18 @@ func newXactMNC(bck *cluster.Bck, p *mncFactory, slab *memsys.Slab) (r *xactMNC)
 
 func (r *xactMNC) Run(wg *sync.WaitGroup) {
 	wg.Done()
-	tname := r.Target().String()
-	if err := fs.ValidateNCopies(tname, r.copies); err != nil {
+	bck := r.Bck()
+	if err := bck.Init(r.Target().Bowner()); err != nil {
 		r.Finish(err)
 		return
 	}
+	if bck.Props.Mirror.Mode != apc.MirrorNode {
+		tname := r.Target().String()
+		if err := fs.ValidateNCopies(tname, r.copies); err != nil {
+			r.Finish(err)
+			return
+		}
+	}
 	r.BckJog.Run()
 	glog.Infoln(r.Name())
 	err := r.BckJog.Wait()
@@ -112,11 +119,17 @@ func (r *xactMNC) Run(wg *sync.WaitGroup) {
 
 func (r *xactMNC) visitObj(lom *cluster.LOM, buf []byte) (err error) {
 	var size int64
+	if lom.MirrorConf().Mode == apc.MirrorNode {
+		return r.visitNodes(lom)
+	}
 	if n := lom.NumCopies(); n == r.copies {
 		return nil
 	} else if n > r.copies {
 		size, err = delCopies(lom, r.copies)
 	} else {
+		if err = r.Reserve(lom.MpathInfo(), lom.SizeBytes()*int64(r.copies-n)); err != nil {
+			return
+		}
 		size, err = addCopies(lom, r.copies, buf)
 	}
 
@@ -135,3 +148,47 @@ func (r *xactMNC) visitObj(lom *cluster.LOM, buf []byte) (err error) {
 	}
 	return nil
 }
+
+func (r *xactMNC) visitNodes(lom *cluster.LOM) error {
+	size, err := syncReplicas(r.Target(), lom)
+	if err == nil {
+		r.ObjsAdd(1, size)
+	}
+	return err
+}
+
+// cross-node mirroring: no local copies; the main target makes sure that all replicas exist,
+// while the rest of the targets remove obsolete replicas (but only if the main target has the object)
+func syncReplicas(t cluster.Target, lom *cluster.LOM) (size int64, err error) {
+	var nodes cluster.Nodes
+	if lom.NumCopies() > 1 {
+		if size, err = delCopies(lom, 1); err != nil {
+			if os.IsNotExist(err) {
+				err = nil
+			}
+			return
+		}
+	}
+	if nodes, err = Holders(lom, t.Sowner().Get()); err != nil || len(nodes) == 0 {
+		return
+	}
+	if nodes[0].ID() == t.SID() {
+		missing := make(cluster.Nodes, 0, len(nodes)-1)
+		for _, tsi := range nodes[1:] {
+			if !t.HeadObjT2T(lom, tsi) {
+				missing = append(missing, tsi)
+			}
+		}
+		NM.Repl(lom, missing)
+		size += int64(len(missing)) * lom.SizeBytes()
+	} else if !IsReplica(nodes, t.SID()) && t.HeadObjT2T(lom, nodes[0]) {
+		lom.Lock(true)
+		err = lom.Remove()
+		lom.Unlock(true)
+		if err != nil && !os.IsNotExist(err) {
+			return
+		}
+		err = nil
+	}
+	return
+}
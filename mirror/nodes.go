@@ -0,0 +1,268 @@
+// Package mirror provides local mirroring and replica management
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package mirror
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/transport"
+	"github.com/NVIDIA/aistore/transport/bundle"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Cross-node mirroring (`mirror.mode` = apc.MirrorNode):
+// the object's main replica is stored, as always, on its HRW target, while the remaining
+// (copies - 1) replicas are stored on the next targets in the object's HRW order
+// (see cluster.HrwTargetList). Only the main target replicates (upon PUT) and
+// deletes replicas (upon DELETE) - which is also why replicated PUTs never recurse.
+// Work items are sharded across the workers by object name, so that PUTs and DELETEs of
+// a given object are sent in order. When the worker's queue is full, the item is dropped
+// (and counted as a replication error) rather than block the caller that may be holding
+// the object's lock - `make-n-copies` restores the missing replicas (see syncReplicas).
+
+const (
+	nodeTrname  = "n-mirror"
+	nodeWorkers = 4
+)
+
+// opcodes
+const (
+	nodeOpcPut = iota + 1
+	nodeOpcDel
+)
+
+type (
+	NodeMirror struct {
+		t       cluster.Target
+		statsT  stats.Tracker
+		streams *bundle.Streams // (lazy)
+		workChs []chan nodeWork // one per worker
+		mu      sync.Mutex
+	}
+	nodeWork struct {
+		lom    *cluster.LOM
+		nodes  cluster.Nodes
+		opcode int
+	}
+)
+
+var NM *NodeMirror
+
+func initNodeMirror(t cluster.Target, statsT stats.Tracker) {
+	NM = &NodeMirror{t: t, statsT: statsT}
+	if err := transport.HandleObjStream(nodeTrname, NM.recv); err != nil {
+		glog.Errorf("%s: failed to register %q receive handler: %v", t, nodeTrname, err)
+	}
+}
+
+// HRW-ordered targets that store the object: main (HRW) target followed by (copies - 1) replicas
+func Holders(lom *cluster.LOM, smap *cluster.Smap) (cluster.Nodes, error) {
+	copies := 1
+	if mconfig := lom.MirrorConf(); mconfig.Enabled {
+		copies = cos.Min(int(mconfig.Copies), smap.CountActiveTargets())
+	}
+	return cluster.HrwTargetList(lom.Uname(), smap, copies)
+}
+
+// given the object's holders (above), whether the target stores one of the (non-main) replicas
+func IsReplica(nodes cluster.Nodes, tid string) bool {
+	for i := 1; i < len(nodes); i++ {
+		if nodes[i].ID() == tid {
+			return true
+		}
+	}
+	return false
+}
+
+////////////////
+// NodeMirror //
+////////////////
+
+// replicate the object to the targets that do not have it yet (`nodes`), or
+// to all replica holders when `nodes` is nil
+func (nm *NodeMirror) Repl(lom *cluster.LOM, nodes cluster.Nodes) {
+	if nodes == nil {
+		nodes = nm.replicas(lom)
+	}
+	nm.post(lom, nodes, nodeOpcPut)
+}
+
+// delete replicas
+func (nm *NodeMirror) Del(lom *cluster.LOM) {
+	nm.post(lom, nm.replicas(lom), nodeOpcDel)
+}
+
+func (nm *NodeMirror) replicas(lom *cluster.LOM) cluster.Nodes {
+	nodes, err := Holders(lom, nm.t.Sowner().Get())
+	if err != nil {
+		glog.Errorf("%s: %s: %v", nm.t, lom, err)
+		return nil
+	}
+	if len(nodes) == 0 || nodes[0].ID() != nm.t.SID() {
+		return nil // not the main target
+	}
+	return nodes[1:]
+}
+
+func (nm *NodeMirror) post(lom *cluster.LOM, nodes cluster.Nodes, opcode int) {
+	if len(nodes) == 0 {
+		return
+	}
+	clone := cluster.AllocLOM(lom.ObjName)
+	if err := clone.InitBck(lom.Bucket()); err != nil {
+		glog.Errorf("%s: %s: %v", nm.t, lom, err)
+		cluster.FreeLOM(clone)
+		return
+	}
+	nm.open()
+	var (
+		w      = nodeWork{lom: clone, nodes: nodes, opcode: opcode}
+		digest = xxhash.ChecksumString64S(clone.Uname(), cos.MLCG32)
+		workCh = nm.workChs[digest%uint64(len(nm.workChs))]
+	)
+	select {
+	case workCh <- w:
+	default:
+		// never block the caller that may be holding the object's lock
+		glog.Errorf("%s: %s: too many pending replication requests - dropping (opcode %d)", nm.t, clone, opcode)
+		nm.statsT.Add(stats.ErrReplCount, 1)
+		cluster.FreeLOM(clone)
+	}
+}
+
+func (nm *NodeMirror) open() {
+	nm.mu.Lock()
+	if nm.streams == nil {
+		var (
+			config = cmn.GCO.Get()
+			sbArgs = bundle.Args{Net: cmn.NetIntraData, Trname: nodeTrname, Extra: &transport.Extra{}}
+		)
+		nm.streams = bundle.NewStreams(nm.t.Sowner(), nm.t.Snode(), transport.NewIntraDataClient(), sbArgs)
+		nm.workChs = make([]chan nodeWork, nodeWorkers)
+		for i := range nm.workChs {
+			nm.workChs[i] = make(chan nodeWork, cos.Max(config.Mirror.Burst/nodeWorkers, 1))
+			go nm.work(nm.workChs[i])
+		}
+	}
+	nm.mu.Unlock()
+}
+
+func (nm *NodeMirror) work(workCh <-chan nodeWork) {
+	for w := range workCh {
+		if w.opcode == nodeOpcDel {
+			nm.sendDel(w.lom, w.nodes)
+		} else {
+			nm.sendPut(w.lom, w.nodes)
+		}
+	}
+}
+
+// NOTE: the object is rlocked only to load it and open its file (one handle per destination) -
+// not for the duration of the transfer: a subsequent PUT (or DELETE) replaces (or removes) the file
+// without affecting the open handles, and replicates (deletes) the replicas on its own
+func (nm *NodeMirror) sendPut(lom *cluster.LOM, nodes cluster.Nodes) {
+	defer cluster.FreeLOM(lom)
+	lom.Lock(false)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(false)
+		if !cmn.IsObjNotExist(err) {
+			glog.Errorf("%s: failed to replicate %s: %v", nm.t, lom, err)
+		}
+		return
+	}
+	fhs := make([]*cos.FileHandle, 0, len(nodes))
+	for range nodes {
+		fh, err := cos.NewFileHandle(lom.FQN)
+		if err != nil {
+			lom.Unlock(false)
+			for _, fh := range fhs {
+				cos.Close(fh)
+			}
+			glog.Errorf("%s: failed to replicate %s: %v", nm.t, lom, err)
+			return
+		}
+		fhs = append(fhs, fh)
+	}
+	lom.Unlock(false)
+
+	for i, tsi := range nodes {
+		o := transport.AllocSend()
+		o.Hdr.Bck.Copy(lom.Bucket())
+		o.Hdr.ObjName = lom.ObjName
+		o.Hdr.Opcode = nodeOpcPut
+		o.Hdr.ObjAttrs.CopyFrom(lom.ObjAttrs())
+		o.Callback = nm.putSent
+		nm.streams.Send(o, fhs[i], tsi) // (errors are reported via putSent)
+	}
+}
+
+func (nm *NodeMirror) putSent(hdr transport.ObjHdr, _ io.ReadCloser, _ any, err error) {
+	if err != nil {
+		glog.Errorf("%s: failed to replicate %s: %v", nm.t, hdr.FullName(), err)
+	}
+}
+
+func (nm *NodeMirror) sendDel(lom *cluster.LOM, nodes cluster.Nodes) {
+	o := transport.AllocSend()
+	o.Hdr.Bck.Copy(lom.Bucket())
+	o.Hdr.ObjName = lom.ObjName
+	o.Hdr.Opcode = nodeOpcDel
+	o.Callback = nm.delSent
+	cluster.FreeLOM(lom)
+	nm.streams.Send(o, nil, nodes...)
+}
+
+func (nm *NodeMirror) delSent(hdr transport.ObjHdr, _ io.ReadCloser, _ any, err error) {
+	if err != nil {
+		glog.Errorf("%s: failed to delete replicas of %s: %v", nm.t, hdr.FullName(), err)
+	}
+}
+
+// receive replica or deletion request from the main target
+func (nm *NodeMirror) recv(hdr transport.ObjHdr, objReader io.Reader, err error) error {
+	defer transport.DrainAndFreeReader(objReader)
+	if err != nil {
+		glog.Error(err)
+		return err
+	}
+	lom := cluster.AllocLOM(hdr.ObjName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(&hdr.Bck); err != nil {
+		glog.Error(err)
+		return nil
+	}
+	if hdr.Opcode == nodeOpcDel {
+		lom.Lock(true)
+		err = lom.Remove()
+		lom.Unlock(true)
+		if err != nil && !os.IsNotExist(err) {
+			glog.Errorf("%s: failed to delete replica %s: %v", nm.t, lom, err)
+		}
+		return nil
+	}
+	lom.CopyAttrs(&hdr.ObjAttrs, true /*skip-checksum*/)
+	params := cluster.AllocPutObjParams()
+	{
+		params.WorkTag = fs.WorkfilePut
+		params.Reader = io.NopCloser(objReader)
+		params.OWT = cmn.OwtMigrate
+		params.Cksum = hdr.ObjAttrs.Cksum
+		params.Atime = lom.Atime()
+		params.SkipEncode = true
+	}
+	if err := nm.t.PutObject(lom, params); err != nil {
+		glog.Errorf("%s: failed to store replica %s: %v", nm.t, lom, err)
+	}
+	cluster.FreePutObjParams(params)
+	return nil
+}
@@ -0,0 +1,280 @@
+// Package mirror provides local mirroring and replica management
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package mirror
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools"
+	"github.com/NVIDIA/aistore/transport"
+	"github.com/NVIDIA/aistore/transport/bundle"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// target that plays (any) one of the nodes of the `smap`
+type nodesTargetMock struct {
+	mock.TargetMock
+	smap *cluster.Smap
+	sid  string
+	have cos.StrSet // targets that store the object
+}
+
+func (t *nodesTargetMock) SID() string                    { return t.sid }
+func (t *nodesTargetMock) String() string                 { return "t[" + t.sid + "]" }
+func (t *nodesTargetMock) Sowner() cluster.Sowner         { return t }
+func (t *nodesTargetMock) Get() *cluster.Smap             { return t.smap }
+func (*nodesTargetMock) Listeners() cluster.SmapListeners { return nil }
+func (t *nodesTargetMock) HeadObjT2T(_ *cluster.LOM, tsi *cluster.Snode) bool {
+	return t.have.Contains(tsi.ID())
+}
+
+var _ = Describe("NodeMirror", func() {
+	const (
+		testDir = "/tmp/nodes-mirror-test_q/"
+
+		testBucketName = "TEST_NODES_MIRROR_BUCKET"
+		mpath          = testDir + "nodesmirrortest_mpath/111"
+
+		testObjectName = "nodesmirrortestobj.ext"
+		testObjectSize = 1234
+		numTargets     = 5
+		copies         = 3
+	)
+
+	var (
+		props = &cmn.BucketProps{
+			Cksum:  cmn.CksumConf{Type: cos.ChecksumXXHash},
+			Mirror: cmn.MirrorConf{Enabled: true, Copies: copies, Mode: apc.MirrorNode},
+			BID:    1,
+		}
+		bck     = cluster.Bck{Name: testBucketName, Provider: apc.AIS, Ns: cmn.NsGlobal, Props: props}
+		bmdMock = mock.NewBaseBownerMock(&bck)
+		mi      = fs.MountpathInfo{Path: mpath}
+		objFQN  = mi.MakePathFQN(bck.Bucket(), fs.ObjectType, testObjectName)
+
+		tMock *nodesTargetMock
+		nodes cluster.Nodes // object's holders: main target followed by replicas
+		other *cluster.Snode
+	)
+
+	BeforeEach(func() {
+		tools.InitTestFS(mpath)
+		smap := &cluster.Smap{Tmap: make(cluster.NodeMap, numTargets), Version: 1}
+		for i := 0; i < numTargets; i++ {
+			id := fmt.Sprintf("t%d", i)
+			smap.Tmap[id] = cluster.NewSnode(id, apc.Target, cluster.NetInfo{}, cluster.NetInfo{}, cluster.NetInfo{})
+		}
+		tMock = &nodesTargetMock{TargetMock: mock.TargetMock{BO: bmdMock}, smap: smap, have: cos.StrSet{}}
+		cluster.Init(tMock)
+
+		// no streams (and no workers): the test takes replication requests off the work channel
+		NM = &NodeMirror{
+			t:       tMock,
+			statsT:  mock.NewStatsTracker(),
+			streams: &bundle.Streams{},
+			workChs: []chan nodeWork{make(chan nodeWork, 8)},
+		}
+
+		var err error
+		nodes, err = Holders(tools.NewBasicLom(objFQN), smap)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(copies))
+		for _, tsi := range smap.Tmap {
+			if !hasNode(nodes, tsi.ID()) {
+				other = tsi
+			}
+		}
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(testDir)
+	})
+
+	createObj := func() *cluster.LOM {
+		tools.CreateTestFile(filepath.Dir(objFQN), testObjectName, testObjectSize)
+		lom := tools.NewBasicLom(objFQN)
+		lom.SetSize(testObjectSize)
+		Expect(lom.Persist()).NotTo(HaveOccurred())
+		return tools.NewBasicLom(objFQN)
+	}
+
+	expectWork := func(opcode int, tids ...string) {
+		Expect(NM.workChs[0]).To(HaveLen(1))
+		w := <-NM.workChs[0]
+		Expect(w.opcode).To(Equal(opcode))
+		Expect(w.lom.ObjName).To(Equal(testObjectName))
+		Expect(w.nodes).To(HaveLen(len(tids)))
+		for _, tid := range tids {
+			Expect(hasNode(w.nodes, tid)).To(BeTrue())
+		}
+		cluster.FreeLOM(w.lom)
+	}
+
+	Describe("Holders", func() {
+		It("should start with the main (HRW) target", func() {
+			lom := tools.NewBasicLom(objFQN)
+			main, err := cluster.HrwTarget(lom.Uname(), tMock.smap)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes[0].ID()).To(Equal(main.ID()))
+
+			Expect(IsReplica(nodes, nodes[0].ID())).To(BeFalse())
+			for _, tsi := range nodes[1:] {
+				Expect(IsReplica(nodes, tsi.ID())).To(BeTrue())
+			}
+			Expect(IsReplica(nodes, other.ID())).To(BeFalse())
+		})
+
+		It("should not have more holders than active targets", func() {
+			for id, tsi := range tMock.smap.Tmap {
+				if id != nodes[0].ID() && id != nodes[1].ID() {
+					tsi.Flags = tsi.Flags.Set(cluster.NodeFlagMaint)
+				}
+			}
+			holders, err := Holders(tools.NewBasicLom(objFQN), tMock.smap)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holders).To(HaveLen(2))
+		})
+	})
+
+	Describe("Repl and Del", func() {
+		It("should replicate to all replica holders from the main target", func() {
+			tMock.sid = nodes[0].ID()
+			lom := createObj()
+			NM.Repl(lom, nil)
+			expectWork(nodeOpcPut, nodes[1].ID(), nodes[2].ID())
+
+			NM.Del(lom)
+			expectWork(nodeOpcDel, nodes[1].ID(), nodes[2].ID())
+		})
+
+		It("should not replicate from other targets", func() {
+			for _, tid := range []string{nodes[1].ID(), other.ID()} {
+				tMock.sid = tid
+				lom := createObj()
+				NM.Repl(lom, nil)
+				NM.Del(lom)
+				Expect(NM.workChs[0]).To(BeEmpty())
+			}
+		})
+
+		It("should queue all requests for a given object to the same worker, in order", func() {
+			tMock.sid = nodes[0].ID()
+			NM.workChs = []chan nodeWork{make(chan nodeWork, 8), make(chan nodeWork, 8), make(chan nodeWork, 8)}
+			lom := createObj()
+			NM.Repl(lom, nil)
+			NM.Del(lom)
+			NM.Repl(lom, nil)
+
+			var opcodes []int
+			for _, workCh := range NM.workChs {
+				if len(workCh) == 0 {
+					continue
+				}
+				Expect(opcodes).To(BeEmpty())
+				for len(workCh) > 0 {
+					w := <-workCh
+					opcodes = append(opcodes, w.opcode)
+					cluster.FreeLOM(w.lom)
+				}
+			}
+			Expect(opcodes).To(Equal([]int{nodeOpcPut, nodeOpcDel, nodeOpcPut}))
+		})
+
+		It("should drop requests when the worker's queue is full", func() {
+			tMock.sid = nodes[0].ID()
+			NM.workChs = []chan nodeWork{make(chan nodeWork, 1)}
+			lom := createObj()
+			NM.Repl(lom, nil)
+			NM.Del(lom) // (dropped)
+			expectWork(nodeOpcPut, nodes[1].ID(), nodes[2].ID())
+			Expect(NM.workChs[0]).To(BeEmpty())
+		})
+	})
+
+	Describe("sendPut", func() {
+		It("should not keep the object locked while sending", func() {
+			tMock.sid = nodes[0].ID()
+			createObj()
+			lom := cluster.AllocLOM(testObjectName)
+			Expect(lom.InitBck(bck.Bucket())).NotTo(HaveOccurred())
+			NM.sendPut(lom, nodes[1:]) // (no streams - fails to send)
+
+			lom = tools.NewBasicLom(objFQN)
+			Expect(lom.TryLock(true)).To(BeTrue())
+			lom.Unlock(true)
+		})
+	})
+
+	Describe("syncReplicas", func() {
+		It("should replicate to the holders that are missing the object", func() {
+			tMock.sid = nodes[0].ID()
+			tMock.have.Add(nodes[1].ID())
+			lom := createObj()
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+
+			size, err := syncReplicas(tMock, lom)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(BeEquivalentTo(testObjectSize))
+			expectWork(nodeOpcPut, nodes[2].ID())
+		})
+
+		It("should keep the replica", func() {
+			tMock.sid = nodes[1].ID()
+			tMock.have.Add(nodes[0].ID())
+			lom := createObj()
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+
+			_, err := syncReplicas(tMock, lom)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(objFQN).To(BeARegularFile())
+			Expect(NM.workChs[0]).To(BeEmpty())
+		})
+
+		It("should remove obsolete replica only if the main target has the object", func() {
+			tMock.sid = other.ID()
+			lom := createObj()
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			_, err := syncReplicas(tMock, lom)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(objFQN).To(BeARegularFile())
+
+			tMock.have.Add(nodes[0].ID())
+			_, err = syncReplicas(tMock, lom)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(objFQN).NotTo(BeAnExistingFile())
+			Expect(NM.workChs[0]).To(BeEmpty())
+		})
+	})
+
+	Describe("recv", func() {
+		It("should delete replica", func() {
+			createObj()
+			hdr := transport.ObjHdr{Bck: *bck.Bucket(), ObjName: testObjectName, Opcode: nodeOpcDel}
+			Expect(NM.recv(hdr, nil, nil)).NotTo(HaveOccurred())
+			Expect(objFQN).NotTo(BeAnExistingFile())
+
+			// (idempotent)
+			Expect(NM.recv(hdr, nil, nil)).NotTo(HaveOccurred())
+		})
+	})
+})
+
+func hasNode(nodes cluster.Nodes, tid string) bool {
+	for _, tsi := range nodes {
+		if tsi.ID() == tid {
+			return true
+		}
+	}
+	return false
+}
@@ -84,7 +84,7 @@ func (p *putFactory) WhenPrevIsRunning(xprev xreg.Renewable) (xreg.WPR, error) {
 // main
 func runXactPut(lom *cluster.LOM, slab *memsys.Slab, t cluster.Target) (r *XactPut, err error) {
 	mirror := *lom.MirrorConf()
-	if !mirror.Enabled {
+	if !mirror.Local() {
 		return nil, errors.New("mirroring disabled, nothing to do")
 	}
 	tname := t.String()
@@ -170,6 +170,16 @@ func newXactTCB(e *tcbFactory, slab *memsys.Slab) (r *XactTCB) {
 
 func (r *XactTCB) FromTo() (*cluster.Bck, *cluster.Bck) { return r.args.BckFrom, r.args.BckTo }
 
+func (r *XactTCB) Snap() cluster.XactSnap {
+	ds, ok := r.args.DP.(cluster.DPStats)
+	if !ok {
+		return r.Base.Snap()
+	}
+	snap := &xact.SnapExt{Ext: &xact.DPStatsExt{DP: ds.DPStats()}}
+	r.ToSnap(&snap.Snap)
+	return snap
+}
+
 func (r *XactTCB) WaitRunning() { r.wg.Wait() }
 
 func (r *XactTCB) Run(wg *sync.WaitGroup) {
@@ -183,6 +193,12 @@ func (r *XactTCB) Run(wg *sync.WaitGroup) {
 	glog.Infoln(r.Name())
 
 	err := r.BckJog.Wait()
+	if err == nil && r.args.Msg.Multi() {
+		var dpm cluster.DPM
+		if dpm, err = r.dpm(); err == nil {
+			err = dpm.Flush(r.emit)
+		}
+	}
 
 	o := transport.AllocSend()
 	o.Hdr.Opcode = OpcTxnDone
@@ -211,14 +227,47 @@ func (r *XactTCB) Run(wg *sync.WaitGroup) {
 }
 
 func (r *XactTCB) copyObject(lom *cluster.LOM, buf []byte) (err error) {
-	objNameTo := r.args.Msg.ToName(lom.ObjName)
+	if r.args.Msg.Multi() {
+		dpm, err := r.dpm()
+		if err != nil {
+			return err
+		}
+		return dpm.Emit(lom, r.emit)
+	}
+	return r._copy(lom, r.args.Msg.ToName(lom.ObjName), r.args.DP, buf)
+}
+
+// multi-output and/or multi-input transformation (see also `apc.TCBMsg.ValidateCopy`)
+func (r *XactTCB) dpm() (cluster.DPM, error) {
+	dpm, ok := r.args.DP.(cluster.DPM)
+	if !ok {
+		return nil, fmt.Errorf("%s: multiple inputs or outputs require ETL", r)
+	}
+	return dpm, nil
+}
+
+// one of the (possibly, many) objects emitted by multi-output and/or multi-input ETL
+func (r *XactTCB) emit(lom *cluster.LOM, objNameTo string, dp cluster.DP) error {
+	return r._copy(lom, objNameTo, dp, nil)
+}
+
+func (r *XactTCB) _copy(lom *cluster.LOM, objNameTo string, dp cluster.DP, buf []byte) (err error) {
+	// local copy takes from the mountpath budget (remote - see bundle.Streams)
+	if !r.args.Msg.DryRun {
+		uname := r.args.BckTo.MakeUname(objNameTo)
+		if tsi, errH := cluster.HrwTarget(uname, r.Target().Sowner().Get()); errH == nil && tsi.ID() == r.Target().SID() {
+			if err = r.Reserve(lom.MpathInfo(), lom.SizeBytes()); err != nil {
+				return
+			}
+		}
+	}
 	params := cluster.AllocCpObjParams()
 	{
 		params.BckTo = r.args.BckTo
 		params.ObjNameTo = objNameTo
 		params.Buf = buf
 		params.DM = r.dm
-		params.DP = r.args.DP
+		params.DP = dp
 		params.Xact = r
 	}
 	_, err = r.Target().CopyObject(lom, params, r.args.Msg.DryRun)
@@ -14,7 +14,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/fs"
-	"github.com/NVIDIA/aistore/tools/readers"
+	"github.com/NVIDIA/aistore/tools"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -31,20 +31,6 @@ var _ = Describe("Mirror", func() {
 		testObjectSize = 1234
 	)
 
-	_ = cos.CreateDir(mpath)
-	_ = cos.CreateDir(mpath2)
-
-	config := cmn.GCO.BeginUpdate()
-	config.TestFSP.Count = 1
-	cmn.GCO.CommitUpdate(config)
-
-	fs.TestNew(nil)
-	fs.TestDisableValidation()
-	_, _ = fs.Add(mpath, "daeID")
-	_, _ = fs.Add(mpath2, "daeID")
-	_ = fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
-	_ = fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
-
 	var (
 		props = &cmn.BucketProps{
 			Cksum:  cmn.CksumConf{Type: cos.ChecksumXXHash},
@@ -62,8 +48,7 @@ var _ = Describe("Mirror", func() {
 	)
 
 	BeforeEach(func() {
-		_ = cos.CreateDir(mpath)
-		_ = cos.CreateDir(mpath2)
+		tools.InitTestFS(mpath, mpath2)
 		_ = mock.NewTarget(bmdMock)
 	})
 
@@ -80,8 +65,8 @@ var _ = Describe("Mirror", func() {
 
 	Describe("copyTo", func() {
 		It("should copy correctly object and set xattrs", func() {
-			createTestFile(bucketPath, testObjectName, testObjectSize)
-			lom := newBasicLom(defaultObjFQN)
+			tools.CreateTestFile(bucketPath, testObjectName, testObjectSize)
+			lom := tools.NewBasicLom(defaultObjFQN)
 			Expect(lom.IsHRW()).To(BeTrue())
 			lom.SetSize(testObjectSize)
 			lom.SetAtimeUnix(time.Now().UnixNano())
@@ -107,7 +92,7 @@ var _ = Describe("Mirror", func() {
 			 */
 
 			// Check reloaded default LOM
-			newLOM := newBasicLom(defaultObjFQN)
+			newLOM := tools.NewBasicLom(defaultObjFQN)
 			Expect(newLOM.IsHRW()).To(BeTrue())
 			Expect(newLOM.Load(false, true)).ShouldNot(HaveOccurred())
 			Expect(newLOM.IsCopy()).To(BeFalse())
@@ -116,7 +101,7 @@ var _ = Describe("Mirror", func() {
 			Expect(newLOM.GetCopies()).To(And(HaveKey(defaultObjFQN), HaveKey(expectedCopyFQN)))
 
 			// Check reloaded copyLOM
-			copyLOM := newBasicLom(expectedCopyFQN)
+			copyLOM := tools.NewBasicLom(expectedCopyFQN)
 			Expect(copyLOM.Load(false, false)).ShouldNot(HaveOccurred())
 			copyCksum, err := copyLOM.ComputeSetCksum()
 			Expect(err).ShouldNot(HaveOccurred())
@@ -127,20 +112,3 @@ var _ = Describe("Mirror", func() {
 		})
 	})
 })
-
-func createTestFile(filePath, objName string, size int64) {
-	err := cos.CreateDir(filePath)
-	Expect(err).ShouldNot(HaveOccurred())
-
-	r, err := readers.NewFileReader(filePath, objName, size, cos.ChecksumNone)
-	Expect(err).ShouldNot(HaveOccurred())
-	Expect(r.Close()).ShouldNot(HaveOccurred())
-}
-
-func newBasicLom(fqn string) *cluster.LOM {
-	lom := &cluster.LOM{}
-	err := lom.InitFQN(fqn, nil)
-	Expect(err).NotTo(HaveOccurred())
-	lom.Uncache(false)
-	return lom
-}
@@ -13,7 +13,6 @@ import (
 	"sync"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
-	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -24,7 +23,8 @@ import (
 )
 
 // High level overview of how EC rebalance works.
-// 1. EC traverses only metafile(%mt) directories. A jogger per mountpath.
+// 1. EC traverses only metafile(%mt) directories. A jogger per mountpath
+//    walks EC buckets one at a time, skipping those that are done (see progress.go).
 // 2. A jogger skips a metafile if:
 //    - its `FullReplica` is not the local target ID
 //    - its `FullReplica` equals the local target ID and HRW chooses local target
@@ -44,52 +44,53 @@ import (
 //        update their metafiles. Targets do not overwrite their metafiles with a new
 //        one. They update only `Daemons` and `FullReplica` fields.
 
-func (reb *Reb) runECjoggers() {
+func (reb *Reb) runECjoggers(rargs *rebArgs) {
 	var (
 		wg             = &sync.WaitGroup{}
 		availablePaths = fs.GetAvail()
-		cfg            = cmn.GCO.Get()
-		b              = reb.xctn().Bck()
 	)
 	for _, mpathInfo := range availablePaths {
-		bck := cmn.Bck{Provider: apc.AIS}
-		if b != nil {
-			bck = cmn.Bck{Name: b.Name, Provider: apc.AIS, Ns: b.Ns}
-		}
 		wg.Add(1)
-		go reb.jogEC(mpathInfo, &bck, wg)
-	}
-	for _, provider := range cfg.Backend.Providers {
-		for _, mpathInfo := range availablePaths {
-			bck := cmn.Bck{Provider: provider.Name}
-			if b != nil {
-				bck = cmn.Bck{Name: bck.Name, Provider: provider.Name, Ns: bck.Ns}
-			}
-			wg.Add(1)
-			go reb.jogEC(mpathInfo, &bck, wg)
-		}
+		go reb.jogEC(mpathInfo, rargs, wg)
 	}
 	wg.Wait()
 }
 
-// mountpath walker - walks through files in /meta/ directory
-func (reb *Reb) jogEC(mpathInfo *fs.MountpathInfo, bck *cmn.Bck, wg *sync.WaitGroup) {
+// mountpath walker - walks through files in /meta/ directories of EC buckets,
+// one bucket at a time (to skip the buckets that are done - see progress.go)
+func (reb *Reb) jogEC(mpathInfo *fs.MountpathInfo, rargs *rebArgs, wg *sync.WaitGroup) {
 	defer wg.Done()
-	opts := &fs.WalkOpts{
-		Mi:       mpathInfo,
-		CTs:      []string{fs.ECMetaType},
-		Callback: reb.walkEC,
-		Sorted:   false,
-	}
-	opts.Bck.Copy(bck)
-	if err := fs.Walk(opts); err != nil {
-		xreb := reb.xctn()
-		if xreb.IsAborted() || xreb.Finished() {
-			glog.Infof("aborting traversal")
-		} else {
-			glog.Warningf("failed to traverse, err: %v", err)
+	var (
+		xreb = reb.xctn()
+		b    = xreb.Bck()
+		bmd  = reb.t.Bowner().Get()
+		opts = &fs.WalkOpts{
+			Mi:       mpathInfo,
+			CTs:      []string{fs.ECMetaType},
+			Callback: reb.walkEC,
+			Sorted:   false,
 		}
-	}
+	)
+	bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+		// do not touch directories for buckets with EC disabled (for now)
+		if !bck.Props.EC.Enabled || (b != nil && !b.Equal(bck, false /*sameID*/, false /*same backend*/)) {
+			return false
+		}
+		if reb.progress.get(mpathInfo.Path, bck, true /*ec*/) == bckDone {
+			return xreb.IsAborted()
+		}
+		opts.Bck.Copy(bck.Bucket())
+		if err := fs.Walk(opts); err != nil {
+			if xreb.IsAborted() || xreb.Finished() {
+				glog.Infof("aborting traversal")
+			} else {
+				glog.Warningf("failed to traverse, err: %v", err)
+			}
+			return true
+		}
+		reb.progress.set(mpathInfo.Path, bck, true /*ec*/, bckDone, rargs.hrw.of(bck))
+		return xreb.IsAborted()
+	})
 }
 
 // Sends local CT along with EC metadata to default target.
@@ -292,6 +293,9 @@ func (reb *Reb) walkEC(fqn string, de fs.DirEntry) (err error) {
 	if de.IsDir() {
 		return nil
 	}
+	if err := waitResumed(xreb); err != nil {
+		return cmn.NewErrAborted(xreb.Name(), "walk-ec", err)
+	}
 
 	ct, err := cluster.NewCTFromFQN(fqn, reb.t.Bowner())
 	if err != nil {
@@ -23,8 +23,10 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/cmn/prob"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/transport/bundle"
 	"github.com/NVIDIA/aistore/xact"
@@ -83,6 +85,8 @@ type (
 		semaCh   *cos.Semaphore
 		ecClient *http.Client
 		stages   *nodeStages
+		limiter  limiter  // throttle (see throttle.go)
+		progress progress // persistent progress (see progress.go)
 		// atomic state
 		xreb    atomic.Pointer // unsafe(*xact.Rebalance)
 		rebID   atomic.Int64
@@ -102,16 +106,25 @@ type (
 	}
 	rebJogger struct {
 		joggerBase
-		smap *cluster.Smap
-		ver  int64
-		opts fs.WalkOpts
+		smap   *cluster.Smap
+		bck    *cluster.Bck // being traversed
+		resume string       // resume point (see progress.go)
+		cur    string       // being visited
+		opts   fs.WalkOpts
+		ver    int64
+		hrw    hrwDigests
+		saved  int64 // last checkpoint (mono time)
+		user   bool  // user-initiated rebalance (see progress.go)
 	}
 	rebArgs struct {
 		id     int64
 		smap   *cluster.Smap
 		config *cmn.Config
 		apaths fs.MPI
+		hrw    hrwDigests // (see progress.go)
 		ecUsed bool
+		user   bool // user-initiated
+		done   bool // the very same rebalance has already completed (see progress.go)
 	}
 )
 
@@ -140,6 +153,7 @@ func New(t cluster.Target, config *cmn.Config) *Reb {
 
 	// serialize one global rebalance at a time
 	reb.semaCh = cos.NewSemaphore(1)
+	reb.progress.init(t)
 	return reb
 }
 
@@ -168,7 +182,7 @@ func (reb *Reb) unregRecv() {
 //  4. Global rebalance performs checks such as `stage > rebStageTraverse` or
 //     `stage < rebStageWaitAck`. Since all EC stages are between
 //     `Traverse` and `WaitAck` non-EC rebalance does not "notice" stage changes.
-func (reb *Reb) RunRebalance(smap *cluster.Smap, id int64, notif *xact.NotifXact) {
+func (reb *Reb) RunRebalance(smap *cluster.Smap, id int64, notif *xact.NotifXact, user bool) {
 	if reb.nxtID.Load() >= id {
 		return
 	}
@@ -182,8 +196,15 @@ func (reb *Reb) RunRebalance(smap *cluster.Smap, id int64, notif *xact.NotifXact
 	reb.mu.Unlock()
 
 	logHdr := reb.logHdr(id, smap, true /*initializing*/)
-	glog.Infof("%s: initializing...", logHdr)
-	rargs := &rebArgs{id: id, smap: smap, config: cmn.GCO.Get(), ecUsed: reb.t.Bowner().Get().IsECUsed()}
+	logFields(id, nil).Infof("%s: initializing...", logHdr)
+	rargs := &rebArgs{
+		id:     id,
+		smap:   smap,
+		config: cmn.GCO.Get(),
+		hrw:    newHrwDigests(smap),
+		ecUsed: reb.t.Bowner().Get().IsECUsed(),
+		user:   user,
+	}
 	if !reb.serialize(rargs, logHdr) {
 		return
 	}
@@ -236,21 +257,22 @@ func (reb *Reb) RunRebalance(smap *cluster.Smap, id int64, notif *xact.NotifXact
 func (reb *Reb) run(rargs *rebArgs) error {
 	// 6. Capture stats, start mpath joggers
 	reb.stages.stage.Store(rebStageTraverse)
+	rargs.done = reb.progress.begin(rargs)
 
 	// No EC-enabled buckets - run only regular rebalance
 	if !rargs.ecUsed {
-		glog.Infof("starting global rebalance (g%d)", rargs.id)
+		logFields(rargs.id, nil).Infof("starting global rebalance (g%d)", rargs.id)
 		return reb.runNoEC(rargs)
 	}
 
 	// In all other cases run both rebalances simultaneously
 	group := &errgroup.Group{}
 	group.Go(func() error {
-		glog.Infof("starting global rebalance (g%d)", rargs.id)
+		logFields(rargs.id, nil).Infof("starting global rebalance (g%d)", rargs.id)
 		return reb.runNoEC(rargs)
 	})
 	group.Go(func() error {
-		glog.Infof("EC detected - starting EC rebalance (g%d)", rargs.id)
+		logFields(rargs.id, nil).Infof("EC detected - starting EC rebalance (g%d)", rargs.id)
 		return reb.runEC(rargs)
 	})
 	return group.Wait()
@@ -429,7 +451,7 @@ func (reb *Reb) initRenew(rargs *rebArgs, notif *xact.NotifXact, logHdr string,
 	reb.stages.cleanup()
 
 	reb.mu.Unlock()
-	glog.Infof("%s: running %s", reb.logHdr(rargs.id, rargs.smap), reb.xctn())
+	logFields(rargs.id, nil).Infof("%s: running %s", reb.logHdr(rargs.id, rargs.smap), reb.xctn())
 	return true
 }
 
@@ -470,8 +492,12 @@ func (reb *Reb) runEC(rargs *rebArgs) error {
 	if err := xreb.AbortErr(); err != nil {
 		return cmn.NewErrAborted(xreb.Name(), "reb-run-ec-bcast", err)
 	}
+	if rargs.done {
+		return nil
+	}
 
-	reb.runECjoggers()
+	reb.runECjoggers(rargs)
+	reb.progress.flush()
 
 	if err := xreb.AbortErr(); err != nil {
 		return cmn.NewErrAborted(xreb.Name(), "reb-run-ec-joggers", err)
@@ -489,17 +515,22 @@ func (reb *Reb) runNoEC(rargs *rebArgs) error {
 	if err := xreb.AbortErr(); err != nil {
 		return cmn.NewErrAborted(xreb.Name(), "reb-run-bcast", err)
 	}
+	if rargs.done {
+		glog.Infof("%s: rebalance (g%d) has already completed - nothing to do", reb.t, rargs.id)
+		return nil
+	}
 
 	wg := &sync.WaitGroup{}
 	for _, mpathInfo := range rargs.apaths {
 		rl := &rebJogger{
 			joggerBase: joggerBase{m: reb, xreb: reb.xctn(), wg: wg},
-			smap:       rargs.smap, ver: ver,
+			smap:       rargs.smap, ver: ver, hrw: rargs.hrw, user: rargs.user,
 		}
 		wg.Add(1)
 		go rl.jog(mpathInfo)
 	}
 	wg.Wait()
+	reb.progress.flush()
 
 	if err := xreb.AbortErr(); err != nil {
 		return cmn.NewErrAborted(xreb.Name(), "reb-run-joggers", err)
@@ -616,9 +647,9 @@ func (reb *Reb) retransmit(rargs *rebArgs) (cnt int) {
 		for uname, lom := range lomAck.q {
 			if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
 				if cmn.IsObjNotExist(err) {
-					glog.Warningf("%s: object not found (lom: %s, err: %v)", loghdr, lom, err)
+					logFields(rargs.id, lom).Warningf("%s: object not found (lom: %s, err: %v)", loghdr, lom, err)
 				} else {
-					glog.Errorf("%s: failed loading %s, err: %s", loghdr, lom, err)
+					logFields(rargs.id, lom).Errorf("%s: failed loading %s, err: %s", loghdr, lom, err)
 				}
 				delete(lomAck.q, uname)
 				continue
@@ -634,10 +665,10 @@ func (reb *Reb) retransmit(rargs *rebArgs) (cnt int) {
 			// retransmit
 			if roc, err := getReader(lom); err != nil {
 				lom.Unlock(false)
-				glog.Errorf("%s: failed to retransmit %s => %s: %v", loghdr, lom, tsi.StringEx(), err)
+				logFields(rargs.id, lom).Errorf("%s: failed to retransmit %s => %s: %v", loghdr, lom, tsi.StringEx(), err)
 			} else {
 				rj.doSend(lom, tsi, roc)
-				glog.Warningf("%s: retransmitting %s => %s", loghdr, lom, tsi.StringEx())
+				logFields(rargs.id, lom).Warningf("%s: retransmitting %s => %s", loghdr, lom, tsi.StringEx())
 				cnt++
 			}
 			if aborted() {
@@ -667,6 +698,9 @@ func (reb *Reb) fini(rargs *rebArgs, logHdr string, err error) {
 	reb.endStreams(err)
 	reb.filterGFN.Reset()
 	xreb := reb.xctn()
+	if err == nil && !xreb.IsAborted() {
+		reb.progress.complete(rargs)
+	}
 	xreb.ToStats(&stats)
 	if stats.Objs > 0 || stats.OutObjs > 0 || stats.InObjs > 0 {
 		s, e := jsoniter.MarshalIndent(&stats, "", " ")
@@ -681,7 +715,7 @@ func (reb *Reb) fini(rargs *rebArgs, logHdr string, err error) {
 	if !xreb.Finished() {
 		xreb.Finish(nil)
 	}
-	glog.Infof("%s: done (%s)", logHdr, xreb)
+	logFields(rargs.id, nil).Infof("%s: done (%s)", logHdr, xreb)
 }
 
 //////////////////////////////
@@ -696,15 +730,22 @@ func (rj *rebJogger) jog(mpathInfo *fs.MountpathInfo) {
 		rj.opts.Mi = mpathInfo
 		rj.opts.CTs = []string{fs.ObjectType}
 		rj.opts.Callback = rj.visitObj
-		rj.opts.Sorted = false
+		rj.opts.Sorted = !rj.user // (resume points - see progress.go)
 	}
+	rj.saved = mono.NanoTime()
 	bmd := rj.m.t.Bowner().Get()
 	bmd.Range(nil, nil, rj.walkBck)
 }
 
 func (rj *rebJogger) walkBck(bck *cluster.Bck) bool {
+	mark := rj.m.progress.get(rj.opts.Mi.Path, bck, false /*ec*/)
+	if mark == bckDone {
+		return rj.xreb.IsAborted()
+	}
+	rj.bck, rj.resume, rj.cur = bck, mark, ""
 	rj.opts.Bck.Copy(bck.Bucket())
 	err := fs.Walk(&rj.opts)
+	rj.checkpoint(bck, err == nil)
 	if err == nil {
 		return rj.xreb.IsAborted()
 	}
@@ -725,7 +766,7 @@ func (rj *rebJogger) objSentCallback(hdr transport.ObjHdr, _ io.ReadCloser, arg
 		rj.m.delLomAck(lom, 0)
 		if bool(glog.FastV(4, glog.SmoduleReb)) || !cos.IsRetriableConnErr(err) {
 			si := rj.m.t.Snode()
-			glog.Errorf("%s: failed to send o[%s]: %v", si, hdr.FullName(), err)
+			logFields(rj.m.RebID(), lom).Errorf("%s: failed to send o[%s]: %v", si, hdr.FullName(), err)
 		}
 		return
 	}
@@ -737,9 +778,24 @@ func (rj *rebJogger) visitObj(fqn string, de fs.DirEntry) (err error) {
 	if err := rj.xreb.AbortErr(); err != nil {
 		return cmn.NewErrAborted(rj.xreb.Name(), "rj-walk", err)
 	}
+	if rj.resume != "" {
+		if err := rj.skip(fqn, de); err != nil {
+			if err == cmn.ErrSkip {
+				err = nil
+			}
+			return err
+		}
+	}
 	if de.IsDir() {
 		return nil
 	}
+	if err := waitResumed(rj.xreb); err != nil {
+		return cmn.NewErrAborted(rj.xreb.Name(), "rj-walk", err)
+	}
+	rj.cur = fqn
+	if mono.Since(rj.saved) > progInterval {
+		rj.checkpoint(rj.bck, false)
+	}
 	// NOTE: free on error or via (send => ... => delLomAck)
 	lom := cluster.AllocLOM("")
 	err = rj._lwalk(lom, fqn)
@@ -770,6 +826,11 @@ func (rj *rebJogger) _lwalk(lom *cluster.LOM, fqn string) error {
 	if tsi.ID() == rj.m.t.SID() {
 		return cmn.ErrSkip
 	}
+	// cross-node mirroring: replicas stay where they are unless the (new) main target is missing the object
+	// (in which case the main target, upon receiving, will also restore the rest of the replicas)
+	if lom.MirrorConf().Nodes() && rj.isReplica(lom) && rj.m.t.HeadObjT2T(lom, tsi) {
+		return cmn.ErrSkip
+	}
 
 	// skip objects that were already sent via GFN (due to probabilistic filtering
 	// false-positives, albeit rare, are still possible)
@@ -778,6 +839,12 @@ func (rj *rebJogger) _lwalk(lom *cluster.LOM, fqn string) error {
 		rj.m.filterGFN.Delete(uname) // it will not be used anymore
 		return cmn.ErrSkip
 	}
+	// throttle before getReader (below) takes the object's lock
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		return err
+	}
+	rj.throttle(lom.SizeBytes())
+
 	// prepare to send
 	roc, err := getReader(lom)
 	if err != nil {
@@ -790,6 +857,11 @@ func (rj *rebJogger) _lwalk(lom *cluster.LOM, fqn string) error {
 	return nil
 }
 
+func (rj *rebJogger) isReplica(lom *cluster.LOM) bool {
+	nodes, err := mirror.Holders(lom, rj.smap)
+	return err == nil && mirror.IsReplica(nodes, rj.m.t.SID())
+}
+
 func getReader(lom *cluster.LOM) (roc cos.ReadOpenCloser, err error) {
 	lom.Lock(false)
 	if err = lom.Load(false /*cache it*/, true /*locked*/); err != nil {
@@ -0,0 +1,265 @@
+// Package reb provides global cluster-wide rebalance upon adding/removing storage nodes.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/mirror"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	"github.com/NVIDIA/aistore/xact/xs"
+)
+
+// dry-run (what-if) rebalance: given a hypothetical cluster map change, traverse
+// local objects (in parallel, one goroutine per mountpath) and tally those that
+// would migrate - without moving any data (see api.PlanRebalance)
+//
+// Erasure coded buckets are planned by their metafiles: each slice (or replica)
+// that would migrate is counted along with its metafile.
+
+type planner struct {
+	t    cluster.Target
+	cur  *cluster.Smap // current
+	smap *cluster.Smap // hypothetical
+	plan *apc.RebPlanTarget
+	mu   sync.Mutex
+}
+
+func Plan(t cluster.Target, msg *apc.ActValPlanReb) (*apc.RebPlanTarget, error) {
+	cur := t.Sowner().Get()
+	smap, err := planSmap(cur, msg)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		wg        = &sync.WaitGroup{}
+		avail, _  = fs.Get()
+		bmd       = t.Bowner().Get()
+		p         = &planner{t: t, cur: cur, smap: smap}
+		started   = time.Now()
+		numMpaths = len(avail)
+	)
+	p.plan = &apc.RebPlanTarget{
+		Buckets: make(map[string]*apc.RebPlanStats, 8),
+		Dsts:    make(map[string]*apc.RebPlanStats, len(smap.Tmap)),
+	}
+	for _, mi := range avail {
+		wg.Add(1)
+		go func(mi *fs.MountpathInfo) {
+			defer wg.Done()
+			bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+				p.walk(mi, bck)
+				return false
+			})
+		}(mi)
+	}
+	wg.Wait()
+	p.plan.Throughput = lastThroughput()
+	glog.Infof("%s: rebalance plan (%d mountpath%s, %v)", t, numMpaths, cos.Plural(numMpaths), time.Since(started))
+	return p.plan, nil
+}
+
+func planSmap(cur *cluster.Smap, msg *apc.ActValPlanReb) (*cluster.Smap, error) {
+	smap := &cluster.Smap{}
+	*smap = *cur
+	smap.Tmap = make(cluster.NodeMap, len(cur.Tmap)+len(msg.Add))
+	for tid, tsi := range cur.Tmap {
+		smap.Tmap[tid] = tsi
+	}
+	for _, tid := range msg.Remove {
+		if _, ok := smap.Tmap[tid]; !ok {
+			return nil, cmn.NewErrNotFound("%s: target %s", cur, tid)
+		}
+		delete(smap.Tmap, tid)
+	}
+	for _, tid := range msg.Add {
+		if tsi, ok := smap.Tmap[tid]; ok {
+			if !tsi.IsAnySet(cluster.NodeFlagsMaintDecomm) {
+				return nil, fmt.Errorf("target %s is already an active member of the cluster (%s)", tid, cur)
+			}
+			// (taking it out of maintenance)
+			tsi = tsi.Clone()
+			tsi.Flags = tsi.Flags.Clear(cluster.NodeFlagsMaintDecomm)
+			smap.Tmap[tid] = tsi
+			continue
+		}
+		tsi := &cluster.Snode{Domain: msg.Domains[tid]}
+		tsi.Init(tid, apc.Target)
+		smap.Tmap[tid] = tsi
+	}
+	if smap.CountActiveTargets() == 0 {
+		return nil, fmt.Errorf("rebalance plan: no targets remain in the cluster (%s)", cur)
+	}
+	return smap, nil
+}
+
+func (p *planner) walk(mi *fs.MountpathInfo, bck *cluster.Bck) {
+	var (
+		buckets = apc.RebPlanStats{}
+		dsts    = make(map[string]*apc.RebPlanStats, 4)
+		opts    = &fs.WalkOpts{Mi: mi, CTs: []string{fs.ObjectType}}
+		ecUsed  = bck.Props.EC.Enabled
+	)
+	if ecUsed {
+		opts.CTs = []string{fs.ECMetaType}
+	}
+	opts.Bck.Copy(bck.Bucket())
+	opts.Callback = func(fqn string, de fs.DirEntry) error {
+		if de.IsDir() {
+			return nil
+		}
+		var (
+			tsi  *cluster.Snode
+			size int64
+		)
+		if ecUsed {
+			tsi, size = p.visitEC(fqn)
+		} else {
+			lom := cluster.AllocLOM("")
+			tsi = p.visit(lom, fqn)
+			size = lom.SizeBytes()
+			cluster.FreeLOM(lom)
+		}
+		if tsi == nil {
+			return nil
+		}
+		buckets.Add(1, size)
+		if dsts[tsi.ID()] == nil {
+			dsts[tsi.ID()] = &apc.RebPlanStats{}
+		}
+		dsts[tsi.ID()].Add(1, size)
+		return nil
+	}
+	if err := fs.Walk(opts); err != nil {
+		glog.Errorf("%s: failed to traverse %s: %v", p.t, bck, err)
+	}
+	if buckets.Objs == 0 {
+		return
+	}
+	name := bck.Bucket().DisplayName()
+	p.mu.Lock()
+	if p.plan.Buckets[name] == nil {
+		p.plan.Buckets[name] = &apc.RebPlanStats{}
+	}
+	p.plan.Buckets[name].Add(buckets.Objs, buckets.Bytes)
+	for tid, s := range dsts {
+		if p.plan.Dsts[tid] == nil {
+			p.plan.Dsts[tid] = &apc.RebPlanStats{}
+		}
+		p.plan.Dsts[tid].Add(s.Objs, s.Bytes)
+	}
+	p.mu.Unlock()
+}
+
+// returns the destination if the object would migrate (compare with rebJogger._lwalk)
+func (p *planner) visit(lom *cluster.LOM, fqn string) *cluster.Snode {
+	if err := lom.InitFQN(fqn, nil); err != nil {
+		return nil
+	}
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil || lom.IsCopy() {
+		return nil
+	}
+	tsi, err := cluster.HrwTarget(lom.Uname(), p.smap)
+	if err != nil || tsi.ID() == p.t.SID() {
+		return nil
+	}
+	// cross-node mirroring: replicas stay unless the main target changes
+	if lom.MirrorConf().Nodes() {
+		if nodes, err := mirror.Holders(lom, p.cur); err == nil && mirror.IsReplica(nodes, p.t.SID()) {
+			if nodes[0].ID() == tsi.ID() {
+				return nil
+			}
+		}
+	}
+	return tsi
+}
+
+// returns the destination (and the size, including metafile) if the slice or replica
+// would migrate - in accordance with its (hypothetical) placement by HrwTargetList
+// (see ec/putjogger.go)
+func (p *planner) visitEC(fqn string) (*cluster.Snode, int64) {
+	ct, err := cluster.NewCTFromFQN(fqn, p.t.Bowner())
+	if err != nil {
+		return nil, 0
+	}
+	md, err := ec.LoadMetadata(fqn)
+	if err != nil {
+		return nil, 0
+	}
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		return nil, 0
+	}
+	var (
+		tsi  *cluster.Snode
+		self = p.t.SID()
+		size = ec.SliceSize(md.Size, md.Data)
+		n    = md.Data + md.Parity + 1
+	)
+	if md.IsCopy {
+		n = md.Parity + 1
+	}
+	targets, err := cluster.HrwTargetList(ct.Bck().MakeUname(ct.ObjectName()), p.smap, n)
+	if err != nil {
+		return nil, 0 // (not enough targets - nothing to plan)
+	}
+	switch {
+	case md.SliceID == 0 && md.FullReplica == self: // main replica
+		tsi, size = targets[0], md.Size
+	case md.IsCopy: // the first (hypothetical) holder that's missing it
+		size = md.Size
+		for _, si := range targets {
+			if si.ID() == self {
+				return nil, 0
+			}
+			if _, ok := md.Daemons[si.ID()]; !ok && tsi == nil {
+				tsi = si
+			}
+		}
+	case md.SliceID < n:
+		tsi = targets[md.SliceID]
+	}
+	if tsi == nil || tsi.ID() == self {
+		return nil, 0
+	}
+	return tsi, size + finfo.Size()
+}
+
+// bytes/s sent by the latest rebalance (0 - unknown)
+func lastThroughput() int64 {
+	entry := xreg.GetLatest(xreg.XactFilter{Kind: apc.ActRebalance})
+	if entry == nil {
+		return 0
+	}
+	xreb, ok := entry.Get().(*xs.Rebalance)
+	if !ok || xreb == nil {
+		return 0
+	}
+	var (
+		stats   xact.Stats
+		elapsed time.Duration
+	)
+	xreb.ToStats(&stats)
+	if end := xreb.EndTime(); !end.IsZero() {
+		elapsed = end.Sub(xreb.StartTime())
+	} else {
+		elapsed = time.Since(xreb.StartTime())
+	}
+	if stats.OutBytes == 0 || elapsed < time.Second {
+		return 0
+	}
+	return int64(float64(stats.OutBytes) / elapsed.Seconds())
+}
@@ -0,0 +1,45 @@
+// Package reb provides global cluster-wide rebalance upon adding/removing storage nodes.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Plan", func() {
+	var cur *cluster.Smap
+
+	BeforeEach(func() {
+		cur = &cluster.Smap{Tmap: make(cluster.NodeMap, 2), Version: 1}
+		for _, id := range []string{"t1", "t2"} {
+			cur.Tmap[id] = cluster.NewSnode(id, apc.Target, cluster.NetInfo{}, cluster.NetInfo{}, cluster.NetInfo{})
+			cur.Tmap[id].Domain = "rack-" + id
+		}
+	})
+
+	It("should add targets to their failure domains", func() {
+		msg := &apc.ActValPlanReb{Add: []string{"t3", "t4"}, Domains: map[string]string{"t3": "rack-t1"}}
+		smap, err := planSmap(cur, msg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(smap.CountActiveTargets()).To(Equal(4))
+		Expect(smap.Tmap["t3"].Domain).To(Equal("rack-t1"))
+		Expect(smap.Tmap["t4"].Domain).To(BeEmpty())
+		Expect(smap.CountDomains()).To(Equal(3)) // (t4 unlabeled - a domain of its own)
+		Expect(cur.CountDomains()).To(Equal(2))
+	})
+
+	It("should remove targets", func() {
+		smap, err := planSmap(cur, &apc.ActValPlanReb{Remove: []string{"t2"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(smap.CountActiveTargets()).To(Equal(1))
+		Expect(cur.CountActiveTargets()).To(Equal(2))
+
+		_, err = planSmap(cur, &apc.ActValPlanReb{Remove: []string{"t1", "t2"}})
+		Expect(err).To(HaveOccurred())
+	})
+})
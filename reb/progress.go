@@ -0,0 +1,339 @@
+// Package reb provides global cluster-wide rebalance upon adding/removing storage nodes.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Incremental rebalance: each target persists (next to RMD) the per-mountpath,
+// per-bucket progress of its rebalance, so that an interrupted rebalance
+// (e.g., upon primary change or node restart) resumes from where it stopped.
+//
+// Each bucket's progress is only valid for the set of targets that determines
+// the bucket's placement (see hrwDigests): the set of active targets and, for
+// buckets placed by HrwTargetList (EC and n-way node mirroring), their failure
+// domains. The Smap listener below drops the progress of the buckets whose set
+// has changed - even when no rebalance runs (e.g., `--no-rebalance`). Conversely,
+// the buckets whose set remains unchanged are unaffected, and the ones that have
+// been fully traversed get skipped.
+//
+// Completion is keyed by rebalance ID and Smap version: only the very same
+// rebalance (e.g., upon restart) is considered done. User-initiated rebalance
+// is always honored: it ignores (and then rebuilds) the progress.
+//
+// Resume point is the first FQN to (re)visit: all objects preceding it have been
+// traversed and acknowledged by their respective destinations. Resume points
+// require sorted traversal (see fqnCmp) - to be reproducible across runs - and are
+// only used by non-EC rebalance; EC rebalance resumes at bucket granularity.
+
+const (
+	progMetaver      = 2
+	progInterval     = 10 * time.Second // checkpoint (persist) interval
+	bckDone          = "*"              // resume point of a fully traversed bucket
+	ecPrefix         = "ec-"            // EC rebalance progress, e.g. "ec-a1b2"
+	progListenerName = "reb-progress"
+)
+
+type (
+	bckMark struct {
+		Digest uint64 `json:"digest,string"` // (see hrwDigests)
+		Mark   string `json:"mark"`          // resume point or bckDone
+	}
+	progMarks struct {
+		RebID    int64                          `json:"reb_id,string"`       // last rebalance
+		SmapVer  int64                          `json:"smap_version,string"` // and its Smap version
+		Mpaths   []string                       `json:"mpaths"`              // available mountpaths, sorted
+		Marks    map[string]map[string]*bckMark `json:"marks"`               // mountpath => bucket key => mark
+		Complete bool                           `json:"complete"`            // all buckets rebalanced
+	}
+	progress struct {
+		t     cluster.Target
+		hrw   hrwDigests // current
+		marks progMarks
+		saved int64 // last persisted (mono time)
+		mu    sync.Mutex
+	}
+	// targets that determine object placement, with and without failure domains
+	hrwDigests struct {
+		targets uint64
+		domains uint64
+	}
+)
+
+// interface guard
+var _ cluster.Slistener = (*progress)(nil)
+
+func (*progMarks) JspOpts() jsp.Options { return jsp.CksumSign(progMetaver) }
+
+func progPath() string { return filepath.Join(cmn.GCO.Get().ConfigDir, fname.RebProgress) }
+
+func newHrwDigests(smap *cluster.Smap) (d hrwDigests) {
+	var (
+		tids    = make([]string, 0, len(smap.Tmap))
+		domains = smap.CountDomains() > 0
+	)
+	for tid, tsi := range smap.Tmap {
+		if !tsi.IsAnySet(cluster.NodeFlagsMaintDecomm) {
+			tids = append(tids, tid)
+		}
+	}
+	sort.Strings(tids)
+	d.targets = xxhash.ChecksumString64S(strings.Join(tids, ","), cos.MLCG32)
+	d.domains = d.targets
+	if domains {
+		for i, tid := range tids {
+			tids[i] = tid + "@" + smap.Tmap[tid].Domain
+		}
+		d.domains = xxhash.ChecksumString64S(strings.Join(tids, ","), cos.MLCG32)
+	}
+	return
+}
+
+func (d hrwDigests) of(bck *cluster.Bck) uint64 {
+	props := bck.Props
+	if props.EC.Enabled || (props.Mirror.Enabled && !props.Mirror.Local()) {
+		return d.domains
+	}
+	return d.targets
+}
+
+func bckKey(bck *cluster.Bck, ec bool) string {
+	key := strconv.FormatUint(bck.Props.BID, 16)
+	if ec {
+		key = ecPrefix + key
+	}
+	return key
+}
+
+//////////////
+// progress //
+//////////////
+
+func (p *progress) init(t cluster.Target) {
+	p.t = t
+	if _, err := jsp.LoadMeta(progPath(), &p.marks); err != nil && !os.IsNotExist(err) {
+		glog.Errorf("%s: failed to load rebalance progress: %v", t, err)
+	}
+	t.Sowner().Listeners().Reg(p)
+}
+
+func (*progress) String() string { return progListenerName }
+
+// drop the progress of the buckets whose placement has changed
+func (p *progress) ListenSmapChanged() {
+	var (
+		hrw = newHrwDigests(p.t.Sowner().Get())
+		bmd = p.t.Bowner().Get()
+	)
+	p.mu.Lock()
+	if p.hrw != hrw {
+		p.hrw = hrw
+		if p.validate(bmd) {
+			p.persist()
+		}
+	}
+	p.mu.Unlock()
+}
+
+// under lock; returns true if anything's been dropped
+func (p *progress) validate(bmd *cluster.BMD) (changed bool) {
+	digests := make(map[string]uint64, 8)
+	bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+		digest := p.hrw.of(bck)
+		digests[bckKey(bck, false)], digests[bckKey(bck, true)] = digest, digest
+		return false
+	})
+	for mpath, marks := range p.marks.Marks {
+		for key, mark := range marks {
+			if digest, ok := digests[key]; !ok || digest != mark.Digest {
+				delete(marks, key)
+				changed = true
+			}
+		}
+		if len(marks) == 0 {
+			delete(p.marks.Marks, mpath)
+		}
+	}
+	if changed {
+		p.marks.Complete = false
+	}
+	return
+}
+
+// begin (or resume) rebalance; returns true when the very same rebalance
+// has already completed - nothing to do
+func (p *progress) begin(rargs *rebArgs) (complete bool) {
+	mpaths := make([]string, 0, len(rargs.apaths))
+	for mpath := range rargs.apaths {
+		mpaths = append(mpaths, mpath)
+	}
+	sort.Strings(mpaths)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.hrw = rargs.hrw
+	if rargs.user {
+		p.marks = progMarks{}
+	} else {
+		if p.marks.Complete && p.marks.RebID == rargs.id && p.marks.SmapVer == rargs.smap.Version {
+			return true
+		}
+		if strings.Join(p.marks.Mpaths, ",") != strings.Join(mpaths, ",") {
+			p.marks = progMarks{}
+		} else {
+			p.validate(p.t.Bowner().Get())
+		}
+	}
+	p.marks.RebID, p.marks.SmapVer, p.marks.Mpaths, p.marks.Complete = rargs.id, rargs.smap.Version, mpaths, false
+	p.persist()
+	return false
+}
+
+func (p *progress) get(mpath string, bck *cluster.Bck, ec bool) (mark string) {
+	p.mu.Lock()
+	if m, ok := p.marks.Marks[mpath][bckKey(bck, ec)]; ok && m.Digest == p.hrw.of(bck) {
+		mark = m.Mark
+	}
+	p.mu.Unlock()
+	return
+}
+
+func (p *progress) set(mpath string, bck *cluster.Bck, ec bool, mark string, digest uint64) {
+	p.mu.Lock()
+	if p.hrw.of(bck) != digest {
+		p.mu.Unlock()
+		return // invalidated in the meantime
+	}
+	if p.marks.Marks == nil {
+		p.marks.Marks = make(map[string]map[string]*bckMark, len(p.marks.Mpaths))
+	}
+	marks, ok := p.marks.Marks[mpath]
+	if !ok {
+		marks = make(map[string]*bckMark, 4)
+		p.marks.Marks[mpath] = marks
+	}
+	marks[bckKey(bck, ec)] = &bckMark{Digest: digest, Mark: mark}
+	if mono.Since(p.saved) > progInterval {
+		p.persist()
+	}
+	p.mu.Unlock()
+}
+
+func (p *progress) flush() {
+	p.mu.Lock()
+	p.persist()
+	p.mu.Unlock()
+}
+
+// (the marks of fully traversed buckets remain - see begin)
+func (p *progress) complete(rargs *rebArgs) {
+	p.mu.Lock()
+	if p.marks.RebID == rargs.id && p.hrw == rargs.hrw {
+		p.marks.Complete = true
+		p.persist()
+	}
+	p.mu.Unlock()
+}
+
+// under lock
+func (p *progress) persist() {
+	p.saved = mono.NanoTime()
+	if err := jsp.SaveMeta(progPath(), &p.marks, nil /*wto*/); err != nil {
+		glog.Errorf("%s: failed to persist rebalance progress: %v", p.t, err)
+	}
+}
+
+///////////////////////////////
+// rebJogger: resume points  //
+///////////////////////////////
+
+// skip what's been traversed prior to the resume point (`rj.resume`)
+func (rj *rebJogger) skip(fqn string, de fs.DirEntry) error {
+	if fqnCmp(fqn, rj.resume) >= 0 {
+		rj.resume = "" // reached
+		return nil
+	}
+	if de.IsDir() && !strings.HasPrefix(rj.resume, fqn+"/") {
+		return filepath.SkipDir
+	}
+	return cmn.ErrSkip
+}
+
+// checkpoint the current bucket: all objects preceding the one being visited
+// (or all of them, when `done`) except those that are still waiting for ACKs
+func (rj *rebJogger) checkpoint(bck *cluster.Bck, done bool) {
+	mark := rj.cur
+	if done {
+		mark = bckDone
+	}
+	if mark == "" || (mark != bckDone && !rj.opts.Sorted) {
+		return
+	}
+	var (
+		mpath = rj.opts.Mi.Path
+		bid   = bck.Props.BID
+	)
+	for _, lomack := range rj.m.lomAcks() {
+		lomack.mu.Lock()
+		for _, lom := range lomack.q {
+			if lom.Bprops().BID != bid || lom.MpathInfo().Path != mpath {
+				continue
+			}
+			if mark == bckDone || fqnCmp(lom.FQN, mark) < 0 {
+				mark = lom.FQN
+			}
+		}
+		lomack.mu.Unlock()
+	}
+	rj.m.progress.set(mpath, bck, false /*ec*/, mark, rj.hrw.of(bck))
+	rj.saved = mono.NanoTime()
+}
+
+// compares pathnames in the order of sorted traversal, one path component
+// at a time (with a directory preceding its content)
+func fqnCmp(a, b string) int {
+	for {
+		ia, ib := strings.IndexByte(a, filepath.Separator), strings.IndexByte(b, filepath.Separator)
+		ca, cb := a, b
+		if ia >= 0 {
+			ca = a[:ia]
+		}
+		if ib >= 0 {
+			cb = b[:ib]
+		}
+		if ca != cb {
+			if ca < cb {
+				return -1
+			}
+			return 1
+		}
+		switch {
+		case ia < 0 && ib < 0:
+			return 0
+		case ia < 0:
+			return -1
+		case ib < 0:
+			return 1
+		}
+		a, b = a[ia+1:], b[ib+1:]
+	}
+}
@@ -0,0 +1,176 @@
+// Package reb provides global cluster-wide rebalance upon adding/removing storage nodes.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type (
+	dirent bool
+
+	progTargetMock struct {
+		mock.TargetMock
+		smap *cluster.Smap
+	}
+)
+
+func (d dirent) IsDir() bool { return bool(d) }
+
+func (t *progTargetMock) Sowner() cluster.Sowner           { return t }
+func (t *progTargetMock) Get() *cluster.Smap               { return t.smap }
+func (t *progTargetMock) Listeners() cluster.SmapListeners { return t }
+func (*progTargetMock) Reg(cluster.Slistener)              {}
+func (*progTargetMock) Unreg(cluster.Slistener)            {}
+
+var _ = Describe("Progress", func() {
+	It("should compare pathnames in the sorted traversal order", func() {
+		Expect(fqnCmp("/mp/a/b", "/mp/a/b")).To(BeZero())
+		Expect(fqnCmp("/mp/a", "/mp/a/b")).To(Equal(-1))
+		Expect(fqnCmp("/mp/a/b", "/mp/a")).To(Equal(1))
+		// (not the same as lexicographic: '-' < '/')
+		Expect(fqnCmp("/mp/a/z", "/mp/a-b/c")).To(Equal(-1))
+		Expect(fqnCmp("/mp/a-b/c", "/mp/a/z")).To(Equal(1))
+		Expect(fqnCmp("/mp/a/obj1", "/mp/a/obj2")).To(Equal(-1))
+	})
+
+	It("should skip what precedes the resume point", func() {
+		rj := &rebJogger{resume: "/mp/bck/b/obj5"}
+		Expect(rj.skip("/mp/bck", dirent(true))).To(Equal(cmn.ErrSkip))
+		Expect(rj.skip("/mp/bck/a", dirent(true))).To(Equal(filepath.SkipDir))
+		Expect(rj.skip("/mp/bck/b", dirent(true))).To(Equal(cmn.ErrSkip))
+		Expect(rj.skip("/mp/bck/b/obj4", dirent(false))).To(Equal(cmn.ErrSkip))
+		Expect(rj.skip("/mp/bck/b/obj5", dirent(false))).To(BeNil())
+		Expect(rj.resume).To(BeEmpty())
+	})
+
+	Describe("marks", func() {
+		const testDir = "/tmp/reb-progress-test_q/"
+
+		var (
+			plain = cluster.NewBck("plain", apc.AIS, cmn.NsGlobal, &cmn.BucketProps{BID: 1})
+			ecbck = cluster.NewBck("ec", apc.AIS, cmn.NsGlobal, &cmn.BucketProps{BID: 2, EC: cmn.ECConf{Enabled: true}})
+
+			tMock  *progTargetMock
+			p      *progress
+			apaths = fs.MPI{"/mp1": nil, "/mp2": nil}
+		)
+
+		newSmap := func(domains ...string) *cluster.Smap {
+			smap := &cluster.Smap{Tmap: make(cluster.NodeMap, 3), Version: 1}
+			for i, id := range []string{"t1", "t2", "t3"} {
+				smap.Tmap[id] = cluster.NewSnode(id, apc.Target, cluster.NetInfo{}, cluster.NetInfo{}, cluster.NetInfo{})
+				if i < len(domains) {
+					smap.Tmap[id].Domain = domains[i]
+				}
+			}
+			return smap
+		}
+
+		newArgs := func(id int64, user bool) *rebArgs {
+			smap := tMock.smap
+			return &rebArgs{id: id, smap: smap, apaths: apaths, hrw: newHrwDigests(smap), user: user}
+		}
+
+		BeforeEach(func() {
+			config := cmn.GCO.BeginUpdate()
+			config.ConfigDir = testDir
+			cmn.GCO.CommitUpdate(config)
+			Expect(os.MkdirAll(testDir, 0o755)).NotTo(HaveOccurred())
+
+			tMock = &progTargetMock{TargetMock: mock.TargetMock{BO: mock.NewBaseBownerMock(plain, ecbck)}, smap: newSmap()}
+			ecbck.Props.BID = plain.Props.BID + 1 // (the mock assigns the same BID)
+			p = &progress{}
+			p.init(tMock)
+		})
+
+		AfterEach(func() {
+			_ = os.RemoveAll(testDir)
+		})
+
+		// begin and fully traverse both buckets on both mountpaths
+		run := func(rargs *rebArgs) {
+			Expect(p.begin(rargs)).To(BeFalse())
+			for mpath := range apaths {
+				for _, bck := range []*cluster.Bck{plain, ecbck} {
+					p.set(mpath, bck, false, bckDone, rargs.hrw.of(bck))
+				}
+				p.set(mpath, ecbck, true, bckDone, rargs.hrw.of(ecbck))
+			}
+			p.complete(rargs)
+		}
+
+		It("should complete only the very same rebalance", func() {
+			run(newArgs(10, false))
+			Expect(p.begin(newArgs(10, false))).To(BeTrue())
+
+			// (persisted)
+			p = &progress{}
+			p.init(tMock)
+			Expect(p.begin(newArgs(10, false))).To(BeTrue())
+
+			tMock.smap.Version++
+			Expect(p.begin(newArgs(10, false))).To(BeFalse())
+			Expect(p.begin(newArgs(11, false))).To(BeFalse())
+		})
+
+		It("should skip the buckets that are done", func() {
+			run(newArgs(10, false))
+			Expect(p.begin(newArgs(11, false))).To(BeFalse())
+			Expect(p.get("/mp1", plain, false)).To(Equal(bckDone))
+			Expect(p.get("/mp2", ecbck, true)).To(Equal(bckDone))
+		})
+
+		It("should always honor user-initiated rebalance", func() {
+			run(newArgs(10, false))
+			Expect(p.begin(newArgs(10, true))).To(BeFalse())
+			Expect(p.get("/mp1", plain, false)).To(BeEmpty())
+			Expect(p.get("/mp1", ecbck, true)).To(BeEmpty())
+		})
+
+		It("should keep the marks of the buckets whose placement did not change", func() {
+			run(newArgs(10, false))
+
+			// failure domains: only affect EC (and node-mirrored) buckets
+			tMock.smap = newSmap("rack1", "rack2", "rack3")
+			Expect(p.begin(newArgs(11, false))).To(BeFalse())
+			Expect(p.get("/mp1", plain, false)).To(Equal(bckDone))
+			Expect(p.get("/mp1", ecbck, false)).To(BeEmpty())
+			Expect(p.get("/mp1", ecbck, true)).To(BeEmpty())
+		})
+
+		It("should drop the marks upon target change without rebalance", func() {
+			run(newArgs(10, false))
+
+			tMock.smap = newSmap()
+			tMock.smap.Tmap["t3"].Flags = cluster.NodeFlagMaint
+			p.ListenSmapChanged()
+			Expect(p.get("/mp1", plain, false)).To(BeEmpty())
+
+			// and back (the objects that were PUT in the meantime may be misplaced)
+			tMock.smap = newSmap()
+			p.ListenSmapChanged()
+			Expect(p.get("/mp1", plain, false)).To(BeEmpty())
+			Expect(p.begin(newArgs(10, false))).To(BeFalse())
+		})
+
+		It("should drop the marks upon mountpath change", func() {
+			run(newArgs(10, false))
+			rargs := newArgs(11, false)
+			rargs.apaths = fs.MPI{"/mp1": nil}
+			Expect(p.begin(rargs)).To(BeFalse())
+			Expect(p.get("/mp1", plain, false)).To(BeEmpty())
+		})
+	})
+})
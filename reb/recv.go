@@ -158,7 +158,7 @@ func (reb *Reb) recvObjRegular(hdr transport.ObjHdr, smap *cluster.Smap, unpacke
 				reb.t.Snode(), cluster.Tname(tsid), lom, stages[stage])
 		}
 	} else if stage < rebStageTraverse {
-		glog.Errorf("%s: early receive from %s %s (stage %s)", reb.t, cluster.Tname(tsid), lom, stages[stage])
+		logFields(reb.RebID(), lom).Errorf("%s: early receive from %s %s (stage %s)", reb.t, cluster.Tname(tsid), lom, stages[stage])
 	}
 	lom.CopyAttrs(&hdr.ObjAttrs, true /*skip-checksum*/) // see "PUT is a no-op"
 	xreb := reb.xctn()
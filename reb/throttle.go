@@ -0,0 +1,116 @@
+// Package reb provides global cluster-wide rebalance upon adding/removing storage nodes.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/xact/xs"
+)
+
+// Rebalance can be paused (and resumed) at runtime, limited to a daily schedule
+// window, and throttled - all via cluster config (see cmn.RebalanceConf).
+// Pausing blocks the joggers in place, so that resuming continues the traversal
+// from where it stopped. The bandwidth caps are cluster-wide, each target
+// taking its equal share.
+
+const pauseCheckInterval = time.Second
+
+type (
+	// token bucket that holds (at most) one second worth of tokens
+	tbucket struct {
+		tokens float64
+		last   int64 // mono time
+	}
+	limiter struct {
+		mu    sync.Mutex
+		bytes tbucket
+		objs  tbucket
+	}
+)
+
+// returns the time to wait for `n` tokens given the `rate` (tokens/s)
+func (b *tbucket) reserve(n, rate float64, now int64) time.Duration {
+	if b.last == 0 {
+		b.tokens = rate
+	} else {
+		b.tokens += float64(now-b.last) / float64(time.Second) * rate
+		if b.tokens > rate {
+			b.tokens = rate
+		}
+	}
+	b.last = now
+	b.tokens -= n
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / rate * float64(time.Second))
+}
+
+func (l *limiter) reserve(size int64, conf *cmn.RebalanceConf, numTargets int) (d time.Duration) {
+	now := mono.NanoTime()
+	l.mu.Lock()
+	if conf.BytesPerSec > 0 {
+		d = l.bytes.reserve(float64(size), float64(conf.BytesPerSec)/float64(numTargets), now)
+	}
+	if conf.ObjsPerSec > 0 {
+		if d2 := l.objs.reserve(1, float64(conf.ObjsPerSec)/float64(numTargets), now); d2 > d {
+			d = d2
+		}
+	}
+	l.mu.Unlock()
+	return
+}
+
+// throttle outgoing traffic as per rebalance.bytes_per_sec and objs_per_sec
+func (rj *rebJogger) throttle(size int64) {
+	conf := &cmn.GCO.Get().Rebalance
+	if conf.BytesPerSec == 0 && conf.ObjsPerSec == 0 {
+		return
+	}
+	numTargets := rj.smap.CountActiveTargets()
+	if numTargets == 0 {
+		numTargets = 1
+	}
+	if d := rj.m.limiter.reserve(size, conf, numTargets); d > 0 {
+		rj.xreb.AbortedAfter(d)
+	}
+}
+
+// block while paused or outside the schedule window (see rebalance.paused and rebalance.window)
+func waitResumed(xreb *xs.Rebalance) error {
+	for {
+		var (
+			reason string
+			conf   = &cmn.GCO.Get().Rebalance
+		)
+		if !conf.Paused && conf.Window == "" && xreb.Paused() == "" {
+			return nil // fast path
+		}
+		switch {
+		case conf.Paused:
+			reason = "by admin"
+		case !conf.InWindow(time.Now()):
+			reason = "outside schedule window " + conf.Window
+		}
+		if xreb.SetPaused(reason) {
+			if reason == "" {
+				glog.Infof("%s: resumed", xreb)
+			} else {
+				glog.Infof("%s: paused %s", xreb, reason)
+			}
+		}
+		if reason == "" {
+			return nil
+		}
+		if err := xreb.AbortedAfter(pauseCheckInterval); err != nil {
+			return err
+		}
+	}
+}
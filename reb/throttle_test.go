@@ -0,0 +1,40 @@
+// Package reb provides global cluster-wide rebalance upon adding/removing storage nodes.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Throttle", func() {
+	const rate = 100.0 // tokens/s
+
+	It("should allow one second worth of burst", func() {
+		var (
+			b   tbucket
+			now = int64(time.Hour)
+		)
+		Expect(b.reserve(rate, rate, now)).To(BeZero())
+		Expect(b.reserve(rate/2, rate, now)).To(Equal(time.Second / 2))
+	})
+
+	It("should refill over time but not above the burst", func() {
+		var (
+			b   tbucket
+			now = int64(time.Hour)
+		)
+		Expect(b.reserve(rate, rate, now)).To(BeZero())
+		now += int64(time.Second / 4)
+		Expect(b.reserve(rate/4, rate, now)).To(BeZero())
+		Expect(b.reserve(rate/4, rate, now)).To(Equal(time.Second / 4))
+
+		now += int64(time.Hour)
+		Expect(b.reserve(rate, rate, now)).To(BeZero())
+		Expect(b.reserve(1, rate, now)).To(Equal(time.Second / rate))
+	})
+})
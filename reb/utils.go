@@ -15,6 +15,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/transport"
+	"github.com/NVIDIA/aistore/xact"
 	"github.com/NVIDIA/aistore/xact/xs"
 )
 
@@ -50,6 +51,15 @@ func (reb *Reb) logHdr(rebID int64, smap *cluster.Smap, initializing ...bool) st
 	return fmt.Sprintf("%s,%s]", s, stage)
 }
 
+// structured-logging fields (see glog.Fields)
+func logFields(rebID int64, lom *cluster.LOM) (f glog.Fields) {
+	f.Comp, f.Xid = cmn.LogCompReb, xact.RebID2S(rebID)
+	if lom != nil {
+		f.Bck, f.Obj = lom.Bucket().String(), lom.ObjName
+	}
+	return
+}
+
 func (reb *Reb) warnID(remoteID int64, tid string) (s string) {
 	const warn = "t[%s] runs %s g[%d] (local g[%d])"
 	if id := reb.RebID(); id < remoteID {
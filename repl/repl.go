@@ -0,0 +1,309 @@
+// Package repl provides asynchronous replication of ais:// buckets to remote AIS clusters and cloud buckets.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package repl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/transport"
+	"github.com/NVIDIA/aistore/transport/bundle"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Replication (see cmn.ReplConf) is asynchronous:
+// upon each PUT (and, optionally, DELETE) the object's HRW target durably records the change
+// in its local kvdb, while the replicating xaction (apc.ActReplicate) - one per bucket, started
+// on demand - ships the queued changes to the destination and removes them from the queue.
+// A newer change of the same object replaces an older one that has not been shipped yet.
+//
+// Changes that could not be shipped (e.g., destination unavailable) remain queued to be retried by
+// the housekeeper, which also resumes replication after target restart.
+// Objects that had existed prior to enabling replication get copied via apc.ActReplSync.
+//
+// A change gets dequeued only when shipped (or when there's nothing to ship - the object was
+// deleted in the meantime). When the object is no longer here because it has migrated to its
+// new HRW target (cluster membership change), the change is forwarded to that target, and while
+// rebalance (or resilver) is in progress, the changes of the objects that are yet to arrive stay queued.
+// Both the replicating xaction and the housekeeper read the queue page by page.
+
+const (
+	dbCollection = "repl"
+	hkName       = "repl" + hk.NameSuffix
+	hkInterval   = 30 * time.Second
+	trname       = "repl" // to forward changes
+
+	bidLen   = 17 // "%016x/" - see makeKey below
+	pageSize = 1024
+)
+
+type (
+	// queued change
+	record struct {
+		Time int64 `json:"t,string"` // when queued (the oldest unshipped change of the object)
+		Seq  int64 `json:"s,string"` // to tell a newer change from the one being shipped
+		Del  bool  `json:"d,omitempty"`
+	}
+	global struct {
+		t       cluster.Target
+		db      kvdb.Driver
+		statsT  stats.Tracker
+		streams *bundle.Streams // (lazy)
+		seq     atomic.Int64
+		mu      sync.Mutex // serializes read-modify-write of the records
+		smu     sync.Mutex // (streams)
+	}
+)
+
+var g global
+
+func Init(t cluster.Target, db kvdb.Driver, statsT stats.Tracker) {
+	g.t, g.db, g.statsT = t, db, statsT
+	g.seq.Store(time.Now().UnixNano()) // unique across restarts
+	xreg.RegBckXact(&factory{})
+	if err := transport.HandleObjStream(trname, recvFwd); err != nil {
+		glog.Errorf("%s: failed to register %q receive handler: %v", t, trname, err)
+	}
+	hk.Reg(hkName, housekeep, hkInterval)
+}
+
+// PUT (or any other kind of new content) - to be called after the object is finalized
+func Put(lom *cluster.LOM) { enqueue(lom, false) }
+
+// DELETE - to be called after the object is deleted
+func Del(lom *cluster.LOM) {
+	if lom.Bprops().Repl.Deletes {
+		enqueue(lom, true)
+	}
+}
+
+func enqueue(lom *cluster.LOM, del bool) {
+	if g.db == nil {
+		return
+	}
+	conf := &lom.Bprops().Repl
+	if !conf.Enabled || !strings.HasPrefix(lom.ObjName, conf.Prefix) {
+		return
+	}
+	// only the main (HRW) target replicates
+	if _, local, err := lom.HrwTarget(g.t.Sowner().Get()); err != nil || !local {
+		return
+	}
+	var (
+		prev record
+		key  = makeKey(lom.Bprops().BID, lom.ObjName)
+		rec  = record{Time: time.Now().UnixNano(), Seq: g.seq.Inc(), Del: del}
+	)
+	g.mu.Lock()
+	if err := g.db.Get(dbCollection, key, &prev); err == nil {
+		rec.Time = prev.Time
+	}
+	err := g.db.Set(dbCollection, key, &rec)
+	g.mu.Unlock()
+	if err != nil {
+		glog.Errorf("%s: failed to queue %s for replication: %v", g.t, lom, err)
+		g.statsT.Add(stats.ErrReplCount, 1)
+		return
+	}
+	kick(lom.Bck())
+}
+
+// (re)queue the change forwarded by the object's previous HRW target - unless there's
+// a newer one already
+func requeue(bck *cluster.Bck, objName string, fwd *record) error {
+	var (
+		prev record
+		key  = makeKey(bck.Props.BID, objName)
+		rec  = record{Time: fwd.Time, Seq: g.seq.Inc(), Del: fwd.Del}
+	)
+	g.mu.Lock()
+	if err := g.db.Get(dbCollection, key, &prev); err == nil {
+		rec.Del = prev.Del
+		rec.Time = cos.MinI64(prev.Time, fwd.Time)
+	}
+	err := g.db.Set(dbCollection, key, &rec)
+	g.mu.Unlock()
+	return err
+}
+
+// remove the record unless it has been replaced by a newer change in the meantime
+func dequeue(key string, rec *record) {
+	var cur record
+	g.mu.Lock()
+	if err := g.db.Get(dbCollection, key, &cur); err == nil && cur.Seq == rec.Seq {
+		if err := g.db.Delete(dbCollection, key); err != nil {
+			glog.Errorf("%s: failed to dequeue %q: %v", g.t, key, err)
+		}
+	}
+	g.mu.Unlock()
+}
+
+// bucket's records are prefixed with its BID (a bucket that gets destroyed and
+// re-created with the same name does not inherit the queue)
+func bidPrefix(bid uint64) string         { return fmt.Sprintf("%016x/", bid) }
+func makeKey(bid uint64, o string) string { return bidPrefix(bid) + o }
+
+// (re)start replicating xaction if not running and make it (re)visit the queue
+func kick(bck *cluster.Bck) {
+	rns := xreg.RenewReplicate(g.t, bck)
+	if rns.Err != nil {
+		glog.Errorf("%s: %s: %v", g.t, bck, rns.Err)
+		return
+	}
+	rns.Entry.Get().(*XactRepl).wake()
+}
+
+// periodically: update backlog and lag stats, retry (or resume) replication of
+// the queued changes, and discard the changes of no longer replicated buckets
+func housekeep() time.Duration {
+	if !g.t.ClusterStarted() {
+		return hkInterval
+	}
+	var (
+		now     = time.Now().UnixNano()
+		oldest  = now
+		total   int64
+		backlog = make(map[string]int, 4) // bid prefix => number of changes
+		after   string
+	)
+	for {
+		keys, vals, err := g.db.GetPage(dbCollection, "", after, pageSize)
+		if err != nil && !kvdb.IsErrNotFound(err) {
+			glog.Errorf("%s: failed to load replication queue: %v", g.t, err)
+			return hkInterval
+		}
+		for i, key := range keys {
+			var rec record
+			if len(key) <= bidLen {
+				continue
+			}
+			if err := jsoniter.UnmarshalFromString(vals[i], &rec); err == nil && rec.Time < oldest {
+				oldest = rec.Time
+			}
+			backlog[key[:bidLen]]++
+		}
+		total += int64(len(keys))
+		if len(keys) < pageSize {
+			break
+		}
+		after = keys[len(keys)-1]
+	}
+	g.statsT.Set(stats.ReplBacklog, total)
+	g.statsT.Set(stats.ReplLag, now-oldest)
+	if len(backlog) == 0 {
+		return hkInterval
+	}
+
+	provider := apc.AIS
+	g.t.Bowner().Get().Range(&provider, nil, func(bck *cluster.Bck) bool {
+		prefix := bidPrefix(bck.Props.BID)
+		if _, ok := backlog[prefix]; !ok {
+			return false
+		}
+		if bck.Props.Repl.Enabled {
+			delete(backlog, prefix)
+			kick(bck)
+		}
+		return false
+	})
+	// whatever remains belongs to the buckets that no longer exist or are not replicated
+	for prefix, n := range backlog {
+		glog.Warningf("%s: discarding %d queued change(s) of a no longer replicated bucket (bid %s)",
+			g.t, n, strings.TrimSuffix(prefix, "/"))
+		discard(prefix)
+	}
+	return hkInterval
+}
+
+func discard(prefix string) {
+	for {
+		keys, _, err := g.db.GetPage(dbCollection, prefix, "", pageSize)
+		if err != nil || len(keys) == 0 {
+			return
+		}
+		g.mu.Lock()
+		for _, key := range keys {
+			if err := g.db.Delete(dbCollection, key); err != nil && !kvdb.IsErrNotFound(err) {
+				glog.Error(err)
+			}
+		}
+		g.mu.Unlock()
+		if len(keys) < pageSize {
+			return
+		}
+	}
+}
+
+////////////////
+// forwarding //
+////////////////
+
+// forward the change to the object's (new) HRW target; dequeue once delivered
+func forward(bck *cluster.Bck, e *entry, tsi *cluster.Snode) {
+	g.smu.Lock()
+	if g.streams == nil {
+		sbArgs := bundle.Args{Net: cmn.NetIntraData, Trname: trname, Extra: &transport.Extra{}}
+		g.streams = bundle.NewStreams(g.t.Sowner(), g.t.Snode(), transport.NewIntraDataClient(), sbArgs)
+	}
+	g.smu.Unlock()
+
+	o := transport.AllocSend()
+	o.Hdr.Bck.Copy(bck.Bucket())
+	o.Hdr.ObjName = e.key[bidLen:]
+	o.Hdr.Opaque = cos.MustMarshal(&e.record)
+	o.Callback, o.CmplArg = fwdSent, e
+	g.streams.Send(o, nil, tsi)
+}
+
+func fwdSent(hdr transport.ObjHdr, _ io.ReadCloser, arg any, err error) {
+	e := arg.(*entry)
+	if err != nil {
+		glog.Errorf("%s: failed to forward %s (will retry): %v", g.t, hdr.FullName(), err)
+		g.statsT.Add(stats.ErrReplCount, 1)
+		return
+	}
+	dequeue(e.key, &e.record)
+}
+
+func recvFwd(hdr transport.ObjHdr, objReader io.Reader, err error) error {
+	defer transport.DrainAndFreeReader(objReader)
+	if err != nil {
+		glog.Error(err)
+		return err
+	}
+	var rec record
+	if err := jsoniter.Unmarshal(hdr.Opaque, &rec); err != nil {
+		glog.Errorf("%s: invalid forwarded change %s: %v", g.t, hdr.FullName(), err)
+		return nil
+	}
+	bck := cluster.CloneBck(&hdr.Bck)
+	if err := bck.Init(g.t.Bowner()); err != nil {
+		glog.Errorf("%s: %s: %v", g.t, hdr.FullName(), err)
+		return nil
+	}
+	if !bck.Props.Repl.Enabled {
+		return nil
+	}
+	if err := requeue(bck, hdr.ObjName, &rec); err != nil {
+		glog.Errorf("%s: failed to queue %s for replication: %v", g.t, hdr.FullName(), err)
+		g.statsT.Add(stats.ErrReplCount, 1)
+		return nil
+	}
+	kick(bck)
+	return nil
+}
@@ -0,0 +1,22 @@
+// Package repl provides asynchronous replication of ais:// buckets to remote AIS clusters and cloud buckets.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package repl
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestRepl(t *testing.T) {
+	xreg.Init()
+	hk.TestInit()
+	go hk.DefaultHK.Run()
+	RegisterFailHandler(Fail)
+	RunSpecs(t, t.Name())
+}
@@ -0,0 +1,246 @@
+// Package repl provides asynchronous replication of ais:// buckets to remote AIS clusters and cloud buckets.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package repl
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/readers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type (
+	// this target ("t0") and one more
+	replTargetMock struct {
+		mock.TargetMock
+		smap    *cluster.Smap
+		backend *backendMock
+	}
+	// destination: records PUTs and DELETEs
+	backendMock struct {
+		cluster.BackendProvider
+		puts []string
+		dels []string
+		mu   sync.Mutex
+	}
+)
+
+func (*replTargetMock) SID() string                                    { return "t0" }
+func (t *replTargetMock) Sowner() cluster.Sowner                       { return t }
+func (t *replTargetMock) Get() *cluster.Smap                           { return t.smap }
+func (*replTargetMock) Listeners() cluster.SmapListeners               { return nil }
+func (t *replTargetMock) Backend(*cluster.Bck) cluster.BackendProvider { return t.backend }
+
+func (b *backendMock) PutObj(r io.ReadCloser, lom *cluster.LOM) (int, error) {
+	cos.DrainReader(r)
+	r.Close()
+	b.mu.Lock()
+	b.puts = append(b.puts, lom.ObjName)
+	b.mu.Unlock()
+	return 0, nil
+}
+
+func (b *backendMock) DeleteObj(lom *cluster.LOM) (int, error) {
+	b.mu.Lock()
+	b.dels = append(b.dels, lom.ObjName)
+	b.mu.Unlock()
+	return 0, nil
+}
+
+var _ = Describe("Replication", func() {
+	const (
+		testDir = "/tmp/repl-test_q/"
+		mpath   = testDir + "repltest_mpath/111"
+	)
+
+	var (
+		props = &cmn.BucketProps{
+			Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash},
+			Repl:  cmn.ReplConf{Dst: "ais://dst", Deletes: true, Enabled: true},
+			BID:   1,
+		}
+		bck    = cluster.Bck{Name: "src", Provider: apc.AIS, Ns: cmn.NsGlobal, Props: props}
+		dstBck = cluster.Bck{Name: "dst", Provider: apc.AIS, Ns: cmn.NsGlobal, Props: &cmn.BucketProps{BID: 2}}
+		mi     = fs.MountpathInfo{Path: mpath}
+
+		tMock *replTargetMock
+		r     *XactRepl
+	)
+
+	BeforeEach(func() {
+		config := cmn.GCO.BeginUpdate()
+		config.TestFSP.Count = 1
+		cmn.GCO.CommitUpdate(config)
+		_ = cos.CreateDir(mpath)
+		fs.TestNew(nil)
+		fs.TestDisableValidation()
+		_, _ = fs.Add(mpath, "daeID")
+		_ = fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
+		_ = fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+
+		smap := &cluster.Smap{Tmap: make(cluster.NodeMap, 2), Version: 1}
+		for _, id := range []string{"t0", "t1"} {
+			smap.Tmap[id] = cluster.NewSnode(id, apc.Target, cluster.NetInfo{}, cluster.NetInfo{}, cluster.NetInfo{})
+		}
+		tMock = &replTargetMock{
+			TargetMock: mock.TargetMock{BO: mock.NewBaseBownerMock(&bck, &dstBck)},
+			smap:       smap,
+			backend:    &backendMock{},
+		}
+		cluster.Init(tMock)
+		g.t, g.db, g.statsT = tMock, mock.NewDBDriver(), mock.NewStatsTracker()
+
+		r = &XactRepl{conf: props.Repl, dst: &dstBck, prefix: bidPrefix(props.BID), wakeCh: make(chan struct{}, 1)}
+		r.DemandBase.Init(cos.GenUUID(), apc.ActReplicate, &bck, 0)
+	})
+
+	AfterEach(func() {
+		r.DemandBase.Stop()
+		_ = os.RemoveAll(testDir)
+	})
+
+	// object names that this target is (or is not) the HRW target for
+	objNames := func(n int, local bool) []string {
+		names := make([]string, 0, n)
+		for i := 0; len(names) < n; i++ {
+			name := fmt.Sprintf("obj-%d", i)
+			tsi, err := cluster.HrwTarget(bck.MakeUname(name), tMock.smap)
+			Expect(err).NotTo(HaveOccurred())
+			if (tsi.ID() == "t0") == local {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+
+	createObj := func(objName string) *cluster.LOM {
+		fqn := mi.MakePathFQN(bck.Bucket(), fs.ObjectType, objName)
+		Expect(cos.CreateDir(filepath.Dir(fqn))).NotTo(HaveOccurred())
+		rd, err := readers.NewFileReader(filepath.Dir(fqn), objName, 1024, cos.ChecksumNone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rd.Close()).NotTo(HaveOccurred())
+
+		lom := &cluster.LOM{}
+		Expect(lom.InitFQN(fqn, nil)).NotTo(HaveOccurred())
+		lom.SetSize(1024)
+		Expect(lom.Persist()).NotTo(HaveOccurred())
+		lom.Uncache(false)
+		return lom
+	}
+
+	queue := func(objName string, del bool) {
+		rec := record{Time: g.seq.Inc(), Seq: g.seq.Inc(), Del: del}
+		Expect(g.db.Set(dbCollection, makeKey(props.BID, objName), &rec)).NotTo(HaveOccurred())
+	}
+
+	queued := func() []string {
+		keys, err := g.db.List(dbCollection, "")
+		Expect(err).NotTo(HaveOccurred())
+		return keys
+	}
+
+	Describe("ship", func() {
+		It("should ship PUTs and DELETEs and dequeue them", func() {
+			names := objNames(2, true)
+			createObj(names[0])
+			queue(names[0], false)
+			queue(names[1], true)
+
+			Expect(r.ship()).To(BeFalse())
+			Expect(tMock.backend.puts).To(Equal([]string{names[0]}))
+			Expect(tMock.backend.dels).To(Equal([]string{names[1]}))
+			Expect(queued()).To(BeEmpty())
+			Expect(r.backlog.Load()).To(BeZero())
+			Expect(r.Objs()).To(BeEquivalentTo(2))
+		})
+
+		It("should ship the queue page by page", func() {
+			const num = 2*pageSize + 1
+			for i := 0; i < num; i++ {
+				queue(fmt.Sprintf("obj-%d", i), true /*del*/)
+			}
+			Expect(r.ship()).To(BeFalse())
+			Expect(tMock.backend.dels).To(HaveLen(num))
+			Expect(queued()).To(BeEmpty())
+		})
+
+		It("should dequeue the change of a deleted object", func() {
+			name := objNames(1, true)[0]
+			queue(name, false)
+			Expect(r.ship()).To(BeFalse())
+			Expect(tMock.backend.puts).To(BeEmpty())
+			Expect(queued()).To(BeEmpty())
+		})
+
+		It("should keep the change of an object that is yet to arrive", func() {
+			name := objNames(1, true)[0]
+			queue(name, false)
+			_, err := fs.PersistMarker(fname.RebalanceMarker) // (interrupted rebalance)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(r.ship()).To(BeFalse())
+			Expect(tMock.backend.puts).To(BeEmpty())
+			Expect(queued()).To(HaveLen(1))
+			Expect(r.backlog.Load()).To(BeEquivalentTo(1))
+
+			// arrived
+			Expect(fs.RemoveMarker(fname.RebalanceMarker)).NotTo(HaveOccurred())
+			createObj(name)
+			Expect(r.ship()).To(BeFalse())
+			Expect(tMock.backend.puts).To(Equal([]string{name}))
+			Expect(queued()).To(BeEmpty())
+		})
+
+		It("should exit when the bucket is no longer replicated", func() {
+			r.conf.Prefix = "changed"
+			Expect(r.ship()).To(BeTrue())
+		})
+	})
+
+	Describe("queue", func() {
+		It("should not dequeue a newer change", func() {
+			key := makeKey(props.BID, "obj")
+			queue("obj", false)
+			var shipped record
+			Expect(g.db.Get(dbCollection, key, &shipped)).NotTo(HaveOccurred())
+			queue("obj", true) // (newer)
+
+			dequeue(key, &shipped)
+			Expect(queued()).To(HaveLen(1))
+		})
+
+		It("should merge forwarded change with the local one", func() {
+			var (
+				key = makeKey(props.BID, "obj")
+				rec record
+			)
+			queue("obj", true)
+			Expect(g.db.Get(dbCollection, key, &rec)).NotTo(HaveOccurred())
+
+			fwd := record{Time: rec.Time - 1000, Seq: 1} // older PUT
+			Expect(requeue(&bck, "obj", &fwd)).NotTo(HaveOccurred())
+			var merged record
+			Expect(g.db.Get(dbCollection, key, &merged)).NotTo(HaveOccurred())
+			Expect(merged.Time).To(Equal(fwd.Time)) // (the oldest unshipped)
+			Expect(merged.Del).To(BeTrue())         // (the latest)
+			Expect(merged.Seq).NotTo(Equal(rec.Seq))
+
+			Expect(requeue(&bck, "other", &fwd)).NotTo(HaveOccurred())
+			Expect(queued()).To(HaveLen(2))
+		})
+	})
+})
@@ -0,0 +1,287 @@
+// Package repl provides asynchronous replication of ais:// buckets to remote AIS clusters and cloud buckets.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package repl
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const maxErrs = 16 // consecutive errors: give up until the next kick (see housekeep)
+
+type (
+	factory struct {
+		xreg.RenewBase
+		xctn *XactRepl
+	}
+	XactRepl struct {
+		xact.DemandBase
+		conf    cmn.ReplConf
+		dst     *cluster.Bck
+		prefix  string // bucket's records (see makeKey)
+		wakeCh  chan struct{}
+		backlog atomic.Int64
+		lag     atomic.Int64
+	}
+	StatsExt struct {
+		xact.BaseDemandStatsExt
+		Dst     string       `json:"dst"`
+		Backlog int64        `json:"backlog,string"`
+		Lag     cos.Duration `json:"lag"`
+	}
+
+	entry struct {
+		key string
+		record
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact   = (*XactRepl)(nil)
+	_ xreg.Renewable = (*factory)(nil)
+)
+
+/////////////
+// factory //
+/////////////
+
+func (*factory) New(args xreg.Args, bck *cluster.Bck) xreg.Renewable {
+	return &factory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+}
+
+func (p *factory) Start() error {
+	conf := p.Bck.Props.Repl
+	bck, err := conf.DstBck()
+	if err != nil {
+		return err
+	}
+	dst := cluster.CloneBck(bck)
+	if err := dst.Init(p.T.Bowner()); err != nil {
+		return err
+	}
+	r := &XactRepl{conf: conf, dst: dst, prefix: bidPrefix(p.Bck.Props.BID), wakeCh: make(chan struct{}, 1)}
+	r.DemandBase.Init(cos.GenUUID(), apc.ActReplicate, p.Bck, 0 /*use default*/)
+	p.xctn = r
+	go r.Run(nil)
+	return nil
+}
+
+func (*factory) Kind() string        { return apc.ActReplicate }
+func (p *factory) Get() cluster.Xact { return p.xctn }
+
+func (*factory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) {
+	return xreg.WprUse, nil // (on-demand: xreg.usePrev)
+}
+
+//////////////
+// XactRepl //
+//////////////
+
+func (r *XactRepl) wake() {
+	select {
+	case r.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+func (r *XactRepl) Run(*sync.WaitGroup) {
+	glog.Infof("%s => %s", r.Name(), r.dst)
+	for {
+		select {
+		case <-r.wakeCh:
+			if r.ship() {
+				r.DemandBase.Stop()
+				r.Finish(nil)
+				return
+			}
+		case <-r.IdleTimer():
+			r.DemandBase.Stop()
+			r.Finish(nil)
+			if len(r.wakeCh) > 0 {
+				go kick(r.Bck()) // (raced with enqueue)
+			}
+			return
+		case errCause := <-r.ChanAbort():
+			r.DemandBase.Stop()
+			r.Finish(cmn.NewErrAborted(r.Name(), "", errCause))
+			return
+		}
+	}
+}
+
+// ship queued changes page by page, the oldest first (within a page); returns true when the bucket
+// is no longer replicated (or replicated elsewhere) - for the xaction to exit
+func (r *XactRepl) ship() (done bool) {
+	bck := cluster.CloneBck(r.Bck().Bucket())
+	if err := bck.Init(g.t.Bowner()); err != nil || bck.Props.BID != r.Bck().Props.BID || bck.Props.Repl != r.conf {
+		return true // (the housekeeper takes it from here)
+	}
+	var (
+		after     string
+		remaining int64 // kept in the queue, to be retried
+		oldest    int64
+		errCnt    int
+	)
+	for {
+		keys, vals, err := g.db.GetPage(dbCollection, r.prefix, after, pageSize)
+		if err != nil {
+			if !kvdb.IsErrNotFound(err) {
+				glog.Errorf("%s: %v", r, err)
+			}
+			return
+		}
+		entries := make([]*entry, 0, len(keys))
+		for i, key := range keys {
+			e := &entry{key: key}
+			if err := jsoniter.UnmarshalFromString(vals[i], &e.record); err != nil {
+				glog.Errorf("%s: invalid record %q: %v", r, key, err)
+				continue
+			}
+			entries = append(entries, e)
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Time < entries[j].Time })
+		if after == "" && len(entries) > 0 {
+			r.lag.Store(time.Now().UnixNano() - entries[0].Time)
+		}
+		for _, e := range entries {
+			if r.IsAborted() {
+				return
+			}
+			r.IncPending()
+			keep, err := r.do(e)
+			r.DecPending()
+			if err != nil {
+				g.statsT.Add(stats.ErrReplCount, 1)
+				glog.Errorf("%s: failed to replicate %q: %v", r, e.key[bidLen:], err)
+				if errCnt++; errCnt >= maxErrs {
+					glog.Errorf("%s: %d consecutive errors, will retry later", r, errCnt)
+					return
+				}
+				keep = true
+			} else {
+				errCnt = 0
+			}
+			if keep {
+				if remaining++; oldest == 0 || e.Time < oldest {
+					oldest = e.Time
+				}
+				continue
+			}
+			dequeue(e.key, &e.record)
+			if r.backlog.Dec() < 0 {
+				r.backlog.Store(0)
+			}
+		}
+		if len(keys) < pageSize {
+			break
+		}
+		after = keys[len(keys)-1]
+	}
+	r.backlog.Store(remaining)
+	if oldest == 0 {
+		r.lag.Store(0)
+	} else {
+		r.lag.Store(time.Now().UnixNano() - oldest)
+	}
+	return
+}
+
+// returns keep == true when the change is to remain queued (see "forwarding")
+func (r *XactRepl) do(e *entry) (keep bool, err error) {
+	objName := e.key[bidLen:]
+	dst := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(dst)
+	if err := dst.InitBck(r.dst.Bucket()); err != nil {
+		return false, err
+	}
+	if e.Del {
+		errCode, err := g.t.Backend(r.dst).DeleteObj(dst)
+		if err != nil && errCode != http.StatusNotFound && !cmn.IsObjNotExist(err) {
+			return false, err
+		}
+		g.statsT.Add(stats.ReplDelCount, 1)
+		r.ObjsAdd(1, 0)
+		return false, nil
+	}
+
+	lom := cluster.AllocLOM(objName)
+	defer cluster.FreeLOM(lom)
+	if err := lom.InitBck(r.Bck().Bucket()); err != nil {
+		return false, err
+	}
+	lom.Lock(false)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(false)
+		if cmn.IsObjNotExist(err) {
+			return r.notExist(lom, e)
+		}
+		return false, err
+	}
+	fh, err := cos.NewFileHandle(lom.FQN)
+	lom.Unlock(false) // (the open handle keeps reading the same content)
+	if err != nil {
+		return false, err
+	}
+	size := lom.SizeBytes()
+	dst.CopyAttrs(lom.ObjAttrs(), false /*skip-checksum*/)
+	if !r.dst.IsRemoteAIS() {
+		// set by the backend.PutObj() - compare with ais/tgtobj.go putRemote()
+		dst.ObjAttrs().DelCustomKeys(cmn.SourceObjMD, cmn.CRC32CObjMD, cmn.ETag, cmn.MD5ObjMD, cmn.VersionObjMD)
+	}
+	if _, err := g.t.Backend(r.dst).PutObj(fh, dst); err != nil {
+		return false, err
+	}
+	g.statsT.AddMany(
+		cos.NamedVal64{Name: stats.ReplCount, Value: 1},
+		cos.NamedVal64{Name: stats.ReplSize, Value: size},
+	)
+	r.ObjsAdd(1, size)
+	return false, nil
+}
+
+// the object is not here: migrated to its new HRW target (forward the change), is yet to arrive
+// (keep it queued), or deleted in the meantime (nothing to do)
+func (r *XactRepl) notExist(lom *cluster.LOM, e *entry) (bool, error) {
+	tsi, local, err := lom.HrwTarget(g.t.Sowner().Get())
+	if err != nil {
+		return true, err
+	}
+	if !local {
+		forward(r.Bck(), e, tsi)
+		return true, nil
+	}
+	return migrating(), nil
+}
+
+func migrating() bool {
+	reb, res := xreg.GetRebMarked(), xreg.GetResilverMarked()
+	return reb.Xact != nil || reb.Interrupted || res.Xact != nil || res.Interrupted
+}
+
+func (r *XactRepl) Snap() cluster.XactSnap {
+	snap := r.DemandBase.ExtSnap()
+	snap.Ext = &StatsExt{
+		BaseDemandStatsExt: *snap.Ext.(*xact.BaseDemandStatsExt),
+		Dst:                r.conf.Dst,
+		Backlog:            r.backlog.Load(),
+		Lag:                cos.Duration(r.lag.Load()),
+	}
+	return snap
+}
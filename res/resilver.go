@@ -111,10 +111,12 @@ func (res *Res) RunResilver(args Args) {
 			VisitCT:               jctx.visitCT,
 			Slab:                  slab,
 			SkipGloballyMisplaced: args.SkipGlobMisplaced,
+			Prio:                  xact.Prio(apc.ActResilver),
 		}
 	)
 	debug.AssertNoErr(err)
-	debug.Assert(args.PostDD == nil || (args.Action == apc.ActMountpathDetach || args.Action == apc.ActMountpathDisable))
+	debug.Assert(args.PostDD == nil || (args.Action == apc.ActMountpathDetach ||
+		args.Action == apc.ActMountpathDisable || args.Action == apc.ActMountpathDrain))
 
 	if args.SingleRmiJogger {
 		jg = mpather.NewJoggerGroup(opts, args.Rmi.Path)
@@ -245,6 +247,12 @@ func (jg *joggerCtx) visitObj(lom *cluster.LOM, buf []byte) (errHrw error) {
 		lom.Unlock(true)
 		if copied && errHrw == nil {
 			jg.xres.ObjsAdd(1, size)
+			mi := lom.MpathInfo()
+			if mi.IsAnySet(fs.FlagBeingDrained) {
+				mi.AddDrained(size)
+			}
+			// take from the budget upon (and not while holding the lock for) copying
+			errHrw = jg.xres.Reserve(mi, size)
 		}
 	}()
 
@@ -0,0 +1,273 @@
+// Package scrub verifies stored objects and their local copies against their respective checksums,
+// repairs corrupted content from copies, EC slices, or remote backend, and quarantines what cannot be repaired.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package scrub
+
+import (
+	"context"
+	"os"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+func (r *XactScrub) visitObj(lom *cluster.LOM, buf []byte) error {
+	if !lom.IsHRW() {
+		return nil // copies are checked along with their respective objects; misplaced - see space cleanup
+	}
+	var (
+		rep             = r.cur
+		errObj          error
+		corrupted, gone []string
+		orphans, repair bool
+	)
+	rep.checked.Inc()
+	// charge each mountpath for the copy it's going to read (before taking the lock)
+	if err := r.Reserve(lom.MpathInfo(), lom.SizeBytes()); err != nil {
+		return err
+	}
+	for copyFQN, mi := range lom.GetCopies() {
+		if copyFQN == lom.FQN {
+			continue
+		}
+		if err := r.Reserve(mi, lom.SizeBytes()); err != nil {
+			return err
+		}
+	}
+
+	lom.Lock(false)
+	if errObj = lom.ValidateContentChecksum(); errObj != nil && !cos.IsErrBadCksum(errObj) {
+		lom.Unlock(false)
+		rep.errs.Inc()
+		glog.Errorf("%s: failed to validate %s: %v", r, lom, errObj)
+		r.failed(lom.MpathInfo(), lom.FQN, errObj)
+		return nil
+	}
+	for copyFQN, mi := range lom.GetCopies() {
+		if copyFQN == lom.FQN {
+			continue
+		}
+		rep.copiesChecked.Inc()
+		switch err := validateCopy(lom, copyFQN); {
+		case err == nil:
+		case os.IsNotExist(err):
+			rep.copiesMissing.Inc()
+			gone = append(gone, copyFQN)
+		case cos.IsErrBadCksum(err):
+			rep.copiesCorrupted.Inc()
+			corrupted = append(corrupted, copyFQN)
+			r.failed(mi, copyFQN, err)
+		default:
+			rep.errs.Inc()
+			glog.Errorf("%s: failed to validate %s copy %s: %v", r, lom, copyFQN, err)
+			r.failed(mi, copyFQN, err)
+		}
+	}
+	if lom.MirrorConf().Enabled {
+		orphans = hasOrphans(lom)
+	}
+	lom.Unlock(false)
+	r.ObjsAdd(1, lom.SizeBytes())
+
+	if errObj == nil && len(corrupted) == 0 && len(gone) == 0 && !orphans {
+		return nil
+	}
+	if errObj != nil {
+		rep.corrupted.Inc()
+		glog.Error(errObj)
+		r.failed(lom.MpathInfo(), lom.FQN, errObj)
+		repair = true
+	}
+
+	lom.Lock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(true) // removed in the meantime
+		return nil
+	}
+	r.fixCopies(lom, corrupted, gone, orphans)
+	if repair && lom.ValidateContentChecksum() == nil {
+		repair = false // overwritten in the meantime
+	}
+	if !repair {
+		if len(corrupted) > 0 || len(gone) > 0 {
+			r.recopy(lom, buf)
+		}
+		lom.Unlock(true)
+		return nil
+	}
+	r.repairObj(lom, buf) // (unlocks)
+	return nil
+}
+
+// quarantine corrupted copies and forget missing ones; remove orphans
+// (under w-lock)
+func (r *XactScrub) fixCopies(lom *cluster.LOM, corrupted, gone []string, orphans bool) {
+	copies := lom.GetCopies()
+	for _, copyFQN := range corrupted {
+		if mi, ok := copies[copyFQN]; ok {
+			if err := quarantine(lom, copyFQN, mi); err != nil {
+				glog.Errorf("%s: failed to quarantine %s copy %s: %v", r, lom, copyFQN, err)
+			}
+		}
+	}
+	if dels := append(corrupted, gone...); len(dels) > 0 {
+		if err := lom.DelCopies(dels...); err != nil {
+			glog.Errorf("%s: %s: %v", r, lom, err)
+		}
+		if err := lom.Persist(); err != nil {
+			glog.Errorf("%s: %s: %v", r, lom, err)
+		}
+	}
+	if orphans {
+		if _, err := lom.DelExtraCopies(); err != nil {
+			glog.Errorf("%s: failed to remove orphaned copies of %s: %v", r, lom, err)
+		}
+	}
+}
+
+// re-create copies as per bucket's mirroring configuration (under w-lock)
+func (r *XactScrub) recopy(lom *cluster.LOM, buf []byte) {
+	mirror := lom.MirrorConf()
+	if !mirror.Enabled {
+		r.cur.repaired.Inc()
+		return
+	}
+	for lom.NumCopies() < int(mirror.Copies) {
+		mi := lom.LeastUtilNoCopy()
+		if mi == nil {
+			break // not enough mountpaths
+		}
+		if err := lom.Copy(mi, buf); err != nil {
+			r.cur.errs.Inc()
+			glog.Errorf("%s: failed to re-create %s copy at %s: %v", r, lom, mi, err)
+			return
+		}
+	}
+	r.cur.repaired.Inc()
+}
+
+// quarantine the (corrupted) object and restore it from an intact copy, EC slices,
+// or remote backend; the object remains quarantined if none of the above works
+// (called under w-lock, unlocks)
+func (r *XactScrub) repairObj(lom *cluster.LOM, buf []byte) {
+	rep := r.cur
+	if err := quarantine(lom, lom.FQN, lom.MpathInfo()); err != nil {
+		lom.Unlock(true)
+		rep.errs.Inc()
+		glog.Errorf("%s: failed to quarantine %s: %v", r, lom, err)
+		return
+	}
+	lom.Uncache(true /*delDirty*/)
+
+	// 1. local copy
+	for copyFQN := range lom.GetCopies() {
+		if copyFQN == lom.FQN {
+			continue
+		}
+		if restoreFromCopy(lom, copyFQN, buf) {
+			lom.Unlock(true)
+			rep.repaired.Inc()
+			glog.Warningf("%s: restored corrupted %s from local copy", r, lom)
+			return
+		}
+	}
+	lom.Unlock(true)
+
+	// 2. EC
+	if lom.ECEnabled() {
+		if err := ec.ECM.RestoreObject(lom); err == nil {
+			rep.repaired.Inc()
+			glog.Warningf("%s: restored corrupted %s from EC slices", r, lom)
+			return
+		}
+	}
+	// 3. remote backend
+	if lom.Bck().IsRemote() {
+		if _, err := r.t.GetCold(context.Background(), lom, cmn.OwtGetLock); err == nil {
+			rep.repaired.Inc()
+			glog.Warningf("%s: restored corrupted %s from %s", r, lom, lom.Bck())
+			return
+		}
+	}
+
+	lom.Lock(true)
+	if err := lom.Remove(); err != nil { // remaining copies, if any
+		glog.Errorf("%s: %s: %v", r, lom, err)
+	}
+	lom.Unlock(true)
+	r.addQuarantined(rep, lom)
+	glog.Errorf("%s: failed to repair %s - quarantined", r, lom)
+}
+
+//
+// helpers
+//
+
+// validate a copy against its own (persisted) metadata
+func validateCopy(lom *cluster.LOM, copyFQN string) error {
+	if lom.Checksum().IsEmpty() {
+		return nil // nothing to validate against
+	}
+	if err := cos.Stat(copyFQN); err != nil {
+		return err
+	}
+	cplom := cluster.AllocLOM(lom.ObjName)
+	defer cluster.FreeLOM(cplom)
+	if err := cplom.InitFQN(copyFQN, lom.Bucket()); err != nil {
+		return err
+	}
+	if err := cplom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return err
+	}
+	return cplom.ValidateContentChecksum()
+}
+
+// on-disk copies that are not listed in the object's metadata
+// (e.g., failed to persist metadata on a copy - see lom.syncMetaWithCopies)
+func hasOrphans(lom *cluster.LOM) bool {
+	copies := lom.GetCopies()
+	for _, mi := range fs.GetAvail() {
+		fqn := mi.MakePathFQN(lom.Bucket(), fs.ObjectType, lom.ObjName)
+		if fqn == lom.FQN {
+			continue
+		}
+		if _, ok := copies[fqn]; ok {
+			continue
+		}
+		if cos.Stat(fqn) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func restoreFromCopy(lom *cluster.LOM, copyFQN string, buf []byte) bool {
+	if validateCopy(lom, copyFQN) != nil {
+		return false
+	}
+	src := cluster.AllocLOM(lom.ObjName)
+	defer cluster.FreeLOM(src)
+	if err := src.InitFQN(copyFQN, lom.Bucket()); err != nil {
+		return false
+	}
+	if err := src.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return false
+	}
+	dst, err := src.Copy2FQN(lom.FQN, buf)
+	if err != nil {
+		return false
+	}
+	cluster.FreeLOM(dst)
+	return lom.Load(true /*cache it*/, true /*locked*/) == nil
+}
+
+// move corrupted content out of the way, keeping it on the same mountpath for inspection
+func quarantine(lom *cluster.LOM, fqn string, mi *fs.MountpathInfo) error {
+	return cos.Rename(fqn, mi.MakePathFQN(lom.Bucket(), fs.QuarantineType, lom.ObjName))
+}
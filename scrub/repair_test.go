@@ -0,0 +1,179 @@
+// Package scrub verifies stored objects and their local copies against their respective checksums,
+// repairs corrupted content from copies, EC slices, or remote backend, and quarantines what cannot be repaired.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package scrub
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cluster/mock"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scrub", func() {
+	const (
+		testDir = "/tmp/scrub-test_q/"
+
+		testBucketName = "TEST_SCRUB_BUCKET"
+		mpath          = testDir + "scrubtest_mpath/111"
+		mpath2         = testDir + "scrubtest_mpath/222"
+
+		testObjectName = "scrubtestobj.ext"
+		testObjectSize = 1234
+	)
+
+	_ = cos.CreateDir(mpath)
+	_ = cos.CreateDir(mpath2)
+
+	config := cmn.GCO.BeginUpdate()
+	config.TestFSP.Count = 1
+	cmn.GCO.CommitUpdate(config)
+
+	fs.TestNew(nil)
+	fs.TestDisableValidation()
+	_, _ = fs.Add(mpath, "daeID")
+	_, _ = fs.Add(mpath2, "daeID")
+	_ = fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_ = fs.CSM.Reg(fs.QuarantineType, &fs.QuarantineResolver{})
+
+	var (
+		props = &cmn.BucketProps{
+			Cksum:  cmn.CksumConf{Type: cos.ChecksumXXHash},
+			Mirror: cmn.MirrorConf{Enabled: true, Copies: 2},
+			BID:    1,
+		}
+		bck     = cluster.Bck{Name: testBucketName, Provider: apc.AIS, Ns: cmn.NsGlobal, Props: props}
+		bmdMock = mock.NewBaseBownerMock(&bck)
+		mi      = fs.MountpathInfo{Path: mpath}
+		mi2     = fs.MountpathInfo{Path: mpath2}
+		buf     = make([]byte, 32*cos.KiB)
+
+		objFQN, copyFQN, qtObjFQN, qtCopyFQN string
+
+		xctn       *XactScrub
+		tMock      cluster.Target
+		newXaction = func() *XactScrub {
+			r := &XactScrub{t: tMock, reps: map[string]*report{}, fshc: map[string]int{}}
+			r.InitBase(cos.GenUUID(), apc.ActScrub, nil)
+			r.cur = &report{}
+			return r
+		}
+	)
+
+	BeforeEach(func() {
+		_ = cos.CreateDir(mpath)
+		_ = cos.CreateDir(mpath2)
+		tMock = mock.NewTarget(bmdMock)
+		xctn = newXaction()
+
+		// HRW mountpath for the object, the other one - for its copy
+		hrw, other := &mi, &mi2
+		if lom := tools.NewBasicLom(mi.MakePathFQN(bck.Bucket(), fs.ObjectType, testObjectName)); !lom.IsHRW() {
+			hrw, other = other, hrw
+		}
+		objFQN = hrw.MakePathFQN(bck.Bucket(), fs.ObjectType, testObjectName)
+		copyFQN = other.MakePathFQN(bck.Bucket(), fs.ObjectType, testObjectName)
+		qtObjFQN = hrw.MakePathFQN(bck.Bucket(), fs.QuarantineType, testObjectName)
+		qtCopyFQN = other.MakePathFQN(bck.Bucket(), fs.QuarantineType, testObjectName)
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(testDir)
+	})
+
+	createObj := func(withCopy bool) *cluster.LOM {
+		tools.CreateTestFile(filepath.Dir(objFQN), testObjectName, testObjectSize)
+		lom := tools.NewBasicLom(objFQN)
+		Expect(lom.IsHRW()).To(BeTrue())
+		lom.SetSize(testObjectSize)
+		lom.SetAtimeUnix(time.Now().UnixNano())
+		_, err := lom.ComputeSetCksum()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lom.Persist()).NotTo(HaveOccurred())
+		if withCopy {
+			lom.Lock(true)
+			clone, err := lom.Copy2FQN(copyFQN, nil)
+			lom.Unlock(true)
+			Expect(err).NotTo(HaveOccurred())
+			cluster.FreeLOM(clone)
+		}
+		return tools.NewBasicLom(objFQN)
+	}
+
+	Describe("visitObj", func() {
+		It("should leave intact object and copy alone", func() {
+			lom := createObj(true)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(xctn.visitObj(lom, buf)).NotTo(HaveOccurred())
+
+			rep := xctn.cur.ext()
+			Expect(rep.Checked).To(BeEquivalentTo(1))
+			Expect(rep.CopiesChecked).To(BeEquivalentTo(1))
+			Expect(rep.Corrupted + rep.CopiesCorrupted + rep.Repaired + rep.Quarantined).To(BeZero())
+			Expect(qtObjFQN).NotTo(BeAnExistingFile())
+			Expect(qtCopyFQN).NotTo(BeAnExistingFile())
+		})
+
+		It("should quarantine and re-create corrupted copy", func() {
+			lom := createObj(true)
+			tools.CorruptFile(copyFQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(xctn.visitObj(lom, buf)).NotTo(HaveOccurred())
+
+			rep := xctn.cur.ext()
+			Expect(rep.CopiesCorrupted).To(BeEquivalentTo(1))
+			Expect(rep.Repaired).To(BeEquivalentTo(1))
+			Expect(rep.Corrupted).To(BeZero())
+			Expect(qtCopyFQN).To(BeARegularFile())
+
+			lom = tools.NewBasicLom(objFQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(lom.GetCopies()).To(HaveKey(copyFQN))
+			Expect(validateCopy(lom, copyFQN)).NotTo(HaveOccurred())
+		})
+
+		It("should quarantine corrupted object and restore it from copy", func() {
+			lom := createObj(true)
+			tools.CorruptFile(objFQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(xctn.visitObj(lom, buf)).NotTo(HaveOccurred())
+
+			rep := xctn.cur.ext()
+			Expect(rep.Corrupted).To(BeEquivalentTo(1))
+			Expect(rep.Repaired).To(BeEquivalentTo(1))
+			Expect(rep.Quarantined).To(BeZero())
+			Expect(qtObjFQN).To(BeARegularFile())
+
+			lom = tools.NewBasicLom(objFQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+		})
+
+		It("should quarantine corrupted object that cannot be repaired", func() {
+			lom := createObj(false)
+			tools.CorruptFile(objFQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(xctn.visitObj(lom, buf)).NotTo(HaveOccurred())
+
+			rep := xctn.cur.ext()
+			Expect(rep.Corrupted).To(BeEquivalentTo(1))
+			Expect(rep.Repaired).To(BeZero())
+			Expect(rep.Quarantined).To(BeEquivalentTo(1))
+			Expect(rep.QuarantinedObjs).To(ConsistOf(testObjectName))
+			Expect(objFQN).NotTo(BeAnExistingFile())
+			Expect(qtObjFQN).To(BeARegularFile())
+		})
+	})
+})
@@ -0,0 +1,18 @@
+// Package scrub verifies stored objects and their local copies against their respective checksums,
+// repairs corrupted content from copies, EC slices, or remote backend, and quarantines what cannot be repaired.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package scrub
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestScrub(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, t.Name())
+}
@@ -0,0 +1,231 @@
+// Package scrub verifies stored objects and their local copies against their respective checksums,
+// repairs corrupted content from copies, EC slices, or remote backend, and quarantines what cannot be repaired.
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package scrub
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Scrub is a low-priority (fs.PrioBackground) xaction that walks the specified buckets
+// (or all buckets) one bucket at a time and rereads every object and every local copy:
+// - corrupted copies get quarantined and re-created from the (intact) object;
+// - a corrupted object gets quarantined and restored from an intact copy, EC slices,
+//   or remote backend - in that order;
+// - otherwise, the object (that cannot be repaired) remains quarantined - see fs.QuarantineType;
+// - copies that are not listed in the object's metadata (orphans) get removed;
+// - repeated corruptions and read errors on a given mountpath trigger FSHC.
+// Findings are reported per bucket - see apc.ScrubReport.
+
+// number of corrupted or unreadable objects (and copies) on a given mountpath to trigger FSHC
+const fshcThreshold = 3
+
+type (
+	factory struct {
+		xreg.RenewBase
+		xctn *XactScrub
+	}
+	XactScrub struct {
+		xact.Base
+		t    cluster.Target
+		bcks []cmn.Bck // empty - all buckets
+		cur  *report   // bucket that is being scrubbed
+		mu   sync.Mutex
+		reps map[string]*report // bucket => report
+		fshc map[string]int     // mountpath => number of failures (since the last FSHC call)
+	}
+	report struct {
+		checked         atomic.Int64
+		copiesChecked   atomic.Int64
+		corrupted       atomic.Int64
+		copiesCorrupted atomic.Int64
+		copiesMissing   atomic.Int64
+		repaired        atomic.Int64
+		quarantined     atomic.Int64
+		errs            atomic.Int64
+		qobjs           []string // quarantined (up to apc.ScrubMaxQuarantined), under XactScrub.mu
+	}
+)
+
+// interface guard
+var (
+	_ cluster.Xact   = (*XactScrub)(nil)
+	_ xreg.Renewable = (*factory)(nil)
+)
+
+func Xreg() { xreg.RegNonBckXact(&factory{}) }
+
+/////////////
+// factory //
+/////////////
+
+func (*factory) New(args xreg.Args, _ *cluster.Bck) xreg.Renewable {
+	return &factory{RenewBase: xreg.RenewBase{Args: args}}
+}
+
+func (p *factory) Start() error {
+	p.xctn = &XactScrub{
+		t:    p.T,
+		reps: make(map[string]*report, 4),
+		fshc: make(map[string]int, 4),
+	}
+	if p.Custom != nil {
+		p.xctn.bcks = p.Custom.([]cmn.Bck)
+	}
+	p.xctn.InitBase(p.UUID(), apc.ActScrub, nil)
+	return nil
+}
+
+func (*factory) Kind() string        { return apc.ActScrub }
+func (p *factory) Get() cluster.Xact { return p.xctn }
+
+func (*factory) WhenPrevIsRunning(prevEntry xreg.Renewable) (wpr xreg.WPR, err error) {
+	return xreg.WprUse, cmn.NewErrUsePrevXaction(prevEntry.Get().String())
+}
+
+///////////////
+// XactScrub //
+///////////////
+
+func (r *XactScrub) Run(wg *sync.WaitGroup) {
+	wg.Done()
+	var (
+		err  error
+		bcks = r.buckets()
+	)
+	glog.Infof("%s: %s, num buckets %d", r.t, r, len(bcks))
+	for i := range bcks {
+		if err = r.scrubBck(&bcks[i]); err != nil {
+			break
+		}
+	}
+	r.mu.Lock()
+	for name, rep := range r.reps {
+		glog.Infof("%s: %s %s: %+v", r.t, r, name, *rep.ext())
+	}
+	r.mu.Unlock()
+	r.Finish(err)
+}
+
+func (r *XactScrub) buckets() (bcks []cmn.Bck) {
+	if len(r.bcks) > 0 {
+		return r.bcks
+	}
+	bmd := r.t.Bowner().Get()
+	bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+		bcks = append(bcks, *bck.Bucket())
+		return false
+	})
+	return
+}
+
+func (r *XactScrub) scrubBck(bck *cmn.Bck) error {
+	b := cluster.CloneBck(bck)
+	if err := b.Init(r.t.Bowner()); err != nil {
+		glog.Warningf("%s: skipping %s: %v", r, bck, err) // e.g., destroyed in the meantime
+		return nil
+	}
+	slab, err := r.t.PageMM().GetSlab(memsys.MaxPageSlabSize)
+	debug.AssertNoErr(err)
+
+	r.mu.Lock()
+	r.cur = &report{}
+	r.reps[b.String()] = r.cur
+	r.mu.Unlock()
+
+	opts := &mpather.JoggerGroupOpts{
+		T:        r.t,
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.visitObj,
+		Slab:     slab,
+		DoLoad:   mpather.Load,
+		Prio:     xact.Prio(apc.ActScrub),
+	}
+	opts.Bck.Copy(b.Bucket())
+	jg := mpather.NewJoggerGroup(opts)
+	jg.Run()
+	select {
+	case errCause := <-r.ChanAbort():
+		jg.Stop()
+		return cmn.NewErrAborted(r.Name(), "", errCause)
+	case <-jg.ListenFinished():
+		return jg.Stop()
+	}
+}
+
+func (r *XactScrub) Snap() cluster.XactSnap {
+	r.mu.Lock()
+	reps := make(apc.ScrubReports, len(r.reps))
+	for name, rep := range r.reps {
+		reps[name] = rep.ext()
+	}
+	r.mu.Unlock()
+	snap := &xact.SnapExt{Ext: reps}
+	r.ToSnap(&snap.Snap)
+	return snap
+}
+
+func (r *XactScrub) String() string {
+	if len(r.bcks) == 1 {
+		return fmt.Sprintf("%s[%s]", r.Base.String(), r.bcks[0])
+	}
+	return r.Base.String()
+}
+
+// repeated failures on a given mountpath trigger FSHC
+func (r *XactScrub) failed(mi *fs.MountpathInfo, fqn string, err error) {
+	r.mu.Lock()
+	r.fshc[mi.Path]++
+	cnt := r.fshc[mi.Path]
+	if cnt >= fshcThreshold {
+		r.fshc[mi.Path] = 0
+	}
+	r.mu.Unlock()
+	if cnt >= fshcThreshold {
+		glog.Errorf("%s: %s: %d failures (last: %v) - checking the filesystem", r, mi, cnt, err)
+		r.t.CheckFS(fqn)
+	}
+}
+
+func (r *XactScrub) addQuarantined(rep *report, lom *cluster.LOM) {
+	rep.quarantined.Inc()
+	r.mu.Lock()
+	if len(rep.qobjs) < apc.ScrubMaxQuarantined {
+		rep.qobjs = append(rep.qobjs, lom.ObjName)
+	}
+	r.mu.Unlock()
+}
+
+////////////
+// report //
+////////////
+
+// (under XactScrub.mu)
+func (rep *report) ext() *apc.ScrubReport {
+	return &apc.ScrubReport{
+		Checked:         rep.checked.Load(),
+		CopiesChecked:   rep.copiesChecked.Load(),
+		Corrupted:       rep.corrupted.Load(),
+		CopiesCorrupted: rep.copiesCorrupted.Load(),
+		CopiesMissing:   rep.copiesMissing.Load(),
+		Repaired:        rep.repaired.Load(),
+		Quarantined:     rep.quarantined.Load(),
+		Errs:            rep.errs.Load(),
+		QuarantinedObjs: append([]string(nil), rep.qobjs...),
+	}
+}
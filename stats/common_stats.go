@@ -117,8 +117,10 @@ type (
 
 		StartedUp() bool
 		AddErrorHTTP(method string, val int64)
+		Set(name string, val int64) // KindGauge
 		CoreStats() *CoreStats
 		GetWhatStats() *DaemonStats
+		GetMetricNames() cos.StrKVs // metric name => kind
 		RegMetrics(node *cluster.Snode)
 		IsPrometheus() bool
 	}
@@ -132,6 +134,7 @@ type (
 	}
 
 	RebalanceSnap struct {
+		Ext *xact.RebalanceStatsExt `json:"ext,omitempty"`
 		xact.Snap
 		RebID int64 `json:"glob.id,string"`
 	}
@@ -436,6 +439,15 @@ func (s *CoreStats) doAdd(name, nameSuffix string, val int64) {
 	}
 }
 
+// gauges are set rather than added
+func (s *CoreStats) set(name string, val int64) {
+	v, ok := s.Tracker[name]
+	debug.Assertf(ok && v.kind == KindGauge, "invalid gauge %q", name)
+	v.Lock()
+	v.Value = val
+	v.Unlock()
+}
+
 func (s *CoreStats) copyT(ctracker copyTracker, diskLowUtil int64) bool {
 	idle := true
 	s.sgl.Reset()
@@ -659,6 +671,14 @@ func (r *statsRunner) GetWhatStats() *DaemonStats {
 	return &DaemonStats{Tracker: ctracker}
 }
 
+func (r *statsRunner) GetMetricNames() cos.StrKVs {
+	out := make(cos.StrKVs, len(r.Core.Tracker))
+	for name, v := range r.Core.Tracker {
+		out[name] = v.kind
+	}
+	return out
+}
+
 func (r *statsRunner) IsPrometheus() bool { return r.Core.isPrometheus() }
 
 func (r *statsRunner) Describe(ch chan<- *prometheus.Desc) {
@@ -862,6 +882,8 @@ func (r *statsRunner) AddMany(nvs ...cos.NamedVal64) {
 	}
 }
 
+func (r *statsRunner) Set(name string, val int64) { r.Core.set(name, val) }
+
 func recycleLogs() time.Duration {
 	// keep total log size below the configured max
 	go removeLogs(cmn.GCO.Get())
@@ -72,6 +72,19 @@ const (
 	// Downloader
 	DownloadSize = "dl.size"
 
+	// Replication (see cmn.ReplConf)
+	ReplCount    = "repl.n"
+	ReplSize     = "repl.size"
+	ReplDelCount = "repl.del.n"
+	ErrReplCount = "err.repl.n"
+
+	// KindGauge
+	ReplBacklog = "repl.backlog.n" // number of changes queued for replication
+	ReplLag     = "repl.lag.ns"    // age of the oldest queued change
+
+	DegradedMpathCount = "mpath.degraded.n" // number of degraded mountpaths (see health.FSHC)
+	MemPressure        = "mem.pressure"     // memsys.PressureLow ... memsys.OOM
+
 	// KindThroughput
 	GetThroughput = "get.bps" // bytes per second
 )
@@ -203,6 +216,17 @@ func (r *Trunner) RegMetrics(node *cluster.Snode) {
 	r.reg(DownloadSize, KindCounter)
 	r.reg(DownloadLatency, KindLatency)
 
+	// replication
+	r.reg(ReplCount, KindCounter)
+	r.reg(ReplSize, KindCounter)
+	r.reg(ReplDelCount, KindCounter)
+	r.reg(ErrReplCount, KindCounter)
+	r.reg(ReplBacklog, KindGauge)
+	r.reg(ReplLag, KindGauge)
+
+	r.reg(DegradedMpathCount, KindGauge)
+	r.reg(MemPressure, KindGauge)
+
 	// dsort
 	r.reg(DSortCreationReqCount, KindCounter)
 	r.reg(DSortCreationReqLatency, KindLatency)
@@ -273,9 +297,11 @@ func (r *Trunner) log(now int64, uptime time.Duration, config *cmn.Config) {
 	// 5. memory pressure
 	_ = r.mem.Get()
 	mm := r.t.PageMM()
-	if p := mm.Pressure(&r.mem); p >= memsys.PressureHigh {
+	p := mm.Pressure(&r.mem)
+	if p >= memsys.PressureHigh {
 		r.lines = append(r.lines, mm.Str(&r.mem))
 	}
+	s.set(MemPressure, int64(p))
 
 	// 6. running xactions
 	if !idle {
@@ -0,0 +1,64 @@
+// Package tools provides common tools and utilities for all unit and integration tests
+/*
+ * Copyright (c) 2023, NVIDIA CORPORATION. All rights reserved.
+ */
+package tools
+
+import (
+	"os"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/readers"
+	"github.com/onsi/gomega"
+)
+
+// local-filesystem fixtures for (ginkgo) unit tests that operate on LOMs and CTs
+
+// InitTestFS creates and adds the mountpaths, and registers all the content types
+// (objects, workfiles, EC slices and metafiles)
+func InitTestFS(mpaths ...string) {
+	config := cmn.GCO.BeginUpdate()
+	config.TestFSP.Count = 1
+	cmn.GCO.CommitUpdate(config)
+
+	fs.TestNew(nil)
+	fs.TestDisableValidation()
+	for _, mpath := range mpaths {
+		_ = cos.CreateDir(mpath)
+		_, _ = fs.Add(mpath, "daeID")
+	}
+	_ = fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_ = fs.CSM.Reg(fs.ECSliceType, &fs.ECSliceContentResolver{})
+	_ = fs.CSM.Reg(fs.ECMetaType, &fs.ECMetaContentResolver{})
+}
+
+// CreateTestFile creates the directory (if need be) and a file of random content of the given size
+func CreateTestFile(dir, objName string, size int64) {
+	err := cos.CreateDir(dir)
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	r, err := readers.NewFileReader(dir, objName, size, cos.ChecksumNone)
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	gomega.Expect(r.Close()).ShouldNot(gomega.HaveOccurred())
+}
+
+func NewBasicLom(fqn string) *cluster.LOM {
+	lom := &cluster.LOM{}
+	err := lom.InitFQN(fqn, nil)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	lom.Uncache(false)
+	return lom
+}
+
+// CorruptFile overwrites the content in place (same size, same xattrs)
+func CorruptFile(fqn string) {
+	f, err := os.OpenFile(fqn, os.O_WRONLY, 0)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	_, err = f.WriteAt([]byte("corrupted"), 0)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	gomega.Expect(f.Close()).NotTo(gomega.HaveOccurred())
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/transport"
+	"github.com/NVIDIA/aistore/xact"
 )
 
 type (
@@ -139,6 +140,7 @@ func (dm *DataMover) Open() {
 	}
 	if dm.xctn != nil {
 		dataArgs.Extra.SenderID = dm.xctn.ID()
+		dataArgs.Prio, dataArgs.Throttle = xact.Prio(dm.xctn.Kind()), true
 	}
 	dm.data.streams = NewStreams(dm.t.Sowner(), dm.t.Snode(), dm.data.client, dataArgs)
 	if dm.useACKs() {
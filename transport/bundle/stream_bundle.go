@@ -8,6 +8,7 @@ package bundle
 import (
 	"fmt"
 	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
@@ -16,6 +17,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/transport"
 )
 
@@ -36,9 +38,12 @@ type (
 		streams      atomic.Pointer // points to bundle (below)
 		extra        transport.Extra
 		lid          string
-		rxNodeType   int // receiving nodes: [Targets, ..., AllNodes ] enum above
-		multiplier   int // optionally: multiple streams per destination (round-robin)
+		rxNodeType   int  // receiving nodes: [Targets, ..., AllNodes ] enum above
+		multiplier   int  // optionally: multiple streams per destination (round-robin)
+		prio         int  // priority class of the sender (see fs.MountpathInfo.Reserve)
+		throttle     bool // take from the mountpath budgets (see reserve)
 		manualResync bool
+		stopCh       cos.StopCh // to abort waiting on the budget
 	}
 	Stats map[string]*transport.Stats // by DaemonID
 	//
@@ -57,6 +62,8 @@ type (
 		Extra        *transport.Extra // additional parameters
 		Ntype        int              // cluster.Target (0) by default
 		Multiplier   int              // so-many TCP connections per Rx endpoint, with round-robin
+		Prio         int              // fs.PrioNormal (default), etc. - see Throttle
+		Throttle     bool             // true: take the sent files from their mountpaths' budgets (see fs.MountpathInfo.Reserve)
 		ManualResync bool             // auto-resync by default
 	}
 )
@@ -96,8 +103,11 @@ func NewStreams(sowner cluster.Sowner, lsnode *cluster.Snode, cl transport.Clien
 		trname:       sbArgs.Trname,
 		rxNodeType:   sbArgs.Ntype,
 		multiplier:   sbArgs.Multiplier,
+		prio:         sbArgs.Prio,
+		throttle:     sbArgs.Throttle,
 		manualResync: sbArgs.ManualResync,
 	}
+	sb.stopCh.Init()
 	if sbArgs.Extra != nil {
 		sb.extra = *sbArgs.Extra
 	}
@@ -127,6 +137,7 @@ func NewStreams(sowner cluster.Sowner, lsnode *cluster.Snode, cl transport.Clien
 // Close closes all contained streams and unregisters the bundle from Smap listeners;
 // graceful=true blocks until all pending objects get completed (for "completion", see transport/README.md)
 func (sb *Streams) Close(gracefully bool) {
+	sb.stopCh.Close()
 	if gracefully {
 		sb.apply(closeFin)
 	} else {
@@ -162,6 +173,11 @@ func (sb *Streams) Send(obj *transport.Obj, roc cos.ReadOpenCloser, nodes ...*cl
 	}
 	if obj.IsHeaderOnly() {
 		roc = nil
+	} else if sb.throttle {
+		if err = sb.reserve(obj, roc); err != nil {
+			_doCmpl(obj, roc, err)
+			return
+		}
 	}
 
 	if nodes == nil {
@@ -248,6 +264,35 @@ func (sb *Streams) get() (bun bundle) {
 	return
 }
 
+// take the size of the file being sent from its mountpath's budget and wait if need be;
+// data that is not read from a local file (e.g., EC slices in memory) is not charged
+func (sb *Streams) reserve(obj *transport.Obj, roc cos.ReadOpenCloser) error {
+	fh, ok := roc.(interface{ FQN() string })
+	if !ok {
+		return nil
+	}
+	size := obj.Size()
+	if size <= 0 {
+		return nil
+	}
+	mi, _, err := fs.FQN2Mpath(fh.FQN())
+	if err != nil {
+		return nil // (not a mountpath)
+	}
+	d := mi.Reserve(sb.prio, size)
+	if d == 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-sb.stopCh.Listen():
+		return cmn.NewErrAborted(sb.String(), "reserve", nil)
+	}
+}
+
 // one obj, one stream
 func (sb *Streams) sendOne(obj *transport.Obj, roc cos.ReadOpenCloser, robin *robin, idx, cnt int) error {
 	obj.Hdr.SID = sb.lsnode.ID()
@@ -277,6 +322,7 @@ snd:
 }
 
 func (sb *Streams) Abort() {
+	sb.stopCh.Close()
 	streams := sb.get()
 	for _, robin := range streams {
 		for _, s := range robin.stsdest {
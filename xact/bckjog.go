@@ -19,6 +19,7 @@ type BckJog struct {
 func (r *BckJog) Init(id, kind string, bck *cluster.Bck, opts *mpather.JoggerGroupOpts) {
 	r.t = opts.T
 	r.InitBase(id, kind, bck)
+	opts.Prio = Prio(kind)
 	r.joggers = mpather.NewJoggerGroup(opts)
 }
 
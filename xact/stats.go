@@ -6,6 +6,8 @@ package xact
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/cluster"
@@ -38,6 +40,24 @@ type (
 	BaseDemandStatsExt struct {
 		IsIdle bool `json:"is_idle"`
 	}
+	// data provider's own stats, if available (see `cluster.DPStats`)
+	DPStatsExt struct {
+		DP any `json:"dp"`
+	}
+	// global rebalance: non-empty when paused (see `RebalanceConf`)
+	RebalanceStatsExt struct {
+		Paused string `json:"paused,omitempty"`
+	}
+	// ditto, on-demand xactions
+	DemandDPStatsExt struct {
+		BaseDemandStatsExt
+		DP any `json:"dp"`
+	}
+	// accumulates per-stage stats of the data providers that are done, by chain (see `cluster.DPStats`)
+	DPStatsAcc struct {
+		m  map[string][]cluster.DPStageStats
+		mu sync.Mutex
+	}
 
 	// NOTE: see closely related `api.XactReqArgs` and comments
 	// TODO: apc package, here and elsewhere
@@ -85,6 +105,62 @@ func (b *SnapExt) Idle() bool {
 	return !b.Running()
 }
 
+////////////////
+// DPStatsAcc //
+////////////////
+
+func (acc *DPStatsAcc) Add(stats []cluster.DPStageStats) {
+	if len(stats) == 0 {
+		return
+	}
+	acc.mu.Lock()
+	if acc.m == nil {
+		acc.m = make(map[string][]cluster.DPStageStats, 2)
+	}
+	chain := stats[0].Chain
+	acc.m[chain] = addStages(acc.m[chain], stats)
+	acc.mu.Unlock()
+}
+
+// returns accumulated stats combined with `inflight` (data providers that are still at work)
+func (acc *DPStatsAcc) Get(inflight ...[]cluster.DPStageStats) (all []cluster.DPStageStats) {
+	acc.mu.Lock()
+	m := make(map[string][]cluster.DPStageStats, len(acc.m)+len(inflight))
+	for chain, stats := range acc.m {
+		m[chain] = addStages(nil, stats)
+	}
+	acc.mu.Unlock()
+	for _, stats := range inflight {
+		if len(stats) > 0 {
+			chain := stats[0].Chain
+			m[chain] = addStages(m[chain], stats)
+		}
+	}
+	chains := make([]string, 0, len(m))
+	for chain := range m {
+		chains = append(chains, chain)
+	}
+	sort.Strings(chains)
+	for _, chain := range chains {
+		all = append(all, m[chain]...)
+	}
+	return
+}
+
+// (same chain - same stages)
+func addStages(acc, stats []cluster.DPStageStats) []cluster.DPStageStats {
+	if acc == nil {
+		return append(make([]cluster.DPStageStats, 0, len(stats)), stats...)
+	}
+	for i := range stats {
+		acc[i].Objs += stats[i].Objs
+		acc[i].InBytes += stats[i].InBytes
+		acc[i].OutBytes += stats[i].OutBytes
+		acc[i].Errs += stats[i].Errs
+	}
+	return acc
+}
+
 //////////////
 // QueryMsg //
 //////////////
@@ -9,6 +9,7 @@ import (
 	"sort"
 
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/fs"
 )
 
 const (
@@ -33,6 +34,7 @@ type (
 		Owned       bool            // (for definition, see ais/ic.go)
 		RefreshCap  bool            // refresh capacity stats upon completion
 		Mountpath   bool            // is a mountpath-traversing ("jogger") xaction
+		Prio        int             // priority class: fs.PrioNormal (default), fs.PrioBackground, fs.PrioForeground
 
 		// see xreg for "limited coexistence"
 		Rebalance  bool // moves data between nodes
@@ -51,11 +53,12 @@ var Table = map[string]Descriptor{
 	apc.ActElection:  {DisplayName: "elect-primary", Scope: ScopeG, Startable: false},
 	apc.ActRebalance: {Scope: ScopeG, Startable: true, Metasync: true, Owned: false, Mountpath: true, Rebalance: true},
 	apc.ActDownload:  {Scope: ScopeG, Startable: false, Mountpath: true},
-	apc.ActETLInline: {Scope: ScopeG, Startable: false, Mountpath: false},
+	apc.ActETLInline: {Scope: ScopeG, Startable: false, Mountpath: false, Prio: fs.PrioForeground},
 
 	// (one bucket) | (all buckets)
-	apc.ActLRU:          {DisplayName: "lru-eviction", Scope: ScopeGB, Startable: true, Mountpath: true},
-	apc.ActStoreCleanup: {DisplayName: "cleanup", Scope: ScopeGB, Startable: true, Mountpath: true},
+	apc.ActLRU:          {DisplayName: "lru-eviction", Scope: ScopeGB, Startable: true, Mountpath: true, Prio: fs.PrioBackground},
+	apc.ActStoreCleanup: {DisplayName: "cleanup", Scope: ScopeGB, Startable: true, Mountpath: true, Prio: fs.PrioBackground},
+	apc.ActScrub:        {Scope: ScopeGB, Startable: true, Mountpath: true, Prio: fs.PrioBackground},
 	apc.ActSummaryBck: {
 		DisplayName: "summary",
 		Scope:       ScopeGB,
@@ -67,13 +70,14 @@ var Table = map[string]Descriptor{
 	},
 
 	// one target
-	apc.ActResilver: {Scope: ScopeT, Startable: true, Mountpath: true, Resilver: true},
+	apc.ActResilver: {Scope: ScopeT, Startable: true, Mountpath: true, Resilver: true, Prio: fs.PrioBackground},
 
 	// xactions that run on (or in) a given bucket
-	apc.ActECGet:     {Scope: ScopeB, Startable: false},
+	apc.ActECGet:     {Scope: ScopeB, Startable: false, Prio: fs.PrioForeground},
 	apc.ActECPut:     {Scope: ScopeB, Startable: false, Mountpath: true, RefreshCap: true},
-	apc.ActECRespond: {Scope: ScopeB, Startable: false},
-	apc.ActPutCopies: {Scope: ScopeB, Startable: false, Mountpath: true, RefreshCap: true},
+	apc.ActECRespond: {Scope: ScopeB, Startable: false, Prio: fs.PrioForeground},
+	apc.ActPutCopies: {Scope: ScopeB, Startable: false, Mountpath: true, RefreshCap: true, Prio: fs.PrioBackground},
+	apc.ActReplicate: {Scope: ScopeB, Startable: false},
 
 	// multi-object
 	apc.ActArchive:     {Scope: ScopeB, Startable: false, RefreshCap: true},
@@ -115,6 +119,28 @@ var Table = map[string]Descriptor{
 		RefreshCap:  true,
 		Mountpath:   true,
 		MassiveBck:  true,
+		Prio:        fs.PrioBackground,
+	},
+	apc.ActECReencode: {
+		Scope:      ScopeB,
+		Access:     apc.AccessRW,
+		Startable:  true,
+		Metasync:   true,
+		Owned:      false,
+		RefreshCap: true,
+		Mountpath:  true,
+		MassiveBck: true,
+		Prio:       fs.PrioBackground,
+	},
+	apc.ActECScrub: {
+		Scope:      ScopeB,
+		Access:     apc.AccessRW,
+		Startable:  true,
+		Metasync:   false,
+		Owned:      false,
+		Mountpath:  true,
+		MassiveBck: true,
+		Prio:       fs.PrioBackground,
 	},
 	apc.ActMakeNCopies: {
 		DisplayName: "mirror",
@@ -125,6 +151,7 @@ var Table = map[string]Descriptor{
 		Owned:       false,
 		RefreshCap:  true,
 		Mountpath:   true,
+		Prio:        fs.PrioBackground,
 	},
 	apc.ActMoveBck: {
 		DisplayName: "rename-bucket",
@@ -163,12 +190,15 @@ var Table = map[string]Descriptor{
 	apc.ActList: {Scope: ScopeB, Access: apc.AceObjLIST, Startable: false, Metasync: false, Owned: true},
 
 	// cache management, internal usage
-	apc.ActLoadLomCache:   {DisplayName: "warm-up-metadata", Scope: ScopeB, Startable: true, Mountpath: true},
+	apc.ActLoadLomCache:   {DisplayName: "warm-up-metadata", Scope: ScopeB, Startable: true, Mountpath: true, Prio: fs.PrioBackground},
 	apc.ActInvalListCache: {Scope: ScopeB, Access: apc.AceObjLIST, Startable: false},
 }
 
 func IsMountpath(kind string) bool { return Table[kind].Mountpath }
 
+// priority class of a given kind (see fs.MountpathInfo.Reserve)
+func Prio(kind string) int { return Table[kind].Prio }
+
 func IsValidKind(kind string) bool {
 	_, ok := Table[kind]
 	return ok
@@ -84,6 +84,20 @@ func (xctn *Base) Bck() *cluster.Bck               { return &xctn.bck }
 func (*Base) FromTo() (*cluster.Bck, *cluster.Bck) { return nil, nil }
 func (xctn *Base) Finished() bool                  { return xctn.eutime.Load() != 0 }
 
+// structured-logging fields (see glog.Fields)
+func (xctn *Base) LogFields() (f glog.Fields) {
+	f.Comp, f.Xid = cmn.LogCompXact, xctn.id
+	if !xctn.bck.IsEmpty() {
+		f.Bck = xctn.bck.Bucket().String()
+	}
+	return
+}
+
+func (xctn *Base) LomFields(lom *cluster.LOM) (f glog.Fields) {
+	f.Comp, f.Xid, f.Bck, f.Obj = cmn.LogCompXact, xctn.id, lom.Bucket().String(), lom.ObjName
+	return
+}
+
 func (xctn *Base) Running() (yes bool) {
 	yes = xctn.sutime.Load() != 0 && !xctn.Finished() && !xctn.IsAborted()
 	debug.Assert(!yes || xctn.ID() != "", xctn.String())
@@ -125,6 +139,20 @@ func (xctn *Base) AbortedAfter(d time.Duration) (err error) {
 	return
 }
 
+// Reserve takes `size` bytes of I/O from the mountpath's budget at the xaction's
+// priority and waits if need be (see fs.MountpathInfo.Reserve) - unless aborted.
+// To be called where (and when) the I/O in question actually takes place.
+func (xctn *Base) Reserve(mi *fs.MountpathInfo, size int64) error {
+	d := mi.Reserve(Prio(xctn.Kind()), size)
+	if d == 0 {
+		return nil
+	}
+	if err := xctn.AbortedAfter(d); err != nil {
+		return cmn.NewErrAborted(xctn.Name(), "reserve", err)
+	}
+	return nil
+}
+
 func (xctn *Base) Abort(err error) (ok bool) {
 	if xctn.Finished() || !xctn.abort.done.CAS(false, true) {
 		return
@@ -145,7 +173,7 @@ func (xctn *Base) Abort(err error) (ok bool) {
 	xctn.abort.mu.Unlock()
 
 	if xctn.Kind() != apc.ActList {
-		glog.Infof("%s aborted(%v)", xctn.Name(), err)
+		xctn.LogFields().Infof("%s aborted(%v)", xctn.Name(), err)
 	}
 	return true
 }
@@ -261,9 +289,9 @@ func (xctn *Base) Finish(err error) {
 		xctn.onFinished(err)
 		if xctn.Kind() != apc.ActList {
 			if err == nil {
-				glog.Infof("%s finished", xctn)
+				xctn.LogFields().Infof("%s finished", xctn)
 			} else {
-				glog.Warningf("%s finished w/err: %v", xctn, err)
+				xctn.LogFields().Warningf("%s finished w/err: %v", xctn, err)
 			}
 		}
 	}
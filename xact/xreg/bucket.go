@@ -24,7 +24,6 @@ type (
 	TCObjsArgs struct {
 		BckFrom *cluster.Bck
 		BckTo   *cluster.Bck
-		DP      cluster.DP
 	}
 
 	ECEncodeArgs struct {
@@ -67,6 +66,14 @@ func RenewECEncode(t cluster.Target, bck *cluster.Bck, uuid, phase string) Renew
 	return RenewBucketXact(apc.ActECEncode, bck, Args{T: t, Custom: &ECEncodeArgs{Phase: phase}, UUID: uuid})
 }
 
+func RenewECReencode(t cluster.Target, bck *cluster.Bck, uuid, phase string) RenewRes {
+	return RenewBucketXact(apc.ActECReencode, bck, Args{T: t, Custom: &ECEncodeArgs{Phase: phase}, UUID: uuid})
+}
+
+func RenewECScrub(t cluster.Target, bck *cluster.Bck, uuid string) RenewRes {
+	return RenewBucketXact(apc.ActECScrub, bck, Args{T: t, UUID: uuid})
+}
+
 func RenewMakeNCopies(t cluster.Target, uuid, tag string) {
 	var (
 		cfg      = cmn.GCO.Get()
@@ -74,7 +81,7 @@ func RenewMakeNCopies(t cluster.Target, uuid, tag string) {
 		provider = apc.AIS
 	)
 	bmd.Range(&provider, nil, func(bck *cluster.Bck) bool {
-		if bck.Props.Mirror.Enabled {
+		if bck.Props.Mirror.Local() { // (cross-node mirroring does not depend on mountpaths)
 			rns := RenewBckMakeNCopies(t, bck, uuid, tag, int(bck.Props.Mirror.Copies))
 			if rns.Err == nil && !rns.IsRunning() {
 				xact.GoRunW(rns.Entry.Get())
@@ -85,7 +92,7 @@ func RenewMakeNCopies(t cluster.Target, uuid, tag string) {
 	// TODO: remote ais
 	for name, ns := range cfg.Backend.Providers {
 		bmd.Range(&name, &ns, func(bck *cluster.Bck) bool {
-			if bck.Props.Mirror.Enabled {
+			if bck.Props.Mirror.Local() {
 				rns := RenewBckMakeNCopies(t, bck, uuid, tag, int(bck.Props.Mirror.Copies))
 				if rns.Err == nil && !rns.IsRunning() {
 					xact.GoRunW(rns.Entry.Get())
@@ -113,6 +120,10 @@ func RenewPutMirror(t cluster.Target, lom *cluster.LOM) RenewRes {
 	return RenewBucketXact(apc.ActPutCopies, lom.Bck(), Args{T: t, Custom: lom})
 }
 
+func RenewReplicate(t cluster.Target, bck *cluster.Bck) RenewRes {
+	return RenewBucketXact(apc.ActReplicate, bck, Args{T: t})
+}
+
 func RenewTCB(t cluster.Target, uuid, kind string, custom *TCBArgs) RenewRes {
 	return RenewBucketXact(kind, custom.BckTo /*NOTE: to not from*/, Args{T: t, Custom: custom, UUID: uuid})
 }
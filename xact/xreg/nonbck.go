@@ -45,6 +45,11 @@ func RenewStoreCleanup(id string) RenewRes {
 	return dreg.renew(e, nil)
 }
 
+func RenewScrub(t cluster.Target, id string, bcks []cmn.Bck) RenewRes {
+	e := dreg.nonbckXacts[apc.ActScrub].New(Args{T: t, UUID: id, Custom: bcks}, nil)
+	return dreg.renew(e, nil)
+}
+
 func RenewDownloader(t cluster.Target, statsT stats.Tracker, xactID string) RenewRes {
 	e := dreg.nonbckXacts[apc.ActDownload].New(Args{T: t, UUID: xactID, Custom: statsT}, nil)
 	return dreg.renew(e, nil)
@@ -206,7 +206,7 @@ func (r *XactArch) Do(msg *cmn.ArchiveMsg) {
 
 func (r *XactArch) Run(wg *sync.WaitGroup) {
 	var err error
-	glog.Infoln(r.Name())
+	r.LogFields().Info(r.Name())
 	wg.Done()
 	for {
 		select {
@@ -111,7 +111,7 @@ func (r *bckRename) Run(wg *sync.WaitGroup) {
 		flt   = xreg.XactFilter{ID: r.rebID, Kind: apc.ActRebalance}
 		sleep = cos.ProbingFrequency(bmvAvgWait)
 	)
-	glog.Infoln(r.Name())
+	r.LogFields().Info(r.Name())
 	wg.Done()
 loop:
 	for total < bmvMaxWait {
@@ -86,9 +86,9 @@ func (r *bsummXact) Run(rwg *sync.WaitGroup) {
 	)
 	rwg.Done()
 	if r.Bck() == nil || r.Bck().IsEmpty() {
-		glog.Infof("%s - all buckets", r.Name())
+		r.LogFields().Infof("%s - all buckets", r.Name())
 	} else {
-		glog.Infof("%s - bucket(s) %s", r.Name(), r.Bck().Bucket())
+		r.LogFields().Infof("%s - bucket(s) %s", r.Name(), r.Bck().Bucket())
 	}
 	if r.totalDisksSize, err = fs.GetTotalDisksSize(); err != nil {
 		r.updRes(err)
@@ -9,7 +9,6 @@ import (
 	"path/filepath"
 	"sync"
 
-	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -75,7 +74,7 @@ func (r *XactDirPromote) SetFshare(v bool) { r.confirmedFshare = v } // is calle
 
 func (r *XactDirPromote) Run(wg *sync.WaitGroup) {
 	wg.Done()
-	glog.Infof("%s(%s)", r.Name(), r.dir)
+	r.LogFields().Infof("%s(%s)", r.Name(), r.dir)
 
 	r.smap = r.Target().Sowner().Get()
 	var (
@@ -22,6 +22,7 @@ type (
 		xctn *xactETL
 	}
 	xactETL struct {
+		dpstats xact.DPStatsAcc // per-stage stats of inline chain transformations (see etl.Chain)
 		xact.Base
 	}
 )
@@ -61,3 +62,15 @@ func newETL(id, kind string) (xctn *xactETL) {
 }
 
 func (*xactETL) Run(*sync.WaitGroup) { debug.Assert(false) }
+
+func (r *xactETL) AddDPStats(stats []cluster.DPStageStats) { r.dpstats.Add(stats) }
+
+func (r *xactETL) Snap() cluster.XactSnap {
+	dp := r.dpstats.Get()
+	if len(dp) == 0 {
+		return r.Base.Snap()
+	}
+	snap := &xact.SnapExt{Ext: &xact.DPStatsExt{DP: dp}}
+	r.ToSnap(&snap.Snap)
+	return snap
+}
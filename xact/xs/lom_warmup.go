@@ -8,7 +8,6 @@ package xs
 import (
 	"sync"
 
-	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/fs"
@@ -74,7 +73,7 @@ func newXactLLC(t cluster.Target, uuid string, bck *cluster.Bck) (r *xactLLC) {
 
 func (r *xactLLC) Run(*sync.WaitGroup) {
 	r.BckJog.Run()
-	glog.Infoln(r.Name())
+	r.LogFields().Info(r.Name())
 	err := r.BckJog.Wait()
 	r.Finish(err)
 }
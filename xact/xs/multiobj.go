@@ -363,12 +363,12 @@ func (r *prefetch) do(lom *cluster.LOM, _ *lriterator) {
 	lom.SetAtimeUnix(-time.Now().UnixNano())
 	if _, err := r.t.GetCold(r.ctx, lom, cmn.OwtGetPrefetchLock); err != nil {
 		if err != cmn.ErrSkip {
-			glog.Warning(err)
+			r.LomFields(lom).Warning(err)
 		}
 		return
 	}
 	if verbose {
-		glog.Infof("prefetch: %s", lom)
+		r.LomFields(lom).Infof("prefetch: %s", lom)
 	}
 	r.ObjsAdd(1, lom.SizeBytes())
 }
@@ -31,6 +31,10 @@ type (
 
 	Rebalance struct {
 		xact.Base
+		paused struct {
+			reason string // why paused (empty when not)
+			mu     sync.RWMutex
+		}
 	}
 	Resilver struct {
 		xact.Base
@@ -99,9 +103,28 @@ func (xreb *Rebalance) Snap() cluster.XactSnap {
 	//       (definition)
 	rebSnap.Stats.Objs = rebSnap.Stats.OutObjs
 	rebSnap.Stats.Bytes = rebSnap.Stats.OutBytes
+	if reason := xreb.Paused(); reason != "" {
+		rebSnap.Ext = &xact.RebalanceStatsExt{Paused: reason}
+	}
 	return rebSnap
 }
 
+// returns true upon transition
+func (xreb *Rebalance) SetPaused(reason string) (changed bool) {
+	xreb.paused.mu.Lock()
+	changed = xreb.paused.reason != reason
+	xreb.paused.reason = reason
+	xreb.paused.mu.Unlock()
+	return
+}
+
+func (xreb *Rebalance) Paused() (reason string) {
+	xreb.paused.mu.RLock()
+	reason = xreb.paused.reason
+	xreb.paused.mu.RUnlock()
+	return
+}
+
 //////////////
 // Resilver //
 //////////////
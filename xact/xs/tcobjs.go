@@ -35,15 +35,18 @@ type (
 			sync.RWMutex
 			m map[string]*tcowi
 		}
-		args   *xreg.TCObjsArgs
-		workCh chan *cmn.TCObjsMsg
+		args    *xreg.TCObjsArgs
+		workCh  chan *cmn.TCObjsMsg
+		dpstats xact.DPStatsAcc // messages that are done (see `fold`)
 		streamingX
 	}
 	tcowi struct {
 		r   *XactTCObjs
 		msg *cmn.TCObjsMsg
+		dp  cluster.DP // this message's data provider (nil when copying)
 		// finishing
-		refc atomic.Int32
+		refc   atomic.Int32
+		folded bool // dp stats added to `dpstats`
 	}
 )
 
@@ -89,8 +92,39 @@ func (p *tcoFactory) Start() error {
 // XactTCObjs //
 ////////////////
 
-func (r *XactTCObjs) Begin(msg *cmn.TCObjsMsg) {
-	wi := &tcowi{r: r, msg: msg}
+// data providers' (ETL per-stage) stats of all the messages: done and in progress
+func (r *XactTCObjs) Snap() cluster.XactSnap {
+	var (
+		snap     = r.DemandBase.ExtSnap()
+		inflight [][]cluster.DPStageStats
+	)
+	r.pending.RLock()
+	for _, wi := range r.pending.m {
+		if ds, ok := wi.dp.(cluster.DPStats); ok && !wi.folded {
+			inflight = append(inflight, ds.DPStats())
+		}
+	}
+	r.pending.RUnlock()
+	if dp := r.dpstats.Get(inflight...); len(dp) > 0 {
+		snap.Ext = &xact.DemandDPStatsExt{BaseDemandStatsExt: *snap.Ext.(*xact.BaseDemandStatsExt), DP: dp}
+	}
+	return snap
+}
+
+// (under pending lock) when done with the message - note that `recv` may have already
+// removed it from pending
+func (r *XactTCObjs) fold(wi *tcowi) {
+	if wi.folded {
+		return
+	}
+	wi.folded = true
+	if ds, ok := wi.dp.(cluster.DPStats); ok {
+		r.dpstats.Add(ds.DPStats())
+	}
+}
+
+func (r *XactTCObjs) Begin(msg *cmn.TCObjsMsg, dp cluster.DP) {
+	wi := &tcowi{r: r, msg: msg, dp: dp}
 	r.pending.Lock()
 	r.pending.m[msg.TxnUUID] = wi
 	r.wiCnt.Inc()
@@ -104,7 +138,7 @@ func (r *XactTCObjs) Do(msg *cmn.TCObjsMsg) {
 
 func (r *XactTCObjs) Run(wg *sync.WaitGroup) {
 	var err error
-	glog.Infoln(r.Name())
+	r.LogFields().Info(r.Name())
 	wg.Done()
 	for {
 		select {
@@ -128,6 +162,15 @@ func (r *XactTCObjs) Run(wg *sync.WaitGroup) {
 			} else {
 				err = lrit.iterateRange(wi, smap)
 			}
+			if err == nil && wi.msg.Multi() {
+				var dpm cluster.DPM
+				if dpm, err = wi.dpm(); err == nil {
+					err = dpm.Flush(wi.emit)
+				}
+			}
+			r.pending.Lock()
+			r.fold(wi)
+			r.pending.Unlock()
 			if r.IsAborted() || err != nil {
 				goto fin
 			}
@@ -147,7 +190,8 @@ fin:
 	if err != nil {
 		// cleanup: destroy destination iff it was created by this copy
 		r.pending.Lock()
-		for uuid := range r.pending.m {
+		for uuid, wi := range r.pending.m {
+			r.fold(wi)
 			delete(r.pending.m, uuid)
 		}
 		r.pending.Unlock()
@@ -222,7 +266,26 @@ func (r *XactTCObjs) recv(hdr transport.ObjHdr, objReader io.Reader, err error)
 // tcowi //
 ///////////
 
+// multi-output and/or multi-input transformation (see also `apc.TCBMsg.ValidateCopy`)
+func (wi *tcowi) dpm() (cluster.DPM, error) {
+	dpm, ok := wi.dp.(cluster.DPM)
+	if !ok {
+		return nil, fmt.Errorf("%s: multiple inputs or outputs require ETL", wi.r)
+	}
+	return dpm, nil
+}
+
 func (wi *tcowi) do(lom *cluster.LOM, lri *lriterator) {
+	if wi.msg.Multi() {
+		dpm, err := wi.dpm()
+		if err == nil {
+			err = dpm.Emit(lom, wi.emit)
+		}
+		if err != nil {
+			wi.r.raiseErr(err, 0, wi.msg.ContinueOnError)
+		}
+		return
+	}
 	objNameTo := wi.msg.ToName(lom.ObjName)
 	buf, slab := lri.t.PageMM().Alloc()
 	params := cluster.AllocCpObjParams()
@@ -231,7 +294,7 @@ func (wi *tcowi) do(lom *cluster.LOM, lri *lriterator) {
 		params.ObjNameTo = objNameTo
 		params.DM = wi.r.p.dm
 		params.Buf = buf
-		params.DP = wi.r.args.DP
+		params.DP = wi.dp
 		params.Xact = wi.r
 	}
 	size, err := lri.t.CopyObject(lom, params, wi.msg.DryRun)
@@ -252,3 +315,18 @@ func (wi *tcowi) do(lom *cluster.LOM, lri *lriterator) {
 		wi.r.raiseErr(err, 0, wi.msg.ContinueOnError)
 	}
 }
+
+// one of the (possibly, many) objects emitted by multi-output and/or multi-input ETL
+func (wi *tcowi) emit(lom *cluster.LOM, objNameTo string, dp cluster.DP) error {
+	params := cluster.AllocCpObjParams()
+	{
+		params.BckTo = wi.r.args.BckTo
+		params.ObjNameTo = objNameTo
+		params.DM = wi.r.p.dm
+		params.DP = dp
+		params.Xact = wi.r
+	}
+	_, err := wi.r.p.T.CopyObject(lom, params, wi.msg.DryRun)
+	cluster.FreeCpObjParams(params)
+	return err
+}
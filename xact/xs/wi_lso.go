@@ -168,7 +168,7 @@ func (wi *walkInfo) cb(lom *cluster.LOM, fqn string) (*cmn.LsoEntry, error) {
 		}
 		if err := hlom.Load(true /*cache it*/, false /*locked*/); err != nil {
 			mirror := lom.MirrorConf()
-			if mirror.Enabled && mirror.Copies > 1 {
+			if mirror.Local() && mirror.Copies > 1 {
 				status = apc.LocIsCopyMissingObj
 			}
 		}
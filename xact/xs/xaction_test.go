@@ -278,3 +278,41 @@ func TestXactionQueryFinished(t *testing.T) {
 		f(t, test)
 	}
 }
+
+func TestXactionETLChainStats(t *testing.T) {
+	xreg.TestReset()
+	xs.Xreg()
+	defer xreg.AbortAll(nil)
+
+	rns := xreg.RenewETL(mock.NewTarget(mock.NewBaseBownerMock()), nil)
+	tassert.CheckFatal(t, rns.Err)
+	xctn := rns.Entry.Get()
+	if _, ok := xctn.Snap().(*xact.Snap); !ok {
+		t.Fatalf("expected no per-stage stats, got %+v", xctn.Snap())
+	}
+
+	adder := xctn.(interface{ AddDPStats([]cluster.DPStageStats) })
+	stages := func(chain string, objs int64, names ...string) []cluster.DPStageStats {
+		stats := make([]cluster.DPStageStats, 0, len(names))
+		for _, name := range names {
+			stats = append(stats, cluster.DPStageStats{Chain: chain, Name: name, Objs: objs, InBytes: 10 * objs, OutBytes: 20 * objs})
+		}
+		return stats
+	}
+	adder.AddDPStats(stages("a,b", 1, "a", "b"))
+	adder.AddDPStats(stages("a,b", 2, "a", "b"))
+	adder.AddDPStats(stages("a,c", 5, "a", "c"))
+
+	snap, ok := xctn.Snap().(*xact.SnapExt)
+	tassert.Fatalf(t, ok, "expected extended snapshot")
+	dp := snap.Ext.(*xact.DPStatsExt).DP.([]cluster.DPStageStats)
+	tassert.Fatalf(t, len(dp) == 4, "expected 4 stages, got %+v", dp)
+	for i, exp := range []cluster.DPStageStats{
+		{Chain: "a,b", Name: "a", Objs: 3, InBytes: 30, OutBytes: 60},
+		{Chain: "a,b", Name: "b", Objs: 3, InBytes: 30, OutBytes: 60},
+		{Chain: "a,c", Name: "a", Objs: 5, InBytes: 50, OutBytes: 100},
+		{Chain: "a,c", Name: "c", Objs: 5, InBytes: 50, OutBytes: 100},
+	} {
+		tassert.Errorf(t, dp[i] == exp, "stage %d: expected %+v, got %+v", i, exp, dp[i])
+	}
+}